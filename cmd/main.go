@@ -1,17 +1,40 @@
 package main
 
 import (
+	"context"
 	"log"
+	"os"
 
 	"github.com/heaveless/dbz-api/internal/bootstrap"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := bootstrap.Migrate(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	app := bootstrap.App()
 	env := app.Env
 
 	defer app.CloseDbConnection()
 
+	if app.Relay != nil {
+		go app.Relay.Run(context.Background())
+	}
+	if app.Webhooks != nil {
+		go app.Webhooks.Run(context.Background())
+	}
+
+	if app.Grpc != nil {
+		go func() {
+			log.Printf("gRPC listening on :%s", env.GrpcPort)
+			log.Fatal(app.ServeGrpc())
+		}()
+	}
+
 	err := app.Svr.Run(":" + env.AppPort)
 	if err != nil {
 		log.Fatal(err)
@@ -0,0 +1,200 @@
+package apikey
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"slices"
+	"time"
+
+	domain "github.com/heaveless/dbz-api/internal/domain/apikey"
+	utils "github.com/heaveless/dbz-api/internal/utils"
+)
+
+const (
+	keyPrefix = "dbz_"
+	// shownPrefix is how much of a key is kept in clear to identify it.
+	shownPrefix = len(keyPrefix) + 8
+)
+
+type ApiKeyService struct {
+	repo domain.ApiKeyRepository
+	now  func() time.Time
+}
+
+func NewApiKeyService(repo domain.ApiKeyRepository) *ApiKeyService {
+	return NewApiKeyServiceWithClock(repo, time.Now)
+}
+
+func NewApiKeyServiceWithClock(repo domain.ApiKeyRepository, now func() time.Time) *ApiKeyService {
+	return &ApiKeyService{repo: repo, now: now}
+}
+
+// Authenticate resolves a raw key to its principal and charges one request
+// to the key's daily quota. A key without scope is turned away before the
+// charge, so requests it was never allowed to make do not spend its quota.
+func (s *ApiKeyService) Authenticate(ctx context.Context, key string, scope string) (*domain.Principal, error) {
+	k, err := s.repo.GetByHash(ctx, HashKey(key))
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.ErrInvalidKey
+	}
+	if err != nil {
+		return nil, err
+	}
+	if k.Revoked() {
+		return nil, domain.ErrInvalidKey
+	}
+
+	p := &domain.Principal{KeyId: k.Id, Name: k.Name, Scopes: k.Scopes}
+	if !p.HasScope(scope) {
+		return nil, domain.ErrScopeMissing
+	}
+
+	if k.DailyQuota > 0 {
+		now := s.now().UTC()
+		used, err := s.repo.IncrementUsage(ctx, k.Id, now.Format(time.DateOnly))
+		if err != nil {
+			return nil, err
+		}
+		if used > k.DailyQuota {
+			day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+			return nil, &domain.QuotaExceededError{Quota: k.DailyQuota, Reset: day.AddDate(0, 0, 1)}
+		}
+	}
+
+	return p, nil
+}
+
+func (s *ApiKeyService) Create(ctx context.Context, req domain.NewApiKey) (*domain.IssuedApiKeyDTO, error) {
+	id, err := utils.RandomString(8, hex.EncodeToString)
+	if err != nil {
+		return nil, err
+	}
+
+	k := &domain.ApiKeyEntity{
+		Id:         id,
+		Name:       req.Name,
+		Scopes:     normalizeScopes(req.Scopes),
+		DailyQuota: req.DailyQuota,
+		CreatedAt:  s.now().UTC(),
+	}
+
+	return s.issue(ctx, k)
+}
+
+func (s *ApiKeyService) List(ctx context.Context) ([]domain.ApiKeyDTO, error) {
+	keys, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]domain.ApiKeyDTO, 0, len(keys))
+	for i := range keys {
+		dtos = append(dtos, *ToDTO(&keys[i]))
+	}
+
+	return dtos, nil
+}
+
+// Rotate replaces the key of id with a new one. The old key stops working
+// immediately; scopes and quota are kept.
+func (s *ApiKeyService) Rotate(ctx context.Context, id string) (*domain.IssuedApiKeyDTO, error) {
+	k, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if k.Revoked() {
+		return nil, domain.ErrRevoked
+	}
+
+	now := s.now().UTC()
+	k.RotatedAt = &now
+
+	return s.issue(ctx, k)
+}
+
+// Revoke disables the key for good. Revoking twice keeps the first date.
+func (s *ApiKeyService) Revoke(ctx context.Context, id string) (*domain.ApiKeyDTO, error) {
+	k, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !k.Revoked() {
+		now := s.now().UTC()
+		k.RevokedAt = &now
+		if err := s.repo.Save(ctx, k); err != nil {
+			return nil, err
+		}
+	}
+
+	return ToDTO(k), nil
+}
+
+// EnsureKey stores key with the given scopes unless it is already known.
+// It lets the first admin key come from configuration.
+func (s *ApiKeyService) EnsureKey(ctx context.Context, key, name string, scopes []string) error {
+	hash := HashKey(key)
+
+	_, err := s.repo.GetByHash(ctx, hash)
+	if !errors.Is(err, domain.ErrNotFound) {
+		return err
+	}
+
+	return s.repo.Save(ctx, &domain.ApiKeyEntity{
+		Id:        hash[:16],
+		Name:      name,
+		Hash:      hash,
+		Prefix:    shown(key),
+		Scopes:    normalizeScopes(scopes),
+		CreatedAt: s.now().UTC(),
+	})
+}
+
+func (s *ApiKeyService) issue(ctx context.Context, k *domain.ApiKeyEntity) (*domain.IssuedApiKeyDTO, error) {
+	key, err := utils.RandomString(32, base64.RawURLEncoding.EncodeToString)
+	if err != nil {
+		return nil, err
+	}
+	key = keyPrefix + key
+
+	k.Hash = HashKey(key)
+	k.Prefix = shown(key)
+	if err := s.repo.Save(ctx, k); err != nil {
+		return nil, err
+	}
+
+	return &domain.IssuedApiKeyDTO{ApiKeyDTO: *ToDTO(k), Key: key}, nil
+}
+
+// HashKey is how keys are stored and looked up. Keys are long random
+// strings, so a fast unsalted hash is enough.
+func HashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func ToDTO(k *domain.ApiKeyEntity) *domain.ApiKeyDTO {
+	return &domain.ApiKeyDTO{
+		Id:         k.Id,
+		Name:       k.Name,
+		Prefix:     k.Prefix,
+		Scopes:     k.Scopes,
+		DailyQuota: k.DailyQuota,
+		CreatedAt:  k.CreatedAt,
+		RotatedAt:  k.RotatedAt,
+		RevokedAt:  k.RevokedAt,
+	}
+}
+
+func normalizeScopes(scopes []string) []string {
+	out := slices.Clone(scopes)
+	slices.Sort(out)
+	return slices.Compact(out)
+}
+
+func shown(key string) string {
+	return key[:min(len(key), shownPrefix)]
+}
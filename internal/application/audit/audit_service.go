@@ -0,0 +1,31 @@
+package audit
+
+import (
+	"context"
+
+	domain "github.com/heaveless/dbz-api/internal/domain/audit"
+)
+
+// AuditService reads the audit log back. Entries are written by the
+// repositories whose changes they record, never through it.
+type AuditService struct {
+	repo domain.Repository
+}
+
+func NewAuditService(repo domain.Repository) *AuditService {
+	return &AuditService{repo: repo}
+}
+
+func (s *AuditService) Query(ctx context.Context, q domain.Query) (*domain.EntryPageDTO, error) {
+	entries, total, err := s.repo.Find(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.EntryPageDTO{
+		Items: entries,
+		Page:  q.Page,
+		Limit: q.Limit,
+		Total: total,
+	}, nil
+}
@@ -0,0 +1,36 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// BudgetExceededError is returned instead of going upstream when the
+// caller's upstream budget is spent.
+type BudgetExceededError struct {
+	RetryAfter time.Duration
+}
+
+func (e *BudgetExceededError) Error() string {
+	return fmt.Sprintf("upstream budget exceeded, retry after %s", e.RetryAfter)
+}
+
+// UpstreamBudget is consulted right before a lookup goes upstream. A non-nil
+// error aborts the upstream call and is returned to the caller.
+type UpstreamBudget func(ctx context.Context) error
+
+type budgetKey struct{}
+
+func WithUpstreamBudget(ctx context.Context, b UpstreamBudget) context.Context {
+	return context.WithValue(ctx, budgetKey{}, b)
+}
+
+func spendUpstreamBudget(ctx context.Context) error {
+	b, ok := ctx.Value(budgetKey{}).(UpstreamBudget)
+	if !ok {
+		return nil
+	}
+
+	return b(ctx)
+}
@@ -0,0 +1,246 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	utils "github.com/heaveless/dbz-api/internal/utils"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	defaultSaveTimeout  = 500 * time.Millisecond
+	defaultFetchTimeout = 10 * time.Second
+)
+
+type ResourceRepository[K any, E any] interface {
+	Get(ctx context.Context, key K) (*E, error)
+	Create(ctx context.Context, e *E) error
+}
+
+type ResourceUpstream[K any, E any] interface {
+	Get(ctx context.Context, key K) (*E, error)
+}
+
+type ResourceMapper[E any, D any] interface {
+	ToDTO(e *E) *D
+}
+
+type WriteBehind int
+
+const (
+	// WriteBehindOnMiss stores only what had to be fetched upstream.
+	WriteBehindOnMiss WriteBehind = iota
+	// WriteBehindAlways re-submits every served entity; the repository is
+	// expected to ignore duplicates.
+	WriteBehindAlways
+)
+
+type CacheOptions[K any, E any] struct {
+	// Name labels the resource in logs and metrics, e.g. "character".
+	Name string
+	// Key maps a lookup key to its singleflight key. Lookups that share a
+	// key share one DB/API round trip.
+	Key         func(K) string
+	WriteBehind WriteBehind
+	SaveTimeout time.Duration
+	// FetchTimeout bounds the shared DB/API round trip, which no single
+	// caller's context may cancel.
+	FetchTimeout time.Duration
+	Metrics      Metrics
+	// AfterSave runs after a successful background save.
+	AfterSave func(e *E)
+	// IsEmpty reports an upstream result with nothing in it. Such a result
+	// is not stored but marked in Marks, and served as a zero E while the
+	// mark lasts.
+	IsEmpty func(e *E) bool
+	Marks   EmptyMarks
+}
+
+// CachedResourceService implements the DB-first, API-fallback policy shared
+// by every resource: read the local store, go upstream on any failure or
+// miss, and persist upstream results in the background.
+type CachedResourceService[K any, E any, D any] struct {
+	repo     ResourceRepository[K, E]
+	upstream ResourceUpstream[K, E]
+	mapper   ResourceMapper[E, D]
+	opts     CacheOptions[K, E]
+	group    singleflight.Group
+}
+
+func NewCachedResourceService[K any, E any, D any](
+	repo ResourceRepository[K, E],
+	upstream ResourceUpstream[K, E],
+	mapper ResourceMapper[E, D],
+	opts CacheOptions[K, E],
+) *CachedResourceService[K, E, D] {
+	if opts.Key == nil {
+		opts.Key = func(k K) string { return fmt.Sprint(k) }
+	}
+	if opts.SaveTimeout == 0 {
+		opts.SaveTimeout = defaultSaveTimeout
+	}
+	if opts.FetchTimeout == 0 {
+		opts.FetchTimeout = defaultFetchTimeout
+	}
+	if opts.Metrics == nil {
+		opts.Metrics = NopMetrics{}
+	}
+
+	return &CachedResourceService[K, E, D]{
+		repo:     repo,
+		upstream: upstream,
+		mapper:   mapper,
+		opts:     opts,
+	}
+}
+
+func (s *CachedResourceService[K, E, D]) Get(ctx context.Context, key K) (*D, error) {
+	e, err := s.GetEntity(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.mapper.ToDTO(e), nil
+}
+
+func (s *CachedResourceService[K, E, D]) GetEntity(ctx context.Context, key K) (*E, error) {
+	flightKey := s.opts.Key(key)
+
+	// The shared call keeps the first caller's values but not its
+	// cancellation, so a caller that goes away fails only itself.
+	ch := s.group.DoChan(flightKey, func() (any, error) {
+		fetchCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), s.opts.FetchTimeout)
+		defer cancel()
+		return s.fetch(fetchCtx, key, flightKey)
+	})
+
+	select {
+	case res := <-ch:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		return res.Val.(*E), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (s *CachedResourceService[K, E, D]) fetch(ctx context.Context, key K, flightKey string) (*E, error) {
+	fromUpstream := false
+
+	e, err := utils.WithFallback(ctx,
+		func(ctx context.Context) (*E, error) {
+			e, err := s.repo.Get(ctx, key)
+			if err != nil && s.markedEmpty(ctx, flightKey) {
+				return new(E), nil
+			}
+			return e, err
+		},
+		func(ctx context.Context) (*E, error) {
+			if err := spendUpstreamBudget(ctx); err != nil {
+				return nil, err
+			}
+			fromUpstream = true
+			return s.upstream.Get(ctx, key)
+		},
+		func(err error) bool {
+			// Any local failure, a plain miss included, goes upstream.
+			return true
+		},
+	)
+
+	if err != nil {
+		s.opts.Metrics.Failure(s.opts.Name)
+		return nil, err
+	}
+
+	if fromUpstream {
+		s.opts.Metrics.Miss(s.opts.Name)
+	} else {
+		s.opts.Metrics.Hit(s.opts.Name)
+	}
+
+	if fromUpstream || s.opts.WriteBehind == WriteBehindAlways {
+		go s.save(ctx, e, flightKey)
+	}
+
+	return e, nil
+}
+
+// save outlives the request that fetched e but keeps its values, such as
+// the request id the audit log records.
+func (s *CachedResourceService[K, E, D]) save(ctx context.Context, e *E, flightKey string) {
+	saveCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), s.opts.SaveTimeout)
+	defer cancel()
+
+	if s.isEmpty(e) {
+		if s.opts.Marks == nil {
+			return
+		}
+		if err := s.opts.Marks.Mark(saveCtx, s.markKey(flightKey)); err != nil {
+			s.opts.Metrics.SaveError(s.opts.Name)
+			log.Printf("[DB] failed to mark %s %q as empty: %v", s.opts.Name, flightKey, err)
+		}
+		return
+	}
+
+	if err := s.repo.Create(saveCtx, e); err != nil {
+		s.opts.Metrics.SaveError(s.opts.Name)
+		log.Printf("[DB] failed to save %s %q from api: %v", s.opts.Name, flightKey, err)
+		return
+	}
+
+	if s.opts.AfterSave != nil {
+		s.opts.AfterSave(e)
+	}
+}
+
+// MarkedEmpty returns the keys, out of keys, that upstream is known to have
+// nothing for, so a batch read can serve them without one lookup each.
+func (s *CachedResourceService[K, E, D]) MarkedEmpty(ctx context.Context, keys []K) ([]K, error) {
+	if s.opts.Marks == nil || len(keys) == 0 {
+		return nil, nil
+	}
+
+	markKeys := make([]string, 0, len(keys))
+	for _, key := range keys {
+		markKeys = append(markKeys, s.markKey(s.opts.Key(key)))
+	}
+
+	marked, err := s.opts.Marks.Marked(ctx, markKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	var empty []K
+	for i, key := range keys {
+		if marked[markKeys[i]] {
+			empty = append(empty, key)
+		}
+	}
+
+	return empty, nil
+}
+
+func (s *CachedResourceService[K, E, D]) isEmpty(e *E) bool {
+	return s.opts.IsEmpty != nil && s.opts.IsEmpty(e)
+}
+
+// markedEmpty is only asked after a local miss; a failure to read the marks
+// counts as no mark.
+func (s *CachedResourceService[K, E, D]) markedEmpty(ctx context.Context, flightKey string) bool {
+	if s.opts.Marks == nil {
+		return false
+	}
+
+	key := s.markKey(flightKey)
+	marked, err := s.opts.Marks.Marked(ctx, []string{key})
+	return err == nil && marked[key]
+}
+
+func (s *CachedResourceService[K, E, D]) markKey(flightKey string) string {
+	return s.opts.Name + ":" + flightKey
+}
@@ -0,0 +1,78 @@
+package character
+
+import (
+	"context"
+	"log"
+
+	domain "github.com/heaveless/dbz-api/internal/domain/character"
+	"golang.org/x/sync/errgroup"
+)
+
+// batchParallelism bounds how many lookups of one batch run at once, so a
+// single large roster cannot monopolize the upstream API.
+const batchParallelism = 4
+
+// GetBatch resolves every ref through the same cached lookups as GetByName
+// and GetById. Results keep the order of refs; a failed lookup only fails
+// its own entry.
+func (s *CharacterService) GetBatch(ctx context.Context, refs []domain.CharacterRef) []domain.CharacterResult {
+	results := make([]domain.CharacterResult, len(refs))
+
+	var g errgroup.Group
+	g.SetLimit(batchParallelism)
+
+	for i, ref := range refs {
+		g.Go(func() error {
+			var chr *domain.CharacterDTO
+			var err error
+			if ref.Id != 0 {
+				chr, err = s.GetById(ctx, ref.Id)
+			} else {
+				chr, err = s.GetByName(ctx, ref.Name)
+			}
+
+			results[i] = domain.CharacterResult{Ref: ref, Character: chr, Err: err}
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	return results
+}
+
+// GetByIds reads every id from the local store with one query. Only the ids
+// missing there go through GetById, and so upstream; when the query fails
+// they all do. Results keep the order of ids.
+func (s *CharacterService) GetByIds(ctx context.Context, ids []int64) []domain.CharacterResult {
+	results := make([]domain.CharacterResult, len(ids))
+
+	records, err := s.repo.GetByIds(ctx, ids)
+	if err != nil {
+		log.Printf("[DB] failed to read %d characters by id, falling back one by one: %v", len(ids), err)
+	}
+
+	stored := make(map[int64]*domain.CharacterEntity, len(records))
+	for i := range records {
+		stored[records[i].Id] = &records[i]
+	}
+
+	var g errgroup.Group
+	g.SetLimit(batchParallelism)
+
+	for i, id := range ids {
+		ref := domain.CharacterRef{Id: id}
+		if c, ok := stored[id]; ok {
+			results[i] = domain.CharacterResult{Ref: ref, Character: ToDTO(c)}
+			continue
+		}
+
+		g.Go(func() error {
+			chr, err := s.GetById(ctx, id)
+			results[i] = domain.CharacterResult{Ref: ref, Character: chr, Err: err}
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	return results
+}
@@ -2,61 +2,152 @@ package character
 
 import (
 	"context"
-	"errors"
 	"log"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/heaveless/dbz-api/internal/application"
 	domain "github.com/heaveless/dbz-api/internal/domain/character"
 	utils "github.com/heaveless/dbz-api/internal/utils"
+	"golang.org/x/sync/singleflight"
 )
 
+const nameIndexTTL = time.Minute
+
 type CharacterService struct {
-	repo domain.CharacterRepository
-	api  domain.CharacterApi
+	repo       domain.CharacterRepository
+	cached     *application.CachedResourceService[string, domain.CharacterEntity, domain.CharacterDTO]
+	byId       *application.CachedResourceService[int64, domain.CharacterEntity, domain.CharacterDTO]
+	index      *NameIndex
+	indexGroup singleflight.Group
 }
 
 func NewCharacterService(dr domain.CharacterRepository, hr domain.CharacterApi) *CharacterService {
-	return &CharacterService{
-		repo: dr,
-		api:  hr,
-	}
+	return NewCharacterServiceWithMetrics(dr, hr, nil)
 }
 
-func (s *CharacterService) GetByName(ctx context.Context, name string) (*domain.CharacterDTO, error) {
-	chr, err := utils.WithFallback(ctx,
-		func(ctx context.Context) (*domain.CharacterEntity, error) {
-			return s.repo.Get(ctx, name)
-		},
-		func(ctx context.Context) (*domain.CharacterEntity, error) {
-			return s.api.Get(ctx, name)
+func NewCharacterServiceWithMetrics(dr domain.CharacterRepository, hr domain.CharacterApi, m application.Metrics) *CharacterService {
+	s := &CharacterService{
+		repo:  dr,
+		index: NewNameIndex(),
+	}
+
+	afterSave := func(c *domain.CharacterEntity) {
+		s.index.Add(c.Id, c.Name)
+	}
+
+	s.cached = application.NewCachedResourceService(dr, hr, Mapper{},
+		application.CacheOptions[string, domain.CharacterEntity]{
+			Name: "character",
+			// Lookups that normalize to the same name share one DB/API round trip.
+			Key:         domain.NormalizeName,
+			WriteBehind: application.WriteBehindOnMiss,
+			Metrics:     m,
+			AfterSave:   afterSave,
 		},
-		func(err error) bool {
-			return errors.Is(err, context.DeadlineExceeded) || true
+	)
+	s.byId = application.NewCachedResourceService(byIdStore{dr}, byIdUpstream{hr}, Mapper{},
+		application.CacheOptions[int64, domain.CharacterEntity]{
+			Name:        "character by id",
+			Key:         func(id int64) string { return strconv.FormatInt(id, 10) },
+			WriteBehind: application.WriteBehindOnMiss,
+			Metrics:     m,
+			AfterSave:   afterSave,
 		},
 	)
 
+	return s
+}
+
+func (s *CharacterService) GetByName(ctx context.Context, name string) (*domain.CharacterDTO, error) {
+	return s.cached.Get(ctx, strings.TrimSpace(name))
+}
+
+func (s *CharacterService) GetById(ctx context.Context, id int64) (*domain.CharacterDTO, error) {
+	return s.byId.Get(ctx, id)
+}
+
+// Search ranks locally stored characters against a possibly misspelled or
+// partial name. Only the local store is consulted, never the upstream API.
+func (s *CharacterService) Search(ctx context.Context, query string, limit int) ([]domain.CharacterMatchDTO, error) {
+	if err := s.refreshIndex(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.index.Search(query, limit), nil
+}
+
+// SearchText runs a relevance-ranked full-text query over name, description,
+// race and affiliation. Pages are 1-based.
+func (s *CharacterService) SearchText(ctx context.Context, query string, page, limit int) (*domain.CharacterTextSearchDTO, error) {
+	matches, total, err := s.repo.TextSearch(ctx, domain.TextSearchQuery{
+		Text:   query,
+		Offset: utils.Offset(page, limit),
+		Limit:  limit,
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	go func(c *domain.CharacterEntity) {
-		saveCtx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
-		defer cancel()
+	terms := searchTerms(query)
+	items := make([]domain.CharacterTextMatchDTO, 0, len(matches))
+	for _, m := range matches {
+		items = append(items, domain.CharacterTextMatchDTO{
+			Character:  *ToDTO(&m.Character),
+			Score:      m.Score,
+			Highlights: highlights(&m.Character, terms),
+		})
+	}
 
-		saveErr := s.repo.Create(saveCtx, c)
-		if saveErr != nil {
-			log.Printf("[DB] failed to save user %d from api: %v", c.Id, saveErr)
-		}
-	}(chr)
-
-	return &domain.CharacterDTO{
-		Id:          chr.Id,
-		Name:        chr.Name,
-		Ki:          chr.Ki,
-		MaxKi:       chr.MaxKi,
-		Race:        chr.Race,
-		Gender:      chr.Gender,
-		Image:       chr.Image,
-		Affiliation: chr.Affiliation,
+	return &domain.CharacterTextSearchDTO{
+		Items: items,
+		Page:  page,
+		Limit: limit,
+		Total: total,
 	}, nil
 }
+
+func (s *CharacterService) refreshIndex(ctx context.Context) error {
+	builtAt := s.index.BuiltAt()
+	if time.Since(builtAt) < nameIndexTTL {
+		return nil
+	}
+
+	_, err, _ := s.indexGroup.Do("rebuild", func() (any, error) {
+		records, err := s.repo.List(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		s.index.Rebuild(records)
+		return nil, nil
+	})
+
+	if err != nil && !builtAt.IsZero() {
+		log.Printf("[DB] failed to refresh name index, serving stale data: %v", err)
+		return nil
+	}
+
+	return err
+}
+
+type byIdStore struct {
+	repo domain.CharacterRepository
+}
+
+func (b byIdStore) Get(ctx context.Context, id int64) (*domain.CharacterEntity, error) {
+	return b.repo.GetById(ctx, id)
+}
+
+func (b byIdStore) Create(ctx context.Context, c *domain.CharacterEntity) error {
+	return b.repo.Create(ctx, c)
+}
+
+type byIdUpstream struct {
+	api domain.CharacterApi
+}
+
+func (b byIdUpstream) Get(ctx context.Context, id int64) (*domain.CharacterEntity, error) {
+	return b.api.GetById(ctx, id)
+}
@@ -0,0 +1,71 @@
+package character
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	domain "github.com/heaveless/dbz-api/internal/domain/character"
+)
+
+// CurationService lets admins write the local store directly. The fields
+// they set are marked overridden, so upstream refreshes keep them.
+type CurationService struct {
+	repo domain.CurationRepository
+	now  func() time.Time
+}
+
+func NewCurationService(repo domain.CurationRepository) *CurationService {
+	return &CurationService{
+		repo: repo,
+		now:  func() time.Time { return time.Now().UTC().Truncate(time.Second) },
+	}
+}
+
+// Create stores a character upstream may not know. Every field of p is
+// curated.
+func (s *CurationService) Create(ctx context.Context, id int64, p domain.CharacterPatch) (*domain.CuratedCharacterDTO, error) {
+	c := &domain.CharacterEntity{Id: id, UpdatedAt: s.now()}
+	c.Curate(p)
+
+	if err := s.repo.Insert(ctx, c); err != nil {
+		return nil, err
+	}
+
+	return ToCuratedDTO(c), nil
+}
+
+// Update sets the fields in p on a stored character and marks them
+// curated; the others keep following upstream.
+func (s *CurationService) Update(ctx context.Context, id int64, p domain.CharacterPatch) (*domain.CuratedCharacterDTO, error) {
+	before, err := s.repo.GetById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	after := *before
+	after.Overridden = slices.Clone(before.Overridden)
+	after.Curate(p)
+	after.UpdatedAt = s.now()
+
+	if err := s.repo.Update(ctx, before, &after); err != nil {
+		return nil, err
+	}
+
+	return ToCuratedDTO(&after), nil
+}
+
+// Delete removes a stored character. One upstream knows is stored again,
+// without curated fields, the next time it is looked up.
+func (s *CurationService) Delete(ctx context.Context, id int64) (*domain.CuratedCharacterDTO, error) {
+	before, err := s.repo.GetById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Delete(ctx, before); err != nil {
+		return nil, err
+	}
+
+	return ToCuratedDTO(before), nil
+}
@@ -0,0 +1,128 @@
+package character
+
+import (
+	"html"
+	"regexp"
+	"strings"
+
+	domain "github.com/heaveless/dbz-api/internal/domain/character"
+)
+
+const (
+	highlightOpen  = "<em>"
+	highlightClose = "</em>"
+	snippetWords   = 30
+	snippetLead    = 8
+)
+
+var wordPattern = regexp.MustCompile(`[\p{L}\p{N}]+`)
+
+// searchTerms extracts the normalized words of a $text query, skipping
+// negated terms since they never appear in a match.
+func searchTerms(query string) []string {
+	var terms []string
+	for _, field := range strings.Fields(query) {
+		if strings.HasPrefix(field, "-") {
+			continue
+		}
+		for _, word := range wordPattern.FindAllString(field, -1) {
+			if term := domain.NormalizeName(word); term != "" {
+				terms = append(terms, term)
+			}
+		}
+	}
+
+	return terms
+}
+
+// matchesTerm approximates the stemming done by the text index: a word
+// matches when it starts with a term or, for longer words, the other way round.
+func matchesTerm(word string, terms []string) bool {
+	key := domain.NormalizeName(word)
+	for _, term := range terms {
+		if strings.HasPrefix(key, term) {
+			return true
+		}
+		if len([]rune(key)) >= 4 && strings.HasPrefix(term, key) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// highlight HTML-escapes text and wraps every matching word in <em> tags.
+// Long texts are cut down to a window of words around the first match.
+func highlight(text string, terms []string, cut bool) (string, bool) {
+	words := wordPattern.FindAllStringIndex(text, -1)
+
+	first := -1
+	for i, w := range words {
+		if matchesTerm(text[w[0]:w[1]], terms) {
+			first = i
+			break
+		}
+	}
+	if first < 0 {
+		return "", false
+	}
+
+	start, end := 0, len(text)
+	prefix, suffix := "", ""
+	if cut && len(words) > snippetWords {
+		from := max(0, first-snippetLead)
+		to := min(len(words), from+snippetWords)
+		from = max(0, to-snippetWords)
+
+		if from > 0 {
+			start, prefix = words[from][0], "…"
+		}
+		if to < len(words) {
+			end, suffix = words[to-1][1], "…"
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(prefix)
+	last := start
+	for _, w := range words {
+		if w[0] < start || w[1] > end {
+			continue
+		}
+		word := text[w[0]:w[1]]
+		if !matchesTerm(word, terms) {
+			continue
+		}
+		b.WriteString(html.EscapeString(text[last:w[0]]))
+		b.WriteString(highlightOpen)
+		b.WriteString(html.EscapeString(word))
+		b.WriteString(highlightClose)
+		last = w[1]
+	}
+	b.WriteString(html.EscapeString(text[last:end]))
+	b.WriteString(suffix)
+
+	return b.String(), true
+}
+
+func highlights(chr *domain.CharacterEntity, terms []string) map[string]string {
+	fields := []struct {
+		name string
+		text string
+		cut  bool
+	}{
+		{name: "name", text: chr.Name},
+		{name: "race", text: chr.Race},
+		{name: "affiliation", text: chr.Affiliation},
+		{name: "description", text: chr.Description, cut: true},
+	}
+
+	out := map[string]string{}
+	for _, f := range fields {
+		if h, ok := highlight(f.text, terms, f.cut); ok {
+			out[f.name] = h
+		}
+	}
+
+	return out
+}
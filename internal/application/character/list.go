@@ -0,0 +1,28 @@
+package character
+
+import (
+	"context"
+
+	domain "github.com/heaveless/dbz-api/internal/domain/character"
+)
+
+// List pages through the locally stored characters. The store filters,
+// sorts and pages them on the ranks it keeps of the Ki strings.
+func (s *CharacterService) List(ctx context.Context, q domain.ListQuery) (*domain.CharacterPageDTO, error) {
+	records, total, err := s.repo.ListPage(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &domain.CharacterPageDTO{
+		Items: make([]domain.CharacterDTO, 0, len(records)),
+		Page:  q.Page,
+		Limit: q.Limit,
+		Total: total,
+	}
+	for i := range records {
+		page.Items = append(page.Items, *ToDTO(&records[i]))
+	}
+
+	return page, nil
+}
@@ -0,0 +1,47 @@
+package character
+
+import (
+	domain "github.com/heaveless/dbz-api/internal/domain/character"
+)
+
+// Mapper converts stored characters into their public DTO. Internal fields
+// such as NameKey never leave the application layer.
+type Mapper struct{}
+
+func (Mapper) ToDTO(chr *domain.CharacterEntity) *domain.CharacterDTO {
+	return ToDTO(chr)
+}
+
+func ToDTO(chr *domain.CharacterEntity) *domain.CharacterDTO {
+	return &domain.CharacterDTO{
+		Id:          chr.Id,
+		Name:        chr.Name,
+		Ki:          chr.Ki,
+		MaxKi:       chr.MaxKi,
+		Race:        chr.Race,
+		Gender:      chr.Gender,
+		Image:       chr.Image,
+		Affiliation: chr.Affiliation,
+		Description: chr.Description,
+		ETag:        chr.ETag(),
+		UpdatedAt:   chr.UpdatedAt,
+	}
+}
+
+func ToCuratedDTO(chr *domain.CharacterEntity) *domain.CuratedCharacterDTO {
+	overridden := chr.Overridden
+	if overridden == nil {
+		overridden = []string{}
+	}
+
+	return &domain.CuratedCharacterDTO{CharacterDTO: *ToDTO(chr), Overridden: overridden}
+}
+
+func ToDTOs(chrs []domain.CharacterEntity) []domain.CharacterDTO {
+	dtos := make([]domain.CharacterDTO, 0, len(chrs))
+	for i := range chrs {
+		dtos = append(dtos, *ToDTO(&chrs[i]))
+	}
+
+	return dtos
+}
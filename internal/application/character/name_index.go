@@ -0,0 +1,196 @@
+package character
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	domain "github.com/heaveless/dbz-api/internal/domain/character"
+)
+
+const minFuzzyScore = 0.5
+
+type indexEntry struct {
+	id       int64
+	name     string
+	key      string
+	trigrams map[string]struct{}
+}
+
+// NameIndex is an in-process index over the locally stored character names.
+// It ranks prefix matches first and falls back to edit distance and trigram
+// similarity, so it works without any server-side search support.
+type NameIndex struct {
+	mu      sync.RWMutex
+	entries map[string]indexEntry
+	builtAt time.Time
+}
+
+func NewNameIndex() *NameIndex {
+	return &NameIndex{entries: map[string]indexEntry{}}
+}
+
+func (idx *NameIndex) Rebuild(records []domain.CharacterEntity) {
+	entries := make(map[string]indexEntry, len(records))
+	for _, r := range records {
+		if e, ok := newIndexEntry(r.Id, r.Name); ok {
+			entries[e.key] = e
+		}
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.entries = entries
+	idx.builtAt = time.Now()
+}
+
+func (idx *NameIndex) Add(id int64, name string) {
+	e, ok := newIndexEntry(id, name)
+	if !ok {
+		return
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.entries[e.key] = e
+}
+
+func (idx *NameIndex) BuiltAt() time.Time {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	return idx.builtAt
+}
+
+func (idx *NameIndex) Search(query string, limit int) []domain.CharacterMatchDTO {
+	q := domain.NormalizeName(query)
+	if q == "" || limit <= 0 {
+		return nil
+	}
+	qTrigrams := trigrams(q)
+
+	idx.mu.RLock()
+	matches := make([]domain.CharacterMatchDTO, 0, len(idx.entries))
+	for _, e := range idx.entries {
+		score := scoreEntry(q, qTrigrams, e)
+		if score < minFuzzyScore {
+			continue
+		}
+		matches = append(matches, domain.CharacterMatchDTO{
+			Id:    e.id,
+			Name:  e.name,
+			Score: score,
+		})
+	}
+	idx.mu.RUnlock()
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].Name < matches[j].Name
+	})
+
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+
+	return matches
+}
+
+func newIndexEntry(id int64, name string) (indexEntry, bool) {
+	key := domain.NormalizeName(name)
+	if key == "" {
+		return indexEntry{}, false
+	}
+
+	return indexEntry{
+		id:       id,
+		name:     name,
+		key:      key,
+		trigrams: trigrams(key),
+	}, true
+}
+
+// scoreEntry returns a relevance in [0, 1]. Exact matches score 1, prefix
+// matches score in (0.9, 1), prefixes of later words in (0.8, 0.9) and
+// anything else is ranked by string similarity.
+func scoreEntry(q string, qTrigrams map[string]struct{}, e indexEntry) float64 {
+	if q == e.key {
+		return 1
+	}
+
+	coverage := float64(len([]rune(q))) / float64(len([]rune(e.key)))
+
+	if strings.HasPrefix(e.key, q) {
+		return 0.9 + 0.09*coverage
+	}
+
+	for _, word := range strings.Fields(e.key)[1:] {
+		if strings.HasPrefix(word, q) {
+			return 0.8 + 0.09*coverage
+		}
+	}
+
+	return min(0.8, max(editSimilarity(q, e.key), trigramSimilarity(qTrigrams, e.trigrams)))
+}
+
+func editSimilarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := max(len(ra), len(rb))
+	if longest == 0 {
+		return 1
+	}
+
+	return 1 - float64(levenshtein(ra, rb))/float64(longest)
+}
+
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
+
+func trigrams(s string) map[string]struct{} {
+	padded := []rune("  " + s + " ")
+	set := make(map[string]struct{}, len(padded))
+	for i := 0; i+3 <= len(padded); i++ {
+		set[string(padded[i:i+3])] = struct{}{}
+	}
+
+	return set
+}
+
+func trigramSimilarity(a, b map[string]struct{}) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	shared := 0
+	for t := range a {
+		if _, ok := b[t]; ok {
+			shared++
+		}
+	}
+
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
@@ -0,0 +1,12 @@
+package application
+
+import "context"
+
+// EmptyMarks remembers lookups that upstream answered with nothing. An empty
+// result has nothing to store, so without a mark it would look like a miss
+// and go upstream again on every request.
+type EmptyMarks interface {
+	Mark(ctx context.Context, key string) error
+	// Marked returns the keys, out of keys, that carry a mark.
+	Marked(ctx context.Context, keys []string) (map[string]bool, error)
+}
@@ -0,0 +1,20 @@
+package application
+
+// Metrics receives one event per resolved lookup of a cached resource.
+type Metrics interface {
+	// Hit: served from the local store.
+	Hit(resource string)
+	// Miss: served from upstream.
+	Miss(resource string)
+	// Failure: neither the local store nor upstream could serve it.
+	Failure(resource string)
+	// SaveError: the background write of an upstream result failed.
+	SaveError(resource string)
+}
+
+type NopMetrics struct{}
+
+func (NopMetrics) Hit(string)       {}
+func (NopMetrics) Miss(string)      {}
+func (NopMetrics) Failure(string)   {}
+func (NopMetrics) SaveError(string) {}
@@ -0,0 +1,308 @@
+package planet
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strconv"
+
+	"github.com/heaveless/dbz-api/internal/application"
+	charapp "github.com/heaveless/dbz-api/internal/application/character"
+	"github.com/heaveless/dbz-api/internal/domain/character"
+	domain "github.com/heaveless/dbz-api/internal/domain/planet"
+	"golang.org/x/sync/errgroup"
+)
+
+// batchParallelism bounds how many planets of one batch go upstream at
+// once, as for character batches.
+const batchParallelism = 4
+
+// listFetchedMark is marked once the whole upstream planet list is stored.
+// The planets collection alone cannot tell: single planets looked up by id
+// are stored there too.
+const listFetchedMark = "planet list:fetched"
+
+var (
+	errListNotFetched    = errors.New("planet list not fetched")
+	errResidentsNotFound = errors.New("planet residents not stored")
+)
+
+type (
+	planetCache    = application.CachedResourceService[int64, domain.PlanetDetail, domain.PlanetDTO]
+	residentsCache = application.CachedResourceService[int64, domain.PlanetDetail, []character.CharacterDTO]
+	listCache      = application.CachedResourceService[struct{}, []domain.PlanetEntity, []domain.PlanetDTO]
+)
+
+type PlanetService struct {
+	repo       domain.PlanetRepository
+	characters character.CharacterRepository
+	planets    *planetCache
+	residents  *residentsCache
+	list       *listCache
+}
+
+// NewPlanetService takes marks to remember that the upstream planet list,
+// empty or not, is stored; with nil marks it is asked for every time.
+func NewPlanetService(pr domain.PlanetRepository, pa domain.PlanetApi, cr character.CharacterRepository, marks application.EmptyMarks) *PlanetService {
+	return NewPlanetServiceWithMetrics(pr, pa, cr, marks, nil)
+}
+
+func NewPlanetServiceWithMetrics(pr domain.PlanetRepository, pa domain.PlanetApi, cr character.CharacterRepository, marks application.EmptyMarks, m application.Metrics) *PlanetService {
+	key := func(id int64) string { return strconv.FormatInt(id, 10) }
+
+	return &PlanetService{
+		repo:       pr,
+		characters: cr,
+		planets: application.NewCachedResourceService(
+			detailStore{repo: pr, characters: cr}, pa, planetMapper{},
+			application.CacheOptions[int64, domain.PlanetDetail]{Name: "planet", Key: key, Metrics: m},
+		),
+		residents: application.NewCachedResourceService(
+			detailStore{repo: pr, characters: cr, withResidents: true}, pa, residentsMapper{},
+			application.CacheOptions[int64, domain.PlanetDetail]{Name: "planet residents", Key: key, Metrics: m},
+		),
+		list: application.NewCachedResourceService(
+			listStore{repo: pr, marks: marks}, listUpstream{api: pa}, listMapper{},
+			application.CacheOptions[struct{}, []domain.PlanetEntity]{
+				Name:    "planet list",
+				Metrics: m,
+				IsEmpty: func(planets *[]domain.PlanetEntity) bool { return len(*planets) == 0 },
+				Marks:   marks,
+			},
+		),
+	}
+}
+
+func (s *PlanetService) GetById(ctx context.Context, id int64) (*domain.PlanetDTO, error) {
+	return s.planets.Get(ctx, id)
+}
+
+// List serves the stored planets once the whole upstream list has been
+// stored, and goes upstream for it until then.
+func (s *PlanetService) List(ctx context.Context) ([]domain.PlanetDTO, error) {
+	dtos, err := s.list.Get(ctx, struct{}{})
+	if err != nil {
+		return nil, err
+	}
+
+	return *dtos, nil
+}
+
+func (s *PlanetService) Characters(ctx context.Context, id int64) ([]character.CharacterDTO, error) {
+	dtos, err := s.residents.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return *dtos, nil
+}
+
+// CharactersByPlanets reads every planet and then all of their residents
+// with one query each. Only planets whose residents are not all stored go
+// through Characters, and so upstream, a few at a time; when a query fails
+// they all do. Results keep the order of planetIds; a failed lookup only
+// fails its own entry.
+func (s *PlanetService) CharactersByPlanets(ctx context.Context, planetIds []int64) []domain.PlanetResidents {
+	results := make([]domain.PlanetResidents, len(planetIds))
+	stored := s.storedResidents(ctx, planetIds)
+
+	var g errgroup.Group
+	g.SetLimit(batchParallelism)
+
+	for i, id := range planetIds {
+		if residents, ok := stored[id]; ok {
+			results[i] = domain.PlanetResidents{PlanetId: id, Residents: residents}
+			continue
+		}
+
+		g.Go(func() error {
+			residents, err := s.Characters(ctx, id)
+			results[i] = domain.PlanetResidents{PlanetId: id, Residents: residents, Err: err}
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	return results
+}
+
+// storedResidents returns the residents of the planets whose residents are
+// all in the local store, in the order the planet lists them.
+func (s *PlanetService) storedResidents(ctx context.Context, planetIds []int64) map[int64][]character.CharacterDTO {
+	planets, err := s.repo.GetByIds(ctx, planetIds)
+	if err != nil {
+		log.Printf("[DB] failed to read %d planets, falling back one by one: %v", len(planetIds), err)
+		return nil
+	}
+
+	var characterIds []int64
+	for _, p := range planets {
+		characterIds = append(characterIds, p.CharacterIds...)
+	}
+
+	var records []character.CharacterEntity
+	if len(characterIds) > 0 {
+		if records, err = s.characters.GetByIds(ctx, characterIds); err != nil {
+			log.Printf("[DB] failed to read residents of %d planets, falling back one by one: %v", len(planets), err)
+			return nil
+		}
+	}
+
+	byId := make(map[int64]*character.CharacterEntity, len(records))
+	for i := range records {
+		byId[records[i].Id] = &records[i]
+	}
+
+	stored := make(map[int64][]character.CharacterDTO, len(planets))
+	for _, p := range planets {
+		if p.CharacterIds == nil {
+			continue
+		}
+
+		residents := make([]character.CharacterEntity, 0, len(p.CharacterIds))
+		for _, id := range p.CharacterIds {
+			if c, ok := byId[id]; ok {
+				residents = append(residents, *c)
+			}
+		}
+		if len(residents) == len(p.CharacterIds) {
+			stored[p.Id] = charapp.ToDTOs(residents)
+		}
+	}
+
+	return stored
+}
+
+// detailStore reads a planet, and its residents when asked to, from the local
+// store. Saving a detail stores the planet together with its residents.
+type detailStore struct {
+	repo          domain.PlanetRepository
+	characters    character.CharacterRepository
+	withResidents bool
+}
+
+func (d detailStore) Get(ctx context.Context, id int64) (*domain.PlanetDetail, error) {
+	p, err := d.repo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !d.withResidents {
+		return &domain.PlanetDetail{Planet: *p}, nil
+	}
+	if p.CharacterIds == nil {
+		return nil, errResidentsNotFound
+	}
+
+	records, err := d.characters.GetByIds(ctx, p.CharacterIds)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) != len(p.CharacterIds) {
+		return nil, errResidentsNotFound
+	}
+
+	return &domain.PlanetDetail{Planet: *p, Characters: records}, nil
+}
+
+func (d detailStore) Create(ctx context.Context, detail *domain.PlanetDetail) error {
+	if err := d.repo.Save(ctx, &detail.Planet); err != nil {
+		return err
+	}
+
+	for i := range detail.Characters {
+		c := &detail.Characters[i]
+		if err := d.characters.Create(ctx, c); err != nil {
+			log.Printf("[DB] failed to save resident %d from api: %v", c.Id, err)
+		}
+	}
+
+	return nil
+}
+
+// listStore serves the planets collection only while listFetchedMark is
+// set. The mark expires with the other marks, so the list is refreshed from
+// upstream now and then.
+type listStore struct {
+	repo  domain.PlanetRepository
+	marks application.EmptyMarks
+}
+
+func (l listStore) Get(ctx context.Context, _ struct{}) (*[]domain.PlanetEntity, error) {
+	if l.marks == nil {
+		return nil, errListNotFetched
+	}
+	marked, err := l.marks.Marked(ctx, []string{listFetchedMark})
+	if err != nil {
+		return nil, err
+	}
+	if !marked[listFetchedMark] {
+		return nil, errListNotFetched
+	}
+
+	planets, err := l.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &planets, nil
+}
+
+// Create marks the list fetched only when every planet was saved.
+func (l listStore) Create(ctx context.Context, planets *[]domain.PlanetEntity) error {
+	var errs []error
+	for i := range *planets {
+		errs = append(errs, l.repo.Save(ctx, &(*planets)[i]))
+	}
+	if err := errors.Join(errs...); err != nil || l.marks == nil {
+		return err
+	}
+
+	return l.marks.Mark(ctx, listFetchedMark)
+}
+
+type listUpstream struct {
+	api domain.PlanetApi
+}
+
+func (l listUpstream) Get(ctx context.Context, _ struct{}) (*[]domain.PlanetEntity, error) {
+	planets, err := l.api.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &planets, nil
+}
+
+type planetMapper struct{}
+
+func (planetMapper) ToDTO(d *domain.PlanetDetail) *domain.PlanetDTO {
+	return ToDTO(&d.Planet)
+}
+
+type residentsMapper struct{}
+
+func (residentsMapper) ToDTO(d *domain.PlanetDetail) *[]character.CharacterDTO {
+	dtos := charapp.ToDTOs(d.Characters)
+	return &dtos
+}
+
+type listMapper struct{}
+
+func (listMapper) ToDTO(planets *[]domain.PlanetEntity) *[]domain.PlanetDTO {
+	dtos := make([]domain.PlanetDTO, 0, len(*planets))
+	for i := range *planets {
+		dtos = append(dtos, *ToDTO(&(*planets)[i]))
+	}
+
+	return &dtos
+}
+
+func ToDTO(p *domain.PlanetEntity) *domain.PlanetDTO {
+	return &domain.PlanetDTO{
+		Id:          p.Id,
+		Name:        p.Name,
+		IsDestroyed: p.IsDestroyed,
+		Description: p.Description,
+		Image:       p.Image,
+	}
+}
@@ -0,0 +1,175 @@
+package transformation
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strconv"
+
+	"github.com/heaveless/dbz-api/internal/application"
+	domain "github.com/heaveless/dbz-api/internal/domain/transformation"
+	"golang.org/x/sync/errgroup"
+)
+
+// batchParallelism bounds how many characters of one batch go upstream at
+// once, as for character batches.
+const batchParallelism = 4
+
+var errNoTransformationsStored = errors.New("no transformations stored")
+
+type TransformationService struct {
+	repo   domain.TransformationRepository
+	cached *application.CachedResourceService[int64, []domain.TransformationEntity, []domain.TransformationDTO]
+}
+
+// NewTransformationService takes marks to remember characters that have no
+// transformations upstream; with nil marks they are asked for every time.
+func NewTransformationService(tr domain.TransformationRepository, ta domain.TransformationApi, marks application.EmptyMarks) *TransformationService {
+	return NewTransformationServiceWithMetrics(tr, ta, marks, nil)
+}
+
+func NewTransformationServiceWithMetrics(tr domain.TransformationRepository, ta domain.TransformationApi, marks application.EmptyMarks, m application.Metrics) *TransformationService {
+	return &TransformationService{
+		repo: tr,
+		cached: application.NewCachedResourceService(
+			store{repo: tr}, upstream{api: ta}, mapper{},
+			application.CacheOptions[int64, []domain.TransformationEntity]{
+				Name:    "transformations of character",
+				Key:     func(id int64) string { return strconv.FormatInt(id, 10) },
+				Metrics: m,
+				IsEmpty: func(records *[]domain.TransformationEntity) bool { return len(*records) == 0 },
+				Marks:   marks,
+			},
+		),
+	}
+}
+
+func (s *TransformationService) ListByCharacter(ctx context.Context, characterId int64) ([]domain.TransformationDTO, error) {
+	dtos, err := s.cached.Get(ctx, characterId)
+	if err != nil {
+		return nil, err
+	}
+
+	return *dtos, nil
+}
+
+// ListByCharacters reads the transformations of every character with one
+// query to the local store, and which of the rest are known to have none
+// with another. Only the characters left go through ListByCharacter, and so
+// upstream, a few at a time; when the first query fails they all do.
+// Results keep the order of characterIds; a failed lookup only fails its
+// own entry.
+func (s *TransformationService) ListByCharacters(ctx context.Context, characterIds []int64) []domain.CharacterTransformations {
+	results := make([]domain.CharacterTransformations, len(characterIds))
+	byCharacter := s.stored(ctx, characterIds)
+
+	var g errgroup.Group
+	g.SetLimit(batchParallelism)
+
+	for i, id := range characterIds {
+		if dtos, ok := byCharacter[id]; ok {
+			results[i] = domain.CharacterTransformations{CharacterId: id, Transformations: dtos}
+			continue
+		}
+
+		g.Go(func() error {
+			dtos, err := s.ListByCharacter(ctx, id)
+			results[i] = domain.CharacterTransformations{CharacterId: id, Transformations: dtos, Err: err}
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	return results
+}
+
+// stored returns the transformations the local store can serve, empty
+// lists of marked characters included.
+func (s *TransformationService) stored(ctx context.Context, characterIds []int64) map[int64][]domain.TransformationDTO {
+	records, err := s.repo.ListByCharacters(ctx, characterIds)
+	if err != nil {
+		log.Printf("[DB] failed to read transformations of %d characters, falling back one by one: %v", len(characterIds), err)
+		return nil
+	}
+
+	byCharacter := make(map[int64][]domain.TransformationDTO, len(characterIds))
+	for i := range records {
+		id := records[i].CharacterId
+		byCharacter[id] = append(byCharacter[id], *ToDTO(&records[i]))
+	}
+
+	var missing []int64
+	for _, id := range characterIds {
+		if _, ok := byCharacter[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+
+	empty, err := s.cached.MarkedEmpty(ctx, missing)
+	if err != nil {
+		log.Printf("[DB] failed to read which of %d characters have no transformations: %v", len(missing), err)
+	}
+	for _, id := range empty {
+		byCharacter[id] = []domain.TransformationDTO{}
+	}
+
+	return byCharacter
+}
+
+type store struct {
+	repo domain.TransformationRepository
+}
+
+func (s store) Get(ctx context.Context, characterId int64) (*[]domain.TransformationEntity, error) {
+	records, err := s.repo.ListByCharacter(ctx, characterId)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, errNoTransformationsStored
+	}
+
+	return &records, nil
+}
+
+func (s store) Create(ctx context.Context, records *[]domain.TransformationEntity) error {
+	if len(*records) == 0 {
+		return nil
+	}
+
+	return s.repo.CreateMany(ctx, *records)
+}
+
+type upstream struct {
+	api domain.TransformationApi
+}
+
+func (u upstream) Get(ctx context.Context, characterId int64) (*[]domain.TransformationEntity, error) {
+	records, err := u.api.ListByCharacter(ctx, characterId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &records, nil
+}
+
+type mapper struct{}
+
+func (mapper) ToDTO(records *[]domain.TransformationEntity) *[]domain.TransformationDTO {
+	dtos := make([]domain.TransformationDTO, 0, len(*records))
+	for i := range *records {
+		dtos = append(dtos, *ToDTO(&(*records)[i]))
+	}
+
+	return &dtos
+}
+
+func ToDTO(t *domain.TransformationEntity) *domain.TransformationDTO {
+	return &domain.TransformationDTO{
+		Id:          t.Id,
+		CharacterId: t.CharacterId,
+		Name:        t.Name,
+		Image:       t.Image,
+		Ki:          t.Ki,
+	}
+}
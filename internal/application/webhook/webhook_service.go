@@ -0,0 +1,335 @@
+package webhook
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/heaveless/dbz-api/internal/domain/event"
+	domain "github.com/heaveless/dbz-api/internal/domain/webhook"
+	utils "github.com/heaveless/dbz-api/internal/utils"
+)
+
+const (
+	DefaultInterval    = time.Second
+	DefaultBatch       = 50
+	DefaultMinBackoff  = 10 * time.Second
+	DefaultMaxBackoff  = time.Hour
+	DefaultMaxAttempts = 10
+
+	secretPrefix = "whsec_"
+	// logLimit is how many deliveries the delivery log shows.
+	logLimit = 50
+	// sendTimeout bounds one attempt.
+	sendTimeout = 10 * time.Second
+)
+
+type Sender interface {
+	Send(ctx context.Context, sub *domain.SubscriptionEntity, d *domain.DeliveryEntity) domain.AttemptEntity
+}
+
+type Options struct {
+	// Interval is how long the dispatcher waits after a poll that found
+	// nothing due.
+	Interval time.Duration
+	Batch    int
+	// MinBackoff doubles after every failed attempt up to MaxBackoff; a
+	// delivery fails for good after MaxAttempts.
+	MinBackoff  time.Duration
+	MaxBackoff  time.Duration
+	MaxAttempts int
+}
+
+// WebhookService manages subscriptions and delivers events to them. As an
+// event.Sink it turns each outbox message into one delivery per interested
+// subscription; Run then sends those deliveries, retrying failures with
+// exponential backoff.
+type WebhookService struct {
+	subs       domain.SubscriptionRepository
+	deliveries domain.DeliveryRepository
+	sender     Sender
+	opts       Options
+	now        func() time.Time
+}
+
+// NewWebhookService uses the defaults for zero options.
+func NewWebhookService(subs domain.SubscriptionRepository, deliveries domain.DeliveryRepository, sender Sender, opts Options) *WebhookService {
+	return NewWebhookServiceWithClock(subs, deliveries, sender, opts, time.Now)
+}
+
+func NewWebhookServiceWithClock(subs domain.SubscriptionRepository, deliveries domain.DeliveryRepository, sender Sender, opts Options, now func() time.Time) *WebhookService {
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultInterval
+	}
+	if opts.Batch <= 0 {
+		opts.Batch = DefaultBatch
+	}
+	if opts.MinBackoff <= 0 {
+		opts.MinBackoff = DefaultMinBackoff
+	}
+	if opts.MaxBackoff < opts.MinBackoff {
+		opts.MaxBackoff = max(DefaultMaxBackoff, opts.MinBackoff)
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = DefaultMaxAttempts
+	}
+
+	return &WebhookService{subs: subs, deliveries: deliveries, sender: sender, opts: opts, now: now}
+}
+
+func (s *WebhookService) Register(ctx context.Context, req domain.NewSubscription) (*domain.CreatedSubscriptionDTO, error) {
+	id, err := utils.RandomString(8, hex.EncodeToString)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := req.Secret
+	if secret == "" {
+		if secret, err = utils.RandomString(24, base64.RawURLEncoding.EncodeToString); err != nil {
+			return nil, err
+		}
+		secret = secretPrefix + secret
+	}
+
+	sub := &domain.SubscriptionEntity{
+		Id:        id,
+		Url:       req.Url,
+		Events:    req.Events,
+		Secret:    secret,
+		CreatedAt: s.now().UTC(),
+	}
+	if sub.Events == nil {
+		sub.Events = []event.Type{}
+	}
+	if err := s.subs.Save(ctx, sub); err != nil {
+		return nil, err
+	}
+
+	return &domain.CreatedSubscriptionDTO{SubscriptionDTO: *toDTO(sub), Secret: secret}, nil
+}
+
+func (s *WebhookService) List(ctx context.Context) ([]domain.SubscriptionDTO, error) {
+	subs, err := s.subs.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]domain.SubscriptionDTO, 0, len(subs))
+	for i := range subs {
+		dtos = append(dtos, *toDTO(&subs[i]))
+	}
+
+	return dtos, nil
+}
+
+// Deliveries is the delivery log of a subscription, latest first.
+func (s *WebhookService) Deliveries(ctx context.Context, id string) ([]domain.DeliveryDTO, error) {
+	if _, err := s.subs.Get(ctx, id); err != nil {
+		return nil, err
+	}
+
+	deliveries, err := s.deliveries.ListBySubscription(ctx, id, logLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]domain.DeliveryDTO, 0, len(deliveries))
+	for i := range deliveries {
+		dtos = append(dtos, *toDeliveryDTO(&deliveries[i]))
+	}
+
+	return dtos, nil
+}
+
+// Test sends a TestEvent to the subscription right away and logs it. It is
+// not retried: the result is for whoever asked.
+func (s *WebhookService) Test(ctx context.Context, id string) (*domain.DeliveryDTO, error) {
+	sub, err := s.subs.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := utils.RandomString(12, hex.EncodeToString)
+	if err != nil {
+		return nil, err
+	}
+	now := s.now().UTC()
+	payload, err := json.Marshal(event.Message{Key: key, Type: domain.TestEvent, At: now, Data: json.RawMessage(`{}`)})
+	if err != nil {
+		return nil, err
+	}
+
+	d := newDelivery(sub, key, domain.TestEvent, string(payload), now)
+	attempt := s.send(ctx, sub, d)
+	d.Attempts = []domain.AttemptEntity{attempt}
+	d.Status = domain.StatusFailed
+	if attempt.Succeeded() {
+		d.Status = domain.StatusDelivered
+	}
+	d.NextAttemptAt = time.Time{}
+
+	if err := s.deliveries.Enqueue(ctx, d); err != nil {
+		return nil, err
+	}
+
+	return toDeliveryDTO(d), nil
+}
+
+func (s *WebhookService) Name() string { return "webhooks" }
+
+// Send enqueues m for every subscription that wants it. Deliveries are keyed
+// by message, so a message sent again is not enqueued twice.
+func (s *WebhookService) Send(ctx context.Context, m event.Message) error {
+	subs, err := s.subs.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	now := s.now().UTC()
+	for i := range subs {
+		if !subs[i].Wants(m.Type) {
+			continue
+		}
+		if err := s.deliveries.Enqueue(ctx, newDelivery(&subs[i], m.Key, m.Type, string(payload), now)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Run dispatches due deliveries until ctx is done.
+func (s *WebhookService) Run(ctx context.Context) {
+	for {
+		n, err := s.DispatchOnce(ctx)
+		if err != nil {
+			log.Printf("[WEBHOOK] dispatch failed: %v", err)
+		}
+		if n == s.opts.Batch && err == nil {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(s.opts.Interval):
+		}
+	}
+}
+
+// DispatchOnce attempts one batch of due deliveries and returns how many it
+// read.
+func (s *WebhookService) DispatchOnce(ctx context.Context) (int, error) {
+	due, err := s.deliveries.Due(ctx, s.now().UTC(), s.opts.Batch)
+	if err != nil {
+		return 0, err
+	}
+
+	var errs []error
+	for i := range due {
+		if ctx.Err() != nil {
+			break
+		}
+		if err := s.dispatch(ctx, &due[i]); err != nil {
+			errs = append(errs, fmt.Errorf("delivery %s: %w", due[i].Id, err))
+		}
+	}
+
+	return len(due), errors.Join(errs...)
+}
+
+func (s *WebhookService) dispatch(ctx context.Context, d *domain.DeliveryEntity) error {
+	sub, err := s.subs.Get(ctx, d.SubscriptionId)
+	if errors.Is(err, domain.ErrNotFound) {
+		attempt := domain.AttemptEntity{At: s.now().UTC(), Error: "the subscription no longer exists"}
+		return s.deliveries.Attempted(ctx, d.Id, attempt, domain.StatusFailed, time.Time{})
+	}
+	if err != nil {
+		return err
+	}
+
+	attempt := s.send(ctx, sub, d)
+	switch {
+	case attempt.Succeeded():
+		return s.deliveries.Attempted(ctx, d.Id, attempt, domain.StatusDelivered, time.Time{})
+	case len(d.Attempts)+1 >= s.opts.MaxAttempts:
+		log.Printf("[WEBHOOK] delivery %s to %s failed for good: %s", d.Id, sub.Url, attempt.Error)
+		return s.deliveries.Attempted(ctx, d.Id, attempt, domain.StatusFailed, time.Time{})
+	}
+
+	next := s.now().UTC().Add(s.backoff(len(d.Attempts)))
+	return s.deliveries.Attempted(ctx, d.Id, attempt, domain.StatusPending, next)
+}
+
+func (s *WebhookService) send(ctx context.Context, sub *domain.SubscriptionEntity, d *domain.DeliveryEntity) domain.AttemptEntity {
+	sendCtx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+
+	return s.sender.Send(sendCtx, sub, d)
+}
+
+// backoff is the wait after the attempts+1th failure.
+func (s *WebhookService) backoff(attempts int) time.Duration {
+	d := s.opts.MinBackoff
+	for range attempts {
+		if d >= s.opts.MaxBackoff/2 {
+			return s.opts.MaxBackoff
+		}
+		d *= 2
+	}
+
+	return min(d, s.opts.MaxBackoff)
+}
+
+func newDelivery(sub *domain.SubscriptionEntity, key string, t event.Type, payload string, now time.Time) *domain.DeliveryEntity {
+	return &domain.DeliveryEntity{
+		Id:             key + ":" + sub.Id,
+		SubscriptionId: sub.Id,
+		EventKey:       key,
+		Type:           t,
+		Payload:        payload,
+		Status:         domain.StatusPending,
+		Attempts:       []domain.AttemptEntity{},
+		NextAttemptAt:  now,
+		CreatedAt:      now,
+	}
+}
+
+func toDTO(sub *domain.SubscriptionEntity) *domain.SubscriptionDTO {
+	return &domain.SubscriptionDTO{
+		Id:        sub.Id,
+		Url:       sub.Url,
+		Events:    sub.Events,
+		CreatedAt: sub.CreatedAt,
+	}
+}
+
+func toDeliveryDTO(d *domain.DeliveryEntity) *domain.DeliveryDTO {
+	dto := &domain.DeliveryDTO{
+		Id:        d.Id,
+		EventKey:  d.EventKey,
+		Type:      d.Type,
+		Status:    d.Status,
+		Attempts:  make([]domain.AttemptDTO, 0, len(d.Attempts)),
+		CreatedAt: d.CreatedAt,
+	}
+	for _, a := range d.Attempts {
+		dto.Attempts = append(dto.Attempts, domain.AttemptDTO(a))
+	}
+	if d.Status == domain.StatusPending {
+		next := d.NextAttemptAt
+		dto.NextAttemptAt = &next
+	}
+
+	return dto
+}
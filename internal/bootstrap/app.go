@@ -1,22 +1,42 @@
 package bootstrap
 
 import (
+	"net"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	auditapp "github.com/heaveless/dbz-api/internal/application/audit"
 	"github.com/heaveless/dbz-api/internal/application/character"
+	"github.com/heaveless/dbz-api/internal/application/planet"
+	"github.com/heaveless/dbz-api/internal/application/transformation"
+	webhookapp "github.com/heaveless/dbz-api/internal/application/webhook"
+	graphqldelivery "github.com/heaveless/dbz-api/internal/delivery/graphql"
+	grpcdelivery "github.com/heaveless/dbz-api/internal/delivery/grpc"
 	"github.com/heaveless/dbz-api/internal/delivery/http"
 	"github.com/heaveless/dbz-api/internal/delivery/http/handler"
+	"github.com/heaveless/dbz-api/internal/delivery/http/middleware"
+	"github.com/heaveless/dbz-api/internal/domain/event"
 	"github.com/heaveless/dbz-api/internal/infrastructure/api"
 	"github.com/heaveless/dbz-api/internal/infrastructure/breaker"
+	"github.com/heaveless/dbz-api/internal/infrastructure/eventbus"
+	"github.com/heaveless/dbz-api/internal/infrastructure/metrics"
+	"github.com/heaveless/dbz-api/internal/infrastructure/outbox"
+	"github.com/heaveless/dbz-api/internal/infrastructure/ratelimit"
 	"github.com/heaveless/dbz-api/internal/infrastructure/repositoy"
 	"go.mongodb.org/mongo-driver/v2/mongo"
+	"google.golang.org/grpc"
 )
 
 type Application struct {
 	Env *Env
 	Db  *mongo.Client
 	Svr *gin.Engine
+	// Grpc is nil unless GRPC_PORT is set.
+	Grpc *grpc.Server
+	// Relay is nil unless OUTBOX_SINKS or WEBHOOKS_ENABLED is set.
+	Relay *outbox.Relay
+	// Webhooks is nil unless WEBHOOKS_ENABLED is set.
+	Webhooks *webhookapp.WebhookService
 }
 
 func App() Application {
@@ -24,24 +44,142 @@ func App() Application {
 	app.Env = NewEnv()
 	app.Db = NewDatabase(app.Env)
 
-	collection := app.Db.Database(app.Env.DBName).Collection("characters")
-	dbCollection := breaker.NewMongoDbCollection(collection)
+	database := app.Db.Database(app.Env.DBName)
+	if app.Env.DBAutoMigrate {
+		RunMigrations(database)
+	}
 
-	dbBreaker := breaker.NewDbCollectionWithBreaker(dbCollection, 3*time.Second)
-	httpBreaker := breaker.NewHttpWithBreaker(3 * time.Second)
+	collection := func(name string) breaker.DbCollection {
+		dbCollection := breaker.NewMongoDbCollection(database.Collection(name))
+		return breaker.NewDbCollectionWithBreaker(dbCollection, 3*time.Second)
+	}
 
-	characterRepo := repositoy.NewCharacterRepository(dbBreaker)
+	events := eventbus.NewBus(app.Env.EventsHistory)
+	auditLog := repositoy.NewAuditRepository(collection(repositoy.AuditCollection))
+	// Webhook subscriptions are fed by the outbox relay like any other sink.
+	app.Webhooks = NewWebhooks(app.Env, collection, auditLog)
+	var webhookSinks []event.Sink
+	if app.Webhooks != nil {
+		webhookSinks = append(webhookSinks, app.Webhooks)
+	}
+	eventOutbox, relay := NewOutbox(app.Env, collection, webhookSinks...)
+	app.Relay = relay
+	// Events are recorded in the transaction of the write they report, which
+	// needs MongoDB to run as a replica set. Without an outbox there is
+	// nothing to record.
+	var tx repositoy.Transactor = repositoy.NoTransaction{}
+	if relay != nil {
+		tx = repositoy.NewMongoTransactor(app.Db)
+	}
+
+	// The limiter sits in front of the breaker so calls it holds back never
+	// count as upstream failures, nor as an upstream outage.
+	httpBreaker := breaker.NewHttpWithLimiter(breaker.NewHttpWithEvents(breaker.NewHttpWithBreaker(3*time.Second), events), breaker.OutboundLimit{
+		Rate:        app.Env.UpstreamRps,
+		Burst:       app.Env.UpstreamBurst,
+		MaxInFlight: app.Env.UpstreamMaxInFlight,
+		Wait:        app.Env.UpstreamWait,
+	})
+
+	characterRepo := repositoy.NewCharacterRepositoryWithAudit(collection(repositoy.CharacterCollection), tx, events, eventOutbox, auditLog)
 	characterApi := api.NewCharacterApi(app.Env.ApiUri, httpBreaker)
 
-	characterService := character.NewCharacterService(characterRepo, characterApi)
+	planetRepo := repositoy.NewPlanetRepository(collection(repositoy.PlanetCollection))
+	planetApi := api.NewPlanetApi(app.Env.ApiUri, httpBreaker)
+
+	transformationRepo := repositoy.NewTransformationRepository(collection(repositoy.TransformationCollection))
+	transformationApi := api.NewTransformationApi(app.Env.ApiUri, httpBreaker)
+
+	cacheMetrics := metrics.NewExpvarMetrics("cache")
 
-	characterHandler := handler.NewCharacterHandler(characterService)
+	characterService := character.NewCharacterServiceWithMetrics(characterRepo, characterApi, cacheMetrics)
+	emptyMarks := repositoy.NewEmptyMarkRepository(collection(repositoy.EmptyMarkCollection))
+	planetService := planet.NewPlanetServiceWithMetrics(planetRepo, planetApi, characterRepo, emptyMarks, cacheMetrics)
+	transformationService := transformation.NewTransformationServiceWithMetrics(transformationRepo, transformationApi, emptyMarks, cacheMetrics)
 
-	app.Svr = http.NewServer(characterHandler)
+	limits := middleware.RateLimit{
+		Store:    ratelimit.NewMemoryStore(),
+		PerIP:    ratelimit.Limit{Rate: app.Env.RateLimitIpRps, Burst: app.Env.RateLimitIpBurst},
+		PerKey:   ratelimit.Limit{Rate: app.Env.RateLimitKeyRps, Burst: app.Env.RateLimitKeyBurst},
+		Upstream: ratelimit.Limit{Rate: app.Env.RateLimitUpstreamRps, Burst: app.Env.RateLimitUpstreamBurst},
+	}
+	rateLimit := middleware.RateLimited(limits)
+	callerRateLimit := middleware.CallerRateLimited(limits)
+
+	handlers := http.Handlers{
+		Character:      handler.NewCharacterHandlerWithCacheControl(characterService, cacheControl(app.Env)),
+		Planet:         handler.NewPlanetHandler(planetService),
+		Transformation: handler.NewTransformationHandler(transformationService),
+		Event: handler.NewEventHandlerWithOptions(events, handler.EventStreamOptions{
+			Heartbeat: app.Env.EventsHeartbeat,
+			Buffer:    app.Env.EventsClientBuffer,
+		}),
+		GraphQL: graphqldelivery.NewHandler(graphqldelivery.Services{
+			Characters:      characterService,
+			Planets:         planetService,
+			Transformations: transformationService,
+		}, graphqldelivery.Limits{MaxDepth: app.Env.GraphqlMaxDepth, MaxComplexity: app.Env.GraphqlMaxComplexity}),
+	}
+	mw := http.Middleware{
+		Global: append([]gin.HandlerFunc{middleware.RequestId()}, compression(app.Env)...),
+		Api:    []gin.HandlerFunc{rateLimit},
+	}
+
+	// The per-IP limit runs first so unauthenticated floods never reach the
+	// key or token checks; the per-caller limit only trusts credentials
+	// those checks accepted.
+	auth := NewAuth(app.Env, collection, auditLog)
+	if auth.Api != nil {
+		mw.Api = append(mw.Api, auth.Api, callerRateLimit)
+	}
+	if auth.Admin != nil {
+		mw.Admin = []gin.HandlerFunc{rateLimit, auth.Admin, callerRateLimit}
+		handlers.ApiKey = auth.ApiKeys
+		if app.Webhooks != nil {
+			handlers.Webhook = handler.NewWebhookHandler(app.Webhooks)
+		}
+		curation := repositoy.NewCharacterCurationRepository(collection(repositoy.CharacterCollection), tx, events, eventOutbox, auditLog)
+		handlers.CharacterAdmin = handler.NewCharacterAdminHandler(character.NewCurationService(curation))
+		handlers.Audit = handler.NewAuditHandler(auditapp.NewAuditService(auditLog))
+	}
+
+	app.Svr = http.NewServer(handlers, mw)
+	if app.Env.GrpcPort != "" {
+		app.Grpc = grpcdelivery.NewServer(grpcdelivery.NewCharacterServer(characterService), auth.Grpc, &limits)
+	}
 
 	return *app
 }
 
+func cacheControl(env *Env) string {
+	if env.HttpCacheControl == "" {
+		return handler.DefaultCacheControl
+	}
+
+	return env.HttpCacheControl
+}
+
+func compression(env *Env) []gin.HandlerFunc {
+	switch {
+	case env.HttpCompressMinSize < 0:
+		return nil
+	case env.HttpCompressMinSize == 0:
+		return []gin.HandlerFunc{middleware.Compress(middleware.DefaultCompressMinSize)}
+	}
+
+	return []gin.HandlerFunc{middleware.Compress(env.HttpCompressMinSize)}
+}
+
+// ServeGrpc blocks serving the gRPC API on GRPC_PORT.
+func (app *Application) ServeGrpc() error {
+	lis, err := net.Listen("tcp", ":"+app.Env.GrpcPort)
+	if err != nil {
+		return err
+	}
+
+	return app.Grpc.Serve(lis)
+}
+
 func (app *Application) CloseDbConnection() {
 	CloseDatabaseConnection(app.Db)
 }
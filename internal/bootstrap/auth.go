@@ -0,0 +1,102 @@
+package bootstrap
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heaveless/dbz-api/internal/application/apikey"
+	grpcdelivery "github.com/heaveless/dbz-api/internal/delivery/grpc"
+	"github.com/heaveless/dbz-api/internal/delivery/http/handler"
+	"github.com/heaveless/dbz-api/internal/delivery/http/middleware"
+	domain "github.com/heaveless/dbz-api/internal/domain/apikey"
+	"github.com/heaveless/dbz-api/internal/domain/audit"
+	"github.com/heaveless/dbz-api/internal/infrastructure/breaker"
+	"github.com/heaveless/dbz-api/internal/infrastructure/oidc"
+	"github.com/heaveless/dbz-api/internal/infrastructure/repositoy"
+)
+
+// Auth holds the authentication middleware per route group. A nil handler
+// leaves that group open; Admin is only set when API keys are enabled,
+// since without them there is nothing to administer. Grpc accepts the same
+// credentials as Api.
+type Auth struct {
+	Api     gin.HandlerFunc
+	Admin   gin.HandlerFunc
+	ApiKeys *handler.ApiKeyHandler
+	Grpc    *grpcdelivery.Auth
+}
+
+// Keys saved through the admin routes are recorded in auditLog.
+func NewAuth(env *Env, collection func(name string) breaker.DbCollection, auditLog audit.Log) Auth {
+	var auth Auth
+
+	if env.AuthEnabled {
+		service := apikey.NewApiKeyService(repositoy.NewAuditedApiKeyRepository(
+			repositoy.NewApiKeyRepository(
+				collection(repositoy.ApiKeyCollection),
+				collection(repositoy.ApiKeyUsageCollection),
+			),
+			auditLog,
+		))
+		if env.AuthAdminKey != "" {
+			EnsureAdminKey(service, env.AuthAdminKey)
+		}
+
+		auth.Api = middleware.Authenticated(service, domain.ScopeRead)
+		auth.Admin = middleware.Authenticated(service, domain.ScopeAdmin)
+		auth.ApiKeys = handler.NewApiKeyHandler(service)
+		auth.Grpc = &grpcdelivery.Auth{Keys: service, KeyScope: domain.ScopeRead}
+	}
+
+	if env.OidcEnabled {
+		if env.OidcJwks == "" {
+			log.Fatal("OIDC_JWKS is required when OIDC_ENABLED is set")
+		}
+		if env.OidcIssuer == "" {
+			log.Fatal("OIDC_ISSUER is required when OIDC_ENABLED is set")
+		}
+		if env.OidcAudience == "" {
+			log.Fatal("OIDC_AUDIENCE is required when OIDC_ENABLED is set")
+		}
+		verifier := oidc.NewVerifier(
+			oidc.NewKeyCache(oidc.NewSource(env.OidcJwks), env.OidcJwksRefresh),
+			oidc.Config{Issuer: env.OidcIssuer, Audience: env.OidcAudience},
+		)
+
+		// Requests without a token fall back to API keys when those are on.
+		auth.Api = middleware.BearerJWT(middleware.JWTAuth{
+			Verifier: verifier,
+			Scopes:   strings.Fields(env.OidcApiScopes),
+			Fallback: auth.Api,
+		})
+		if auth.Grpc == nil {
+			auth.Grpc = &grpcdelivery.Auth{}
+		}
+		auth.Grpc.Tokens = verifier
+		auth.Grpc.TokenScopes = strings.Fields(env.OidcApiScopes)
+		// Tokens reach /admin only with explicitly configured scopes.
+		if auth.Admin != nil && env.OidcAdminScopes != "" {
+			auth.Admin = middleware.BearerJWT(middleware.JWTAuth{
+				Verifier: verifier,
+				Scopes:   strings.Fields(env.OidcAdminScopes),
+				Fallback: auth.Admin,
+			})
+		}
+	}
+
+	return auth
+}
+
+// EnsureAdminKey registers AUTH_ADMIN_KEY so a fresh deployment has a key
+// that can create the others.
+func EnsureAdminKey(s *apikey.ApiKeyService, key string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := s.EnsureKey(ctx, key, "bootstrap admin", []string{domain.ScopeAdmin}); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -15,7 +15,9 @@ func NewDatabase(env *Env) *mongo.Client {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	mongoURI := fmt.Sprintf("mongodb://%s:%s", env.DBHost, env.DBPort)
+	// directConnection lets a single-node replica set be reached by the
+	// address it is published on rather than the one it advertises.
+	mongoURI := fmt.Sprintf("mongodb://%s:%s/?directConnection=true", env.DBHost, env.DBPort)
 
 	client, err := mongo.Connect(options.Client().ApplyURI(mongoURI))
 	if err != nil {
@@ -2,17 +2,86 @@ package bootstrap
 
 import (
 	"log"
+	"time"
 
 	"github.com/spf13/viper"
 )
 
 type Env struct {
-	AppEnv  string `mapstructure:"APP_ENV"`
-	AppPort string `mapstructure:"APP_PORT"`
-	DBHost  string `mapstructure:"DB_HOST"`
-	DBPort  string `mapstructure:"DB_PORT"`
-	DBName  string `mapstructure:"DB_NAME"`
-	ApiUri  string `mapstructure:"API_URI"`
+	AppEnv        string `mapstructure:"APP_ENV"`
+	AppPort       string `mapstructure:"APP_PORT"`
+	DBHost        string `mapstructure:"DB_HOST"`
+	DBPort        string `mapstructure:"DB_PORT"`
+	DBName        string `mapstructure:"DB_NAME"`
+	DBAutoMigrate bool   `mapstructure:"DB_AUTO_MIGRATE"`
+	ApiUri        string `mapstructure:"API_URI"`
+	// HttpCacheControl overrides the Cache-Control of character responses.
+	HttpCacheControl string `mapstructure:"HTTP_CACHE_CONTROL"`
+	// HttpCompressMinSize is the smallest body, in bytes, worth compressing.
+	// Zero uses the default of 1024; a negative value turns compression off.
+	HttpCompressMinSize int `mapstructure:"HTTP_COMPRESS_MIN_SIZE"`
+
+	// GraphqlMaxDepth and GraphqlMaxComplexity bound /graphql queries; zero
+	// uses the defaults of 8 and 500.
+	GraphqlMaxDepth      int `mapstructure:"GRAPHQL_MAX_DEPTH"`
+	GraphqlMaxComplexity int `mapstructure:"GRAPHQL_MAX_COMPLEXITY"`
+
+	// EventsHistory is how many events GET /events keeps for clients that
+	// resume with Last-Event-ID; EventsClientBuffer is how far a client may
+	// fall behind before it is disconnected. Zero uses 256 and 64.
+	EventsHistory      int           `mapstructure:"EVENTS_HISTORY"`
+	EventsClientBuffer int           `mapstructure:"EVENTS_CLIENT_BUFFER"`
+	EventsHeartbeat    time.Duration `mapstructure:"EVENTS_HEARTBEAT"`
+
+	// OutboxSinks is a comma-separated list of stdout, file, webhook and
+	// nats; empty records no events in the outbox. The relay polls every
+	// OutboxInterval for up to OutboxBatch entries; zero uses 1s and 100.
+	OutboxSinks       string        `mapstructure:"OUTBOX_SINKS"`
+	OutboxFile        string        `mapstructure:"OUTBOX_FILE"`
+	OutboxWebhookUrl  string        `mapstructure:"OUTBOX_WEBHOOK_URL"`
+	OutboxNatsUrl     string        `mapstructure:"OUTBOX_NATS_URL"`
+	OutboxNatsSubject string        `mapstructure:"OUTBOX_NATS_SUBJECT"`
+	OutboxInterval    time.Duration `mapstructure:"OUTBOX_INTERVAL"`
+	OutboxBatch       int           `mapstructure:"OUTBOX_BATCH"`
+
+	// WebhooksEnabled mounts /admin/webhooks and delivers events to the
+	// subscriptions registered there. A delivery fails for good after
+	// WebhooksMaxAttempts; zero uses 10.
+	WebhooksEnabled     bool `mapstructure:"WEBHOOKS_ENABLED"`
+	WebhooksMaxAttempts int  `mapstructure:"WEBHOOKS_MAX_ATTEMPTS"`
+
+	// GrpcPort serves the gRPC API on its own port; empty leaves it off.
+	GrpcPort string `mapstructure:"GRPC_PORT"`
+
+	// Inbound rate limits in requests per second and burst size; a zero
+	// rate disables the limit.
+	RateLimitIpRps         float64 `mapstructure:"RATE_LIMIT_IP_RPS"`
+	RateLimitIpBurst       int     `mapstructure:"RATE_LIMIT_IP_BURST"`
+	RateLimitKeyRps        float64 `mapstructure:"RATE_LIMIT_KEY_RPS"`
+	RateLimitKeyBurst      int     `mapstructure:"RATE_LIMIT_KEY_BURST"`
+	RateLimitUpstreamRps   float64 `mapstructure:"RATE_LIMIT_UPSTREAM_RPS"`
+	RateLimitUpstreamBurst int     `mapstructure:"RATE_LIMIT_UPSTREAM_BURST"`
+
+	// Outbound limits shared by every call to API_URI.
+	UpstreamRps         float64 `mapstructure:"UPSTREAM_RPS"`
+	UpstreamBurst       int     `mapstructure:"UPSTREAM_BURST"`
+	UpstreamMaxInFlight int     `mapstructure:"UPSTREAM_MAX_IN_FLIGHT"`
+	UpstreamWait        bool    `mapstructure:"UPSTREAM_WAIT"`
+
+	// AuthEnabled requires an API key on every API route and mounts /admin.
+	// AuthAdminKey, when set, is registered as an admin key on startup.
+	AuthEnabled  bool   `mapstructure:"AUTH_ENABLED"`
+	AuthAdminKey string `mapstructure:"AUTH_ADMIN_KEY"`
+
+	// OidcEnabled accepts OIDC access tokens as bearer tokens. OidcJwks is a
+	// file path or an http(s) URL; scopes are space separated.
+	OidcEnabled     bool          `mapstructure:"OIDC_ENABLED"`
+	OidcIssuer      string        `mapstructure:"OIDC_ISSUER"`
+	OidcAudience    string        `mapstructure:"OIDC_AUDIENCE"`
+	OidcJwks        string        `mapstructure:"OIDC_JWKS"`
+	OidcJwksRefresh time.Duration `mapstructure:"OIDC_JWKS_REFRESH"`
+	OidcApiScopes   string        `mapstructure:"OIDC_API_SCOPES"`
+	OidcAdminScopes string        `mapstructure:"OIDC_ADMIN_SCOPES"`
 }
 
 func NewEnv() *Env {
@@ -0,0 +1,82 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/heaveless/dbz-api/internal/infrastructure/migration"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+func NewMigrationRunner(db *mongo.Database) *migration.Runner {
+	runner, err := migration.NewRunner(db, migration.NewMongoStore(db), migration.All())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return runner
+}
+
+func RunMigrations(db *mongo.Database) {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	applied, err := NewMigrationRunner(db).Up(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, record := range applied {
+		log.Printf("[DB] applied migration %d: %s", record.Version, record.Description)
+	}
+}
+
+// Migrate implements the `migrate [up|status]` command.
+func Migrate(args []string) error {
+	env := NewEnv()
+	client := NewDatabase(env)
+	defer CloseDatabaseConnection(client)
+
+	db := client.Database(env.DBName)
+
+	subcommand := "up"
+	if len(args) > 0 {
+		subcommand = args[0]
+	}
+
+	switch subcommand {
+	case "up":
+		RunMigrations(db)
+		return nil
+	case "status":
+		return printMigrationStatus(db)
+	default:
+		return fmt.Errorf("unknown migrate subcommand %q (expected up or status)", subcommand)
+	}
+}
+
+func printMigrationStatus(db *mongo.Database) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	statuses, err := NewMigrationRunner(db).Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tSTATUS\tAPPLIED AT\tDESCRIPTION")
+	for _, s := range statuses {
+		state, appliedAt := "pending", "-"
+		if s.Applied {
+			state, appliedAt = "applied", s.AppliedAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", s.Version, state, appliedAt, s.Description)
+	}
+
+	return w.Flush()
+}
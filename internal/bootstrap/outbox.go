@@ -0,0 +1,72 @@
+package bootstrap
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/heaveless/dbz-api/internal/domain/event"
+	"github.com/heaveless/dbz-api/internal/infrastructure/breaker"
+	"github.com/heaveless/dbz-api/internal/infrastructure/outbox"
+	"github.com/heaveless/dbz-api/internal/infrastructure/repositoy"
+)
+
+const defaultNatsSubject = "dbz.events"
+
+// NewOutbox returns where repositories record their events and the relay
+// that delivers them to OUTBOX_SINKS and to extra. Without sinks nothing is
+// recorded and the relay is nil.
+func NewOutbox(env *Env, collection func(name string) breaker.DbCollection, extra ...event.Sink) (event.Outbox, *outbox.Relay) {
+	sinks := append(outboxSinks(env), extra...)
+	if len(sinks) == 0 {
+		return event.NopOutbox{}, nil
+	}
+
+	repo := repositoy.NewOutboxRepository(collection(repositoy.OutboxCollection))
+	relay := outbox.NewRelay(repo, sinks, outbox.RelayOptions{
+		Interval: env.OutboxInterval,
+		Batch:    env.OutboxBatch,
+	})
+
+	return repo, relay
+}
+
+func outboxSinks(env *Env) []event.Sink {
+	var sinks []event.Sink
+
+	for _, name := range strings.Split(env.OutboxSinks, ",") {
+		switch name = strings.TrimSpace(name); name {
+		case "":
+		case "stdout":
+			sinks = append(sinks, outbox.NewStdoutSink())
+		case "file":
+			if env.OutboxFile == "" {
+				log.Fatal("OUTBOX_FILE is required for the file sink")
+			}
+			sink, err := outbox.NewFileSink(env.OutboxFile)
+			if err != nil {
+				log.Fatal("Outbox file can't be opened: ", err)
+			}
+			sinks = append(sinks, sink)
+		case "webhook":
+			if env.OutboxWebhookUrl == "" {
+				log.Fatal("OUTBOX_WEBHOOK_URL is required for the webhook sink")
+			}
+			sinks = append(sinks, outbox.NewWebhookSink(env.OutboxWebhookUrl, http.DefaultClient))
+		case "nats":
+			broker, err := outbox.NewNatsBroker(env.OutboxNatsUrl)
+			if err != nil {
+				log.Fatal("OUTBOX_NATS_URL is invalid: ", err)
+			}
+			subject := env.OutboxNatsSubject
+			if subject == "" {
+				subject = defaultNatsSubject
+			}
+			sinks = append(sinks, outbox.NewBrokerSink("nats", broker, subject))
+		default:
+			log.Fatalf("OUTBOX_SINKS: unknown sink %q", name)
+		}
+	}
+
+	return sinks
+}
@@ -0,0 +1,30 @@
+package bootstrap
+
+import (
+	"log"
+
+	webhookapp "github.com/heaveless/dbz-api/internal/application/webhook"
+	"github.com/heaveless/dbz-api/internal/domain/audit"
+	"github.com/heaveless/dbz-api/internal/infrastructure/breaker"
+	"github.com/heaveless/dbz-api/internal/infrastructure/repositoy"
+	"github.com/heaveless/dbz-api/internal/infrastructure/webhook"
+)
+
+// NewWebhooks returns nil unless WEBHOOKS_ENABLED is set. Subscriptions are
+// managed under /admin, so API keys must be enabled too. Changes to them
+// are recorded in auditLog.
+func NewWebhooks(env *Env, collection func(name string) breaker.DbCollection, auditLog audit.Log) *webhookapp.WebhookService {
+	if !env.WebhooksEnabled {
+		return nil
+	}
+	if !env.AuthEnabled {
+		log.Fatal("WEBHOOKS_ENABLED requires AUTH_ENABLED: subscriptions are managed under /admin")
+	}
+
+	return webhookapp.NewWebhookService(
+		repositoy.NewAuditedSubscriptionRepository(repositoy.NewWebhookRepository(collection(repositoy.WebhookCollection)), auditLog),
+		repositoy.NewWebhookDeliveryRepository(collection(repositoy.WebhookDeliveryCollection)),
+		webhook.NewSender(webhook.NewClient()),
+		webhookapp.Options{MaxAttempts: env.WebhooksMaxAttempts},
+	)
+}
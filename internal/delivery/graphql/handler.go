@@ -0,0 +1,119 @@
+// Package graphql serves characters, planets and transformations as one
+// GraphQL query API so clients can fetch related data in a single round
+// trip. The resolvers call the same application services as the HTTP API.
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+	"github.com/heaveless/dbz-api/internal/delivery/http/render"
+)
+
+type Handler struct {
+	schema   graphql.Schema
+	services Services
+	limits   Limits
+}
+
+type request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+// NewHandler builds the schema once. Zero limits use DefaultMaxDepth and
+// DefaultMaxComplexity.
+func NewHandler(s Services, limits Limits) *Handler {
+	schema, err := newSchema(s)
+	if err != nil {
+		// The schema is static, so this is a programming error.
+		panic(err)
+	}
+
+	if limits.MaxDepth <= 0 {
+		limits.MaxDepth = DefaultMaxDepth
+	}
+	if limits.MaxComplexity <= 0 {
+		limits.MaxComplexity = DefaultMaxComplexity
+	}
+
+	return &Handler{schema: schema, services: s, limits: limits}
+}
+
+// Serve answers GET with the query in the URL and POST with a JSON body.
+// Requests that cannot run at all (malformed, invalid or over a limit) get
+// 400; once a query runs the status is 200 and field errors travel in
+// "errors" next to the partial data.
+func (h *Handler) Serve(c *gin.Context) {
+	req, ok := bindRequest(c)
+	if !ok {
+		return
+	}
+	if req.Query == "" {
+		respondErrors(c, gqlerrors.NewFormattedError("query is required"))
+		return
+	}
+
+	doc, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{
+		Body: []byte(req.Query),
+		Name: "GraphQL request",
+	})})
+	if err != nil {
+		respondErrors(c, gqlerrors.FormatErrors(err)...)
+		return
+	}
+
+	if res := graphql.ValidateDocument(&h.schema, doc, nil); !res.IsValid {
+		respondErrors(c, res.Errors...)
+		return
+	}
+	if err := h.limits.check(&h.schema, doc, req.OperationName, req.Variables); err != nil {
+		respondErrors(c, gqlerrors.NewFormattedError(err.Error()))
+		return
+	}
+
+	ctx := c.Request.Context()
+	res := graphql.Execute(graphql.ExecuteParams{
+		Schema:        h.schema,
+		AST:           doc,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       context.WithValue(ctx, loadersKey{}, newLoaders(ctx, h.services)),
+	})
+
+	render.Respond(c, http.StatusOK, res)
+}
+
+func bindRequest(c *gin.Context) (request, bool) {
+	var req request
+
+	if c.Request.Method == http.MethodGet {
+		req.Query = c.Query("query")
+		req.OperationName = c.Query("operationName")
+		if raw := c.Query("variables"); raw != "" {
+			if err := json.Unmarshal([]byte(raw), &req.Variables); err != nil {
+				respondErrors(c, gqlerrors.NewFormattedError("variables must be a JSON object"))
+				return req, false
+			}
+		}
+		return req, true
+	}
+
+	if err := json.NewDecoder(c.Request.Body).Decode(&req); err != nil {
+		respondErrors(c, gqlerrors.NewFormattedError("the body must be a JSON object with a query"))
+		return req, false
+	}
+
+	return req, true
+}
+
+func respondErrors(c *gin.Context, errs ...gqlerrors.FormattedError) {
+	render.Respond(c, http.StatusBadRequest, &graphql.Result{Errors: errs})
+}
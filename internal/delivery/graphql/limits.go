@@ -0,0 +1,184 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+const (
+	DefaultMaxDepth      = 8
+	DefaultMaxComplexity = 500
+
+	// defaultListSize is the size assumed for lists whose length the query
+	// does not bound.
+	defaultListSize = 10
+)
+
+// Limits bound a query before it runs. The depth counts nested fields; the
+// complexity adds one per field and multiplies what is selected under a
+// list by the list size. Introspection fields are not counted.
+type Limits struct {
+	MaxDepth      int
+	MaxComplexity int
+}
+
+// listSizes estimates how many items each list field returns. A list field
+// missing here counts as a single item.
+var listSizes = map[string]func(f *ast.Field, vars map[string]any) int{
+	"Query.characters": func(f *ast.Field, vars map[string]any) int {
+		return min(intArg(f, "limit", vars, defaultListLimit), maxListLimit)
+	},
+	"Query.charactersById": func(f *ast.Field, vars map[string]any) int {
+		return listArgLen(f, "ids", vars)
+	},
+	"Query.planets":             func(*ast.Field, map[string]any) int { return defaultListSize },
+	"Planet.residents":          func(*ast.Field, map[string]any) int { return defaultListSize },
+	"Character.transformations": func(*ast.Field, map[string]any) int { return defaultListSize },
+}
+
+// check measures the operation that will run and reports the first limit
+// it breaks.
+func (l Limits) check(schema *graphql.Schema, doc *ast.Document, operationName string, vars map[string]any) error {
+	m := measure{schema: schema, vars: vars, fragments: map[string]*ast.FragmentDefinition{}, seen: map[string]bool{}}
+
+	var op *ast.OperationDefinition
+	for _, def := range doc.Definitions {
+		switch d := def.(type) {
+		case *ast.FragmentDefinition:
+			m.fragments[d.Name.Value] = d
+		case *ast.OperationDefinition:
+			if op == nil && (operationName == "" || d.Name != nil && d.Name.Value == operationName) {
+				op = d
+			}
+		}
+	}
+	if op == nil || op.Operation != ast.OperationTypeQuery {
+		return nil
+	}
+
+	depth, complexity := m.selections(schema.QueryType(), op.SelectionSet, 0)
+	if depth > l.MaxDepth {
+		return fmt.Errorf("the query is %d levels deep, the limit is %d", depth, l.MaxDepth)
+	}
+	if complexity > l.MaxComplexity {
+		return fmt.Errorf("the query has a complexity of %d, the limit is %d", complexity, l.MaxComplexity)
+	}
+
+	return nil
+}
+
+type measure struct {
+	schema    *graphql.Schema
+	vars      map[string]any
+	fragments map[string]*ast.FragmentDefinition
+	// seen guards against fragment cycles; validation reports them.
+	seen map[string]bool
+}
+
+func (m *measure) selections(parent *graphql.Object, set *ast.SelectionSet, depth int) (maxDepth, cost int) {
+	maxDepth = depth
+	if set == nil || parent == nil {
+		return maxDepth, 0
+	}
+
+	for _, sel := range set.Selections {
+		var d, c int
+		switch s := sel.(type) {
+		case *ast.Field:
+			d, c = m.field(parent, s, depth)
+		case *ast.InlineFragment:
+			d, c = m.selections(m.condition(parent, s.TypeCondition), s.SelectionSet, depth)
+		case *ast.FragmentSpread:
+			frag, ok := m.fragments[s.Name.Value]
+			if !ok || m.seen[s.Name.Value] {
+				continue
+			}
+			m.seen[s.Name.Value] = true
+			d, c = m.selections(m.condition(parent, frag.TypeCondition), frag.SelectionSet, depth)
+			delete(m.seen, s.Name.Value)
+		}
+
+		maxDepth = max(maxDepth, d)
+		cost += c
+	}
+
+	return maxDepth, cost
+}
+
+func (m *measure) field(parent *graphql.Object, f *ast.Field, depth int) (int, int) {
+	name := f.Name.Value
+	if strings.HasPrefix(name, "__") {
+		return depth, 0
+	}
+
+	def, ok := parent.Fields()[name]
+	if !ok {
+		return depth, 0
+	}
+
+	child, _ := graphql.GetNamed(def.Type).(*graphql.Object)
+	d, c := m.selections(child, f.SelectionSet, depth+1)
+
+	if size, ok := listSizes[parent.Name()+"."+name]; ok {
+		c *= size(f, m.vars)
+	}
+
+	return d, 1 + c
+}
+
+func (m *measure) condition(parent *graphql.Object, named *ast.Named) *graphql.Object {
+	if named == nil {
+		return parent
+	}
+
+	obj, _ := m.schema.Type(named.Name.Value).(*graphql.Object)
+	return obj
+}
+
+func argument(f *ast.Field, name string, vars map[string]any) any {
+	for _, arg := range f.Arguments {
+		if arg.Name.Value != name {
+			continue
+		}
+		if v, ok := arg.Value.(*ast.Variable); ok {
+			return vars[v.Name.Value]
+		}
+		return arg.Value
+	}
+
+	return nil
+}
+
+func intArg(f *ast.Field, name string, vars map[string]any, fallback int) int {
+	switch v := argument(f, name, vars).(type) {
+	case *ast.IntValue:
+		if n, err := strconv.Atoi(v.Value); err == nil && n > 0 {
+			return n
+		}
+	case float64:
+		if v > 0 {
+			return int(v)
+		}
+	case int:
+		if v > 0 {
+			return v
+		}
+	}
+
+	return fallback
+}
+
+func listArgLen(f *ast.Field, name string, vars map[string]any) int {
+	switch v := argument(f, name, vars).(type) {
+	case *ast.ListValue:
+		return len(v.Values)
+	case []any:
+		return len(v)
+	}
+
+	return 1
+}
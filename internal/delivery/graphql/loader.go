@@ -0,0 +1,73 @@
+package graphql
+
+import (
+	"context"
+	"sync"
+)
+
+// batchFn fetches many keys at once. It returns one value and one error per
+// key, in the order of keys.
+type batchFn[K comparable, V any] func(ctx context.Context, keys []K) ([]V, []error)
+
+type loaded[V any] struct {
+	value V
+	err   error
+}
+
+// loader batches lookups in the manner of DataLoader. Load only records the
+// key and returns a thunk; the executor resolves thunks breadth first, so
+// every key asked for on one level of the query is known when the first
+// thunk runs and fetches them all with a single call. A loader lives for one
+// request and caches what it fetched.
+type loader[K comparable, V any] struct {
+	ctx   context.Context
+	fetch batchFn[K, V]
+
+	mu      sync.Mutex
+	pending []K
+	queued  map[K]bool
+	done    map[K]loaded[V]
+}
+
+func newLoader[K comparable, V any](ctx context.Context, fetch batchFn[K, V]) *loader[K, V] {
+	return &loader[K, V]{
+		ctx:    ctx,
+		fetch:  fetch,
+		queued: map[K]bool{},
+		done:   map[K]loaded[V]{},
+	}
+}
+
+// Load returns a thunk for the executor to resolve the value of key.
+func (l *loader[K, V]) Load(key K) func() (any, error) {
+	l.mu.Lock()
+	if _, ok := l.done[key]; !ok && !l.queued[key] {
+		l.queued[key] = true
+		l.pending = append(l.pending, key)
+	}
+	l.mu.Unlock()
+
+	return func() (any, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		if _, ok := l.done[key]; !ok {
+			l.dispatch()
+		}
+
+		r := l.done[key]
+		return r.value, r.err
+	}
+}
+
+// dispatch fetches every pending key. The caller holds mu.
+func (l *loader[K, V]) dispatch() {
+	keys := l.pending
+	l.pending = nil
+	clear(l.queued)
+
+	values, errs := l.fetch(l.ctx, keys)
+	for i, key := range keys {
+		l.done[key] = loaded[V]{value: values[i], err: errs[i]}
+	}
+}
@@ -0,0 +1,395 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/heaveless/dbz-api/internal/application"
+	"github.com/heaveless/dbz-api/internal/delivery/http/middleware"
+	"github.com/heaveless/dbz-api/internal/delivery/http/validation"
+	"github.com/heaveless/dbz-api/internal/domain/character"
+	"github.com/heaveless/dbz-api/internal/domain/planet"
+	"github.com/heaveless/dbz-api/internal/domain/transformation"
+)
+
+// The limits match the HTTP routes.
+const (
+	maxNameLength    = 64
+	defaultListLimit = 10
+	maxListLimit     = 50
+	maxBatchSize     = 50
+)
+
+const unavailableMessage = "Service temporarily unavailable, please try again later."
+
+// CharacterService is the part of application/character.CharacterService
+// the resolvers need.
+type CharacterService interface {
+	GetByName(ctx context.Context, name string) (*character.CharacterDTO, error)
+	GetByIds(ctx context.Context, ids []int64) []character.CharacterResult
+	List(ctx context.Context, q character.ListQuery) (*character.CharacterPageDTO, error)
+}
+
+type PlanetService interface {
+	GetById(ctx context.Context, id int64) (*planet.PlanetDTO, error)
+	List(ctx context.Context) ([]planet.PlanetDTO, error)
+	CharactersByPlanets(ctx context.Context, planetIds []int64) []planet.PlanetResidents
+}
+
+type TransformationService interface {
+	ListByCharacters(ctx context.Context, characterIds []int64) []transformation.CharacterTransformations
+}
+
+type Services struct {
+	Characters      CharacterService
+	Planets         PlanetService
+	Transformations TransformationService
+}
+
+// loaders are the per-request batches the resolvers share.
+type loaders struct {
+	characters      *loader[int64, *character.CharacterDTO]
+	transformations *loader[int64, []*transformation.TransformationDTO]
+	residents       *loader[int64, []*character.CharacterDTO]
+}
+
+type loadersKey struct{}
+
+func newLoaders(ctx context.Context, s Services) *loaders {
+	return &loaders{
+		characters: newLoader(ctx, func(ctx context.Context, ids []int64) ([]*character.CharacterDTO, []error) {
+			results := s.Characters.GetByIds(ctx, ids)
+
+			chrs := make([]*character.CharacterDTO, len(ids))
+			errs := make([]error, len(ids))
+			for i, r := range results {
+				chrs[i], errs[i] = r.Character, publicError(r.Err)
+			}
+			return chrs, errs
+		}),
+		transformations: newLoader(ctx, func(ctx context.Context, ids []int64) ([][]*transformation.TransformationDTO, []error) {
+			results := s.Transformations.ListByCharacters(ctx, ids)
+
+			lists := make([][]*transformation.TransformationDTO, len(ids))
+			errs := make([]error, len(ids))
+			for i, r := range results {
+				lists[i], errs[i] = pointers(r.Transformations), publicError(r.Err)
+			}
+			return lists, errs
+		}),
+		residents: newLoader(ctx, func(ctx context.Context, ids []int64) ([][]*character.CharacterDTO, []error) {
+			results := s.Planets.CharactersByPlanets(ctx, ids)
+
+			lists := make([][]*character.CharacterDTO, len(ids))
+			errs := make([]error, len(ids))
+			for i, r := range results {
+				lists[i], errs[i] = pointers(r.Residents), publicError(r.Err)
+			}
+			return lists, errs
+		}),
+	}
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+// newSchema builds the query schema. Character lookups by id, the
+// transformations of characters and the residents of planets go through the
+// request's loaders, so a roster costs one store read per level instead of
+// one per character or planet.
+func newSchema(s Services) (graphql.Schema, error) {
+	var characterType *graphql.Object
+
+	transformationType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Transformation",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":    {Type: graphql.NewNonNull(graphql.Int)},
+				"name":  {Type: graphql.NewNonNull(graphql.String)},
+				"image": {Type: graphql.String},
+				"ki":    {Type: graphql.NewNonNull(graphql.String)},
+				"character": {
+					Type: characterType,
+					Resolve: func(p graphql.ResolveParams) (any, error) {
+						t := p.Source.(*transformation.TransformationDTO)
+						return loadersFrom(p.Context).characters.Load(t.CharacterId), nil
+					},
+				},
+			}
+		}),
+	})
+
+	characterType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Character",
+		Fields: graphql.Fields{
+			"id":          {Type: graphql.NewNonNull(graphql.Int)},
+			"name":        {Type: graphql.NewNonNull(graphql.String)},
+			"ki":          {Type: graphql.NewNonNull(graphql.String)},
+			"maxKi":       {Type: graphql.NewNonNull(graphql.String)},
+			"race":        {Type: graphql.NewNonNull(graphql.String)},
+			"gender":      {Type: graphql.String},
+			"image":       {Type: graphql.String},
+			"affiliation": {Type: graphql.String},
+			"description": {Type: graphql.String},
+			// Nullable, as residents, so a failed lookup nulls only its own
+			// list: the executor cannot null a non-null field that a loader
+			// resolves late without nulling the whole response.
+			"transformations": {
+				Type: graphql.NewList(graphql.NewNonNull(transformationType)),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					chr := p.Source.(*character.CharacterDTO)
+					return loadersFrom(p.Context).transformations.Load(chr.Id), nil
+				},
+			},
+		},
+	})
+
+	planetType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Planet",
+		Fields: graphql.Fields{
+			"id":          {Type: graphql.NewNonNull(graphql.Int)},
+			"name":        {Type: graphql.NewNonNull(graphql.String)},
+			"isDestroyed": {Type: graphql.NewNonNull(graphql.Boolean)},
+			"description": {Type: graphql.String},
+			"image":       {Type: graphql.String},
+			"residents": {
+				Type: graphql.NewList(graphql.NewNonNull(characterType)),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					pl := p.Source.(*planet.PlanetDTO)
+					return loadersFrom(p.Context).residents.Load(pl.Id), nil
+				},
+			},
+		},
+	})
+
+	pageType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "CharacterPage",
+		Fields: graphql.Fields{
+			"items":      {Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(characterType)))},
+			"page":       {Type: graphql.NewNonNull(graphql.Int)},
+			"limit":      {Type: graphql.NewNonNull(graphql.Int)},
+			"total":      {Type: graphql.NewNonNull(graphql.Int)},
+			"totalPages": {Type: graphql.NewNonNull(graphql.Int)},
+		},
+	})
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"character": {
+				Type:        characterType,
+				Description: "A character by id or by name; null when there is none.",
+				Args: graphql.FieldConfigArgument{
+					"id":   {Type: graphql.Int},
+					"name": {Type: graphql.String},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return resolveCharacter(p, s)
+				},
+			},
+			"charactersById": {
+				Type:        graphql.NewNonNull(graphql.NewList(characterType)),
+				Description: "Characters in the order of ids; null for an id with no character.",
+				Args: graphql.FieldConfigArgument{
+					"ids": {Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.Int)))},
+				},
+				Resolve: resolveCharactersById,
+			},
+			"characters": {
+				Type:        graphql.NewNonNull(pageType),
+				Description: "A page of the stored characters, as GET /v1/characters.",
+				Args: graphql.FieldConfigArgument{
+					"page":     {Type: graphql.Int, DefaultValue: 1},
+					"limit":    {Type: graphql.Int, DefaultValue: defaultListLimit},
+					"sort":     {Type: graphql.String},
+					"minKi":    {Type: graphql.String},
+					"minMaxKi": {Type: graphql.String},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return resolveCharacters(p, s)
+				},
+			},
+			"planet": {
+				Type: planetType,
+				Args: graphql.FieldConfigArgument{
+					"id": {Type: graphql.NewNonNull(graphql.Int)},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					id, _ := p.Args["id"].(int)
+					if id <= 0 {
+						return nil, errors.New("id must be a positive integer")
+					}
+
+					pl, err := s.Planets.GetById(p.Context, int64(id))
+					if errors.Is(err, planet.ErrNotFound) {
+						return nil, nil
+					}
+					if err != nil {
+						return nil, publicError(err)
+					}
+					return pl, nil
+				},
+			},
+			"planets": {
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(planetType))),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					pls, err := s.Planets.List(p.Context)
+					if err != nil {
+						return nil, publicError(err)
+					}
+					return pointers(pls), nil
+				},
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: query})
+}
+
+func resolveCharacter(p graphql.ResolveParams, s Services) (any, error) {
+	id, hasId := p.Args["id"].(int)
+	name, hasName := p.Args["name"].(string)
+
+	switch {
+	case hasId == hasName:
+		return nil, errors.New("character needs either an id or a name")
+	case hasId && id <= 0:
+		return nil, errors.New("id must be a positive integer")
+	case hasId:
+		return loadersFrom(p.Context).characters.Load(int64(id)), nil
+	}
+
+	if err := checkName(name); err != nil {
+		return nil, err
+	}
+
+	chr, err := s.Characters.GetByName(p.Context, name)
+	if errors.Is(err, character.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, publicError(err)
+	}
+	return chr, nil
+}
+
+func resolveCharactersById(p graphql.ResolveParams) (any, error) {
+	ids, _ := p.Args["ids"].([]any)
+	if len(ids) > maxBatchSize {
+		return nil, fmt.Errorf("ids holds at most %d items", maxBatchSize)
+	}
+
+	ld := loadersFrom(p.Context)
+	thunks := make([]func() (any, error), 0, len(ids))
+	for _, v := range ids {
+		id, _ := v.(int)
+		if id <= 0 {
+			return nil, errors.New("ids must be positive integers")
+		}
+		thunks = append(thunks, ld.characters.Load(int64(id)))
+	}
+
+	return func() (any, error) {
+		chrs := make([]any, 0, len(thunks))
+		for _, thunk := range thunks {
+			// A failed lookup only nulls its own entry.
+			chr, _ := thunk()
+			chrs = append(chrs, chr)
+		}
+		return chrs, nil
+	}, nil
+}
+
+func resolveCharacters(p graphql.ResolveParams, s Services) (any, error) {
+	q := character.ListQuery{Page: p.Args["page"].(int), Limit: p.Args["limit"].(int)}
+	if q.Page <= 0 {
+		return nil, errors.New("page must be a positive integer")
+	}
+	if q.Limit <= 0 {
+		return nil, errors.New("limit must be a positive integer")
+	}
+	q.Limit = min(q.Limit, maxListLimit)
+
+	var err error
+	if q.MinKi, err = powerLevel(p.Args, "minKi"); err != nil {
+		return nil, err
+	}
+	if q.MinMaxKi, err = powerLevel(p.Args, "minMaxKi"); err != nil {
+		return nil, err
+	}
+	sort, _ := p.Args["sort"].(string)
+	if q.Sort, err = character.ParseSortOrder(sort); err != nil {
+		return nil, err
+	}
+
+	res, err := s.Characters.List(p.Context, q)
+	if err != nil {
+		return nil, publicError(err)
+	}
+
+	return map[string]any{
+		"items":      pointers(res.Items),
+		"page":       res.Page,
+		"limit":      res.Limit,
+		"total":      res.Total,
+		"totalPages": (res.Total + int64(res.Limit) - 1) / int64(res.Limit),
+	}, nil
+}
+
+func checkName(name string) error {
+	switch {
+	case strings.TrimSpace(name) == "":
+		return errors.New("name is required")
+	case len([]rune(name)) > maxNameLength:
+		return fmt.Errorf("name is longer than %d characters", maxNameLength)
+	case !validation.IsCharacterName(name):
+		return errors.New("name has invalid characters")
+	}
+
+	return nil
+}
+
+func powerLevel(args map[string]any, key string) (*character.PowerLevel, error) {
+	raw, _ := args[key].(string)
+	if raw == "" {
+		return nil, nil
+	}
+
+	level, err := character.ParsePowerLevel(raw)
+	if err != nil || !level.Known() {
+		return nil, fmt.Errorf("%s must be a power level such as 60.000.000 or 90 Septillion", key)
+	}
+
+	return &level, nil
+}
+
+// publicError keeps internal failures out of the response. A missing
+// character is not an error: the field is null.
+func publicError(err error) error {
+	var exceeded *application.BudgetExceededError
+	switch {
+	case err == nil, errors.Is(err, character.ErrNotFound):
+		return nil
+	case errors.As(err, &exceeded):
+		return errors.New(middleware.TooManyRequestsMessage)
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return err
+	}
+
+	log.Printf("[GRAPHQL] lookup failed: %v", err)
+	return errors.New(unavailableMessage)
+}
+
+func pointers[T any](items []T) []*T {
+	ptrs := make([]*T, 0, len(items))
+	for i := range items {
+		ptrs = append(ptrs, &items[i])
+	}
+
+	return ptrs
+}
@@ -0,0 +1,133 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"log"
+	"slices"
+	"strings"
+
+	"github.com/heaveless/dbz-api/internal/domain/apikey"
+	"github.com/heaveless/dbz-api/internal/infrastructure/oidc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type KeyAuthenticator interface {
+	Authenticate(ctx context.Context, key string, scope string) (*apikey.Principal, error)
+}
+
+type TokenVerifier interface {
+	Verify(ctx context.Context, token string) (*oidc.Claims, error)
+}
+
+// Auth checks callers with the same credentials as the HTTP API, sent as
+// metadata: "authorization: Bearer <jwt or key>" or "x-api-key: <key>".
+// A JWT needs Tokens, anything else is tried as an API key and needs Keys.
+type Auth struct {
+	Keys     KeyAuthenticator
+	KeyScope string
+
+	Tokens TokenVerifier
+	// TokenScopes must all be granted by the token.
+	TokenScopes []string
+}
+
+type principalKey struct{}
+
+// PrincipalFrom returns the caller authenticated by Auth.
+func PrincipalFrom(ctx context.Context) (*apikey.Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(*apikey.Principal)
+	return p, ok
+}
+
+// Unary rejects unauthenticated calls. Health checks and reflection stay
+// open so load balancers and tooling work without credentials.
+func (a Auth) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if open(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		p, err := a.authenticate(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(context.WithValue(ctx, principalKey{}, p), req)
+	}
+}
+
+func open(method string) bool {
+	return strings.HasPrefix(method, "/grpc.health.v1.Health/") ||
+		strings.HasPrefix(method, "/grpc.reflection.")
+}
+
+func (a Auth) authenticate(ctx context.Context) (*apikey.Principal, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	key := first(md, "x-api-key")
+	if token, ok := strings.CutPrefix(first(md, "authorization"), "Bearer "); ok && key == "" {
+		key = strings.TrimSpace(token)
+	}
+	if key == "" {
+		return nil, status.Error(codes.Unauthenticated, "an API key or bearer token is required")
+	}
+
+	// API keys never contain dots; a compact JWS has exactly two.
+	if a.Tokens != nil && strings.Count(key, ".") == 2 {
+		return a.verifyToken(ctx, key)
+	}
+	if a.Keys == nil {
+		return nil, status.Error(codes.Unauthenticated, "the credentials are not valid")
+	}
+
+	return a.checkKey(ctx, key)
+}
+
+func (a Auth) checkKey(ctx context.Context, key string) (*apikey.Principal, error) {
+	p, err := a.Keys.Authenticate(ctx, key, a.KeyScope)
+	var quota *apikey.QuotaExceededError
+	switch {
+	case errors.Is(err, apikey.ErrInvalidKey):
+		return nil, status.Error(codes.Unauthenticated, "the API key is not valid")
+	case errors.Is(err, apikey.ErrScopeMissing):
+		return nil, status.Error(codes.PermissionDenied, "the API key is not allowed to call this service")
+	case errors.As(err, &quota):
+		return nil, status.Error(codes.ResourceExhausted, "the daily quota of this API key is spent")
+	case err != nil:
+		log.Printf("[GRPC] cannot check api key: %v", err)
+		return nil, status.Error(codes.Unavailable, "service temporarily unavailable, please try again later")
+	}
+
+	return p, nil
+}
+
+func (a Auth) verifyToken(ctx context.Context, token string) (*apikey.Principal, error) {
+	claims, err := a.Tokens.Verify(ctx, token)
+	if errors.Is(err, oidc.ErrKeysUnavailable) {
+		log.Printf("[GRPC] cannot check bearer token: %v", err)
+		return nil, status.Error(codes.Unavailable, "service temporarily unavailable, please try again later")
+	}
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "the bearer token is not valid")
+	}
+
+	for _, scope := range a.TokenScopes {
+		if !slices.Contains(claims.Scopes, scope) {
+			return nil, status.Error(codes.PermissionDenied, "the bearer token lacks scope "+scope)
+		}
+	}
+
+	return &apikey.Principal{Name: claims.Subject, Scopes: claims.Scopes}, nil
+}
+
+func first(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
+}
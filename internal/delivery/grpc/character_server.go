@@ -0,0 +1,183 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/heaveless/dbz-api/internal/delivery/http/validation"
+	"github.com/heaveless/dbz-api/internal/delivery/pb/dbzv1"
+	domain "github.com/heaveless/dbz-api/internal/domain/character"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// The limits match the HTTP routes.
+const (
+	maxNameLength    = 64
+	defaultListLimit = 10
+	maxListLimit     = 50
+	maxBatchSize     = 50
+)
+
+// CharacterService is the part of application/character.CharacterService
+// the gRPC service needs.
+type CharacterService interface {
+	GetByName(ctx context.Context, name string) (*domain.CharacterDTO, error)
+	GetById(ctx context.Context, id int64) (*domain.CharacterDTO, error)
+	List(ctx context.Context, q domain.ListQuery) (*domain.CharacterPageDTO, error)
+	GetBatch(ctx context.Context, refs []domain.CharacterRef) []domain.CharacterResult
+}
+
+type CharacterServer struct {
+	dbzv1.UnimplementedCharacterServiceServer
+	service CharacterService
+}
+
+func NewCharacterServer(s CharacterService) *CharacterServer {
+	return &CharacterServer{service: s}
+}
+
+func (s *CharacterServer) GetByName(ctx context.Context, req *dbzv1.GetByNameRequest) (*dbzv1.Character, error) {
+	if err := checkName("name", req.GetName()); err != nil {
+		return nil, err
+	}
+
+	chr, err := s.service.GetByName(ctx, req.GetName())
+	if err != nil {
+		return nil, statusOf(err).Err()
+	}
+
+	return dbzv1.FromCharacter(chr), nil
+}
+
+func (s *CharacterServer) GetById(ctx context.Context, req *dbzv1.GetByIdRequest) (*dbzv1.Character, error) {
+	if req.GetId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "id must be a positive integer")
+	}
+
+	chr, err := s.service.GetById(ctx, req.GetId())
+	if err != nil {
+		return nil, statusOf(err).Err()
+	}
+
+	return dbzv1.FromCharacter(chr), nil
+}
+
+func (s *CharacterServer) List(ctx context.Context, req *dbzv1.ListRequest) (*dbzv1.CharacterPage, error) {
+	q := domain.ListQuery{Page: 1, Limit: defaultListLimit}
+
+	var err error
+	if q.MinKi, err = powerLevel("min_ki", req.GetMinKi()); err != nil {
+		return nil, err
+	}
+	if q.MinMaxKi, err = powerLevel("min_max_ki", req.GetMinMaxKi()); err != nil {
+		return nil, err
+	}
+	if q.Sort, err = domain.ParseSortOrder(req.GetSort()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	switch page := req.GetPage(); {
+	case page < 0:
+		return nil, status.Error(codes.InvalidArgument, "page must be a positive integer")
+	case page > 0:
+		q.Page = int(page)
+	}
+	switch limit := req.GetLimit(); {
+	case limit < 0:
+		return nil, status.Error(codes.InvalidArgument, "limit must be a positive integer")
+	case limit > 0:
+		q.Limit = min(int(limit), maxListLimit)
+	}
+
+	res, err := s.service.List(ctx, q)
+	if err != nil {
+		return nil, statusOf(err).Err()
+	}
+
+	page := &dbzv1.CharacterPage{
+		Data: make([]*dbzv1.Character, 0, len(res.Items)),
+		Meta: &dbzv1.PageMeta{
+			Page:       int32(res.Page),
+			Limit:      int32(res.Limit),
+			Total:      res.Total,
+			TotalPages: (res.Total + int64(res.Limit) - 1) / int64(res.Limit),
+		},
+	}
+	for i := range res.Items {
+		page.Data = append(page.Data, dbzv1.FromCharacter(&res.Items[i]))
+	}
+
+	return page, nil
+}
+
+// BatchGet validates the whole request up front, like POST
+// /v1/characters/batch, then reports each lookup separately.
+func (s *CharacterServer) BatchGet(ctx context.Context, req *dbzv1.BatchGetRequest) (*dbzv1.BatchGetResponse, error) {
+	switch n := len(req.GetRefs()); {
+	case n == 0:
+		return nil, status.Error(codes.InvalidArgument, "refs must not be empty")
+	case n > maxBatchSize:
+		return nil, status.Errorf(codes.InvalidArgument, "refs holds at most %d items", maxBatchSize)
+	}
+
+	refs := make([]domain.CharacterRef, 0, len(req.GetRefs()))
+	for i, ref := range req.GetRefs() {
+		field := fmt.Sprintf("refs[%d]", i)
+		switch r := ref.GetRef().(type) {
+		case *dbzv1.CharacterRef_Id:
+			if r.Id <= 0 {
+				return nil, status.Errorf(codes.InvalidArgument, "%s.id must be a positive integer", field)
+			}
+			refs = append(refs, domain.CharacterRef{Id: r.Id})
+		case *dbzv1.CharacterRef_Name:
+			if err := checkName(field+".name", r.Name); err != nil {
+				return nil, err
+			}
+			refs = append(refs, domain.CharacterRef{Name: r.Name})
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "%s needs an id or a name", field)
+		}
+	}
+
+	results := s.service.GetBatch(ctx, refs)
+
+	res := &dbzv1.BatchGetResponse{Results: make([]*dbzv1.BatchGetResult, 0, len(results))}
+	for i, r := range results {
+		st := statusOf(r.Err)
+		item := &dbzv1.BatchGetResult{Ref: req.GetRefs()[i], Code: int32(st.Code()), Message: st.Message()}
+		if r.Err == nil {
+			item.Character = dbzv1.FromCharacter(r.Character)
+		}
+		res.Results = append(res.Results, item)
+	}
+
+	return res, nil
+}
+
+func checkName(field, name string) error {
+	switch {
+	case strings.TrimSpace(name) == "":
+		return status.Errorf(codes.InvalidArgument, "%s is required", field)
+	case len([]rune(name)) > maxNameLength:
+		return status.Errorf(codes.InvalidArgument, "%s is longer than %d characters", field, maxNameLength)
+	case !validation.IsCharacterName(name):
+		return status.Errorf(codes.InvalidArgument, "%s has invalid characters", field)
+	}
+
+	return nil
+}
+
+func powerLevel(field, raw string) (*domain.PowerLevel, error) {
+	if raw == "" {
+		return nil, nil
+	}
+
+	level, err := domain.ParsePowerLevel(raw)
+	if err != nil || !level.Known() {
+		return nil, status.Errorf(codes.InvalidArgument, "%s must be a power level such as 60.000.000 or 90 Septillion", field)
+	}
+
+	return &level, nil
+}
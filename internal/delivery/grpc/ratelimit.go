@@ -0,0 +1,94 @@
+package grpc
+
+import (
+	"context"
+	"log"
+	"net"
+
+	"github.com/heaveless/dbz-api/internal/application"
+	"github.com/heaveless/dbz-api/internal/delivery/http/middleware"
+	"github.com/heaveless/dbz-api/internal/infrastructure/ratelimit"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// rateLimited charges every call to the peer address's bucket before
+// authentication, as middleware.RateLimited does for HTTP, and spends the
+// upstream budget of that address.
+func rateLimited(rl middleware.RateLimit) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if open(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		client := "ip:" + peerHost(ctx)
+		if err := take(ctx, rl.Store, "req:"+client, rl.PerIP); err != nil {
+			return nil, err
+		}
+
+		return handler(withUpstreamBudget(ctx, rl, client), req)
+	}
+}
+
+// callerRateLimited charges the caller Auth let through to its own bucket
+// and moves its upstream budget from the address to the caller, as
+// middleware.CallerRateLimited does. Calls without a principal pass as they
+// are.
+func callerRateLimited(rl middleware.RateLimit) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		p, ok := PrincipalFrom(ctx)
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		client := middleware.CallerKey(p)
+		if err := take(ctx, rl.Store, "req:"+client, rl.PerKey); err != nil {
+			return nil, err
+		}
+
+		return handler(withUpstreamBudget(ctx, rl, client), req)
+	}
+}
+
+// take answers RESOURCE_EXHAUSTED, with a retry-after header, once key's
+// bucket is empty. Store failures let the call through.
+func take(ctx context.Context, store ratelimit.Store, key string, limit ratelimit.Limit) error {
+	if !limit.Enabled() {
+		return nil
+	}
+
+	d, err := store.Take(ctx, key, limit)
+	if err != nil {
+		log.Printf("[RATE] store unavailable, not limiting: %v", err)
+		return nil
+	}
+	if d.Allowed {
+		return nil
+	}
+
+	_ = grpc.SetHeader(ctx, metadata.Pairs("retry-after", middleware.RetryAfterSeconds(d.RetryAfter)))
+	return status.Error(codes.ResourceExhausted, middleware.TooManyRequestsMessage)
+}
+
+func withUpstreamBudget(ctx context.Context, rl middleware.RateLimit, client string) context.Context {
+	if !rl.Upstream.Enabled() {
+		return ctx
+	}
+
+	return application.WithUpstreamBudget(ctx, middleware.UpstreamBudget(rl.Store, client, rl.Upstream))
+}
+
+func peerHost(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+		return host
+	}
+
+	return p.Addr.String()
+}
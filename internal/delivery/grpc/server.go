@@ -0,0 +1,60 @@
+// Package grpc serves the character lookups over gRPC for backend callers.
+// It shares the application services with the HTTP API and runs on its own
+// port.
+package grpc
+
+import (
+	"context"
+	"log"
+	"runtime/debug"
+
+	"github.com/heaveless/dbz-api/internal/delivery/http/middleware"
+	"github.com/heaveless/dbz-api/internal/delivery/pb/dbzv1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+// NewServer registers the character service next to the standard health
+// and reflection services. A nil auth leaves the character service open; a
+// nil limits leaves it unlimited. The limits are the ones the HTTP API
+// uses, so a caller shares its buckets across both.
+func NewServer(characters *CharacterServer, auth *Auth, limits *middleware.RateLimit) *grpc.Server {
+	interceptors := []grpc.UnaryServerInterceptor{recovered}
+	if limits != nil {
+		interceptors = append(interceptors, rateLimited(*limits))
+	}
+	if auth != nil {
+		interceptors = append(interceptors, auth.Unary())
+	}
+	if limits != nil && auth != nil {
+		interceptors = append(interceptors, callerRateLimited(*limits))
+	}
+
+	srv := grpc.NewServer(grpc.ChainUnaryInterceptor(interceptors...))
+	dbzv1.RegisterCharacterServiceServer(srv, characters)
+
+	hs := health.NewServer()
+	hs.SetServingStatus(dbzv1.CharacterService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(srv, hs)
+
+	reflection.Register(srv)
+
+	return srv
+}
+
+// recovered turns a panic into INTERNAL instead of taking the process
+// down, as gin's recovery does for HTTP.
+func recovered(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (res any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("[GRPC] panic in %s: %v\n%s", info.FullMethod, r, debug.Stack())
+			err = status.Error(codes.Internal, "internal error")
+		}
+	}()
+
+	return handler(ctx, req)
+}
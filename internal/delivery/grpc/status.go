@@ -0,0 +1,31 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/heaveless/dbz-api/internal/application"
+	domain "github.com/heaveless/dbz-api/internal/domain/character"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// statusOf maps a lookup error to its gRPC status, as respondLookupError
+// does for HTTP: not found, spent budget and cancellation keep their
+// meaning, everything else is unavailable.
+func statusOf(err error) *status.Status {
+	switch {
+	case err == nil:
+		return status.New(codes.OK, "")
+	case errors.Is(err, domain.ErrNotFound):
+		return status.New(codes.NotFound, err.Error())
+	case errors.As(err, new(*application.BudgetExceededError)):
+		return status.New(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.New(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.New(codes.DeadlineExceeded, err.Error())
+	}
+
+	return status.New(codes.Unavailable, err.Error())
+}
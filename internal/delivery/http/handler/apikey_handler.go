@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heaveless/dbz-api/internal/delivery/http/render"
+	"github.com/heaveless/dbz-api/internal/delivery/http/validation"
+	domain "github.com/heaveless/dbz-api/internal/domain/apikey"
+)
+
+type ApiKeyService interface {
+	Create(ctx context.Context, req domain.NewApiKey) (*domain.IssuedApiKeyDTO, error)
+	List(ctx context.Context) ([]domain.ApiKeyDTO, error)
+	Rotate(ctx context.Context, id string) (*domain.IssuedApiKeyDTO, error)
+	Revoke(ctx context.Context, id string) (*domain.ApiKeyDTO, error)
+}
+
+type ApiKeyHandler struct {
+	service ApiKeyService
+}
+
+type createApiKeyRequest struct {
+	Name       string   `json:"name" binding:"required,notblank,max=64"`
+	Scopes     []string `json:"scopes" binding:"required,min=1,dive,oneof=read admin"`
+	DailyQuota int64    `json:"dailyQuota" binding:"min=0"`
+}
+
+func NewApiKeyHandler(s ApiKeyService) *ApiKeyHandler {
+	validation.Register()
+	return &ApiKeyHandler{service: s}
+}
+
+func (h *ApiKeyHandler) List(c *gin.Context) {
+	keys, err := h.service.List(c.Request.Context())
+	if err != nil {
+		render.Respond(c, http.StatusServiceUnavailable, gin.H{"message": err.Error()})
+		return
+	}
+
+	render.Respond(c, http.StatusOK, gin.H{"data": keys})
+}
+
+// Create issues a new key. The response is the only place the key is ever
+// shown.
+func (h *ApiKeyHandler) Create(c *gin.Context) {
+	var req createApiKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		validation.Respond(c, http.StatusBadRequest, err)
+		return
+	}
+
+	key, err := h.service.Create(auditContext(c), domain.NewApiKey{
+		Name:       req.Name,
+		Scopes:     req.Scopes,
+		DailyQuota: req.DailyQuota,
+	})
+	if err != nil {
+		render.Respond(c, http.StatusServiceUnavailable, gin.H{"message": err.Error()})
+		return
+	}
+
+	render.Respond(c, http.StatusCreated, gin.H{"data": key})
+}
+
+func (h *ApiKeyHandler) Rotate(c *gin.Context) {
+	key, err := h.service.Rotate(auditContext(c), c.Param("id"))
+	if err != nil {
+		respondApiKeyError(c, err)
+		return
+	}
+
+	render.Respond(c, http.StatusOK, gin.H{"data": key})
+}
+
+func (h *ApiKeyHandler) Revoke(c *gin.Context) {
+	key, err := h.service.Revoke(auditContext(c), c.Param("id"))
+	if err != nil {
+		respondApiKeyError(c, err)
+		return
+	}
+
+	render.Respond(c, http.StatusOK, gin.H{"data": key})
+}
+
+func respondApiKeyError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		render.Respond(c, http.StatusNotFound, gin.H{"message": err.Error()})
+	case errors.Is(err, domain.ErrRevoked):
+		render.Respond(c, http.StatusConflict, gin.H{"message": err.Error()})
+	default:
+		render.Respond(c, http.StatusServiceUnavailable, gin.H{"message": err.Error()})
+	}
+}
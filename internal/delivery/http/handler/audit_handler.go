@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heaveless/dbz-api/internal/delivery/http/middleware"
+	"github.com/heaveless/dbz-api/internal/delivery/http/render"
+	domain "github.com/heaveless/dbz-api/internal/domain/audit"
+)
+
+const (
+	defaultAuditLimit = 20
+	maxAuditLimit     = 100
+)
+
+type AuditService interface {
+	Query(ctx context.Context, q domain.Query) (*domain.EntryPageDTO, error)
+}
+
+// AuditHandler serves /admin/audit, the audit log read back.
+type AuditHandler struct {
+	service AuditService
+}
+
+func NewAuditHandler(s AuditService) *AuditHandler {
+	return &AuditHandler{service: s}
+}
+
+// List answers the latest entries first. Every filter is optional; from and
+// to bound the time of the change.
+func (h *AuditHandler) List(c *gin.Context) {
+	q := domain.Query{
+		Actor:     c.Query("actor"),
+		Action:    c.Query("action"),
+		Target:    c.Query("target"),
+		Field:     c.Query("field"),
+		RequestId: c.Query("requestId"),
+	}
+	var ok bool
+
+	if q.From, ok = timeQuery(c, "from"); !ok {
+		return
+	}
+	if q.To, ok = timeQuery(c, "to"); !ok {
+		return
+	}
+	if q.Page, ok = positiveQueryInt(c, "page", 1); !ok {
+		return
+	}
+	if q.Limit, ok = positiveQueryInt(c, "limit", defaultAuditLimit); !ok {
+		return
+	}
+	q.Limit = min(q.Limit, maxAuditLimit)
+
+	res, err := h.service.Query(c.Request.Context(), q)
+	if err != nil {
+		render.Respond(c, http.StatusServiceUnavailable, gin.H{"message": err.Error()})
+		return
+	}
+
+	render.Respond(c, http.StatusOK, gin.H{
+		"data": res.Items,
+		"meta": pageMeta(res.Page, res.Limit, res.Total),
+	})
+}
+
+func timeQuery(c *gin.Context, key string) (time.Time, bool) {
+	raw := c.Query(key)
+	if raw == "" {
+		return time.Time{}, true
+	}
+
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		render.Respond(c, http.StatusBadRequest, gin.H{"message": "The query parameter " + key + " must be an RFC 3339 time."})
+		return time.Time{}, false
+	}
+
+	return t.UTC(), true
+}
+
+// auditContext attributes the changes made with the request to its caller.
+func auditContext(c *gin.Context) context.Context {
+	ctx := c.Request.Context()
+
+	p, ok := middleware.PrincipalFrom(c)
+	if !ok {
+		return ctx
+	}
+
+	return domain.WithActor(ctx, domain.Actor{Id: p.KeyId, Name: p.Name})
+}
@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heaveless/dbz-api/internal/application"
+	"github.com/heaveless/dbz-api/internal/delivery/http/middleware"
+	"github.com/heaveless/dbz-api/internal/delivery/http/render"
+	"github.com/heaveless/dbz-api/internal/infrastructure/breaker"
+)
+
+// respondBudgetExceeded answers 429 when the lookup was stopped because the
+// client spent its upstream budget.
+func respondBudgetExceeded(c *gin.Context, err error) bool {
+	var exceeded *application.BudgetExceededError
+	if !errors.As(err, &exceeded) {
+		return false
+	}
+
+	c.Header("Retry-After", middleware.RetryAfterSeconds(exceeded.RetryAfter))
+	render.Respond(c, http.StatusTooManyRequests, gin.H{"message": middleware.TooManyRequestsMessage})
+	return true
+}
+
+// respondUpstreamLimited answers 503 with Retry-After when the lookup was
+// held back by the outbound limit on the upstream API, which is ours to
+// keep and not the client's.
+func respondUpstreamLimited(c *gin.Context, err error) bool {
+	var limited *breaker.UpstreamLimitedError
+	if !errors.As(err, &limited) {
+		return false
+	}
+
+	c.Header("Retry-After", middleware.RetryAfterSeconds(limited.RetryAfter))
+	render.Respond(c, http.StatusServiceUnavailable, gin.H{"message": err.Error()})
+	return true
+}
@@ -0,0 +1,186 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heaveless/dbz-api/internal/delivery/http/render"
+	"github.com/heaveless/dbz-api/internal/delivery/http/validation"
+	domain "github.com/heaveless/dbz-api/internal/domain/character"
+)
+
+type CharacterCurationService interface {
+	Create(ctx context.Context, id int64, p domain.CharacterPatch) (*domain.CuratedCharacterDTO, error)
+	Update(ctx context.Context, id int64, p domain.CharacterPatch) (*domain.CuratedCharacterDTO, error)
+	Delete(ctx context.Context, id int64) (*domain.CuratedCharacterDTO, error)
+}
+
+// CharacterAdminHandler serves the /admin/characters routes. Changes are
+// attributed to the authenticated caller in the audit log.
+type CharacterAdminHandler struct {
+	service CharacterCurationService
+}
+
+// characterRequest is a whole character, as POST and PUT take it. Id is
+// required by POST; PUT takes it from the path.
+type characterRequest struct {
+	Id          int64  `json:"id" binding:"omitempty,min=1"`
+	Name        string `json:"name" binding:"required,notblank,max=64,charactername"`
+	Ki          string `json:"ki" binding:"required,max=64,powerlevel"`
+	MaxKi       string `json:"maxKi" binding:"required,max=64,powerlevel"`
+	Race        string `json:"race" binding:"required,notblank,max=64"`
+	Gender      string `json:"gender" binding:"max=64"`
+	Image       string `json:"image" binding:"omitempty,http_url,max=2048"`
+	Affiliation string `json:"affiliation" binding:"max=64"`
+	Description string `json:"description" binding:"max=4096"`
+}
+
+func (r characterRequest) patch() domain.CharacterPatch {
+	return domain.CharacterPatch{
+		"name":        r.Name,
+		"ki":          r.Ki,
+		"maxKi":       r.MaxKi,
+		"race":        r.Race,
+		"gender":      r.Gender,
+		"image":       r.Image,
+		"affiliation": r.Affiliation,
+		"description": r.Description,
+	}
+}
+
+// patchCharacterRequest holds the fields a PATCH sets; missing ones are
+// left alone.
+type patchCharacterRequest struct {
+	Name        *string `json:"name" binding:"omitempty,notblank,max=64,charactername"`
+	Ki          *string `json:"ki" binding:"omitempty,max=64,powerlevel"`
+	MaxKi       *string `json:"maxKi" binding:"omitempty,max=64,powerlevel"`
+	Race        *string `json:"race" binding:"omitempty,notblank,max=64"`
+	Gender      *string `json:"gender" binding:"omitempty,max=64"`
+	Image       *string `json:"image" binding:"omitempty,http_url,max=2048"`
+	Affiliation *string `json:"affiliation" binding:"omitempty,max=64"`
+	Description *string `json:"description" binding:"omitempty,max=4096"`
+}
+
+func (r patchCharacterRequest) patch() domain.CharacterPatch {
+	p := domain.CharacterPatch{}
+	for name, value := range map[string]*string{
+		"name":        r.Name,
+		"ki":          r.Ki,
+		"maxKi":       r.MaxKi,
+		"race":        r.Race,
+		"gender":      r.Gender,
+		"image":       r.Image,
+		"affiliation": r.Affiliation,
+		"description": r.Description,
+	} {
+		if value != nil {
+			p[name] = *value
+		}
+	}
+
+	return p
+}
+
+func NewCharacterAdminHandler(s CharacterCurationService) *CharacterAdminHandler {
+	validation.Register()
+	return &CharacterAdminHandler{service: s}
+}
+
+func (h *CharacterAdminHandler) Create(c *gin.Context) {
+	var req characterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		validation.Respond(c, http.StatusBadRequest, err)
+		return
+	}
+	if req.Id == 0 {
+		validation.RespondFields(c, http.StatusBadRequest, []validation.FieldError{{Field: "id", Code: validation.CodeRequired}})
+		return
+	}
+
+	chr, err := h.service.Create(auditContext(c), req.Id, req.patch())
+	if err != nil {
+		respondCurationError(c, err)
+		return
+	}
+
+	render.Respond(c, http.StatusCreated, gin.H{"data": chr})
+}
+
+// Replace curates every field: the ones left out of the body are cleared.
+func (h *CharacterAdminHandler) Replace(c *gin.Context) {
+	id, ok := pathId(c)
+	if !ok {
+		return
+	}
+
+	var req characterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		validation.Respond(c, http.StatusBadRequest, err)
+		return
+	}
+	if req.Id != 0 && req.Id != id {
+		validation.RespondFields(c, http.StatusBadRequest, []validation.FieldError{{Field: "id", Code: validation.CodeInvalid}})
+		return
+	}
+
+	h.update(c, id, req.patch())
+}
+
+// Patch curates only the fields in the body.
+func (h *CharacterAdminHandler) Patch(c *gin.Context) {
+	id, ok := pathId(c)
+	if !ok {
+		return
+	}
+
+	var req patchCharacterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		validation.Respond(c, http.StatusBadRequest, err)
+		return
+	}
+	p := req.patch()
+	if len(p) == 0 {
+		validation.RespondFields(c, http.StatusBadRequest, []validation.FieldError{{Field: "body", Code: validation.CodeRequired}})
+		return
+	}
+
+	h.update(c, id, p)
+}
+
+func (h *CharacterAdminHandler) update(c *gin.Context, id int64, p domain.CharacterPatch) {
+	chr, err := h.service.Update(auditContext(c), id, p)
+	if err != nil {
+		respondCurationError(c, err)
+		return
+	}
+
+	render.Respond(c, http.StatusOK, gin.H{"data": chr})
+}
+
+func (h *CharacterAdminHandler) Delete(c *gin.Context) {
+	id, ok := pathId(c)
+	if !ok {
+		return
+	}
+
+	chr, err := h.service.Delete(auditContext(c), id)
+	if err != nil {
+		respondCurationError(c, err)
+		return
+	}
+
+	render.Respond(c, http.StatusOK, gin.H{"data": chr})
+}
+
+func respondCurationError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		render.Respond(c, http.StatusNotFound, gin.H{"message": err.Error()})
+	case errors.Is(err, domain.ErrExists), errors.Is(err, domain.ErrConflict):
+		render.Respond(c, http.StatusConflict, gin.H{"message": err.Error()})
+	default:
+		render.Respond(c, http.StatusServiceUnavailable, gin.H{"message": err.Error()})
+	}
+}
@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heaveless/dbz-api/internal/application"
+	"github.com/heaveless/dbz-api/internal/delivery/http/middleware"
+	"github.com/heaveless/dbz-api/internal/delivery/http/render"
+	"github.com/heaveless/dbz-api/internal/delivery/http/validation"
+	domain "github.com/heaveless/dbz-api/internal/domain/character"
+	"github.com/heaveless/dbz-api/internal/infrastructure/breaker"
+)
+
+// A batch holds at most 50 items.
+type batchRequest struct {
+	Items []batchItemRequest `json:"items" binding:"required,min=1,max=50,dive"`
+}
+
+// batchItemRequest names a character by id or by name, never both.
+type batchItemRequest struct {
+	Id   int64  `json:"id" binding:"omitempty,min=1"`
+	Name string `json:"name" binding:"omitempty,notblank,max=64,charactername"`
+}
+
+type batchItemResponse struct {
+	Id      int64                `json:"id,omitempty"`
+	Name    string               `json:"name,omitempty"`
+	Status  int                  `json:"status"`
+	Data    *domain.CharacterDTO `json:"data,omitempty"`
+	Message string               `json:"message,omitempty"`
+}
+
+// Batch serves POST /characters/batch. The response is 200 as soon as the
+// request is valid; each item carries its own status, in request order.
+// Items held back by a limit answer 429 or 503 as the single lookups do, and
+// the response carries the longest Retry-After among them.
+func (h *CharacterHandler) Batch(c *gin.Context) {
+	var req batchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		validation.Respond(c, http.StatusBadRequest, err)
+		return
+	}
+
+	refs := make([]domain.CharacterRef, 0, len(req.Items))
+	var errs []validation.FieldError
+	for i, item := range req.Items {
+		switch {
+		case item.Id == 0 && item.Name == "":
+			errs = append(errs, validation.FieldError{Field: fmt.Sprintf("items[%d]", i), Code: validation.CodeRequired})
+		case item.Id != 0 && item.Name != "":
+			errs = append(errs, validation.FieldError{Field: fmt.Sprintf("items[%d]", i), Code: validation.CodeAmbiguous})
+		}
+		refs = append(refs, domain.CharacterRef{Id: item.Id, Name: item.Name})
+	}
+	if len(errs) > 0 {
+		validation.RespondFields(c, http.StatusBadRequest, errs)
+		return
+	}
+
+	results := h.service.GetBatch(c.Request.Context(), refs)
+
+	items := make([]batchItemResponse, 0, len(results))
+	var retryAfter time.Duration
+	for _, r := range results {
+		item := batchItemResponse{Id: r.Ref.Id, Name: r.Ref.Name}
+		var exceeded *application.BudgetExceededError
+		var limited *breaker.UpstreamLimitedError
+		switch {
+		case r.Err == nil:
+			item.Status = http.StatusOK
+			item.Data = r.Character
+		case errors.As(r.Err, &exceeded):
+			item.Status = http.StatusTooManyRequests
+			item.Message = middleware.TooManyRequestsMessage
+			retryAfter = max(retryAfter, exceeded.RetryAfter)
+		case errors.As(r.Err, &limited):
+			item.Status = http.StatusServiceUnavailable
+			item.Message = r.Err.Error()
+			retryAfter = max(retryAfter, limited.RetryAfter)
+		case errors.Is(r.Err, domain.ErrNotFound):
+			item.Status = http.StatusNotFound
+			item.Message = r.Err.Error()
+		default:
+			item.Status = http.StatusServiceUnavailable
+			item.Message = r.Err.Error()
+		}
+		items = append(items, item)
+	}
+
+	if retryAfter > 0 {
+		c.Header("Retry-After", middleware.RetryAfterSeconds(retryAfter))
+	}
+	render.Respond(c, http.StatusOK, gin.H{"data": items})
+}
@@ -2,41 +2,266 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/heaveless/dbz-api/internal/delivery/http/render"
+	"github.com/heaveless/dbz-api/internal/delivery/http/validation"
 	domain "github.com/heaveless/dbz-api/internal/domain/character"
 )
 
+const (
+	defaultSearchLimit = 10
+	maxSearchLimit     = 50
+	suggestionLimit    = 3
+)
+
 type CharacterService interface {
 	GetByName(ctx context.Context, name string) (*domain.CharacterDTO, error)
+	GetById(ctx context.Context, id int64) (*domain.CharacterDTO, error)
+	Search(ctx context.Context, query string, limit int) ([]domain.CharacterMatchDTO, error)
+	SearchText(ctx context.Context, query string, page, limit int) (*domain.CharacterTextSearchDTO, error)
+	List(ctx context.Context, q domain.ListQuery) (*domain.CharacterPageDTO, error)
+	GetBatch(ctx context.Context, refs []domain.CharacterRef) []domain.CharacterResult
 }
 
 type CharacterHandler struct {
-	service CharacterService
+	service      CharacterService
+	cacheControl string
 }
 
 type getCharacterRequest struct {
-	Name string `json:"name" binding:"required"`
+	Name string `json:"name" binding:"required,notblank,max=64,charactername"`
+}
+
+// searchRequest holds q to the rules of a name, in both search modes.
+type searchRequest struct {
+	Query string `form:"q" json:"q" binding:"required,notblank,max=64,charactername"`
 }
 
 func NewCharacterHandler(s CharacterService) *CharacterHandler {
-	return &CharacterHandler{service: s}
+	return NewCharacterHandlerWithCacheControl(s, DefaultCacheControl)
+}
+
+// NewCharacterHandlerWithCacheControl sets the Cache-Control sent with a
+// single character; an empty value sends none.
+func NewCharacterHandlerWithCacheControl(s CharacterService, cacheControl string) *CharacterHandler {
+	validation.Register()
+	return &CharacterHandler{service: s, cacheControl: cacheControl}
 }
 
 func (h *CharacterHandler) GetOne(c *gin.Context) {
+	h.getOne(c, func(chr *domain.CharacterDTO) any { return chr })
+}
+
+// GetOneLegacy serves the unversioned POST /characters with the body it had
+// before /v1.
+func (h *CharacterHandler) GetOneLegacy(c *gin.Context) {
+	h.getOne(c, func(chr *domain.CharacterDTO) any { return newLegacyCharacter(chr) })
+}
+
+// getOne looks a character up by name and writes it as present returns it.
+func (h *CharacterHandler) getOne(c *gin.Context, present func(*domain.CharacterDTO) any) {
 	var req getCharacterRequest
 	err := c.ShouldBindJSON(&req)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"message": "The data submitted is invalid."})
+		validation.Respond(c, http.StatusBadRequest, err)
 		return
 	}
 
 	chr, err := h.service.GetByName(c.Request.Context(), req.Name)
+	if respondBudgetExceeded(c, err) || respondUpstreamLimited(c, err) {
+		return
+	}
+	if errors.Is(err, domain.ErrNotFound) {
+		render.Respond(c, http.StatusNotFound, gin.H{
+			"message":     err.Error(),
+			"suggestions": h.suggestions(c.Request.Context(), req.Name),
+		})
+		return
+	}
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		render.Respond(c, http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	if notModified(c, chr.ETag, chr.UpdatedAt, h.cacheControl) {
+		return
+	}
+
+	render.Respond(c, http.StatusFound, gin.H{"data": present(chr)})
+}
+
+// GetById is the cacheable way to read one character: unlike the POST
+// lookup by name it honours If-None-Match and If-Modified-Since.
+func (h *CharacterHandler) GetById(c *gin.Context) {
+	id, ok := pathId(c)
+	if !ok {
+		return
+	}
+
+	chr, err := h.service.GetById(c.Request.Context(), id)
+	if err != nil {
+		respondLookupError(c, err, domain.ErrNotFound)
+		return
+	}
+
+	if notModified(c, chr.ETag, chr.UpdatedAt, h.cacheControl) {
+		return
+	}
+
+	render.Respond(c, http.StatusOK, gin.H{"data": chr})
+}
+
+// Search serves both modes of GET /characters/search: fuzzy name matching
+// (default) and full-text search over the stored documents (mode=text).
+func (h *CharacterHandler) Search(c *gin.Context) {
+	var req searchRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		validation.Respond(c, http.StatusBadRequest, err)
+		return
+	}
+	query := req.Query
+
+	limit, ok := positiveQueryInt(c, "limit", defaultSearchLimit)
+	if !ok {
+		return
+	}
+	limit = min(limit, maxSearchLimit)
+
+	switch c.DefaultQuery("mode", "fuzzy") {
+	case "fuzzy":
+		h.searchNames(c, query, limit)
+	case "text":
+		h.searchText(c, query, limit)
+	default:
+		render.Respond(c, http.StatusBadRequest, gin.H{"message": "The query parameter mode must be fuzzy or text."})
+	}
+}
+
+func (h *CharacterHandler) searchNames(c *gin.Context, query string, limit int) {
+	matches, err := h.service.Search(c.Request.Context(), query, limit)
+	if err != nil {
+		render.Respond(c, http.StatusServiceUnavailable, gin.H{"message": err.Error()})
+		return
+	}
+
+	render.Respond(c, http.StatusOK, gin.H{"data": matches})
+}
+
+func (h *CharacterHandler) searchText(c *gin.Context, query string, limit int) {
+	page, ok := positiveQueryInt(c, "page", 1)
+	if !ok {
 		return
 	}
 
-	c.JSON(http.StatusFound, gin.H{"data": chr})
+	res, err := h.service.SearchText(c.Request.Context(), query, page, limit)
+	if err != nil {
+		render.Respond(c, http.StatusServiceUnavailable, gin.H{"message": err.Error()})
+		return
+	}
+
+	render.Respond(c, http.StatusOK, gin.H{
+		"data": res.Items,
+		"meta": pageMeta(res.Page, res.Limit, res.Total),
+	})
+}
+
+// List serves GET /characters with optional power level filters (minKi,
+// minMaxKi), sorting (sort=maxKi, sort=-ki, ...) and pagination.
+func (h *CharacterHandler) List(c *gin.Context) {
+	var q domain.ListQuery
+	var ok bool
+
+	if q.MinKi, ok = powerLevelQuery(c, "minKi"); !ok {
+		return
+	}
+	if q.MinMaxKi, ok = powerLevelQuery(c, "minMaxKi"); !ok {
+		return
+	}
+
+	sort, err := domain.ParseSortOrder(c.Query("sort"))
+	if err != nil {
+		render.Respond(c, http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+	q.Sort = sort
+
+	if q.Page, ok = positiveQueryInt(c, "page", 1); !ok {
+		return
+	}
+	if q.Limit, ok = positiveQueryInt(c, "limit", defaultSearchLimit); !ok {
+		return
+	}
+	q.Limit = min(q.Limit, maxSearchLimit)
+
+	res, err := h.service.List(c.Request.Context(), q)
+	if err != nil {
+		render.Respond(c, http.StatusServiceUnavailable, gin.H{"message": err.Error()})
+		return
+	}
+
+	render.Respond(c, http.StatusOK, gin.H{
+		"data": res.Items,
+		"meta": pageMeta(res.Page, res.Limit, res.Total),
+	})
+}
+
+func powerLevelQuery(c *gin.Context, key string) (*domain.PowerLevel, bool) {
+	raw := c.Query(key)
+	if raw == "" {
+		return nil, true
+	}
+
+	level, err := domain.ParsePowerLevel(raw)
+	if err != nil || !level.Known() {
+		render.Respond(c, http.StatusBadRequest, gin.H{"message": "The query parameter " + key + " must be a power level such as 60.000.000 or 90 Septillion."})
+		return nil, false
+	}
+
+	return &level, true
+}
+
+func pageMeta(page, limit int, total int64) render.PageMeta {
+	return render.PageMeta{
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: (total + int64(limit) - 1) / int64(limit),
+	}
+}
+
+// positiveQueryInt reads an optional positive integer query parameter and
+// answers 400 itself when the value is malformed.
+func positiveQueryInt(c *gin.Context, key string, def int) (int, bool) {
+	raw := c.Query(key)
+	if raw == "" {
+		return def, true
+	}
+
+	n, err := strconv.Atoi(raw)
+	if err != nil || n <= 0 {
+		render.Respond(c, http.StatusBadRequest, gin.H{"message": "The query parameter " + key + " must be a positive integer."})
+		return 0, false
+	}
+
+	return n, true
+}
+
+// suggestions is best effort: a failing search never masks the not-found answer.
+func (h *CharacterHandler) suggestions(ctx context.Context, name string) []string {
+	names := []string{}
+
+	matches, err := h.service.Search(ctx, name, suggestionLimit)
+	if err != nil {
+		return names
+	}
+
+	for _, m := range matches {
+		names = append(names, m.Name)
+	}
+
+	return names
 }
@@ -0,0 +1,30 @@
+package handler
+
+import domain "github.com/heaveless/dbz-api/internal/domain/character"
+
+// legacyCharacter is the body of the unversioned POST /characters as it was
+// before /v1: Go field names and no omitted fields. It must not change until
+// the route is removed.
+type legacyCharacter struct {
+	Id          int64
+	Name        string
+	Ki          string
+	MaxKi       string
+	Race        string
+	Gender      string
+	Image       string
+	Affiliation string
+}
+
+func newLegacyCharacter(chr *domain.CharacterDTO) *legacyCharacter {
+	return &legacyCharacter{
+		Id:          chr.Id,
+		Name:        chr.Name,
+		Ki:          chr.Ki,
+		MaxKi:       chr.MaxKi,
+		Race:        chr.Race,
+		Gender:      chr.Gender,
+		Image:       chr.Image,
+		Affiliation: chr.Affiliation,
+	}
+}
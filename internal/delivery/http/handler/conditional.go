@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heaveless/dbz-api/internal/delivery/http/middleware"
+	"github.com/heaveless/dbz-api/internal/delivery/http/render"
+)
+
+// DefaultCacheControl lets clients and shared caches reuse a character for
+// a minute before revalidating it.
+const DefaultCacheControl = "public, max-age=60"
+
+// notModified sets the validators and Cache-Control on the response and,
+// for GET and HEAD, answers 304 when the client's copy is still current.
+// It reports whether the response is complete; if not, the caller renders
+// the body as usual.
+func notModified(c *gin.Context, etag string, lastModified time.Time, cacheControl string) bool {
+	etag = representationETag(c, etag)
+	h := c.Writer.Header()
+	if etag != "" {
+		h.Set("ETag", etag)
+	}
+	if !lastModified.IsZero() {
+		h.Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+	if cacheControl != "" {
+		h.Set("Cache-Control", cacheControl)
+	}
+
+	if m := c.Request.Method; m != http.MethodGet && m != http.MethodHead {
+		return false
+	}
+	if !fresh(c.Request, etag, lastModified) {
+		return false
+	}
+
+	c.AbortWithStatus(http.StatusNotModified)
+	return true
+}
+
+// representationETag gives each format and content coding of a character
+// its own strong tag, derived from etag, the tag of the character itself.
+func representationETag(c *gin.Context, etag string) string {
+	if etag == "" {
+		return ""
+	}
+
+	h := sha256.New()
+	for _, part := range []string{etag, render.Default.MediaType(c.GetHeader("Accept")), middleware.Encoding(c)} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+
+	return `"` + hex.EncodeToString(h.Sum(nil)[:16]) + `"`
+}
+
+// fresh follows RFC 9110 section 13.2.2: If-None-Match wins over
+// If-Modified-Since when both are sent.
+func fresh(r *http.Request, etag string, lastModified time.Time) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		return etag != "" && etagMatches(inm, etag)
+	}
+
+	ims := r.Header.Get("If-Modified-Since")
+	if ims == "" || lastModified.IsZero() {
+		return false
+	}
+	since, err := http.ParseTime(ims)
+	if err != nil {
+		return false
+	}
+
+	return !lastModified.Truncate(time.Second).After(since)
+}
+
+// etagMatches uses the weak comparison If-None-Match calls for.
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+
+	return false
+}
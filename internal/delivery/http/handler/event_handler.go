@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heaveless/dbz-api/internal/delivery/http/render"
+	"github.com/heaveless/dbz-api/internal/domain/event"
+)
+
+const DefaultHeartbeat = 15 * time.Second
+
+// retryMillis tells EventSource how long to wait before reconnecting.
+const retryMillis = 3000
+
+var streamedTypes = []event.Type{
+	event.CharacterCached,
+	event.CharacterRefreshed,
+	event.CharacterCreated,
+	event.CharacterUpdated,
+	event.CharacterDeleted,
+	event.UpstreamUnavailable,
+}
+
+type EventStream interface {
+	Subscribe(afterId uint64, buffer int) ([]event.Event, event.Subscription)
+}
+
+type EventStreamOptions struct {
+	// Heartbeat is how often a comment is sent on an idle stream so proxies
+	// keep it open; zero uses DefaultHeartbeat.
+	Heartbeat time.Duration
+	// Buffer is how many events a client may fall behind before it is
+	// disconnected; zero uses the bus default.
+	Buffer int
+}
+
+type EventHandler struct {
+	stream EventStream
+	opts   EventStreamOptions
+}
+
+func NewEventHandler(s EventStream) *EventHandler {
+	return NewEventHandlerWithOptions(s, EventStreamOptions{})
+}
+
+func NewEventHandlerWithOptions(s EventStream, opts EventStreamOptions) *EventHandler {
+	if opts.Heartbeat <= 0 {
+		opts.Heartbeat = DefaultHeartbeat
+	}
+
+	return &EventHandler{stream: s, opts: opts}
+}
+
+type eventData struct {
+	At   time.Time `json:"at"`
+	Data any       `json:"data"`
+}
+
+// Stream serves domain events as Server-Sent Events. A client that
+// reconnects with Last-Event-ID first gets the events it missed, as far as
+// the bus still holds them. A client that falls behind is disconnected and
+// resumes the same way. ?types= narrows the stream to a comma-separated
+// list of event types.
+func (h *EventHandler) Stream(c *gin.Context) {
+	lastId, err := lastEventId(c)
+	if err != nil {
+		render.Respond(c, http.StatusBadRequest, gin.H{"message": "Last-Event-ID must be the id of an event."})
+		return
+	}
+
+	types, unknown := eventTypes(c.Query("types"))
+	if unknown != "" {
+		render.Respond(c, http.StatusBadRequest, gin.H{"message": fmt.Sprintf("The event type %q does not exist.", unknown)})
+		return
+	}
+
+	missed, sub := h.stream.Subscribe(lastId, h.opts.Buffer)
+	defer sub.Close()
+
+	header := c.Writer.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	_, _ = fmt.Fprintf(c.Writer, "retry: %d\n\n", retryMillis)
+	for _, e := range missed {
+		writeEvent(c.Writer, e, types)
+	}
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(h.opts.Heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case e, ok := <-sub.Events():
+			if !ok {
+				if sub.Dropped() {
+					log.Printf("[SSE] dropped a client %s that fell behind", c.ClientIP())
+				}
+				return
+			}
+			writeEvent(c.Writer, e, types)
+		case <-heartbeat.C:
+			_, _ = io.WriteString(c.Writer, ": heartbeat\n\n")
+		}
+		c.Writer.Flush()
+	}
+}
+
+func writeEvent(w io.Writer, e event.Event, types []event.Type) {
+	if len(types) > 0 && !slices.Contains(types, e.Type) {
+		return
+	}
+
+	data, err := json.Marshal(eventData{At: e.At, Data: e.Data})
+	if err != nil {
+		log.Printf("[SSE] cannot encode event %d: %v", e.Id, err)
+		return
+	}
+
+	_, _ = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.Id, e.Type, data)
+}
+
+// lastEventId reads the header EventSource sends on reconnect, or the
+// lastEventId query parameter for clients that cannot set headers.
+func lastEventId(c *gin.Context) (uint64, error) {
+	raw := c.GetHeader("Last-Event-ID")
+	if raw == "" {
+		raw = c.Query("lastEventId")
+	}
+	if raw == "" {
+		return 0, nil
+	}
+
+	return strconv.ParseUint(strings.TrimSpace(raw), 10, 64)
+}
+
+// eventTypes also returns the first name that is not an event type.
+func eventTypes(raw string) ([]event.Type, string) {
+	if raw == "" {
+		return nil, ""
+	}
+
+	var types []event.Type
+	for _, name := range strings.Split(raw, ",") {
+		t := event.Type(strings.TrimSpace(name))
+		if t == "" {
+			continue
+		}
+		if !slices.Contains(streamedTypes, t) {
+			return nil, string(t)
+		}
+		types = append(types, t)
+	}
+
+	return types, ""
+}
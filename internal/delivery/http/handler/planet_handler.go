@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heaveless/dbz-api/internal/delivery/http/render"
+	"github.com/heaveless/dbz-api/internal/domain/character"
+	domain "github.com/heaveless/dbz-api/internal/domain/planet"
+)
+
+type PlanetService interface {
+	GetById(ctx context.Context, id int64) (*domain.PlanetDTO, error)
+	List(ctx context.Context) ([]domain.PlanetDTO, error)
+	Characters(ctx context.Context, id int64) ([]character.CharacterDTO, error)
+}
+
+type PlanetHandler struct {
+	service PlanetService
+}
+
+func NewPlanetHandler(s PlanetService) *PlanetHandler {
+	return &PlanetHandler{service: s}
+}
+
+func (h *PlanetHandler) List(c *gin.Context) {
+	planets, err := h.service.List(c.Request.Context())
+	if err != nil {
+		render.Respond(c, http.StatusServiceUnavailable, gin.H{"message": err.Error()})
+		return
+	}
+
+	render.Respond(c, http.StatusOK, gin.H{"data": planets})
+}
+
+func (h *PlanetHandler) GetOne(c *gin.Context) {
+	id, ok := pathId(c)
+	if !ok {
+		return
+	}
+
+	p, err := h.service.GetById(c.Request.Context(), id)
+	if err != nil {
+		respondLookupError(c, err, domain.ErrNotFound)
+		return
+	}
+
+	render.Respond(c, http.StatusOK, gin.H{"data": p})
+}
+
+func (h *PlanetHandler) Characters(c *gin.Context) {
+	id, ok := pathId(c)
+	if !ok {
+		return
+	}
+
+	residents, err := h.service.Characters(c.Request.Context(), id)
+	if err != nil {
+		respondLookupError(c, err, domain.ErrNotFound)
+		return
+	}
+
+	render.Respond(c, http.StatusOK, gin.H{"data": residents})
+}
+
+// pathId reads the numeric :id route parameter and answers 400 itself when
+// it is malformed.
+func pathId(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		render.Respond(c, http.StatusBadRequest, gin.H{"message": "The id must be a positive integer."})
+		return 0, false
+	}
+
+	return id, true
+}
+
+func respondLookupError(c *gin.Context, err error, notFound error) {
+	if respondBudgetExceeded(c, err) || respondUpstreamLimited(c, err) {
+		return
+	}
+	if errors.Is(err, notFound) {
+		render.Respond(c, http.StatusNotFound, gin.H{"message": err.Error()})
+		return
+	}
+
+	render.Respond(c, http.StatusServiceUnavailable, gin.H{"message": err.Error()})
+}
@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heaveless/dbz-api/internal/delivery/http/render"
+	domain "github.com/heaveless/dbz-api/internal/domain/transformation"
+)
+
+type TransformationService interface {
+	ListByCharacter(ctx context.Context, characterId int64) ([]domain.TransformationDTO, error)
+}
+
+type TransformationHandler struct {
+	service TransformationService
+}
+
+func NewTransformationHandler(s TransformationService) *TransformationHandler {
+	return &TransformationHandler{service: s}
+}
+
+func (h *TransformationHandler) ListByCharacter(c *gin.Context) {
+	id, ok := pathId(c)
+	if !ok {
+		return
+	}
+
+	ts, err := h.service.ListByCharacter(c.Request.Context(), id)
+	if err != nil {
+		respondLookupError(c, err, domain.ErrNotFound)
+		return
+	}
+
+	render.Respond(c, http.StatusOK, gin.H{"data": ts})
+}
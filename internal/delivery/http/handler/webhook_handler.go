@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heaveless/dbz-api/internal/delivery/http/render"
+	"github.com/heaveless/dbz-api/internal/delivery/http/validation"
+	"github.com/heaveless/dbz-api/internal/domain/event"
+	domain "github.com/heaveless/dbz-api/internal/domain/webhook"
+)
+
+type WebhookService interface {
+	Register(ctx context.Context, req domain.NewSubscription) (*domain.CreatedSubscriptionDTO, error)
+	List(ctx context.Context) ([]domain.SubscriptionDTO, error)
+	Test(ctx context.Context, id string) (*domain.DeliveryDTO, error)
+	Deliveries(ctx context.Context, id string) ([]domain.DeliveryDTO, error)
+}
+
+type WebhookHandler struct {
+	service WebhookService
+}
+
+type createWebhookRequest struct {
+	Url    string       `json:"url" binding:"required,http_url,max=2048"`
+	Events []event.Type `json:"events" binding:"dive,oneof=CharacterCached CharacterRefreshed CharacterCreated CharacterUpdated CharacterDeleted"`
+	Secret string       `json:"secret" binding:"omitempty,min=16,max=256"`
+}
+
+func NewWebhookHandler(s WebhookService) *WebhookHandler {
+	validation.Register()
+	return &WebhookHandler{service: s}
+}
+
+func (h *WebhookHandler) List(c *gin.Context) {
+	subs, err := h.service.List(c.Request.Context())
+	if err != nil {
+		render.Respond(c, http.StatusServiceUnavailable, gin.H{"message": err.Error()})
+		return
+	}
+
+	render.Respond(c, http.StatusOK, gin.H{"data": subs})
+}
+
+// Create registers a subscription. The response is the only place its
+// secret is ever shown.
+func (h *WebhookHandler) Create(c *gin.Context) {
+	var req createWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		validation.Respond(c, http.StatusBadRequest, err)
+		return
+	}
+
+	sub, err := h.service.Register(auditContext(c), domain.NewSubscription{
+		Url:    req.Url,
+		Events: req.Events,
+		Secret: req.Secret,
+	})
+	if err != nil {
+		render.Respond(c, http.StatusServiceUnavailable, gin.H{"message": err.Error()})
+		return
+	}
+
+	render.Respond(c, http.StatusCreated, gin.H{"data": sub})
+}
+
+// Test answers 200 whether or not the endpoint accepted the test delivery;
+// the delivery says how it went.
+func (h *WebhookHandler) Test(c *gin.Context) {
+	d, err := h.service.Test(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondWebhookError(c, err)
+		return
+	}
+
+	render.Respond(c, http.StatusOK, gin.H{"data": d})
+}
+
+func (h *WebhookHandler) Deliveries(c *gin.Context) {
+	ds, err := h.service.Deliveries(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondWebhookError(c, err)
+		return
+	}
+
+	render.Respond(c, http.StatusOK, gin.H{"data": ds})
+}
+
+func respondWebhookError(c *gin.Context, err error) {
+	if errors.Is(err, domain.ErrNotFound) {
+		render.Respond(c, http.StatusNotFound, gin.H{"message": err.Error()})
+		return
+	}
+
+	render.Respond(c, http.StatusServiceUnavailable, gin.H{"message": err.Error()})
+}
@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heaveless/dbz-api/internal/delivery/http/render"
+	"github.com/heaveless/dbz-api/internal/domain/apikey"
+)
+
+const (
+	MissingKeyMessage    = "An API key is required."
+	InvalidKeyMessage    = "The API key is not valid."
+	ForbiddenMessage     = "The API key is not allowed to use this route."
+	QuotaExceededMessage = "The daily quota of this API key is spent."
+	unavailableMessage   = "service temporarily unavailable, please try again later"
+
+	principalKey = "principal"
+)
+
+type Authenticator interface {
+	Authenticate(ctx context.Context, key string, scope string) (*apikey.Principal, error)
+}
+
+// Authenticated lets a request through only with a valid API key granted
+// scope. It answers 401 without a usable key, 403 when the scope is
+// missing and 429 once the key spent its daily quota.
+func Authenticated(auth Authenticator, scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := apiKey(c)
+		if key == "" {
+			abortUnauthorized(c, MissingKeyMessage)
+			return
+		}
+
+		p, err := auth.Authenticate(c.Request.Context(), key, scope)
+		var quota *apikey.QuotaExceededError
+		switch {
+		case errors.Is(err, apikey.ErrInvalidKey):
+			abortUnauthorized(c, InvalidKeyMessage)
+			return
+		case errors.Is(err, apikey.ErrScopeMissing):
+			render.Abort(c, http.StatusForbidden, gin.H{"message": ForbiddenMessage})
+			return
+		case errors.As(err, &quota):
+			c.Header("Retry-After", RetryAfterSeconds(time.Until(quota.Reset)))
+			render.Abort(c, http.StatusTooManyRequests, gin.H{"message": QuotaExceededMessage})
+			return
+		case err != nil:
+			log.Printf("[AUTH] cannot check api key: %v", err)
+			render.Abort(c, http.StatusServiceUnavailable, gin.H{"message": unavailableMessage})
+			return
+		}
+
+		c.Set(principalKey, p)
+		c.Next()
+	}
+}
+
+// apiKey reads the key from X-API-Key or, failing that, from an
+// Authorization: Bearer header.
+func apiKey(c *gin.Context) string {
+	if key := c.GetHeader("X-API-Key"); key != "" {
+		return key
+	}
+	if token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer "); ok {
+		return strings.TrimSpace(token)
+	}
+
+	return ""
+}
+
+// PrincipalFrom returns the caller authenticated by Authenticated.
+func PrincipalFrom(c *gin.Context) (*apikey.Principal, bool) {
+	p, ok := c.Get(principalKey)
+	if !ok {
+		return nil, false
+	}
+
+	principal, ok := p.(*apikey.Principal)
+	return principal, ok
+}
+
+func abortUnauthorized(c *gin.Context, message string) {
+	c.Header("WWW-Authenticate", `Bearer realm="dbz-api"`)
+	render.Abort(c, http.StatusUnauthorized, gin.H{"message": message})
+}
@@ -0,0 +1,213 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+	"github.com/gin-gonic/gin"
+	"github.com/heaveless/dbz-api/internal/delivery/http/render"
+	"github.com/klauspost/compress/gzip"
+	"github.com/klauspost/compress/zstd"
+)
+
+// DefaultCompressMinSize leaves bodies under 1 KiB alone: below that the
+// encoding overhead outweighs the savings.
+const DefaultCompressMinSize = 1024
+
+const encodingKey = "contentEncoding"
+
+// encoder is what the three codecs have in common; Reset lets them be
+// pooled.
+type encoder interface {
+	io.WriteCloser
+	Flush() error
+	Reset(w io.Writer)
+}
+
+type compressor struct {
+	name string
+	pool sync.Pool
+}
+
+// compressors is in server preference order, used to break ties between
+// encodings the client rates equally.
+var compressors = []*compressor{
+	{name: "zstd", pool: sync.Pool{New: func() any {
+		// A 1 MiB window keeps the encoder within what browsers decode.
+		enc, _ := zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1), zstd.WithWindowSize(1<<20))
+		return enc
+	}}},
+	{name: "br", pool: sync.Pool{New: func() any {
+		return brotli.NewWriterLevel(nil, 4)
+	}}},
+	{name: "gzip", pool: sync.Pool{New: func() any {
+		enc, _ := gzip.NewWriterLevel(nil, gzip.DefaultCompression)
+		return enc
+	}}},
+}
+
+// Compress encodes response bodies of at least minSize bytes with the best
+// of zstd, br and gzip the client accepts. Smaller bodies, bodies that are
+// already encoded and bodiless statuses are sent as they are.
+func Compress(minSize int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		render.AddVary(c.Writer.Header(), "Accept-Encoding")
+
+		comp := negotiateEncoding(c.GetHeader("Accept-Encoding"))
+		if comp != nil {
+			c.Set(encodingKey, comp.name)
+		}
+		if comp == nil || c.Request.Method == http.MethodHead {
+			c.Next()
+			return
+		}
+
+		w := &compressWriter{ResponseWriter: c.Writer, comp: comp, minSize: minSize}
+		c.Writer = w
+		defer w.finish()
+
+		c.Next()
+	}
+}
+
+// Encoding is the content coding Compress negotiated for the request, empty
+// for identity. Bodies under the minimum size still go out as they are.
+func Encoding(c *gin.Context) string {
+	return c.GetString(encodingKey)
+}
+
+// negotiateEncoding returns nil when identity is the best the client
+// accepts.
+func negotiateEncoding(header string) *compressor {
+	if header == "" {
+		return nil
+	}
+
+	prefs := render.Preferences(header)
+
+	var best *compressor
+	bestQ := 0.0
+	for _, comp := range compressors {
+		q, wildcard := 0.0, -1.0
+		listed := false
+		for _, p := range prefs {
+			switch p.Value {
+			case comp.name:
+				if !listed {
+					q, listed = p.Q, true
+				}
+			case "*":
+				if wildcard < 0 {
+					wildcard = p.Q
+				}
+			}
+		}
+		if !listed && wildcard > 0 {
+			q = wildcard
+		}
+		if q > bestQ {
+			best, bestQ = comp, q
+		}
+	}
+
+	return best
+}
+
+// compressWriter holds the body back until it reaches minSize, then
+// decides between compressing and passing it through.
+type compressWriter struct {
+	gin.ResponseWriter
+	comp    *compressor
+	minSize int
+
+	buf     []byte
+	enc     encoder
+	bypass  bool
+	started bool
+}
+
+func (w *compressWriter) Write(p []byte) (int, error) {
+	if w.started {
+		if w.bypass {
+			return w.ResponseWriter.Write(p)
+		}
+		return w.enc.Write(p)
+	}
+
+	w.buf = append(w.buf, p...)
+	if len(w.buf) >= w.minSize {
+		if err := w.start(true); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(p), nil
+}
+
+func (w *compressWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Flush sends what is buffered right away, compressed when possible, so
+// streaming responses are not held back by minSize.
+func (w *compressWriter) Flush() {
+	if !w.started && len(w.buf) > 0 {
+		_ = w.start(true)
+	}
+	if w.enc != nil {
+		_ = w.enc.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// start commits to compressing or not and writes out the buffer.
+func (w *compressWriter) start(compress bool) error {
+	w.started = true
+
+	h := w.Header()
+	if !compress || h.Get("Content-Encoding") != "" || !bodyAllowed(w.Status()) {
+		w.bypass = true
+	} else {
+		h.Set("Content-Encoding", w.comp.name)
+		h.Del("Content-Length")
+		w.enc = w.comp.pool.Get().(encoder)
+		w.enc.Reset(w.ResponseWriter)
+	}
+
+	buf := w.buf
+	w.buf = nil
+	if len(buf) == 0 {
+		return nil
+	}
+	if w.bypass {
+		_, err := w.ResponseWriter.Write(buf)
+		return err
+	}
+	_, err := w.enc.Write(buf)
+
+	return err
+}
+
+// finish writes a body that never reached minSize and closes the encoder.
+func (w *compressWriter) finish() {
+	if !w.started {
+		if len(w.buf) == 0 {
+			return
+		}
+		_ = w.start(false)
+	}
+	if w.enc == nil {
+		return
+	}
+
+	_ = w.enc.Close()
+	w.enc.Reset(nil)
+	w.comp.pool.Put(w.enc)
+	w.enc = nil
+}
+
+func bodyAllowed(status int) bool {
+	return status >= http.StatusOK && status != http.StatusNoContent && status != http.StatusNotModified
+}
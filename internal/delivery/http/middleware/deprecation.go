@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Deprecation describes a route that is still served but has a successor.
+type Deprecation struct {
+	// Since is when the route was deprecated (RFC 9745 Deprecation header).
+	Since time.Time
+	// Sunset is when the route stops being served (RFC 8594 Sunset header).
+	Sunset time.Time
+	// SuccessorPrefix is prepended to the request path to build the
+	// successor-version link, e.g. "/v1".
+	SuccessorPrefix string
+}
+
+// Deprecated marks every response of the routes it wraps as deprecated and
+// points clients at the same path under the successor prefix.
+func Deprecated(d Deprecation) gin.HandlerFunc {
+	deprecation := fmt.Sprintf("@%d", d.Since.Unix())
+	sunset := d.Sunset.UTC().Format(http.TimeFormat)
+	prefix := strings.TrimSuffix(d.SuccessorPrefix, "/")
+
+	return func(c *gin.Context) {
+		h := c.Writer.Header()
+		h.Set("Deprecation", deprecation)
+		h.Set("Sunset", sunset)
+		h.Add("Link", fmt.Sprintf(`<%s%s>; rel="successor-version"`, prefix, c.Request.URL.Path))
+
+		c.Next()
+	}
+}
@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heaveless/dbz-api/internal/delivery/http/render"
+	"github.com/heaveless/dbz-api/internal/domain/apikey"
+	"github.com/heaveless/dbz-api/internal/infrastructure/oidc"
+)
+
+const (
+	InvalidTokenMessage = "The bearer token is not valid."
+
+	claimsKey = "claims"
+)
+
+type TokenVerifier interface {
+	Verify(ctx context.Context, token string) (*oidc.Claims, error)
+}
+
+type JWTAuth struct {
+	Verifier TokenVerifier
+	// Scopes must all be granted by the token.
+	Scopes []string
+	// Fallback handles requests that carry no JWT, typically API key
+	// authentication. Without one they are answered 401.
+	Fallback gin.HandlerFunc
+}
+
+// BearerJWT authenticates requests with an OIDC access token sent as
+// Authorization: Bearer. Errors follow RFC 6750: 401 invalid_token and
+// 403 insufficient_scope.
+func BearerJWT(cfg JWTAuth) gin.HandlerFunc {
+	wantScope := strings.Join(cfg.Scopes, " ")
+
+	return func(c *gin.Context) {
+		token, ok := bearerJWT(c)
+		if !ok {
+			if cfg.Fallback != nil {
+				cfg.Fallback(c)
+				return
+			}
+			abortUnauthorized(c, MissingKeyMessage)
+			return
+		}
+
+		claims, err := cfg.Verifier.Verify(c.Request.Context(), token)
+		if errors.Is(err, oidc.ErrKeysUnavailable) {
+			log.Printf("[AUTH] cannot check bearer token: %v", err)
+			render.Abort(c, http.StatusServiceUnavailable, gin.H{"message": unavailableMessage})
+			return
+		}
+		if err != nil {
+			c.Header("WWW-Authenticate", `Bearer realm="dbz-api", error="invalid_token"`)
+			render.Abort(c, http.StatusUnauthorized, gin.H{"message": InvalidTokenMessage})
+			return
+		}
+
+		// Token scopes are matched literally; unlike API keys there is no
+		// scope that implies the others.
+		for _, scope := range cfg.Scopes {
+			if !slices.Contains(claims.Scopes, scope) {
+				c.Header("WWW-Authenticate", `Bearer realm="dbz-api", error="insufficient_scope", scope="`+wantScope+`"`)
+				render.Abort(c, http.StatusForbidden, gin.H{"message": ForbiddenMessage})
+				return
+			}
+		}
+
+		c.Set(principalKey, &apikey.Principal{Name: claims.Subject, Scopes: claims.Scopes})
+		c.Set(claimsKey, claims)
+		c.Next()
+	}
+}
+
+// ClaimsFrom returns the token claims of a request authenticated by
+// BearerJWT.
+func ClaimsFrom(c *gin.Context) (*oidc.Claims, bool) {
+	v, ok := c.Get(claimsKey)
+	if !ok {
+		return nil, false
+	}
+
+	claims, ok := v.(*oidc.Claims)
+	return claims, ok
+}
+
+// bearerJWT returns the bearer token when it has the three segments of a
+// compact JWS. API keys never contain dots.
+func bearerJWT(c *gin.Context) (string, bool) {
+	token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if !ok {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+
+	return token, strings.Count(token, ".") == 2
+}
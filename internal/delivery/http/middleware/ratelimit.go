@@ -0,0 +1,143 @@
+package middleware
+
+import (
+	"context"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heaveless/dbz-api/internal/application"
+	"github.com/heaveless/dbz-api/internal/delivery/http/render"
+	"github.com/heaveless/dbz-api/internal/domain/apikey"
+	"github.com/heaveless/dbz-api/internal/infrastructure/ratelimit"
+)
+
+const TooManyRequestsMessage = "Too many requests, please try again later."
+
+type RateLimit struct {
+	Store ratelimit.Store
+	// PerIP applies to every request, authenticated or not, so it bounds
+	// what one address can send before its credentials are checked. PerKey
+	// applies on top to authenticated callers. Upstream is a separate,
+	// usually smaller, budget spent only by requests that miss the local
+	// store and go to the upstream API.
+	PerIP    ratelimit.Limit
+	PerKey   ratelimit.Limit
+	Upstream ratelimit.Limit
+}
+
+// RateLimited takes one token per request from the client IP's bucket and
+// answers 429 once it is empty. It runs before authentication, so made-up
+// credentials never buy a bucket of their own. Store failures let the
+// request through.
+func RateLimited(rl RateLimit) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		client := "ip:" + c.ClientIP()
+		if !take(c, rl.Store, "req:"+client, rl.PerIP) {
+			return
+		}
+
+		withUpstreamBudget(c, rl, client)
+		c.Next()
+	}
+}
+
+// CallerRateLimited charges the caller authentication let through to its
+// own bucket, and moves its upstream budget from the IP to the caller. It
+// must run after authentication; requests without a principal pass as they
+// are.
+func CallerRateLimited(rl RateLimit) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		p, ok := PrincipalFrom(c)
+		if !ok {
+			c.Next()
+			return
+		}
+
+		client := CallerKey(p)
+		if !take(c, rl.Store, "req:"+client, rl.PerKey) {
+			return
+		}
+
+		withUpstreamBudget(c, rl, client)
+		c.Next()
+	}
+}
+
+// CallerKey names an authenticated caller in the rate limit store: its key
+// id, or the token subject for callers authenticated by token.
+func CallerKey(p *apikey.Principal) string {
+	if p.KeyId != "" {
+		return "key:" + p.KeyId
+	}
+
+	return "sub:" + p.Name
+}
+
+// take reports whether the request may go on; it has answered 429 when it
+// may not.
+func take(c *gin.Context, store ratelimit.Store, key string, limit ratelimit.Limit) bool {
+	if !limit.Enabled() {
+		return true
+	}
+
+	d, err := store.Take(c.Request.Context(), key, limit)
+	if err != nil {
+		log.Printf("[RATE] store unavailable, not limiting: %v", err)
+		return true
+	}
+
+	setRateLimitHeaders(c, d)
+	if !d.Allowed {
+		abortTooManyRequests(c, d.RetryAfter)
+		return false
+	}
+
+	return true
+}
+
+func withUpstreamBudget(c *gin.Context, rl RateLimit, client string) {
+	if !rl.Upstream.Enabled() {
+		return
+	}
+
+	ctx := application.WithUpstreamBudget(c.Request.Context(), UpstreamBudget(rl.Store, client, rl.Upstream))
+	c.Request = c.Request.WithContext(ctx)
+}
+
+// UpstreamBudget spends client's upstream budget from store. Store failures
+// let the call through.
+func UpstreamBudget(store ratelimit.Store, client string, limit ratelimit.Limit) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		d, err := store.Take(ctx, "upstream:"+client, limit)
+		if err != nil || d.Allowed {
+			return nil
+		}
+		return &application.BudgetExceededError{RetryAfter: d.RetryAfter}
+	}
+}
+
+func setRateLimitHeaders(c *gin.Context, d ratelimit.Decision) {
+	h := c.Writer.Header()
+	h.Set("RateLimit-Limit", strconv.Itoa(d.Limit))
+	h.Set("RateLimit-Remaining", strconv.Itoa(d.Remaining))
+	h.Set("RateLimit-Reset", strconv.Itoa(seconds(d.Reset)))
+}
+
+// abortTooManyRequests answers 429 with a Retry-After header.
+func abortTooManyRequests(c *gin.Context, retryAfter time.Duration) {
+	c.Header("Retry-After", RetryAfterSeconds(retryAfter))
+	render.Abort(c, http.StatusTooManyRequests, gin.H{"message": TooManyRequestsMessage})
+}
+
+// RetryAfterSeconds renders d as a Retry-After value, at least one second.
+func RetryAfterSeconds(d time.Duration) string {
+	return strconv.Itoa(max(1, seconds(d)))
+}
+
+func seconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"encoding/hex"
+	"fmt"
+	"log"
+	"regexp"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heaveless/dbz-api/internal/domain/audit"
+	"github.com/heaveless/dbz-api/internal/utils"
+)
+
+const RequestIdHeader = "X-Request-Id"
+
+// requestIdPattern bounds what a client may send as its own id, so it is
+// safe to log and store as is.
+var requestIdPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// fallbackIds numbers the ids made while the system has no randomness.
+var fallbackIds atomic.Uint64
+
+// RequestId tags every request with an id: the client's X-Request-Id when
+// it is well formed, a random one otherwise. The id is echoed in the
+// response and recorded with the audit entries of the changes the request
+// makes.
+func RequestId() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIdHeader)
+		if !requestIdPattern.MatchString(id) {
+			id = newRequestId()
+		}
+
+		c.Header(RequestIdHeader, id)
+		c.Request = c.Request.WithContext(audit.WithRequestId(c.Request.Context(), id))
+
+		c.Next()
+	}
+}
+
+// newRequestId falls back to the time and a process-wide counter when no
+// random id can be made, so ids stay unique within the process.
+func newRequestId() string {
+	id, err := utils.RandomString(16, hex.EncodeToString)
+	if err != nil {
+		log.Printf("[HTTP] cannot make a random request id: %v", err)
+		return fmt.Sprintf("%x-%x", time.Now().UnixNano(), fallbackIds.Add(1))
+	}
+
+	return id
+}
@@ -0,0 +1,72 @@
+package openapi
+
+import (
+	_ "embed"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+const versionPrefix = "/v1"
+
+var (
+	//go:embed openapi.json
+	spec []byte
+
+	//go:embed redoc.html
+	redoc []byte
+)
+
+// Document returns the served OpenAPI document. Only the /v1 paths are
+// written by hand; their deprecated unversioned twins are derived from them
+// so both always describe the same contract.
+var Document = sync.OnceValue(func() []byte {
+	var doc map[string]any
+	if err := json.Unmarshal(spec, &doc); err != nil {
+		panic("openapi: invalid embedded document: " + err.Error())
+	}
+
+	paths := doc["paths"].(map[string]any)
+	for path, item := range paths {
+		legacy, ok := strings.CutPrefix(path, versionPrefix+"/")
+		if !ok {
+			continue
+		}
+		paths["/"+legacy] = deprecate(item.(map[string]any), path)
+	}
+
+	out, err := json.Marshal(doc)
+	if err != nil {
+		panic("openapi: " + err.Error())
+	}
+
+	return out
+})
+
+func deprecate(item map[string]any, successor string) map[string]any {
+	copied := make(map[string]any, len(item))
+	for method, raw := range item {
+		op := make(map[string]any, len(raw.(map[string]any))+1)
+		for k, v := range raw.(map[string]any) {
+			op[k] = v
+		}
+		op["operationId"] = op["operationId"].(string) + "Legacy"
+		op["deprecated"] = true
+		op["description"] = "Deprecated, use " + successor + ". Responses carry Deprecation, Sunset and Link headers."
+		copied[method] = op
+	}
+
+	return copied
+}
+
+func Register(r gin.IRoutes) {
+	r.GET("/openapi.json", func(c *gin.Context) {
+		c.Data(http.StatusOK, "application/json", Document())
+	})
+	r.GET("/docs", func(c *gin.Context) {
+		c.Data(http.StatusOK, "text/html; charset=utf-8", redoc)
+	})
+}
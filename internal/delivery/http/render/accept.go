@@ -0,0 +1,79 @@
+package render
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Preference is one entry of an Accept or Accept-Encoding header.
+type Preference struct {
+	Value string
+	Q     float64
+}
+
+// Preferences parses a header such as "application/msgpack, */*;q=0.5"
+// into entries sorted by descending quality; ties keep header order.
+// Entries with a malformed quality are dropped.
+func Preferences(header string) []Preference {
+	var prefs []Preference
+	for _, part := range strings.Split(header, ",") {
+		value, params, _ := strings.Cut(part, ";")
+		value = strings.ToLower(strings.TrimSpace(value))
+		if value == "" {
+			continue
+		}
+
+		q, ok := quality(params)
+		if !ok {
+			continue
+		}
+		prefs = append(prefs, Preference{Value: value, Q: q})
+	}
+
+	sort.SliceStable(prefs, func(i, j int) bool { return prefs[i].Q > prefs[j].Q })
+
+	return prefs
+}
+
+func quality(params string) (float64, bool) {
+	for _, param := range strings.Split(params, ";") {
+		key, value, _ := strings.Cut(param, "=")
+		if strings.TrimSpace(key) != "q" {
+			continue
+		}
+
+		q, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || q < 0 || q > 1 {
+			return 0, false
+		}
+		return q, true
+	}
+
+	return 1, true
+}
+
+// mediaQuality is the quality the client gives mediaType: the most specific
+// matching range wins, as RFC 9110 section 12.5.1 asks.
+func mediaQuality(prefs []Preference, mediaType string) float64 {
+	mediaType, _, _ = strings.Cut(mediaType, ";")
+	kind, _, _ := strings.Cut(mediaType, "/")
+
+	best, specificity := 0.0, -1
+	for _, p := range prefs {
+		s := -1
+		switch p.Value {
+		case mediaType:
+			s = 2
+		case kind + "/*":
+			s = 1
+		case "*/*":
+			s = 0
+		}
+		if s > specificity {
+			best, specificity = p.Q, s
+		}
+	}
+
+	return best
+}
@@ -0,0 +1,15 @@
+package render
+
+import "encoding/json"
+
+// JSON is the default format and the reference for the others: every body
+// can be written as JSON.
+type JSON struct{}
+
+func (JSON) MediaType() string {
+	return "application/json; charset=utf-8"
+}
+
+func (JSON) Marshal(body any) ([]byte, error) {
+	return json.Marshal(body)
+}
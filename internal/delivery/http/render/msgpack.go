@@ -0,0 +1,22 @@
+package render
+
+import "github.com/ugorji/go/codec"
+
+// msgpackHandle honours the json struct tags, so MessagePack bodies carry
+// the same keys as JSON ones. WriteExt enables the str8 and bin types of
+// the current spec.
+var msgpackHandle = &codec.MsgpackHandle{WriteExt: true}
+
+// MsgPack writes any body JSON can, field for field.
+type MsgPack struct{}
+
+func (MsgPack) MediaType() string {
+	return "application/msgpack"
+}
+
+func (MsgPack) Marshal(body any) ([]byte, error) {
+	var out []byte
+	err := codec.NewEncoderBytes(&out, msgpackHandle).Encode(body)
+
+	return out, err
+}
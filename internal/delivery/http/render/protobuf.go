@@ -0,0 +1,102 @@
+package render
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/heaveless/dbz-api/internal/delivery/pb/dbzv1"
+	domain "github.com/heaveless/dbz-api/internal/domain/character"
+	"google.golang.org/protobuf/proto"
+)
+
+// PageMeta is the meta block of a paginated list.
+type PageMeta struct {
+	Limit      int   `json:"limit"`
+	Page       int   `json:"page"`
+	Total      int64 `json:"total"`
+	TotalPages int64 `json:"totalPages"`
+}
+
+// Protobuf writes the messages in proto/dbz/v1. Only characters, character
+// pages and plain errors have a schema; anything else is ErrUnsupported, and
+// a client that accepts nothing else gets 406.
+type Protobuf struct{}
+
+func (Protobuf) MediaType() string {
+	return "application/x-protobuf"
+}
+
+func (Protobuf) Marshal(body any) ([]byte, error) {
+	msg, ok := toProto(body)
+	if !ok {
+		return nil, ErrUnsupported
+	}
+
+	return proto.Marshal(msg)
+}
+
+func toProto(body any) (proto.Message, bool) {
+	switch b := body.(type) {
+	case proto.Message:
+		return b, true
+	case gin.H:
+		if _, ok := b["data"]; ok {
+			return dataToProto(b)
+		}
+		return errorToProto(b)
+	}
+
+	return nil, false
+}
+
+func dataToProto(h gin.H) (proto.Message, bool) {
+	switch data := h["data"].(type) {
+	case *domain.CharacterDTO:
+		if len(h) != 1 {
+			return nil, false
+		}
+		return &dbzv1.CharacterResponse{Data: dbzv1.FromCharacter(data)}, true
+	case []domain.CharacterDTO:
+		meta, ok := h["meta"].(PageMeta)
+		if !ok || len(h) != 2 {
+			return nil, false
+		}
+		page := &dbzv1.CharacterPage{
+			Data: make([]*dbzv1.Character, 0, len(data)),
+			Meta: &dbzv1.PageMeta{
+				Page:       int32(meta.Page),
+				Limit:      int32(meta.Limit),
+				Total:      meta.Total,
+				TotalPages: meta.TotalPages,
+			},
+		}
+		for i := range data {
+			page.Data = append(page.Data, dbzv1.FromCharacter(&data[i]))
+		}
+		return page, true
+	}
+
+	return nil, false
+}
+
+// errorToProto keeps the error body whole or not at all: validation errors
+// carry per-field details the Error message has no room for.
+func errorToProto(h gin.H) (proto.Message, bool) {
+	message, ok := h["message"].(string)
+	if !ok {
+		return nil, false
+	}
+
+	msg := &dbzv1.Error{Message: message}
+	for key, value := range h {
+		switch key {
+		case "message":
+		case "suggestions":
+			if msg.Suggestions, ok = value.([]string); !ok {
+				return nil, false
+			}
+		default:
+			return nil, false
+		}
+	}
+
+	return msg, true
+}
@@ -0,0 +1,152 @@
+// Package render writes response bodies in the format the client asks for
+// in its Accept header. Every handler goes through the same Registry so a
+// new format only has to be registered once.
+package render
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ErrUnsupported is returned by a Serializer that has no schema for a body.
+// Negotiation then moves on to the client's next choice.
+var ErrUnsupported = errors.New("body not supported by this format")
+
+// Serializer encodes response bodies in a single media type. MediaType may
+// carry parameters such as a charset; only the type itself is negotiated.
+type Serializer interface {
+	MediaType() string
+	Marshal(body any) ([]byte, error)
+}
+
+// Registry picks a Serializer per request. The first one registered is the
+// default: it serves clients without an Accept header, clients that accept
+// none of the registered types, and error bodies no accepted type can write.
+type Registry struct {
+	serializers []Serializer
+}
+
+func NewRegistry(serializers ...Serializer) *Registry {
+	return &Registry{serializers: serializers}
+}
+
+// Default serves JSON, MessagePack and protobuf, in that order.
+var Default = NewRegistry(JSON{}, MsgPack{}, Protobuf{})
+
+const notAcceptableMessage = "None of the accepted formats can represent this response."
+
+// Respond writes body with Default.
+func Respond(c *gin.Context, status int, body any) {
+	Default.Render(c, status, body)
+}
+
+// Abort writes body with Default and stops the handler chain.
+func Abort(c *gin.Context, status int, body any) {
+	c.Abort()
+	Default.Render(c, status, body)
+}
+
+// Render negotiates the format, then writes status and the encoded body.
+// When the client accepts registered types but none of them can write a
+// successful body, such as protobuf for a route without a message, it
+// answers 406 instead.
+func (r *Registry) Render(c *gin.Context, status int, body any) {
+	AddVary(c.Writer.Header(), "Accept")
+	if len(r.serializers) == 0 {
+		return
+	}
+
+	candidates := r.candidates(c.GetHeader("Accept"))
+	if len(candidates) == 0 {
+		candidates = r.serializers[:1]
+	}
+	for _, s := range candidates {
+		if r.write(c, s, status, body) {
+			return
+		}
+	}
+
+	if status < http.StatusBadRequest {
+		// The validators describe a representation that is not sent.
+		c.Writer.Header().Del("ETag")
+		c.Writer.Header().Del("Last-Modified")
+		r.Render(c, http.StatusNotAcceptable, gin.H{"message": notAcceptableMessage})
+		return
+	}
+	r.write(c, r.serializers[0], status, body)
+}
+
+// MediaType is what Render picks for a body every registered format can
+// write, such as a character.
+func (r *Registry) MediaType(accept string) string {
+	if candidates := r.candidates(accept); len(candidates) > 0 {
+		return candidates[0].MediaType()
+	}
+	if len(r.serializers) == 0 {
+		return ""
+	}
+
+	return r.serializers[0].MediaType()
+}
+
+// write reports false when s has no schema for body.
+func (r *Registry) write(c *gin.Context, s Serializer, status int, body any) bool {
+	data, err := s.Marshal(body)
+	if errors.Is(err, ErrUnsupported) {
+		return false
+	}
+	if err != nil {
+		_ = c.Error(err)
+		c.Status(http.StatusInternalServerError)
+		return true
+	}
+
+	c.Data(status, s.MediaType(), data)
+	return true
+}
+
+// candidates lists the serializers the client accepts, best first. It is
+// empty without an Accept header.
+func (r *Registry) candidates(accept string) []Serializer {
+	if accept == "" {
+		return nil
+	}
+
+	prefs := Preferences(accept)
+
+	type ranked struct {
+		s Serializer
+		q float64
+	}
+	var accepted []ranked
+	for _, s := range r.serializers {
+		if q := mediaQuality(prefs, s.MediaType()); q > 0 {
+			accepted = append(accepted, ranked{s, q})
+		}
+	}
+	sort.SliceStable(accepted, func(i, j int) bool { return accepted[i].q > accepted[j].q })
+
+	out := make([]Serializer, 0, len(accepted))
+	for _, a := range accepted {
+		out = append(out, a.s)
+	}
+
+	return out
+}
+
+// AddVary appends token to Vary unless it is already listed.
+func AddVary(h http.Header, token string) {
+	for _, v := range h.Values("Vary") {
+		for _, t := range strings.Split(v, ",") {
+			if strings.EqualFold(strings.TrimSpace(t), token) {
+				return
+			}
+		}
+	}
+
+	h.Add("Vary", token)
+}
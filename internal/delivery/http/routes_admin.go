@@ -0,0 +1,32 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// registerAdmin mounts the operator routes. They are not versioned and not
+// part of the public contract.
+func registerAdmin(r gin.IRoutes, h Handlers) {
+	r.GET("/keys", h.ApiKey.List)
+	r.POST("/keys", h.ApiKey.Create)
+	r.POST("/keys/:id/rotate", h.ApiKey.Rotate)
+	r.DELETE("/keys/:id", h.ApiKey.Revoke)
+
+	if h.Webhook != nil {
+		r.GET("/webhooks", h.Webhook.List)
+		r.POST("/webhooks", h.Webhook.Create)
+		r.POST("/webhooks/:id/test", h.Webhook.Test)
+		r.GET("/webhooks/:id/deliveries", h.Webhook.Deliveries)
+	}
+
+	if h.CharacterAdmin != nil {
+		r.POST("/characters", h.CharacterAdmin.Create)
+		r.PUT("/characters/:id", h.CharacterAdmin.Replace)
+		r.PATCH("/characters/:id", h.CharacterAdmin.Patch)
+		r.DELETE("/characters/:id", h.CharacterAdmin.Delete)
+	}
+
+	if h.Audit != nil {
+		r.GET("/audit", h.Audit.List)
+	}
+}
@@ -0,0 +1,20 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// registerV1 mounts the v1 contract. A later version gets its own
+// registerVn with handlers and DTOs of its own, so v1 stays frozen.
+func registerV1(r gin.IRoutes, h Handlers) {
+	r.GET("/characters", h.Character.List)
+	r.POST("/characters", h.Character.GetOne)
+	r.POST("/characters/batch", h.Character.Batch)
+	r.GET("/characters/search", h.Character.Search)
+	r.GET("/characters/:id", h.Character.GetById)
+	r.GET("/characters/:id/transformations", h.Transformation.ListByCharacter)
+
+	r.GET("/planets", h.Planet.List)
+	r.GET("/planets/:id", h.Planet.GetOne)
+	r.GET("/planets/:id/characters", h.Planet.Characters)
+}
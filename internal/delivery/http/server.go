@@ -1,20 +1,83 @@
 package http
 
 import (
+	"expvar"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	graphqldelivery "github.com/heaveless/dbz-api/internal/delivery/graphql"
 	"github.com/heaveless/dbz-api/internal/delivery/http/handler"
+	"github.com/heaveless/dbz-api/internal/delivery/http/middleware"
+	"github.com/heaveless/dbz-api/internal/delivery/http/openapi"
+	"github.com/heaveless/dbz-api/internal/delivery/http/render"
 )
 
-func NewServer(handler *handler.CharacterHandler) *gin.Engine {
+// legacyRoutes covers POST /characters, the only route that predates /v1.
+// It keeps its original body until the sunset date.
+var legacyRoutes = middleware.Deprecation{
+	Since:           time.Date(2026, time.November, 1, 0, 0, 0, 0, time.UTC),
+	Sunset:          time.Date(2027, time.May, 1, 0, 0, 0, 0, time.UTC),
+	SuccessorPrefix: "/v1",
+}
+
+type Handlers struct {
+	Character      *handler.CharacterHandler
+	Planet         *handler.PlanetHandler
+	Transformation *handler.TransformationHandler
+	// ApiKey serves /admin; the admin routes are left out when it is nil.
+	ApiKey *handler.ApiKeyHandler
+	// Webhook adds the subscription routes to /admin when it is not nil.
+	Webhook *handler.WebhookHandler
+	// CharacterAdmin adds the character curation routes to /admin when it
+	// is not nil.
+	CharacterAdmin *handler.CharacterAdminHandler
+	// Audit adds /admin/audit when it is not nil.
+	Audit *handler.AuditHandler
+	// Event serves /events; the route is left out when it is nil.
+	Event *handler.EventHandler
+	// GraphQL serves /graphql; the route is left out when it is nil.
+	GraphQL *graphqldelivery.Handler
+}
+
+// Middleware is installed per route group: Api on the API routes, Admin on
+// /admin and /debug/vars. Neither runs on health or documentation; Global
+// runs on every route.
+type Middleware struct {
+	Global []gin.HandlerFunc
+	Api    []gin.HandlerFunc
+	Admin  []gin.HandlerFunc
+}
+
+func NewServer(h Handlers, mw Middleware) *gin.Engine {
 	r := gin.Default()
+	r.Use(mw.Global...)
 
 	r.GET("/health", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+		render.Respond(c, http.StatusOK, gin.H{"status": "ok"})
 	})
+	openapi.Register(r)
+
+	registerV1(r.Group("/v1", mw.Api...), h)
+	legacy := r.Group("", append([]gin.HandlerFunc{middleware.Deprecated(legacyRoutes)}, mw.Api...)...)
+	legacy.POST("/characters", h.Character.GetOneLegacy)
+
+	if h.Event != nil {
+		r.Group("/events", mw.Api...).GET("", h.Event.Stream)
+	}
+
+	// GraphQL has no versions; the schema evolves by adding fields.
+	if h.GraphQL != nil {
+		gql := r.Group("/graphql", mw.Api...)
+		gql.GET("", h.GraphQL.Serve)
+		gql.POST("", h.GraphQL.Serve)
+	}
 
-	r.POST("/characters", handler.GetOne)
+	if h.ApiKey != nil {
+		registerAdmin(r.Group("/admin", mw.Admin...), h)
+		// Runtime and cache counters are for operators, like /admin.
+		r.Group("/debug", mw.Admin...).GET("/vars", gin.WrapH(expvar.Handler()))
+	}
 
 	return r
 }
@@ -0,0 +1,147 @@
+package validation
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/heaveless/dbz-api/internal/delivery/http/render"
+	"github.com/heaveless/dbz-api/internal/domain/character"
+)
+
+const InvalidMessage = "The data submitted is invalid."
+
+// Codes reported per field. They are part of the API contract.
+const (
+	CodeRequired          = "required"
+	CodeBlank             = "blank"
+	CodeTooLong           = "too_long"
+	CodeTooShort          = "too_short"
+	CodeInvalidCharacters = "invalid_characters"
+	CodeInvalidType       = "invalid_type"
+	CodeMalformed         = "malformed"
+	CodeAmbiguous         = "ambiguous"
+	CodeInvalid           = "invalid"
+)
+
+type FieldError struct {
+	Field string `json:"field"`
+	Code  string `json:"code"`
+}
+
+var register sync.Once
+
+// Register installs the custom rules on Gin's validator and makes it report
+// fields by their JSON names. It is safe to call more than once.
+func Register() {
+	register.Do(func() {
+		v, ok := binding.Validator.Engine().(*validator.Validate)
+		if !ok {
+			return
+		}
+
+		v.RegisterTagNameFunc(func(f reflect.StructField) string {
+			name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+			if name == "-" {
+				return ""
+			}
+			if name == "" {
+				return f.Name
+			}
+			return name
+		})
+
+		_ = v.RegisterValidation("notblank", func(fl validator.FieldLevel) bool {
+			return strings.TrimSpace(fl.Field().String()) != ""
+		})
+		_ = v.RegisterValidation("charactername", func(fl validator.FieldLevel) bool {
+			return IsCharacterName(fl.Field().String())
+		})
+		_ = v.RegisterValidation("powerlevel", func(fl validator.FieldLevel) bool {
+			_, err := character.ParsePowerLevel(fl.Field().String())
+			return err == nil
+		})
+	})
+}
+
+// IsCharacterName accepts letters, digits, spaces and the punctuation found
+// in real names ("Mr. Satan", "Android 17", "Zamasu (Fused)"). Control
+// characters, markup and other symbols are rejected.
+func IsCharacterName(s string) bool {
+	for _, r := range s {
+		switch {
+		case unicode.IsLetter(r), unicode.IsMark(r), unicode.IsDigit(r):
+		case r == ' ', strings.ContainsRune(".-'’()", r):
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// Errors translates a binding error into per-field errors.
+func Errors(err error) []FieldError {
+	var verrs validator.ValidationErrors
+	if errors.As(err, &verrs) {
+		out := make([]FieldError, 0, len(verrs))
+		for _, fe := range verrs {
+			out = append(out, FieldError{Field: fieldPath(fe), Code: code(fe.Tag())})
+		}
+		return out
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return []FieldError{{Field: typeErr.Field, Code: CodeInvalidType}}
+	}
+
+	return []FieldError{{Field: "body", Code: CodeMalformed}}
+}
+
+// Respond answers with the generic message and the per-field details of a
+// binding error.
+func Respond(c *gin.Context, status int, err error) {
+	RespondFields(c, status, Errors(err))
+}
+
+// RespondFields is Respond for rules checked by hand after binding.
+func RespondFields(c *gin.Context, status int, errs []FieldError) {
+	render.Respond(c, status, gin.H{
+		"message": InvalidMessage,
+		"errors":  errs,
+	})
+}
+
+// fieldPath drops the struct name from the namespace: "req.name" -> "name".
+func fieldPath(fe validator.FieldError) string {
+	_, path, found := strings.Cut(fe.Namespace(), ".")
+	if !found {
+		return fe.Field()
+	}
+
+	return path
+}
+
+func code(tag string) string {
+	switch tag {
+	case "required":
+		return CodeRequired
+	case "notblank":
+		return CodeBlank
+	case "max":
+		return CodeTooLong
+	case "min":
+		return CodeTooShort
+	case "charactername":
+		return CodeInvalidCharacters
+	}
+
+	return CodeInvalid
+}
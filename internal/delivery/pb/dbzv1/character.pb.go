@@ -0,0 +1,437 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: dbz/v1/character.proto
+
+package dbzv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Character mirrors the JSON representation. Optional fields the upstream
+// API leaves out are empty strings.
+type Character struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Ki            string                 `protobuf:"bytes,3,opt,name=ki,proto3" json:"ki,omitempty"`
+	MaxKi         string                 `protobuf:"bytes,4,opt,name=max_ki,json=maxKi,proto3" json:"max_ki,omitempty"`
+	Race          string                 `protobuf:"bytes,5,opt,name=race,proto3" json:"race,omitempty"`
+	Gender        string                 `protobuf:"bytes,6,opt,name=gender,proto3" json:"gender,omitempty"`
+	Image         string                 `protobuf:"bytes,7,opt,name=image,proto3" json:"image,omitempty"`
+	Affiliation   string                 `protobuf:"bytes,8,opt,name=affiliation,proto3" json:"affiliation,omitempty"`
+	Description   string                 `protobuf:"bytes,9,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Character) Reset() {
+	*x = Character{}
+	mi := &file_dbz_v1_character_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Character) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Character) ProtoMessage() {}
+
+func (x *Character) ProtoReflect() protoreflect.Message {
+	mi := &file_dbz_v1_character_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Character.ProtoReflect.Descriptor instead.
+func (*Character) Descriptor() ([]byte, []int) {
+	return file_dbz_v1_character_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Character) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Character) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Character) GetKi() string {
+	if x != nil {
+		return x.Ki
+	}
+	return ""
+}
+
+func (x *Character) GetMaxKi() string {
+	if x != nil {
+		return x.MaxKi
+	}
+	return ""
+}
+
+func (x *Character) GetRace() string {
+	if x != nil {
+		return x.Race
+	}
+	return ""
+}
+
+func (x *Character) GetGender() string {
+	if x != nil {
+		return x.Gender
+	}
+	return ""
+}
+
+func (x *Character) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *Character) GetAffiliation() string {
+	if x != nil {
+		return x.Affiliation
+	}
+	return ""
+}
+
+func (x *Character) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+// CharacterResponse is the body of a single character lookup.
+type CharacterResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          *Character             `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CharacterResponse) Reset() {
+	*x = CharacterResponse{}
+	mi := &file_dbz_v1_character_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CharacterResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CharacterResponse) ProtoMessage() {}
+
+func (x *CharacterResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dbz_v1_character_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CharacterResponse.ProtoReflect.Descriptor instead.
+func (*CharacterResponse) Descriptor() ([]byte, []int) {
+	return file_dbz_v1_character_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CharacterResponse) GetData() *Character {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type PageMeta struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Total         int64                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	TotalPages    int64                  `protobuf:"varint,4,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PageMeta) Reset() {
+	*x = PageMeta{}
+	mi := &file_dbz_v1_character_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PageMeta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PageMeta) ProtoMessage() {}
+
+func (x *PageMeta) ProtoReflect() protoreflect.Message {
+	mi := &file_dbz_v1_character_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PageMeta.ProtoReflect.Descriptor instead.
+func (*PageMeta) Descriptor() ([]byte, []int) {
+	return file_dbz_v1_character_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PageMeta) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *PageMeta) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *PageMeta) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *PageMeta) GetTotalPages() int64 {
+	if x != nil {
+		return x.TotalPages
+	}
+	return 0
+}
+
+// CharacterPage is the body of GET /v1/characters.
+type CharacterPage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []*Character           `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty"`
+	Meta          *PageMeta              `protobuf:"bytes,2,opt,name=meta,proto3" json:"meta,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CharacterPage) Reset() {
+	*x = CharacterPage{}
+	mi := &file_dbz_v1_character_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CharacterPage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CharacterPage) ProtoMessage() {}
+
+func (x *CharacterPage) ProtoReflect() protoreflect.Message {
+	mi := &file_dbz_v1_character_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CharacterPage.ProtoReflect.Descriptor instead.
+func (*CharacterPage) Descriptor() ([]byte, []int) {
+	return file_dbz_v1_character_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CharacterPage) GetData() []*Character {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *CharacterPage) GetMeta() *PageMeta {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+// Error is the body of an error response. Suggestions are only filled when
+// a lookup by name misses.
+type Error struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Suggestions   []string               `protobuf:"bytes,2,rep,name=suggestions,proto3" json:"suggestions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Error) Reset() {
+	*x = Error{}
+	mi := &file_dbz_v1_character_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Error) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Error) ProtoMessage() {}
+
+func (x *Error) ProtoReflect() protoreflect.Message {
+	mi := &file_dbz_v1_character_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Error.ProtoReflect.Descriptor instead.
+func (*Error) Descriptor() ([]byte, []int) {
+	return file_dbz_v1_character_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Error) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *Error) GetSuggestions() []string {
+	if x != nil {
+		return x.Suggestions
+	}
+	return nil
+}
+
+var File_dbz_v1_character_proto protoreflect.FileDescriptor
+
+const file_dbz_v1_character_proto_rawDesc = "" +
+	"\n" +
+	"\x16dbz/v1/character.proto\x12\x06dbz.v1\"\xdc\x01\n" +
+	"\tCharacter\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x0e\n" +
+	"\x02ki\x18\x03 \x01(\tR\x02ki\x12\x15\n" +
+	"\x06max_ki\x18\x04 \x01(\tR\x05maxKi\x12\x12\n" +
+	"\x04race\x18\x05 \x01(\tR\x04race\x12\x16\n" +
+	"\x06gender\x18\x06 \x01(\tR\x06gender\x12\x14\n" +
+	"\x05image\x18\a \x01(\tR\x05image\x12 \n" +
+	"\vaffiliation\x18\b \x01(\tR\vaffiliation\x12 \n" +
+	"\vdescription\x18\t \x01(\tR\vdescription\":\n" +
+	"\x11CharacterResponse\x12%\n" +
+	"\x04data\x18\x01 \x01(\v2\x11.dbz.v1.CharacterR\x04data\"k\n" +
+	"\bPageMeta\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x03R\x05total\x12\x1f\n" +
+	"\vtotal_pages\x18\x04 \x01(\x03R\n" +
+	"totalPages\"\\\n" +
+	"\rCharacterPage\x12%\n" +
+	"\x04data\x18\x01 \x03(\v2\x11.dbz.v1.CharacterR\x04data\x12$\n" +
+	"\x04meta\x18\x02 \x01(\v2\x10.dbz.v1.PageMetaR\x04meta\"C\n" +
+	"\x05Error\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12 \n" +
+	"\vsuggestions\x18\x02 \x03(\tR\vsuggestionsB?Z=github.com/heaveless/dbz-api/internal/delivery/pb/dbzv1;dbzv1b\x06proto3"
+
+var (
+	file_dbz_v1_character_proto_rawDescOnce sync.Once
+	file_dbz_v1_character_proto_rawDescData []byte
+)
+
+func file_dbz_v1_character_proto_rawDescGZIP() []byte {
+	file_dbz_v1_character_proto_rawDescOnce.Do(func() {
+		file_dbz_v1_character_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_dbz_v1_character_proto_rawDesc), len(file_dbz_v1_character_proto_rawDesc)))
+	})
+	return file_dbz_v1_character_proto_rawDescData
+}
+
+var file_dbz_v1_character_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_dbz_v1_character_proto_goTypes = []any{
+	(*Character)(nil),         // 0: dbz.v1.Character
+	(*CharacterResponse)(nil), // 1: dbz.v1.CharacterResponse
+	(*PageMeta)(nil),          // 2: dbz.v1.PageMeta
+	(*CharacterPage)(nil),     // 3: dbz.v1.CharacterPage
+	(*Error)(nil),             // 4: dbz.v1.Error
+}
+var file_dbz_v1_character_proto_depIdxs = []int32{
+	0, // 0: dbz.v1.CharacterResponse.data:type_name -> dbz.v1.Character
+	0, // 1: dbz.v1.CharacterPage.data:type_name -> dbz.v1.Character
+	2, // 2: dbz.v1.CharacterPage.meta:type_name -> dbz.v1.PageMeta
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_dbz_v1_character_proto_init() }
+func file_dbz_v1_character_proto_init() {
+	if File_dbz_v1_character_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_dbz_v1_character_proto_rawDesc), len(file_dbz_v1_character_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_dbz_v1_character_proto_goTypes,
+		DependencyIndexes: file_dbz_v1_character_proto_depIdxs,
+		MessageInfos:      file_dbz_v1_character_proto_msgTypes,
+	}.Build()
+	File_dbz_v1_character_proto = out.File
+	file_dbz_v1_character_proto_goTypes = nil
+	file_dbz_v1_character_proto_depIdxs = nil
+}
@@ -0,0 +1,538 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: dbz/v1/character_service.proto
+
+package dbzv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetByNameRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetByNameRequest) Reset() {
+	*x = GetByNameRequest{}
+	mi := &file_dbz_v1_character_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetByNameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetByNameRequest) ProtoMessage() {}
+
+func (x *GetByNameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dbz_v1_character_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetByNameRequest.ProtoReflect.Descriptor instead.
+func (*GetByNameRequest) Descriptor() ([]byte, []int) {
+	return file_dbz_v1_character_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetByNameRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type GetByIdRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetByIdRequest) Reset() {
+	*x = GetByIdRequest{}
+	mi := &file_dbz_v1_character_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetByIdRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetByIdRequest) ProtoMessage() {}
+
+func (x *GetByIdRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dbz_v1_character_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetByIdRequest.ProtoReflect.Descriptor instead.
+func (*GetByIdRequest) Descriptor() ([]byte, []int) {
+	return file_dbz_v1_character_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetByIdRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// ListRequest takes the query parameters of GET /v1/characters. Power
+// levels are text such as "60.000.000" or "90 Septillion"; sort is id,
+// name, ki or maxKi with an optional leading "-".
+type ListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MinKi         string                 `protobuf:"bytes,1,opt,name=min_ki,json=minKi,proto3" json:"min_ki,omitempty"`
+	MinMaxKi      string                 `protobuf:"bytes,2,opt,name=min_max_ki,json=minMaxKi,proto3" json:"min_max_ki,omitempty"`
+	Sort          string                 `protobuf:"bytes,3,opt,name=sort,proto3" json:"sort,omitempty"`
+	Page          int32                  `protobuf:"varint,4,opt,name=page,proto3" json:"page,omitempty"`
+	Limit         int32                  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRequest) Reset() {
+	*x = ListRequest{}
+	mi := &file_dbz_v1_character_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRequest) ProtoMessage() {}
+
+func (x *ListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dbz_v1_character_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRequest.ProtoReflect.Descriptor instead.
+func (*ListRequest) Descriptor() ([]byte, []int) {
+	return file_dbz_v1_character_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListRequest) GetMinKi() string {
+	if x != nil {
+		return x.MinKi
+	}
+	return ""
+}
+
+func (x *ListRequest) GetMinMaxKi() string {
+	if x != nil {
+		return x.MinMaxKi
+	}
+	return ""
+}
+
+func (x *ListRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// CharacterRef names a character by id or by name.
+type CharacterRef struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Ref:
+	//
+	//	*CharacterRef_Id
+	//	*CharacterRef_Name
+	Ref           isCharacterRef_Ref `protobuf_oneof:"ref"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CharacterRef) Reset() {
+	*x = CharacterRef{}
+	mi := &file_dbz_v1_character_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CharacterRef) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CharacterRef) ProtoMessage() {}
+
+func (x *CharacterRef) ProtoReflect() protoreflect.Message {
+	mi := &file_dbz_v1_character_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CharacterRef.ProtoReflect.Descriptor instead.
+func (*CharacterRef) Descriptor() ([]byte, []int) {
+	return file_dbz_v1_character_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CharacterRef) GetRef() isCharacterRef_Ref {
+	if x != nil {
+		return x.Ref
+	}
+	return nil
+}
+
+func (x *CharacterRef) GetId() int64 {
+	if x != nil {
+		if x, ok := x.Ref.(*CharacterRef_Id); ok {
+			return x.Id
+		}
+	}
+	return 0
+}
+
+func (x *CharacterRef) GetName() string {
+	if x != nil {
+		if x, ok := x.Ref.(*CharacterRef_Name); ok {
+			return x.Name
+		}
+	}
+	return ""
+}
+
+type isCharacterRef_Ref interface {
+	isCharacterRef_Ref()
+}
+
+type CharacterRef_Id struct {
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3,oneof"`
+}
+
+type CharacterRef_Name struct {
+	Name string `protobuf:"bytes,2,opt,name=name,proto3,oneof"`
+}
+
+func (*CharacterRef_Id) isCharacterRef_Ref() {}
+
+func (*CharacterRef_Name) isCharacterRef_Ref() {}
+
+type BatchGetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Refs          []*CharacterRef        `protobuf:"bytes,1,rep,name=refs,proto3" json:"refs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetRequest) Reset() {
+	*x = BatchGetRequest{}
+	mi := &file_dbz_v1_character_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetRequest) ProtoMessage() {}
+
+func (x *BatchGetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dbz_v1_character_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetRequest.ProtoReflect.Descriptor instead.
+func (*BatchGetRequest) Descriptor() ([]byte, []int) {
+	return file_dbz_v1_character_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *BatchGetRequest) GetRefs() []*CharacterRef {
+	if x != nil {
+		return x.Refs
+	}
+	return nil
+}
+
+// BatchGetResult has a character when code is OK (0) and a message
+// otherwise. Codes are google.rpc.Code values.
+type BatchGetResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ref           *CharacterRef          `protobuf:"bytes,1,opt,name=ref,proto3" json:"ref,omitempty"`
+	Code          int32                  `protobuf:"varint,2,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	Character     *Character             `protobuf:"bytes,4,opt,name=character,proto3" json:"character,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetResult) Reset() {
+	*x = BatchGetResult{}
+	mi := &file_dbz_v1_character_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetResult) ProtoMessage() {}
+
+func (x *BatchGetResult) ProtoReflect() protoreflect.Message {
+	mi := &file_dbz_v1_character_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetResult.ProtoReflect.Descriptor instead.
+func (*BatchGetResult) Descriptor() ([]byte, []int) {
+	return file_dbz_v1_character_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *BatchGetResult) GetRef() *CharacterRef {
+	if x != nil {
+		return x.Ref
+	}
+	return nil
+}
+
+func (x *BatchGetResult) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *BatchGetResult) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *BatchGetResult) GetCharacter() *Character {
+	if x != nil {
+		return x.Character
+	}
+	return nil
+}
+
+type BatchGetResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*BatchGetResult      `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetResponse) Reset() {
+	*x = BatchGetResponse{}
+	mi := &file_dbz_v1_character_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetResponse) ProtoMessage() {}
+
+func (x *BatchGetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dbz_v1_character_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetResponse.ProtoReflect.Descriptor instead.
+func (*BatchGetResponse) Descriptor() ([]byte, []int) {
+	return file_dbz_v1_character_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *BatchGetResponse) GetResults() []*BatchGetResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+var File_dbz_v1_character_service_proto protoreflect.FileDescriptor
+
+const file_dbz_v1_character_service_proto_rawDesc = "" +
+	"\n" +
+	"\x1edbz/v1/character_service.proto\x12\x06dbz.v1\x1a\x16dbz/v1/character.proto\"&\n" +
+	"\x10GetByNameRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\" \n" +
+	"\x0eGetByIdRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\x80\x01\n" +
+	"\vListRequest\x12\x15\n" +
+	"\x06min_ki\x18\x01 \x01(\tR\x05minKi\x12\x1c\n" +
+	"\n" +
+	"min_max_ki\x18\x02 \x01(\tR\bminMaxKi\x12\x12\n" +
+	"\x04sort\x18\x03 \x01(\tR\x04sort\x12\x12\n" +
+	"\x04page\x18\x04 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit\"=\n" +
+	"\fCharacterRef\x12\x10\n" +
+	"\x02id\x18\x01 \x01(\x03H\x00R\x02id\x12\x14\n" +
+	"\x04name\x18\x02 \x01(\tH\x00R\x04nameB\x05\n" +
+	"\x03ref\";\n" +
+	"\x0fBatchGetRequest\x12(\n" +
+	"\x04refs\x18\x01 \x03(\v2\x14.dbz.v1.CharacterRefR\x04refs\"\x97\x01\n" +
+	"\x0eBatchGetResult\x12&\n" +
+	"\x03ref\x18\x01 \x01(\v2\x14.dbz.v1.CharacterRefR\x03ref\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\x05R\x04code\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12/\n" +
+	"\tcharacter\x18\x04 \x01(\v2\x11.dbz.v1.CharacterR\tcharacter\"D\n" +
+	"\x10BatchGetResponse\x120\n" +
+	"\aresults\x18\x01 \x03(\v2\x16.dbz.v1.BatchGetResultR\aresults2\xf5\x01\n" +
+	"\x10CharacterService\x128\n" +
+	"\tGetByName\x12\x18.dbz.v1.GetByNameRequest\x1a\x11.dbz.v1.Character\x124\n" +
+	"\aGetById\x12\x16.dbz.v1.GetByIdRequest\x1a\x11.dbz.v1.Character\x122\n" +
+	"\x04List\x12\x13.dbz.v1.ListRequest\x1a\x15.dbz.v1.CharacterPage\x12=\n" +
+	"\bBatchGet\x12\x17.dbz.v1.BatchGetRequest\x1a\x18.dbz.v1.BatchGetResponseB?Z=github.com/heaveless/dbz-api/internal/delivery/pb/dbzv1;dbzv1b\x06proto3"
+
+var (
+	file_dbz_v1_character_service_proto_rawDescOnce sync.Once
+	file_dbz_v1_character_service_proto_rawDescData []byte
+)
+
+func file_dbz_v1_character_service_proto_rawDescGZIP() []byte {
+	file_dbz_v1_character_service_proto_rawDescOnce.Do(func() {
+		file_dbz_v1_character_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_dbz_v1_character_service_proto_rawDesc), len(file_dbz_v1_character_service_proto_rawDesc)))
+	})
+	return file_dbz_v1_character_service_proto_rawDescData
+}
+
+var file_dbz_v1_character_service_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_dbz_v1_character_service_proto_goTypes = []any{
+	(*GetByNameRequest)(nil), // 0: dbz.v1.GetByNameRequest
+	(*GetByIdRequest)(nil),   // 1: dbz.v1.GetByIdRequest
+	(*ListRequest)(nil),      // 2: dbz.v1.ListRequest
+	(*CharacterRef)(nil),     // 3: dbz.v1.CharacterRef
+	(*BatchGetRequest)(nil),  // 4: dbz.v1.BatchGetRequest
+	(*BatchGetResult)(nil),   // 5: dbz.v1.BatchGetResult
+	(*BatchGetResponse)(nil), // 6: dbz.v1.BatchGetResponse
+	(*Character)(nil),        // 7: dbz.v1.Character
+	(*CharacterPage)(nil),    // 8: dbz.v1.CharacterPage
+}
+var file_dbz_v1_character_service_proto_depIdxs = []int32{
+	3, // 0: dbz.v1.BatchGetRequest.refs:type_name -> dbz.v1.CharacterRef
+	3, // 1: dbz.v1.BatchGetResult.ref:type_name -> dbz.v1.CharacterRef
+	7, // 2: dbz.v1.BatchGetResult.character:type_name -> dbz.v1.Character
+	5, // 3: dbz.v1.BatchGetResponse.results:type_name -> dbz.v1.BatchGetResult
+	0, // 4: dbz.v1.CharacterService.GetByName:input_type -> dbz.v1.GetByNameRequest
+	1, // 5: dbz.v1.CharacterService.GetById:input_type -> dbz.v1.GetByIdRequest
+	2, // 6: dbz.v1.CharacterService.List:input_type -> dbz.v1.ListRequest
+	4, // 7: dbz.v1.CharacterService.BatchGet:input_type -> dbz.v1.BatchGetRequest
+	7, // 8: dbz.v1.CharacterService.GetByName:output_type -> dbz.v1.Character
+	7, // 9: dbz.v1.CharacterService.GetById:output_type -> dbz.v1.Character
+	8, // 10: dbz.v1.CharacterService.List:output_type -> dbz.v1.CharacterPage
+	6, // 11: dbz.v1.CharacterService.BatchGet:output_type -> dbz.v1.BatchGetResponse
+	8, // [8:12] is the sub-list for method output_type
+	4, // [4:8] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_dbz_v1_character_service_proto_init() }
+func file_dbz_v1_character_service_proto_init() {
+	if File_dbz_v1_character_service_proto != nil {
+		return
+	}
+	file_dbz_v1_character_proto_init()
+	file_dbz_v1_character_service_proto_msgTypes[3].OneofWrappers = []any{
+		(*CharacterRef_Id)(nil),
+		(*CharacterRef_Name)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_dbz_v1_character_service_proto_rawDesc), len(file_dbz_v1_character_service_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_dbz_v1_character_service_proto_goTypes,
+		DependencyIndexes: file_dbz_v1_character_service_proto_depIdxs,
+		MessageInfos:      file_dbz_v1_character_service_proto_msgTypes,
+	}.Build()
+	File_dbz_v1_character_service_proto = out.File
+	file_dbz_v1_character_service_proto_goTypes = nil
+	file_dbz_v1_character_service_proto_depIdxs = nil
+}
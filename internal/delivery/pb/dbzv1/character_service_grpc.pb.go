@@ -0,0 +1,247 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: dbz/v1/character_service.proto
+
+package dbzv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	CharacterService_GetByName_FullMethodName = "/dbz.v1.CharacterService/GetByName"
+	CharacterService_GetById_FullMethodName   = "/dbz.v1.CharacterService/GetById"
+	CharacterService_List_FullMethodName      = "/dbz.v1.CharacterService/List"
+	CharacterService_BatchGet_FullMethodName  = "/dbz.v1.CharacterService/BatchGet"
+)
+
+// CharacterServiceClient is the client API for CharacterService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// CharacterService serves the same lookups as the /v1/characters routes.
+// Errors use the standard codes: NOT_FOUND, INVALID_ARGUMENT,
+// RESOURCE_EXHAUSTED and UNAVAILABLE.
+type CharacterServiceClient interface {
+	GetByName(ctx context.Context, in *GetByNameRequest, opts ...grpc.CallOption) (*Character, error)
+	GetById(ctx context.Context, in *GetByIdRequest, opts ...grpc.CallOption) (*Character, error)
+	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*CharacterPage, error)
+	// BatchGet never fails as a whole once the request is valid; each result
+	// carries its own code.
+	BatchGet(ctx context.Context, in *BatchGetRequest, opts ...grpc.CallOption) (*BatchGetResponse, error)
+}
+
+type characterServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCharacterServiceClient(cc grpc.ClientConnInterface) CharacterServiceClient {
+	return &characterServiceClient{cc}
+}
+
+func (c *characterServiceClient) GetByName(ctx context.Context, in *GetByNameRequest, opts ...grpc.CallOption) (*Character, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Character)
+	err := c.cc.Invoke(ctx, CharacterService_GetByName_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *characterServiceClient) GetById(ctx context.Context, in *GetByIdRequest, opts ...grpc.CallOption) (*Character, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Character)
+	err := c.cc.Invoke(ctx, CharacterService_GetById_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *characterServiceClient) List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*CharacterPage, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CharacterPage)
+	err := c.cc.Invoke(ctx, CharacterService_List_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *characterServiceClient) BatchGet(ctx context.Context, in *BatchGetRequest, opts ...grpc.CallOption) (*BatchGetResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchGetResponse)
+	err := c.cc.Invoke(ctx, CharacterService_BatchGet_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CharacterServiceServer is the server API for CharacterService service.
+// All implementations must embed UnimplementedCharacterServiceServer
+// for forward compatibility.
+//
+// CharacterService serves the same lookups as the /v1/characters routes.
+// Errors use the standard codes: NOT_FOUND, INVALID_ARGUMENT,
+// RESOURCE_EXHAUSTED and UNAVAILABLE.
+type CharacterServiceServer interface {
+	GetByName(context.Context, *GetByNameRequest) (*Character, error)
+	GetById(context.Context, *GetByIdRequest) (*Character, error)
+	List(context.Context, *ListRequest) (*CharacterPage, error)
+	// BatchGet never fails as a whole once the request is valid; each result
+	// carries its own code.
+	BatchGet(context.Context, *BatchGetRequest) (*BatchGetResponse, error)
+	mustEmbedUnimplementedCharacterServiceServer()
+}
+
+// UnimplementedCharacterServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCharacterServiceServer struct{}
+
+func (UnimplementedCharacterServiceServer) GetByName(context.Context, *GetByNameRequest) (*Character, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetByName not implemented")
+}
+func (UnimplementedCharacterServiceServer) GetById(context.Context, *GetByIdRequest) (*Character, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetById not implemented")
+}
+func (UnimplementedCharacterServiceServer) List(context.Context, *ListRequest) (*CharacterPage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (UnimplementedCharacterServiceServer) BatchGet(context.Context, *BatchGetRequest) (*BatchGetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchGet not implemented")
+}
+func (UnimplementedCharacterServiceServer) mustEmbedUnimplementedCharacterServiceServer() {}
+func (UnimplementedCharacterServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafeCharacterServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CharacterServiceServer will
+// result in compilation errors.
+type UnsafeCharacterServiceServer interface {
+	mustEmbedUnimplementedCharacterServiceServer()
+}
+
+func RegisterCharacterServiceServer(s grpc.ServiceRegistrar, srv CharacterServiceServer) {
+	// If the following call pancis, it indicates UnimplementedCharacterServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&CharacterService_ServiceDesc, srv)
+}
+
+func _CharacterService_GetByName_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetByNameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CharacterServiceServer).GetByName(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CharacterService_GetByName_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CharacterServiceServer).GetByName(ctx, req.(*GetByNameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CharacterService_GetById_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetByIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CharacterServiceServer).GetById(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CharacterService_GetById_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CharacterServiceServer).GetById(ctx, req.(*GetByIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CharacterService_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CharacterServiceServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CharacterService_List_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CharacterServiceServer).List(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CharacterService_BatchGet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CharacterServiceServer).BatchGet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CharacterService_BatchGet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CharacterServiceServer).BatchGet(ctx, req.(*BatchGetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CharacterService_ServiceDesc is the grpc.ServiceDesc for CharacterService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CharacterService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "dbz.v1.CharacterService",
+	HandlerType: (*CharacterServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetByName",
+			Handler:    _CharacterService_GetByName_Handler,
+		},
+		{
+			MethodName: "GetById",
+			Handler:    _CharacterService_GetById_Handler,
+		},
+		{
+			MethodName: "List",
+			Handler:    _CharacterService_List_Handler,
+		},
+		{
+			MethodName: "BatchGet",
+			Handler:    _CharacterService_BatchGet_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "dbz/v1/character_service.proto",
+}
@@ -0,0 +1,19 @@
+package dbzv1
+
+import domain "github.com/heaveless/dbz-api/internal/domain/character"
+
+// FromCharacter maps the public character representation shared by the
+// protobuf bodies of the HTTP API and the gRPC service.
+func FromCharacter(c *domain.CharacterDTO) *Character {
+	return &Character{
+		Id:          c.Id,
+		Name:        c.Name,
+		Ki:          c.Ki,
+		MaxKi:       c.MaxKi,
+		Race:        c.Race,
+		Gender:      c.Gender,
+		Image:       c.Image,
+		Affiliation: c.Affiliation,
+		Description: c.Description,
+	}
+}
@@ -0,0 +1,60 @@
+package apikey
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/heaveless/dbz-api/internal/domain/audit"
+)
+
+// auditedFields lists what the audit log shows of a key; never its hash.
+var auditedFields = []string{"name", "prefix", "scopes", "dailyQuota", "rotatedAt", "revokedAt"}
+
+// Diff lists the audited fields that differ between before and after. A
+// nil side counts as a key with empty fields.
+func Diff(before, after *ApiKeyEntity) []audit.Change {
+	return audit.Diff(auditedFields, before.auditFields(), after.auditFields())
+}
+
+// AuditAction names the save that turns before into after; before is nil
+// for a new key.
+func AuditAction(before, after *ApiKeyEntity) string {
+	switch {
+	case before == nil:
+		return audit.ApiKeyCreated
+	case !before.Revoked() && after.Revoked():
+		return audit.ApiKeyRevoked
+	case !timeEqual(before.RotatedAt, after.RotatedAt):
+		return audit.ApiKeyRotated
+	}
+
+	return audit.ApiKeyUpdated
+}
+
+func (k *ApiKeyEntity) auditFields() map[string]string {
+	if k == nil {
+		return nil
+	}
+
+	return map[string]string{
+		"name":       k.Name,
+		"prefix":     k.Prefix,
+		"scopes":     strings.Join(k.Scopes, ","),
+		"dailyQuota": strconv.FormatInt(k.DailyQuota, 10),
+		"rotatedAt":  formatTime(k.RotatedAt),
+		"revokedAt":  formatTime(k.RevokedAt),
+	}
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+
+	return t.UTC().Format(time.RFC3339)
+}
+
+func timeEqual(a, b *time.Time) bool {
+	return formatTime(a) == formatTime(b)
+}
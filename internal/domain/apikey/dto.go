@@ -0,0 +1,27 @@
+package apikey
+
+import "time"
+
+type ApiKeyDTO struct {
+	Id         string     `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	DailyQuota int64      `json:"dailyQuota"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	RotatedAt  *time.Time `json:"rotatedAt,omitempty"`
+	RevokedAt  *time.Time `json:"revokedAt,omitempty"`
+}
+
+// IssuedApiKeyDTO is returned when a key is created or rotated. It is the
+// only time the key itself is ever shown.
+type IssuedApiKeyDTO struct {
+	ApiKeyDTO
+	Key string `json:"key"`
+}
+
+type NewApiKey struct {
+	Name       string
+	Scopes     []string
+	DailyQuota int64
+}
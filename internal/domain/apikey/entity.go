@@ -0,0 +1,46 @@
+package apikey
+
+import (
+	"slices"
+	"time"
+)
+
+const (
+	ScopeRead  = "read"
+	ScopeAdmin = "admin"
+)
+
+// Scopes lists every scope a key may be granted.
+var Scopes = []string{ScopeRead, ScopeAdmin}
+
+// ApiKeyEntity never holds the key itself, only its SHA-256 hash. Prefix is
+// the first characters of the key so operators can tell keys apart.
+type ApiKeyEntity struct {
+	Id     string   `bson:"_id"`
+	Name   string   `bson:"name"`
+	Hash   string   `bson:"hash"`
+	Prefix string   `bson:"prefix"`
+	Scopes []string `bson:"scopes"`
+	// DailyQuota caps the requests per UTC day; zero means unlimited.
+	DailyQuota int64      `bson:"dailyQuota"`
+	CreatedAt  time.Time  `bson:"createdAt"`
+	RotatedAt  *time.Time `bson:"rotatedAt,omitempty"`
+	RevokedAt  *time.Time `bson:"revokedAt,omitempty"`
+}
+
+func (k *ApiKeyEntity) Revoked() bool {
+	return k.RevokedAt != nil
+}
+
+// Principal is the authenticated caller behind a request.
+type Principal struct {
+	KeyId  string
+	Name   string
+	Scopes []string
+}
+
+// HasScope reports whether the principal was granted scope. Admin keys are
+// granted every scope.
+func (p *Principal) HasScope(scope string) bool {
+	return slices.Contains(p.Scopes, scope) || slices.Contains(p.Scopes, ScopeAdmin)
+}
@@ -0,0 +1,28 @@
+package apikey
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrNotFound = errors.New("not found")
+	// ErrInvalidKey covers unknown and revoked keys alike so callers cannot
+	// probe which keys exist.
+	ErrInvalidKey = errors.New("invalid api key")
+	ErrRevoked    = errors.New("api key is revoked")
+	// ErrScopeMissing is returned for a valid key not granted the scope the
+	// caller asked for; such a request never counts against the quota.
+	ErrScopeMissing = errors.New("api key lacks the required scope")
+)
+
+// QuotaExceededError is returned once a key spent its daily quota.
+type QuotaExceededError struct {
+	Quota int64
+	Reset time.Time
+}
+
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("daily quota of %d requests exceeded, resets at %s", e.Quota, e.Reset.Format(time.RFC3339))
+}
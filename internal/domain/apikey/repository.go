@@ -0,0 +1,13 @@
+package apikey
+
+import "context"
+
+type ApiKeyRepository interface {
+	Get(ctx context.Context, id string) (*ApiKeyEntity, error)
+	GetByHash(ctx context.Context, hash string) (*ApiKeyEntity, error)
+	List(ctx context.Context) ([]ApiKeyEntity, error)
+	Save(ctx context.Context, k *ApiKeyEntity) error
+	// IncrementUsage adds one request to the key's counter for day
+	// (YYYY-MM-DD, UTC) and returns the new count.
+	IncrementUsage(ctx context.Context, id string, day string) (int64, error)
+}
@@ -0,0 +1,39 @@
+package audit
+
+import "context"
+
+type (
+	actorKey     struct{}
+	requestIdKey struct{}
+)
+
+// System is the actor of changes no caller asked for.
+var System = Actor{Name: "system"}
+
+// Upstream is the actor of the data copied from the upstream API.
+var Upstream = Actor{Name: "upstream"}
+
+// WithActor returns a copy of ctx whose changes are attributed to a.
+func WithActor(ctx context.Context, a Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, a)
+}
+
+// ActorFrom returns the actor set by WithActor, or System.
+func ActorFrom(ctx context.Context) Actor {
+	if a, ok := ctx.Value(actorKey{}).(Actor); ok {
+		return a
+	}
+
+	return System
+}
+
+// WithRequestId returns a copy of ctx whose changes are recorded with id.
+func WithRequestId(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIdKey{}, id)
+}
+
+// RequestIdFrom returns the id set by WithRequestId, or "".
+func RequestIdFrom(ctx context.Context) string {
+	id, _ := ctx.Value(requestIdKey{}).(string)
+	return id
+}
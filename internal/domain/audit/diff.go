@@ -0,0 +1,15 @@
+package audit
+
+// Diff lists the fields whose values differ between before and after, in
+// the order of fields. A field missing from a map counts as empty; it never
+// returns nil.
+func Diff(fields []string, before, after map[string]string) []Change {
+	changes := []Change{}
+	for _, name := range fields {
+		if b, a := before[name], after[name]; b != a {
+			changes = append(changes, Change{Field: name, Before: b, After: a})
+		}
+	}
+
+	return changes
+}
@@ -0,0 +1,50 @@
+package audit
+
+import "time"
+
+// Actions recorded in the log.
+const (
+	CharacterCreated = "character.create"
+	CharacterUpdated = "character.update"
+	CharacterDeleted = "character.delete"
+	// CharacterCached and CharacterRefreshed are upstream data stored or
+	// updated by a lookup.
+	CharacterCached    = "character.cache"
+	CharacterRefreshed = "character.refresh"
+	ApiKeyCreated      = "apikey.create"
+	ApiKeyRotated      = "apikey.rotate"
+	ApiKeyRevoked      = "apikey.revoke"
+	ApiKeyUpdated      = "apikey.update"
+	WebhookCreated     = "webhook.create"
+	WebhookUpdated     = "webhook.update"
+)
+
+// Actor is who made a change. Id is the API key behind it, empty for
+// callers authenticated by token.
+type Actor struct {
+	Id   string `bson:"id,omitempty" json:"id,omitempty"`
+	Name string `bson:"name" json:"name"`
+}
+
+// Change is one field that an entry changed. Before is empty for a field
+// that was just set, After for one that was cleared.
+type Change struct {
+	Field  string `bson:"field" json:"field"`
+	Before string `bson:"before,omitempty" json:"before,omitempty"`
+	After  string `bson:"after,omitempty" json:"after,omitempty"`
+}
+
+// Entry is one change to the data, as it was made. Entries are never
+// updated or deleted.
+type Entry struct {
+	Id     string    `bson:"_id" json:"id"`
+	At     time.Time `bson:"at" json:"at"`
+	Actor  Actor     `bson:"actor" json:"actor"`
+	Action string    `bson:"action" json:"action"`
+	// Target names what changed, e.g. "character:1".
+	Target  string   `bson:"target" json:"target"`
+	Changes []Change `bson:"changes" json:"changes"`
+	// RequestId is the X-Request-Id of the request that made the change,
+	// empty for changes made outside one.
+	RequestId string `bson:"requestId,omitempty" json:"requestId,omitempty"`
+}
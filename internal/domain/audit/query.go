@@ -0,0 +1,26 @@
+package audit
+
+import "time"
+
+// Query filters the log; empty fields match every entry. Actor matches the
+// actor's id or name, Field any change to that field. From is inclusive,
+// To exclusive.
+type Query struct {
+	Actor     string
+	Action    string
+	Target    string
+	Field     string
+	RequestId string
+	From      time.Time
+	To        time.Time
+	Page      int
+	Limit     int
+}
+
+// EntryPageDTO lists the entries of a query, latest first.
+type EntryPageDTO struct {
+	Items []Entry `json:"items"`
+	Page  int     `json:"page"`
+	Limit int     `json:"limit"`
+	Total int64   `json:"total"`
+}
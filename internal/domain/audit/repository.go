@@ -0,0 +1,22 @@
+package audit
+
+import "context"
+
+// Log appends entries to the audit trail. It assigns Id and, when they are
+// zero, At and RequestId.
+type Log interface {
+	Record(ctx context.Context, e Entry) error
+}
+
+type NopLog struct{}
+
+func (NopLog) Record(context.Context, Entry) error { return nil }
+
+// Repository is the log and what reads it back. Nothing updates or deletes
+// entries.
+type Repository interface {
+	Log
+	// Find returns a page of the entries matching q, latest first, and how
+	// many match in all.
+	Find(ctx context.Context, q Query) ([]Entry, int64, error)
+}
@@ -4,4 +4,5 @@ import "context"
 
 type CharacterApi interface {
 	Get(ctx context.Context, name string) (*CharacterEntity, error)
+	GetById(ctx context.Context, id int64) (*CharacterEntity, error)
 }
@@ -0,0 +1,16 @@
+package character
+
+// CharacterRef identifies a character either by id or by name. Exactly one
+// of them is set.
+type CharacterRef struct {
+	Id   int64
+	Name string
+}
+
+// CharacterResult is the outcome of one lookup of a batch. Err is set
+// instead of Character when that single lookup failed.
+type CharacterResult struct {
+	Ref       CharacterRef
+	Character *CharacterDTO
+	Err       error
+}
@@ -0,0 +1,88 @@
+package character
+
+import (
+	"slices"
+
+	"github.com/heaveless/dbz-api/internal/domain/audit"
+)
+
+// CuratedFields lists, by name, the fields admins can set.
+var CuratedFields = []string{"name", "ki", "maxKi", "race", "gender", "image", "affiliation", "description"}
+
+// CharacterPatch maps names from CuratedFields to new values.
+type CharacterPatch map[string]string
+
+// Curate applies p and marks its fields overridden. Unknown names are
+// ignored.
+func (c *CharacterEntity) Curate(p CharacterPatch) {
+	for name, value := range p {
+		field := c.field(name)
+		if field == nil {
+			continue
+		}
+		*field = value
+		c.Overridden = append(c.Overridden, name)
+	}
+
+	slices.Sort(c.Overridden)
+	c.Overridden = slices.Compact(c.Overridden)
+}
+
+// Refresh copies from's fields over the ones no admin curated, along with
+// its UpdatedAt: what an upstream refresh does to the stored copy.
+func (c *CharacterEntity) Refresh(from *CharacterEntity) {
+	for name, value := range from.Fields() {
+		if !slices.Contains(c.Overridden, name) {
+			*c.field(name) = value
+		}
+	}
+	c.SetKeys()
+	c.UpdatedAt = from.UpdatedAt
+}
+
+// Fields returns the value of every field in CuratedFields.
+func (c *CharacterEntity) Fields() CharacterPatch {
+	fields := make(CharacterPatch, len(CuratedFields))
+	for _, name := range CuratedFields {
+		fields[name] = *c.field(name)
+	}
+
+	return fields
+}
+
+func (c *CharacterEntity) field(name string) *string {
+	switch name {
+	case "name":
+		return &c.Name
+	case "ki":
+		return &c.Ki
+	case "maxKi":
+		return &c.MaxKi
+	case "race":
+		return &c.Race
+	case "gender":
+		return &c.Gender
+	case "image":
+		return &c.Image
+	case "affiliation":
+		return &c.Affiliation
+	case "description":
+		return &c.Description
+	}
+
+	return nil
+}
+
+// Diff lists the curated fields that differ between before and after, in
+// CuratedFields order. A nil side counts as a character with empty fields.
+func Diff(before, after *CharacterEntity) []audit.Change {
+	var b, a CharacterPatch
+	if before != nil {
+		b = before.Fields()
+	}
+	if after != nil {
+		a = after.Fields()
+	}
+
+	return audit.Diff(CuratedFields, b, a)
+}
@@ -1,12 +1,56 @@
 package character
 
+import "time"
+
+// CharacterDTO is the public /v1 representation of a character. Field names
+// and omitempty rules are part of the API contract; see the golden files
+// under tests/unit/delivery/handler/testdata before changing them.
 type CharacterDTO struct {
-	Id          int64
-	Name        string
-	Ki          string
-	MaxKi       string
-	Race        string
-	Gender      string
-	Image       string
-	Affiliation string
+	Id          int64  `json:"id"`
+	Name        string `json:"name"`
+	Ki          string `json:"ki"`
+	MaxKi       string `json:"maxKi"`
+	Race        string `json:"race"`
+	Gender      string `json:"gender,omitempty"`
+	Image       string `json:"image,omitempty"`
+	Affiliation string `json:"affiliation,omitempty"`
+	Description string `json:"description,omitempty"`
+
+	// Validators for conditional requests. They travel as headers, never
+	// in the body.
+	ETag      string    `json:"-"`
+	UpdatedAt time.Time `json:"-"`
+}
+
+// CuratedCharacterDTO is a character as /admin shows it: its public fields
+// and the ones upstream refreshes leave alone.
+type CuratedCharacterDTO struct {
+	CharacterDTO
+	Overridden []string `json:"overridden"`
+}
+
+type CharacterMatchDTO struct {
+	Id    int64   `json:"id"`
+	Name  string  `json:"name"`
+	Score float64 `json:"score"`
+}
+
+type CharacterTextMatchDTO struct {
+	Character  CharacterDTO      `json:"character"`
+	Score      float64           `json:"score"`
+	Highlights map[string]string `json:"highlights,omitempty"`
+}
+
+type CharacterTextSearchDTO struct {
+	Items []CharacterTextMatchDTO `json:"items"`
+	Page  int                     `json:"page"`
+	Limit int                     `json:"limit"`
+	Total int64                   `json:"total"`
+}
+
+type CharacterPageDTO struct {
+	Items []CharacterDTO `json:"items"`
+	Page  int            `json:"page"`
+	Limit int            `json:"limit"`
+	Total int64          `json:"total"`
 }
@@ -1,12 +1,33 @@
 package character
 
+import "time"
+
 type CharacterEntity struct {
 	Id          int64  `bson:"_id" json:"id"`
 	Name        string `bson:"name" json:"name"`
+	NameKey     string `bson:"nameKey" json:"-"`
 	Ki          string `bson:"ki" json:"ki"`
 	MaxKi       string `bson:"maxKi" json:"maxKi"`
 	Race        string `bson:"race" json:"race"`
 	Gender      string `bson:"gender" json:"gender"`
 	Image       string `bson:"image" json:"image"`
 	Affiliation string `bson:"affiliation" json:"affiliation"`
+	Description string `bson:"description" json:"description"`
+	// KiRank and MaxKiRank are the Rank of Ki and MaxKi, for queries.
+	KiRank    string `bson:"kiRank" json:"-"`
+	MaxKiRank string `bson:"maxKiRank" json:"-"`
+	// UpdatedAt is when the character was last fetched from upstream or
+	// changed by an admin.
+	UpdatedAt time.Time `bson:"updatedAt" json:"-"`
+	// Overridden lists, by name, the fields an admin curated. Upstream
+	// refreshes leave them as they are.
+	Overridden []string `bson:"overridden,omitempty" json:"-"`
+}
+
+// SetKeys derives the fields queries match and sort on from the ones the
+// API serves.
+func (c *CharacterEntity) SetKeys() {
+	c.NameKey = NormalizeName(c.Name)
+	c.KiRank = LenientPowerLevel(c.Ki).Rank()
+	c.MaxKiRank = LenientPowerLevel(c.MaxKi).Rank()
 }
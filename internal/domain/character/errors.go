@@ -2,4 +2,9 @@ package character
 
 import "errors"
 
-var ErrNotFound = errors.New("not found")
+var (
+	ErrNotFound = errors.New("not found")
+	ErrExists   = errors.New("already exists")
+	// ErrConflict means the character changed between reading and writing it.
+	ErrConflict = errors.New("changed concurrently")
+)
@@ -0,0 +1,24 @@
+package character
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+)
+
+// ETag is a strong validator over the fields the API serves, so it changes
+// exactly when the character does. Each response hashes it again with its
+// media type and content coding, since those bodies differ byte for byte.
+// UpdatedAt is left out: refetching an unchanged character keeps its ETag.
+func (c *CharacterEntity) ETag() string {
+	h := sha256.New()
+	for _, field := range []string{
+		strconv.FormatInt(c.Id, 10), c.Name, c.Ki, c.MaxKi, c.Race,
+		c.Gender, c.Image, c.Affiliation, c.Description,
+	} {
+		h.Write([]byte(field))
+		h.Write([]byte{0})
+	}
+
+	return `"` + hex.EncodeToString(h.Sum(nil)[:16]) + `"`
+}
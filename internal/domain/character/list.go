@@ -0,0 +1,40 @@
+package character
+
+import (
+	"fmt"
+	"strings"
+)
+
+var sortFields = map[string]bool{
+	"id":    true,
+	"name":  true,
+	"ki":    true,
+	"maxKi": true,
+}
+
+type SortOrder struct {
+	Field string
+	Desc  bool
+}
+
+// ParseSortOrder reads "field" or "-field" (descending). An empty value sorts by id.
+func ParseSortOrder(raw string) (SortOrder, error) {
+	order := SortOrder{Field: strings.TrimPrefix(raw, "-"), Desc: strings.HasPrefix(raw, "-")}
+	if order.Field == "" {
+		return SortOrder{Field: "id"}, nil
+	}
+
+	if !sortFields[order.Field] {
+		return SortOrder{}, fmt.Errorf("unsupported sort field %q", order.Field)
+	}
+
+	return order, nil
+}
+
+type ListQuery struct {
+	MinKi    *PowerLevel
+	MinMaxKi *PowerLevel
+	Sort     SortOrder
+	Page     int
+	Limit    int
+}
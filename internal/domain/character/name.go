@@ -0,0 +1,33 @@
+package character
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// NormalizeName returns the canonical lookup key for a character name so that
+// "Goku", " goku " and "GOKU" resolve to the same record. The policy is:
+// Unicode NFKC, accent folding, case folding and whitespace trimming/collapsing.
+func NormalizeName(name string) string {
+	folded, _, err := transform.String(
+		transform.Chain(
+			norm.NFKC,
+			norm.NFD,
+			runes.Remove(runes.In(unicode.Mn)),
+			norm.NFC,
+		),
+		name,
+	)
+	if err != nil {
+		folded = name
+	}
+
+	folded = cases.Fold().String(folded)
+
+	return strings.Join(strings.Fields(folded), " ")
+}
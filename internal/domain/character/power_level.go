@@ -0,0 +1,161 @@
+package character
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+)
+
+var ErrInvalidPowerLevel = errors.New("invalid power level")
+
+type powerTier int
+
+const (
+	tierUnknown powerTier = iota
+	tierFinite
+	// tierGoogolplex holds values too large to materialize (10^googol); the
+	// stored value is the multiplier in front of the word.
+	tierGoogolplex
+)
+
+// PowerLevel is a comparable reading of the free-form Ki strings served by
+// the upstream API, e.g. "60.000.000", "2.5 Billion", "90 Septillion" or
+// "unknown". The original text is kept untouched in Raw.
+type PowerLevel struct {
+	Raw   string
+	tier  powerTier
+	value *big.Int
+}
+
+var powerExponents = map[string]int{
+	"thousand":    3,
+	"million":     6,
+	"billion":     9,
+	"trillion":    12,
+	"quadrillion": 15,
+	"quintillion": 18,
+	"sextillion":  21,
+	"septillion":  24,
+	"octillion":   27,
+	"nonillion":   30,
+	"decillion":   33,
+	"googol":      100,
+}
+
+var (
+	powerPattern     = regexp.MustCompile(`^([0-9][0-9.,]*)\s*([a-z]*)$`)
+	thousandsPattern = regexp.MustCompile(`^\d{1,3}([.,]\d{3})+$`)
+)
+
+func ParsePowerLevel(raw string) (PowerLevel, error) {
+	text := strings.ToLower(strings.TrimSpace(raw))
+	if text == "" || text == "unknown" || text == "desconocido" {
+		return PowerLevel{Raw: raw}, nil
+	}
+
+	m := powerPattern.FindStringSubmatch(strings.Join(strings.Fields(text), " "))
+	if m == nil {
+		return PowerLevel{}, fmt.Errorf("%w: %q", ErrInvalidPowerLevel, raw)
+	}
+	number, word := m[1], strings.TrimSuffix(m[2], "s")
+
+	mantissa, ok := parsePowerNumber(number, word != "")
+	if !ok {
+		return PowerLevel{}, fmt.Errorf("%w: %q", ErrInvalidPowerLevel, raw)
+	}
+
+	if word == "googolplex" {
+		return PowerLevel{Raw: raw, tier: tierGoogolplex, value: floor(mantissa)}, nil
+	}
+
+	exp := 0
+	if word != "" {
+		var known bool
+		if exp, known = powerExponents[word]; !known {
+			return PowerLevel{}, fmt.Errorf("%w: unknown magnitude %q", ErrInvalidPowerLevel, m[2])
+		}
+	}
+
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exp)), nil)
+	mantissa.Mul(mantissa, new(big.Rat).SetInt(scale))
+
+	return PowerLevel{Raw: raw, tier: tierFinite, value: floor(mantissa)}, nil
+}
+
+// LenientPowerLevel falls back to an unknown level on malformed input, so
+// odd stored data ranks last instead of failing a whole query.
+func LenientPowerLevel(raw string) PowerLevel {
+	p, err := ParsePowerLevel(raw)
+	if err != nil {
+		return PowerLevel{Raw: raw}
+	}
+
+	return p
+}
+
+// parsePowerNumber reads "60.000.000" and "1,000" as grouped integers and a
+// single separator as a decimal point, which is how the upstream writes
+// "2.5 Billion". A lone "500.000" without magnitude word is a grouped integer.
+func parsePowerNumber(number string, hasWord bool) (*big.Rat, bool) {
+	grouped := thousandsPattern.MatchString(number)
+	if grouped && (!hasWord || strings.Count(number, ".")+strings.Count(number, ",") > 1) {
+		number = strings.NewReplacer(".", "", ",", "").Replace(number)
+	} else {
+		number = strings.ReplaceAll(number, ",", ".")
+	}
+
+	r, ok := new(big.Rat).SetString(number)
+	return r, ok
+}
+
+func floor(r *big.Rat) *big.Int {
+	return new(big.Int).Quo(r.Num(), r.Denom())
+}
+
+func (p PowerLevel) Known() bool {
+	return p.tier != tierUnknown
+}
+
+// Value returns the numeric power level, or nil when it is unknown or too
+// large to represent (googolplex scale).
+func (p PowerLevel) Value() *big.Int {
+	if p.tier != tierFinite {
+		return nil
+	}
+
+	return new(big.Int).Set(p.value)
+}
+
+// Cmp orders unknown < finite < googolplex-scale values.
+func (p PowerLevel) Cmp(o PowerLevel) int {
+	if p.tier != o.tier {
+		if p.tier < o.tier {
+			return -1
+		}
+		return 1
+	}
+
+	if p.tier == tierUnknown {
+		return 0
+	}
+
+	return p.value.Cmp(o.value)
+}
+
+// Rank orders like Cmp when compared byte by byte, so the store can filter
+// and sort on it: the tier, the digit count, then the digits. Unknown levels
+// rank "", below every other.
+func (p PowerLevel) Rank() string {
+	if p.tier == tierUnknown {
+		return ""
+	}
+
+	digits := p.value.String()
+	return fmt.Sprintf("%d%04d%s", p.tier, len(digits), digits)
+}
+
+func (p PowerLevel) String() string {
+	return p.Raw
+}
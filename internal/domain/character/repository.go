@@ -4,5 +4,22 @@ import "context"
 
 type CharacterRepository interface {
 	Get(ctx context.Context, name string) (*CharacterEntity, error)
+	GetById(ctx context.Context, id int64) (*CharacterEntity, error)
+	// List returns every stored character; ListPage only the page q asks
+	// for, along with how many characters match it.
+	List(ctx context.Context) ([]CharacterEntity, error)
+	ListPage(ctx context.Context, q ListQuery) ([]CharacterEntity, int64, error)
+	GetByIds(ctx context.Context, ids []int64) ([]CharacterEntity, error)
+	TextSearch(ctx context.Context, q TextSearchQuery) ([]TextMatch, int64, error)
 	Create(ctx context.Context, c *CharacterEntity) error
 }
+
+// CurationRepository writes the changes admins make to stored characters
+// and records each of them in the audit log. Update and Delete only apply
+// when the stored character is still before.
+type CurationRepository interface {
+	GetById(ctx context.Context, id int64) (*CharacterEntity, error)
+	Insert(ctx context.Context, c *CharacterEntity) error
+	Update(ctx context.Context, before, after *CharacterEntity) error
+	Delete(ctx context.Context, before *CharacterEntity) error
+}
@@ -0,0 +1,12 @@
+package character
+
+type TextSearchQuery struct {
+	Text   string
+	Offset int
+	Limit  int
+}
+
+type TextMatch struct {
+	Character CharacterEntity
+	Score     float64
+}
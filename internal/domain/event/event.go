@@ -0,0 +1,55 @@
+package event
+
+import "time"
+
+type Type string
+
+const (
+	// CharacterCached: a character fetched from upstream was stored for the
+	// first time.
+	CharacterCached Type = "CharacterCached"
+	// CharacterRefreshed: a newer upstream copy replaced a stored character.
+	CharacterRefreshed Type = "CharacterRefreshed"
+	// CharacterCreated, CharacterUpdated and CharacterDeleted: an admin
+	// curated a stored character. A deleted character is sent as it was.
+	CharacterCreated Type = "CharacterCreated"
+	CharacterUpdated Type = "CharacterUpdated"
+	CharacterDeleted Type = "CharacterDeleted"
+	// UpstreamUnavailable: calls to the upstream API started failing. It is
+	// published once per outage, not once per failed call.
+	UpstreamUnavailable Type = "UpstreamUnavailable"
+)
+
+// Event is something that happened in the domain. The bus assigns Id, an
+// increasing sequence number, and At when it is zero.
+type Event struct {
+	Id   uint64
+	Type Type
+	At   time.Time
+	Data any
+}
+
+// Publisher must not block: events are published from request paths and
+// background writes.
+type Publisher interface {
+	Publish(e Event)
+}
+
+type NopPublisher struct{}
+
+func (NopPublisher) Publish(Event) {}
+
+// UpstreamFailure is the data of UpstreamUnavailable.
+type UpstreamFailure struct {
+	Host   string `json:"host"`
+	Reason string `json:"reason"`
+}
+
+// Subscription delivers events in the order they were published.
+type Subscription interface {
+	// Events is closed when the subscription ends.
+	Events() <-chan Event
+	// Dropped reports whether it ended because the subscriber fell behind.
+	Dropped() bool
+	Close()
+}
@@ -0,0 +1,66 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// Outbox stores events durably, next to the write that caused them, so they
+// reach consumers outside the process even if it stops right after.
+type Outbox interface {
+	Record(ctx context.Context, e Event) error
+}
+
+type NopOutbox struct{}
+
+func (NopOutbox) Record(context.Context, Event) error { return nil }
+
+// OutboxEntry is a recorded event and how far its delivery got. Key is
+// unique per event and never changes, so consumers can use it to drop the
+// duplicates at-least-once delivery brings.
+type OutboxEntry struct {
+	Key  string    `bson:"_id"`
+	Type Type      `bson:"type"`
+	At   time.Time `bson:"at"`
+	// Data is the event data encoded as JSON, the way sinks send it.
+	Data string `bson:"data"`
+	// DeliveredTo lists the sinks that acknowledged the entry, so a retry
+	// only goes to the others.
+	DeliveredTo   []string   `bson:"deliveredTo"`
+	Attempts      int        `bson:"attempts"`
+	NextAttemptAt time.Time  `bson:"nextAttemptAt,omitempty"`
+	LastError     string     `bson:"lastError,omitempty"`
+	DeliveredAt   *time.Time `bson:"deliveredAt,omitempty"`
+}
+
+type OutboxRepository interface {
+	Outbox
+	// Pending lists undelivered entries due at now, oldest first.
+	Pending(ctx context.Context, now time.Time, limit int) ([]OutboxEntry, error)
+	// Delivered records that sinks acknowledged the entry and that no sink
+	// is left.
+	Delivered(ctx context.Context, key string, sinks []string, at time.Time) error
+	// Retry records the sinks that acknowledged the entry and schedules
+	// another attempt for the rest.
+	Retry(ctx context.Context, key string, sinks []string, at time.Time, reason string) error
+}
+
+// Message is an event as sinks deliver it.
+type Message struct {
+	Key  string          `json:"key"`
+	Type Type            `json:"type"`
+	At   time.Time       `json:"at"`
+	Data json.RawMessage `json:"data"`
+}
+
+func (e OutboxEntry) Message() Message {
+	return Message{Key: e.Key, Type: e.Type, At: e.At, Data: json.RawMessage(e.Data)}
+}
+
+// Sink delivers messages to consumers outside the process. Send may get a
+// message it already delivered; a nil error means the consumer has it.
+type Sink interface {
+	Name() string
+	Send(ctx context.Context, m Message) error
+}
@@ -0,0 +1,17 @@
+package planet
+
+import (
+	"context"
+
+	"github.com/heaveless/dbz-api/internal/domain/character"
+)
+
+type PlanetDetail struct {
+	Planet     PlanetEntity
+	Characters []character.CharacterEntity
+}
+
+type PlanetApi interface {
+	Get(ctx context.Context, id int64) (*PlanetDetail, error)
+	List(ctx context.Context) ([]PlanetEntity, error)
+}
@@ -0,0 +1,11 @@
+package planet
+
+import "github.com/heaveless/dbz-api/internal/domain/character"
+
+// PlanetResidents is the outcome for one planet of a batch. Err is set
+// instead of Residents when that single lookup failed.
+type PlanetResidents struct {
+	PlanetId  int64
+	Residents []character.CharacterDTO
+	Err       error
+}
@@ -0,0 +1,9 @@
+package planet
+
+type PlanetDTO struct {
+	Id          int64  `json:"id"`
+	Name        string `json:"name"`
+	IsDestroyed bool   `json:"isDestroyed"`
+	Description string `json:"description,omitempty"`
+	Image       string `json:"image,omitempty"`
+}
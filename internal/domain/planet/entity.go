@@ -0,0 +1,12 @@
+package planet
+
+type PlanetEntity struct {
+	Id          int64  `bson:"_id" json:"id"`
+	Name        string `bson:"name" json:"name"`
+	IsDestroyed bool   `bson:"isDestroyed" json:"isDestroyed"`
+	Description string `bson:"description" json:"description"`
+	Image       string `bson:"image" json:"image"`
+	// CharacterIds is only known once the planet detail has been fetched
+	// upstream; nil means the residents have not been loaded yet.
+	CharacterIds []int64 `bson:"characterIds" json:"-"`
+}
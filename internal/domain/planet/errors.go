@@ -0,0 +1,5 @@
+package planet
+
+import "errors"
+
+var ErrNotFound = errors.New("not found")
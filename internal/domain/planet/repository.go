@@ -0,0 +1,10 @@
+package planet
+
+import "context"
+
+type PlanetRepository interface {
+	Get(ctx context.Context, id int64) (*PlanetEntity, error)
+	GetByIds(ctx context.Context, ids []int64) ([]PlanetEntity, error)
+	List(ctx context.Context) ([]PlanetEntity, error)
+	Save(ctx context.Context, p *PlanetEntity) error
+}
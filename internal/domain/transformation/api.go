@@ -0,0 +1,7 @@
+package transformation
+
+import "context"
+
+type TransformationApi interface {
+	ListByCharacter(ctx context.Context, characterId int64) ([]TransformationEntity, error)
+}
@@ -0,0 +1,9 @@
+package transformation
+
+// CharacterTransformations is the outcome for one character of a batch. Err
+// is set instead of Transformations when that single lookup failed.
+type CharacterTransformations struct {
+	CharacterId     int64
+	Transformations []TransformationDTO
+	Err             error
+}
@@ -0,0 +1,9 @@
+package transformation
+
+type TransformationDTO struct {
+	Id          int64  `json:"id"`
+	CharacterId int64  `json:"characterId"`
+	Name        string `json:"name"`
+	Image       string `json:"image,omitempty"`
+	Ki          string `json:"ki"`
+}
@@ -0,0 +1,9 @@
+package transformation
+
+type TransformationEntity struct {
+	Id          int64  `bson:"_id" json:"id"`
+	CharacterId int64  `bson:"characterId" json:"-"`
+	Name        string `bson:"name" json:"name"`
+	Image       string `bson:"image" json:"image"`
+	Ki          string `bson:"ki" json:"ki"`
+}
@@ -0,0 +1,5 @@
+package transformation
+
+import "errors"
+
+var ErrNotFound = errors.New("not found")
@@ -0,0 +1,9 @@
+package transformation
+
+import "context"
+
+type TransformationRepository interface {
+	ListByCharacter(ctx context.Context, characterId int64) ([]TransformationEntity, error)
+	ListByCharacters(ctx context.Context, characterIds []int64) ([]TransformationEntity, error)
+	CreateMany(ctx context.Context, ts []TransformationEntity) error
+}
@@ -0,0 +1,33 @@
+package webhook
+
+import (
+	"strings"
+
+	"github.com/heaveless/dbz-api/internal/domain/audit"
+)
+
+// auditedFields lists what the audit log shows of a subscription; never
+// its secret.
+var auditedFields = []string{"url", "events"}
+
+// Diff lists the audited fields that differ between before and after. A
+// nil side counts as a subscription with empty fields.
+func Diff(before, after *SubscriptionEntity) []audit.Change {
+	return audit.Diff(auditedFields, before.auditFields(), after.auditFields())
+}
+
+func (s *SubscriptionEntity) auditFields() map[string]string {
+	if s == nil {
+		return nil
+	}
+
+	events := make([]string, 0, len(s.Events))
+	for _, e := range s.Events {
+		events = append(events, string(e))
+	}
+
+	return map[string]string{
+		"url":    s.Url,
+		"events": strings.Join(events, ","),
+	}
+}
@@ -0,0 +1,45 @@
+package webhook
+
+import (
+	"time"
+
+	"github.com/heaveless/dbz-api/internal/domain/event"
+)
+
+type SubscriptionDTO struct {
+	Id        string       `json:"id"`
+	Url       string       `json:"url"`
+	Events    []event.Type `json:"events"`
+	CreatedAt time.Time    `json:"createdAt"`
+}
+
+// CreatedSubscriptionDTO is returned when a subscription is registered. It
+// is the only time the secret is ever shown.
+type CreatedSubscriptionDTO struct {
+	SubscriptionDTO
+	Secret string `json:"secret"`
+}
+
+type NewSubscription struct {
+	Url    string
+	Events []event.Type
+	// Secret is generated when empty.
+	Secret string
+}
+
+type AttemptDTO struct {
+	At         time.Time `json:"at"`
+	StatusCode int       `json:"statusCode,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	DurationMs int64     `json:"durationMs"`
+}
+
+type DeliveryDTO struct {
+	Id            string         `json:"id"`
+	EventKey      string         `json:"eventKey"`
+	Type          event.Type     `json:"type"`
+	Status        DeliveryStatus `json:"status"`
+	Attempts      []AttemptDTO   `json:"attempts"`
+	NextAttemptAt *time.Time     `json:"nextAttemptAt,omitempty"`
+	CreatedAt     time.Time      `json:"createdAt"`
+}
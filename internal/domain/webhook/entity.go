@@ -0,0 +1,76 @@
+package webhook
+
+import (
+	"slices"
+	"time"
+
+	"github.com/heaveless/dbz-api/internal/domain/event"
+)
+
+// TestEvent is the type of the deliveries sent by the test endpoint. It is
+// sent whatever the subscription filters.
+const TestEvent event.Type = "WebhookTest"
+
+// Events lists the event types a subscription may filter on.
+var Events = []event.Type{
+	event.CharacterCached,
+	event.CharacterRefreshed,
+	event.CharacterCreated,
+	event.CharacterUpdated,
+	event.CharacterDeleted,
+}
+
+// SubscriptionEntity keeps Secret in clear: it is needed to sign every
+// delivery.
+type SubscriptionEntity struct {
+	Id  string `bson:"_id"`
+	Url string `bson:"url"`
+	// Events filters what the subscription receives; empty receives every
+	// event.
+	Events    []event.Type `bson:"events"`
+	Secret    string       `bson:"secret"`
+	CreatedAt time.Time    `bson:"createdAt"`
+}
+
+func (s *SubscriptionEntity) Wants(t event.Type) bool {
+	return len(s.Events) == 0 || slices.Contains(s.Events, t)
+}
+
+type DeliveryStatus string
+
+const (
+	StatusPending   DeliveryStatus = "pending"
+	StatusDelivered DeliveryStatus = "delivered"
+	// StatusFailed is final: every attempt failed.
+	StatusFailed DeliveryStatus = "failed"
+)
+
+type AttemptEntity struct {
+	At time.Time `bson:"at"`
+	// StatusCode is zero when no response came back.
+	StatusCode int    `bson:"statusCode,omitempty"`
+	Error      string `bson:"error,omitempty"`
+	DurationMs int64  `bson:"durationMs"`
+}
+
+// Succeeded is true for a 2xx response.
+func (a AttemptEntity) Succeeded() bool {
+	return a.Error == "" && a.StatusCode >= 200 && a.StatusCode <= 299
+}
+
+// DeliveryEntity is one event sent to one subscription, with every attempt
+// made, which makes the collection the delivery log.
+type DeliveryEntity struct {
+	// Id joins the event key and the subscription id, so an event the outbox
+	// relays twice is still delivered once.
+	Id             string          `bson:"_id"`
+	SubscriptionId string          `bson:"subscriptionId"`
+	EventKey       string          `bson:"eventKey"`
+	Type           event.Type      `bson:"type"`
+	Payload        string          `bson:"payload"`
+	Status         DeliveryStatus  `bson:"status"`
+	Attempts       []AttemptEntity `bson:"attempts"`
+	// NextAttemptAt is only set while the delivery is pending.
+	NextAttemptAt time.Time `bson:"nextAttemptAt,omitempty"`
+	CreatedAt     time.Time `bson:"createdAt"`
+}
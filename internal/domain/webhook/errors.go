@@ -0,0 +1,5 @@
+package webhook
+
+import "errors"
+
+var ErrNotFound = errors.New("not found")
@@ -0,0 +1,25 @@
+package webhook
+
+import (
+	"context"
+	"time"
+)
+
+type SubscriptionRepository interface {
+	Save(ctx context.Context, s *SubscriptionEntity) error
+	Get(ctx context.Context, id string) (*SubscriptionEntity, error)
+	List(ctx context.Context) ([]SubscriptionEntity, error)
+}
+
+type DeliveryRepository interface {
+	// Enqueue stores d unless a delivery with its id already exists.
+	Enqueue(ctx context.Context, d *DeliveryEntity) error
+	// Due lists pending deliveries whose next attempt is at or before now,
+	// oldest first.
+	Due(ctx context.Context, now time.Time, limit int) ([]DeliveryEntity, error)
+	// Attempted appends a to the delivery and moves it to status; next is
+	// only kept for StatusPending.
+	Attempted(ctx context.Context, id string, a AttemptEntity, status DeliveryStatus, next time.Time) error
+	// ListBySubscription returns the latest deliveries first.
+	ListBySubscription(ctx context.Context, subscriptionId string, limit int) ([]DeliveryEntity, error)
+}
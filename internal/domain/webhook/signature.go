@@ -0,0 +1,40 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"time"
+)
+
+// Headers of every delivery.
+const (
+	HeaderSignature = "X-Webhook-Signature"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+)
+
+// Sign returns "sha256=" and the hex HMAC-SHA256, keyed with secret, of the
+// Unix timestamp, a dot and the body. Signing the timestamp lets receivers
+// reject old deliveries replayed by someone else.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify is what receivers do: check the signature in constant time and
+// that the timestamp is within tolerance of now.
+func Verify(secret, signature string, timestamp int64, body []byte, now time.Time, tolerance time.Duration) bool {
+	age := now.Sub(time.Unix(timestamp, 0))
+	if age > tolerance || age < -tolerance {
+		return false
+	}
+
+	return hmac.Equal([]byte(signature), []byte(Sign(secret, timestamp, body)))
+}
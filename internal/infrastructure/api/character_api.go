@@ -2,11 +2,10 @@ package api
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
-	"net/http"
 	"net/url"
+	"time"
 
 	domain "github.com/heaveless/dbz-api/internal/domain/character"
 	"github.com/heaveless/dbz-api/internal/infrastructure/breaker"
@@ -27,29 +26,36 @@ func NewCharacterApi(baseURL string, client breaker.ExternalClient) domain.Chara
 func (api *characterApi) Get(ctx context.Context, name string) (*domain.CharacterEntity, error) {
 	endpoint := fmt.Sprintf("%s/api/characters?name=%s", api.baseURL, url.QueryEscape(name))
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
-	if err != nil {
-		return nil, fmt.Errorf("creating request: %w", err)
+	var characters []domain.CharacterEntity
+	err := getJSON(ctx, api.client, endpoint, &characters)
+	if errors.Is(err, errUpstreamNotFound) || (err == nil && len(characters) == 0) {
+		return nil, fmt.Errorf("character %w", domain.ErrNotFound)
 	}
-
-	res, err := api.client.Do(req)
 	if err != nil {
-		return nil, errors.New("service temporarily unavailable, please try again later")
+		return nil, err
 	}
-	defer res.Body.Close()
 
-	if res.StatusCode >= 400 {
-		return nil, fmt.Errorf("unexpected status code: %d", res.StatusCode)
-	}
+	characters[0].UpdatedAt = fetchedAt()
+	return &characters[0], nil
+}
 
-	var characters []domain.CharacterEntity
-	if err := json.NewDecoder(res.Body).Decode(&characters); err != nil {
-		return nil, fmt.Errorf("decoding response: %w", err)
-	}
+func (api *characterApi) GetById(ctx context.Context, id int64) (*domain.CharacterEntity, error) {
+	endpoint := fmt.Sprintf("%s/api/characters/%d", api.baseURL, id)
 
-	if len(characters) == 0 {
-		return nil, fmt.Errorf("character not found")
+	var character domain.CharacterEntity
+	err := getJSON(ctx, api.client, endpoint, &character)
+	if errors.Is(err, errUpstreamNotFound) {
+		return nil, fmt.Errorf("character %w", domain.ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	return &characters[0], nil
+	character.UpdatedAt = fetchedAt()
+	return &character, nil
+}
+
+// fetchedAt stamps upstream results. HTTP dates carry whole seconds only.
+func fetchedAt() time.Time {
+	return time.Now().UTC().Truncate(time.Second)
 }
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/heaveless/dbz-api/internal/infrastructure/breaker"
+)
+
+var errUpstreamNotFound = errors.New("upstream resource not found")
+
+// getJSON performs a GET through the breaker-protected client and decodes
+// the JSON body into out. A 404 is reported as errUpstreamNotFound so each
+// adapter can translate it into its own domain error.
+func getJSON(ctx context.Context, client breaker.ExternalClient, endpoint string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+
+	res, err := client.Do(req)
+	if errors.Is(err, breaker.ErrUpstreamLimited) {
+		return err
+	}
+	if err != nil {
+		return errors.New("service temporarily unavailable, please try again later")
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return errUpstreamNotFound
+	}
+
+	if res.StatusCode >= 400 {
+		return fmt.Errorf("unexpected status code: %d", res.StatusCode)
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(out); err != nil {
+		return fmt.Errorf("decoding response: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/heaveless/dbz-api/internal/domain/character"
+	domain "github.com/heaveless/dbz-api/internal/domain/planet"
+	"github.com/heaveless/dbz-api/internal/infrastructure/breaker"
+)
+
+const planetPageSize = 100
+
+type planetApi struct {
+	baseURL string
+	client  breaker.ExternalClient
+}
+
+type planetDetailResponse struct {
+	domain.PlanetEntity
+	Characters []character.CharacterEntity `json:"characters"`
+}
+
+type planetPageResponse struct {
+	Items []domain.PlanetEntity `json:"items"`
+	Meta  struct {
+		TotalPages int `json:"totalPages"`
+	} `json:"meta"`
+}
+
+func NewPlanetApi(baseURL string, client breaker.ExternalClient) domain.PlanetApi {
+	return &planetApi{
+		baseURL: baseURL,
+		client:  client,
+	}
+}
+
+func (api *planetApi) Get(ctx context.Context, id int64) (*domain.PlanetDetail, error) {
+	endpoint := fmt.Sprintf("%s/api/planets/%d", api.baseURL, id)
+
+	var res planetDetailResponse
+	err := getJSON(ctx, api.client, endpoint, &res)
+	if errors.Is(err, errUpstreamNotFound) {
+		return nil, fmt.Errorf("planet %w", domain.ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	res.PlanetEntity.CharacterIds = make([]int64, 0, len(res.Characters))
+	for _, c := range res.Characters {
+		res.PlanetEntity.CharacterIds = append(res.PlanetEntity.CharacterIds, c.Id)
+	}
+
+	return &domain.PlanetDetail{
+		Planet:     res.PlanetEntity,
+		Characters: res.Characters,
+	}, nil
+}
+
+func (api *planetApi) List(ctx context.Context) ([]domain.PlanetEntity, error) {
+	var planets []domain.PlanetEntity
+
+	for page, totalPages := 1, 1; page <= totalPages; page++ {
+		endpoint := fmt.Sprintf("%s/api/planets?page=%d&limit=%d", api.baseURL, page, planetPageSize)
+
+		var res planetPageResponse
+		if err := getJSON(ctx, api.client, endpoint, &res); err != nil {
+			return nil, err
+		}
+
+		planets = append(planets, res.Items...)
+		totalPages = res.Meta.TotalPages
+	}
+
+	return planets, nil
+}
@@ -0,0 +1,47 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	domain "github.com/heaveless/dbz-api/internal/domain/transformation"
+	"github.com/heaveless/dbz-api/internal/infrastructure/breaker"
+)
+
+type transformationApi struct {
+	baseURL string
+	client  breaker.ExternalClient
+}
+
+// Upstream only exposes transformations per character through the
+// character detail document.
+type characterTransformationsResponse struct {
+	Transformations []domain.TransformationEntity `json:"transformations"`
+}
+
+func NewTransformationApi(baseURL string, client breaker.ExternalClient) domain.TransformationApi {
+	return &transformationApi{
+		baseURL: baseURL,
+		client:  client,
+	}
+}
+
+func (api *transformationApi) ListByCharacter(ctx context.Context, characterId int64) ([]domain.TransformationEntity, error) {
+	endpoint := fmt.Sprintf("%s/api/characters/%d", api.baseURL, characterId)
+
+	var res characterTransformationsResponse
+	err := getJSON(ctx, api.client, endpoint, &res)
+	if errors.Is(err, errUpstreamNotFound) {
+		return nil, fmt.Errorf("character %w", domain.ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range res.Transformations {
+		res.Transformations[i].CharacterId = characterId
+	}
+
+	return res.Transformations, nil
+}
@@ -17,11 +17,43 @@ type DbCollection interface {
 		opts ...options.Lister[options.FindOneOptions],
 	) (SingleResult, error)
 
+	Find(
+		ctx context.Context,
+		filter any,
+		opts ...options.Lister[options.FindOptions],
+	) (Cursor, error)
+
+	CountDocuments(
+		ctx context.Context,
+		filter any,
+		opts ...options.Lister[options.CountOptions],
+	) (int64, error)
+
 	InsertOne(
 		ctx context.Context,
 		document any,
 		opts ...options.Lister[options.InsertOneOptions],
 	) (*mongo.InsertOneResult, error)
+
+	ReplaceOne(
+		ctx context.Context,
+		filter any,
+		replacement any,
+		opts ...options.Lister[options.ReplaceOptions],
+	) (*mongo.UpdateResult, error)
+
+	FindOneAndUpdate(
+		ctx context.Context,
+		filter any,
+		update any,
+		opts ...options.Lister[options.FindOneAndUpdateOptions],
+	) (SingleResult, error)
+
+	DeleteOne(
+		ctx context.Context,
+		filter any,
+		opts ...options.Lister[options.DeleteOneOptions],
+	) (*mongo.DeleteResult, error)
 }
 
 type DbCollectionWithBreaker struct {
@@ -78,6 +110,38 @@ func (c *DbCollectionWithBreaker) FindOne(
 	return res.(SingleResult), nil
 }
 
+func (c *DbCollectionWithBreaker) Find(
+	ctx context.Context,
+	filter any,
+	opts ...options.Lister[options.FindOptions],
+) (Cursor, error) {
+	res, err := c.circuitBreaker.Execute(func() (any, error) {
+		return c.collection.Find(ctx, filter, opts...)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res.(Cursor), nil
+}
+
+func (c *DbCollectionWithBreaker) CountDocuments(
+	ctx context.Context,
+	filter any,
+	opts ...options.Lister[options.CountOptions],
+) (int64, error) {
+	res, err := c.circuitBreaker.Execute(func() (any, error) {
+		return c.collection.CountDocuments(ctx, filter, opts...)
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	return res.(int64), nil
+}
+
 func (c *DbCollectionWithBreaker) InsertOne(
 	ctx context.Context,
 	document any,
@@ -110,3 +174,65 @@ func (c *DbCollectionWithBreaker) InsertOne(
 
 	return res.(*mongo.InsertOneResult), nil
 }
+
+func (c *DbCollectionWithBreaker) ReplaceOne(
+	ctx context.Context,
+	filter any,
+	replacement any,
+	opts ...options.Lister[options.ReplaceOptions],
+) (*mongo.UpdateResult, error) {
+	res, err := c.circuitBreaker.Execute(func() (any, error) {
+		return c.collection.ReplaceOne(ctx, filter, replacement, opts...)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res.(*mongo.UpdateResult), nil
+}
+
+func (c *DbCollectionWithBreaker) FindOneAndUpdate(
+	ctx context.Context,
+	filter any,
+	update any,
+	opts ...options.Lister[options.FindOneAndUpdateOptions],
+) (SingleResult, error) {
+	res, err := c.circuitBreaker.Execute(func() (any, error) {
+		sr, err := c.collection.FindOneAndUpdate(ctx, filter, update, opts...)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := sr.Err(); err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				return sr, nil
+			}
+			return nil, err
+		}
+
+		return sr, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res.(SingleResult), nil
+}
+
+func (c *DbCollectionWithBreaker) DeleteOne(
+	ctx context.Context,
+	filter any,
+	opts ...options.Lister[options.DeleteOneOptions],
+) (*mongo.DeleteResult, error) {
+	res, err := c.circuitBreaker.Execute(func() (any, error) {
+		return c.collection.DeleteOne(ctx, filter, opts...)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res.(*mongo.DeleteResult), nil
+}
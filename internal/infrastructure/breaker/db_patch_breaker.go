@@ -12,6 +12,10 @@ type SingleResult interface {
 	Err() error
 }
 
+type Cursor interface {
+	All(ctx context.Context, results any) error
+}
+
 type MongoSingleResultWrapper struct {
 	Sr *mongo.SingleResult
 }
@@ -45,6 +49,27 @@ func (r *MongoDbCollection) FindOne(
 	return WrapMongoSingleResult(sr), nil
 }
 
+func (r *MongoDbCollection) Find(
+	ctx context.Context,
+	filter any,
+	opts ...options.Lister[options.FindOptions],
+) (Cursor, error) {
+	cur, err := r.col.Find(ctx, filter, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return cur, nil
+}
+
+func (r *MongoDbCollection) CountDocuments(
+	ctx context.Context,
+	filter any,
+	opts ...options.Lister[options.CountOptions],
+) (int64, error) {
+	return r.col.CountDocuments(ctx, filter, opts...)
+}
+
 func (r *MongoDbCollection) InsertOne(
 	ctx context.Context,
 	document any,
@@ -52,3 +77,30 @@ func (r *MongoDbCollection) InsertOne(
 ) (*mongo.InsertOneResult, error) {
 	return r.col.InsertOne(ctx, document, opts...)
 }
+
+func (r *MongoDbCollection) ReplaceOne(
+	ctx context.Context,
+	filter any,
+	replacement any,
+	opts ...options.Lister[options.ReplaceOptions],
+) (*mongo.UpdateResult, error) {
+	return r.col.ReplaceOne(ctx, filter, replacement, opts...)
+}
+
+func (r *MongoDbCollection) FindOneAndUpdate(
+	ctx context.Context,
+	filter any,
+	update any,
+	opts ...options.Lister[options.FindOneAndUpdateOptions],
+) (SingleResult, error) {
+	sr := r.col.FindOneAndUpdate(ctx, filter, update, opts...)
+	return WrapMongoSingleResult(sr), nil
+}
+
+func (r *MongoDbCollection) DeleteOne(
+	ctx context.Context,
+	filter any,
+	opts ...options.Lister[options.DeleteOneOptions],
+) (*mongo.DeleteResult, error) {
+	return r.col.DeleteOne(ctx, filter, opts...)
+}
@@ -0,0 +1,49 @@
+package breaker
+
+import (
+	"fmt"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/heaveless/dbz-api/internal/domain/event"
+)
+
+// HttpWithEvents publishes UpstreamUnavailable when upstream calls start
+// failing: a transport error, an open breaker or a 5xx. It publishes again
+// only after a call has succeeded in between, so an outage is one event.
+// Calls cancelled by their caller say nothing about upstream and are
+// ignored.
+type HttpWithEvents struct {
+	next   ExternalClient
+	events event.Publisher
+	down   atomic.Bool
+}
+
+func NewHttpWithEvents(next ExternalClient, events event.Publisher) ExternalClient {
+	return &HttpWithEvents{next: next, events: events}
+}
+
+func (c *HttpWithEvents) Do(req *http.Request) (*http.Response, error) {
+	res, err := c.next.Do(req)
+
+	var reason string
+	switch {
+	case err != nil && req.Context().Err() != nil:
+		return res, err
+	case err != nil:
+		reason = err.Error()
+	case res.StatusCode >= http.StatusInternalServerError:
+		reason = fmt.Sprintf("status %d", res.StatusCode)
+	}
+
+	if reason == "" {
+		c.down.Store(false)
+	} else if !c.down.Swap(true) {
+		c.events.Publish(event.Event{
+			Type: event.UpstreamUnavailable,
+			Data: event.UpstreamFailure{Host: req.URL.Host, Reason: reason},
+		})
+	}
+
+	return res, err
+}
@@ -0,0 +1,168 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/heaveless/dbz-api/internal/infrastructure/ratelimit"
+)
+
+// ErrUpstreamLimited is matched by every UpstreamLimitedError.
+var ErrUpstreamLimited = errors.New("upstream rate limit reached")
+
+// UpstreamLimitedError is returned without calling upstream when the
+// outbound budget is spent and the limiter is configured to fail fast.
+type UpstreamLimitedError struct {
+	// Reason is "rate" or "in-flight".
+	Reason     string
+	RetryAfter time.Duration
+}
+
+func (e *UpstreamLimitedError) Error() string {
+	if e.Reason == "in-flight" {
+		return "upstream rate limit reached: too many requests in flight"
+	}
+	return fmt.Sprintf("upstream rate limit reached, retry after %s", e.RetryAfter)
+}
+
+func (e *UpstreamLimitedError) Is(target error) bool {
+	return target == ErrUpstreamLimited
+}
+
+type OutboundLimit struct {
+	// Rate (requests per second) and Burst feed a token bucket shared by
+	// every call; a zero Rate disables it.
+	Rate  float64
+	Burst int
+	// MaxInFlight caps concurrent requests, response bodies included; zero
+	// means no cap.
+	MaxInFlight int
+	// Wait queues callers until their context is done instead of failing
+	// fast with an UpstreamLimitedError.
+	Wait bool
+}
+
+type HttpWithLimiter struct {
+	next   ExternalClient
+	limit  OutboundLimit
+	now    func() time.Time
+	mu     sync.Mutex
+	bucket *ratelimit.Bucket
+	slots  chan struct{}
+}
+
+func NewHttpWithLimiter(next ExternalClient, limit OutboundLimit) ExternalClient {
+	return NewHttpWithLimiterClock(next, limit, time.Now)
+}
+
+func NewHttpWithLimiterClock(next ExternalClient, limit OutboundLimit, now func() time.Time) ExternalClient {
+	c := &HttpWithLimiter{
+		next:  next,
+		limit: limit,
+		now:   now,
+	}
+	if limit.Rate > 0 && limit.Burst > 0 {
+		c.bucket = ratelimit.NewBucket(ratelimit.Limit{Rate: limit.Rate, Burst: limit.Burst}, now())
+	}
+	if limit.MaxInFlight > 0 {
+		c.slots = make(chan struct{}, limit.MaxInFlight)
+	}
+
+	return c
+}
+
+func (c *HttpWithLimiter) Do(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	// The slot comes first: a call turned away for lack of one never spends
+	// a rate token.
+	if err := c.acquireSlot(ctx); err != nil {
+		return nil, err
+	}
+	if err := c.takeToken(ctx); err != nil {
+		c.releaseSlot()
+		return nil, err
+	}
+
+	res, err := c.next.Do(req)
+	if err != nil {
+		c.releaseSlot()
+		return nil, err
+	}
+
+	// The slot is held until the caller is done with the body.
+	res.Body = &releasingBody{ReadCloser: res.Body, release: c.releaseSlot}
+	return res, nil
+}
+
+func (c *HttpWithLimiter) takeToken(ctx context.Context) error {
+	if c.bucket == nil {
+		return nil
+	}
+
+	for {
+		c.mu.Lock()
+		d := c.bucket.Take(c.now())
+		c.mu.Unlock()
+
+		if d.Allowed {
+			return nil
+		}
+		if !c.limit.Wait {
+			return &UpstreamLimitedError{Reason: "rate", RetryAfter: d.RetryAfter}
+		}
+
+		timer := time.NewTimer(d.RetryAfter)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (c *HttpWithLimiter) acquireSlot(ctx context.Context) error {
+	if c.slots == nil {
+		return nil
+	}
+
+	if !c.limit.Wait {
+		select {
+		case c.slots <- struct{}{}:
+			return nil
+		default:
+			return &UpstreamLimitedError{Reason: "in-flight"}
+		}
+	}
+
+	select {
+	case c.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c *HttpWithLimiter) releaseSlot() {
+	if c.slots != nil {
+		<-c.slots
+	}
+}
+
+type releasingBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
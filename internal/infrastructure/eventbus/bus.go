@@ -0,0 +1,147 @@
+// Package eventbus fans domain events out to in-process subscribers.
+package eventbus
+
+import (
+	"sync"
+	"time"
+
+	"github.com/heaveless/dbz-api/internal/domain/event"
+)
+
+const (
+	DefaultHistory = 256
+	DefaultBuffer  = 64
+)
+
+// Bus keeps the last events in a ring so a subscriber that reconnects can
+// resume after the last id it saw. Publish never blocks: a subscriber whose
+// buffer is full is dropped, and resumes from the history when it comes
+// back.
+type Bus struct {
+	mu      sync.Mutex
+	lastId  uint64
+	history []event.Event
+	next    int
+	full    bool
+	subs    map[*Subscription]struct{}
+	now     func() time.Time
+}
+
+type Subscription struct {
+	bus *Bus
+	c   chan event.Event
+	// dropped is set, under the bus lock, before c is closed for overflow.
+	dropped bool
+}
+
+// NewBus keeps the last history events for replay; zero uses
+// DefaultHistory.
+func NewBus(history int) *Bus {
+	if history <= 0 {
+		history = DefaultHistory
+	}
+
+	return &Bus{
+		history: make([]event.Event, history),
+		subs:    map[*Subscription]struct{}{},
+		now:     func() time.Time { return time.Now().UTC() },
+	}
+}
+
+func (b *Bus) Publish(e event.Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lastId++
+	e.Id = b.lastId
+	if e.At.IsZero() {
+		e.At = b.now()
+	}
+
+	b.history[b.next] = e
+	b.next = (b.next + 1) % len(b.history)
+	if b.next == 0 {
+		b.full = true
+	}
+
+	for s := range b.subs {
+		select {
+		case s.c <- e:
+		default:
+			s.dropped = true
+			b.remove(s)
+		}
+	}
+}
+
+// Subscribe returns the retained events published after afterId, oldest
+// first, and a subscription to everything published from then on. A zero
+// afterId replays nothing; one ahead of the bus, left by a previous process,
+// replays all it retains. buffer bounds the events held for a slow
+// subscriber; zero uses DefaultBuffer.
+func (b *Bus) Subscribe(afterId uint64, buffer int) ([]event.Event, event.Subscription) {
+	if buffer <= 0 {
+		buffer = DefaultBuffer
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var missed []event.Event
+	if afterId > 0 {
+		if afterId > b.lastId {
+			afterId = 0
+		}
+		for _, e := range b.retained() {
+			if e.Id > afterId {
+				missed = append(missed, e)
+			}
+		}
+	}
+
+	s := &Subscription{bus: b, c: make(chan event.Event, buffer)}
+	b.subs[s] = struct{}{}
+
+	return missed, s
+}
+
+// retained lists the history oldest first. The caller holds mu.
+func (b *Bus) retained() []event.Event {
+	if !b.full {
+		return b.history[:b.next]
+	}
+
+	return append(append([]event.Event(nil), b.history[b.next:]...), b.history[:b.next]...)
+}
+
+// remove closes s. The caller holds mu.
+func (b *Bus) remove(s *Subscription) {
+	if _, ok := b.subs[s]; !ok {
+		return
+	}
+
+	delete(b.subs, s)
+	close(s.c)
+}
+
+// Events is closed when the subscription ends, by Close or because the
+// subscriber fell behind.
+func (s *Subscription) Events() <-chan event.Event {
+	return s.c
+}
+
+// Dropped reports whether the bus ended the subscription because its buffer
+// was full. Read it after Events is closed.
+func (s *Subscription) Dropped() bool {
+	s.bus.mu.Lock()
+	defer s.bus.mu.Unlock()
+
+	return s.dropped
+}
+
+func (s *Subscription) Close() {
+	s.bus.mu.Lock()
+	defer s.bus.mu.Unlock()
+
+	s.bus.remove(s)
+}
@@ -0,0 +1,46 @@
+package metrics
+
+import (
+	"expvar"
+)
+
+// ExpvarMetrics counts cache outcomes per resource in an expvar map, so they
+// are served at /debug/vars as e.g. {"cache": {"character.hit": 12, ...}}.
+type ExpvarMetrics struct {
+	counters *expvar.Map
+}
+
+// NewExpvarMetrics publishes the counters under name. expvar names are
+// process-global, so an existing map with that name is reused.
+func NewExpvarMetrics(name string) *ExpvarMetrics {
+	if m, ok := expvar.Get(name).(*expvar.Map); ok {
+		return &ExpvarMetrics{counters: m}
+	}
+
+	return &ExpvarMetrics{counters: expvar.NewMap(name)}
+}
+
+func (m *ExpvarMetrics) Hit(resource string) {
+	m.counters.Add(resource+".hit", 1)
+}
+
+func (m *ExpvarMetrics) Miss(resource string) {
+	m.counters.Add(resource+".miss", 1)
+}
+
+func (m *ExpvarMetrics) Failure(resource string) {
+	m.counters.Add(resource+".failure", 1)
+}
+
+func (m *ExpvarMetrics) SaveError(resource string) {
+	m.counters.Add(resource+".save_error", 1)
+}
+
+// Value returns the current count of a "<resource>.<event>" counter.
+func (m *ExpvarMetrics) Value(key string) int64 {
+	if v, ok := m.counters.Get(key).(*expvar.Int); ok {
+		return v.Value()
+	}
+
+	return 0
+}
@@ -0,0 +1,128 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+type Migration struct {
+	Version     int
+	Description string
+	Up          func(ctx context.Context, db *mongo.Database) error
+}
+
+type Record struct {
+	Version     int       `bson:"_id"`
+	Description string    `bson:"description"`
+	AppliedAt   time.Time `bson:"appliedAt"`
+}
+
+type Status struct {
+	Version     int
+	Description string
+	Applied     bool
+	AppliedAt   time.Time
+}
+
+type Store interface {
+	Applied(ctx context.Context) ([]Record, error)
+	Save(ctx context.Context, record Record) error
+}
+
+type Runner struct {
+	db         *mongo.Database
+	store      Store
+	migrations []Migration
+}
+
+func NewRunner(db *mongo.Database, store Store, migrations []Migration) (*Runner, error) {
+	sorted := make([]Migration, len(migrations))
+	copy(sorted, migrations)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Version < sorted[j].Version
+	})
+
+	for i, m := range sorted {
+		if m.Version <= 0 {
+			return nil, fmt.Errorf("migration %q: version must be positive", m.Description)
+		}
+		if i > 0 && sorted[i-1].Version == m.Version {
+			return nil, fmt.Errorf("duplicate migration version: %d", m.Version)
+		}
+	}
+
+	return &Runner{
+		db:         db,
+		store:      store,
+		migrations: sorted,
+	}, nil
+}
+
+func (r *Runner) Up(ctx context.Context) ([]Record, error) {
+	applied, err := r.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Record
+	for _, m := range r.migrations {
+		if _, ok := applied[m.Version]; ok {
+			continue
+		}
+
+		if err := m.Up(ctx, r.db); err != nil {
+			return done, fmt.Errorf("applying migration %d (%s): %w", m.Version, m.Description, err)
+		}
+
+		record := Record{
+			Version:     m.Version,
+			Description: m.Description,
+			AppliedAt:   time.Now().UTC(),
+		}
+		if err := r.store.Save(ctx, record); err != nil {
+			return done, fmt.Errorf("recording migration %d: %w", m.Version, err)
+		}
+
+		done = append(done, record)
+	}
+
+	return done, nil
+}
+
+func (r *Runner) Status(ctx context.Context) ([]Status, error) {
+	applied, err := r.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(r.migrations))
+	for _, m := range r.migrations {
+		record, ok := applied[m.Version]
+		statuses = append(statuses, Status{
+			Version:     m.Version,
+			Description: m.Description,
+			Applied:     ok,
+			AppliedAt:   record.AppliedAt,
+		})
+	}
+
+	return statuses, nil
+}
+
+func (r *Runner) applied(ctx context.Context) (map[int]Record, error) {
+	records, err := r.store.Applied(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading applied migrations: %w", err)
+	}
+
+	applied := make(map[int]Record, len(records))
+	for _, record := range records {
+		applied[record.Version] = record
+	}
+
+	return applied, nil
+}
@@ -0,0 +1,290 @@
+package migration
+
+import (
+	"context"
+	"time"
+
+	domain "github.com/heaveless/dbz-api/internal/domain/character"
+	"github.com/heaveless/dbz-api/internal/infrastructure/repositoy"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// All returns every known migration. Versions are append-only: never renumber
+// or edit a step that may already be recorded in schema_migrations.
+func All() []Migration {
+	return []Migration{
+		{
+			Version:     1,
+			Description: "unique case-insensitive index on characters.name",
+			Up: func(ctx context.Context, db *mongo.Database) error {
+				_, err := db.Collection(repositoy.CharacterCollection).Indexes().CreateOne(ctx, mongo.IndexModel{
+					Keys: bson.D{{Key: "name", Value: 1}},
+					Options: options.Index().
+						SetName("name_ci_unique").
+						SetUnique(true).
+						SetCollation(repositoy.CaseInsensitive),
+				})
+				return err
+			},
+		},
+		{
+			Version:     2,
+			Description: "indexes on characters.race and characters.affiliation",
+			Up: func(ctx context.Context, db *mongo.Database) error {
+				_, err := db.Collection(repositoy.CharacterCollection).Indexes().CreateMany(ctx, []mongo.IndexModel{
+					{
+						Keys:    bson.D{{Key: "race", Value: 1}},
+						Options: options.Index().SetName("race"),
+					},
+					{
+						Keys:    bson.D{{Key: "affiliation", Value: 1}},
+						Options: options.Index().SetName("affiliation"),
+					},
+				})
+				return err
+			},
+		},
+		{
+			Version:     3,
+			Description: "backfill characters.nameKey and add its unique index",
+			Up:          backfillNameKey,
+		},
+		{
+			Version:     4,
+			Description: "weighted text index for full-text character search",
+			Up: func(ctx context.Context, db *mongo.Database) error {
+				_, err := db.Collection(repositoy.CharacterCollection).Indexes().CreateOne(ctx, mongo.IndexModel{
+					Keys: bson.D{
+						{Key: "name", Value: "text"},
+						{Key: "description", Value: "text"},
+						{Key: "race", Value: "text"},
+						{Key: "affiliation", Value: "text"},
+					},
+					Options: options.Index().
+						SetName("character_text").
+						// Upstream descriptions are written in Spanish.
+						SetDefaultLanguage("spanish").
+						SetWeights(bson.D{
+							{Key: "name", Value: 10},
+							{Key: "race", Value: 4},
+							{Key: "affiliation", Value: 4},
+							{Key: "description", Value: 1},
+						}),
+				})
+				return err
+			},
+		},
+		{
+			Version:     5,
+			Description: "index transformations by character",
+			Up: func(ctx context.Context, db *mongo.Database) error {
+				_, err := db.Collection(repositoy.TransformationCollection).Indexes().CreateOne(ctx, mongo.IndexModel{
+					Keys:    bson.D{{Key: "characterId", Value: 1}},
+					Options: options.Index().SetName("characterId"),
+				})
+				return err
+			},
+		},
+		{
+			Version:     6,
+			Description: "unique index on api_keys.hash and TTL on api_key_usage",
+			Up: func(ctx context.Context, db *mongo.Database) error {
+				_, err := db.Collection(repositoy.ApiKeyCollection).Indexes().CreateOne(ctx, mongo.IndexModel{
+					Keys:    bson.D{{Key: "hash", Value: 1}},
+					Options: options.Index().SetName("hash_unique").SetUnique(true),
+				})
+				if err != nil {
+					return err
+				}
+
+				_, err = db.Collection(repositoy.ApiKeyUsageCollection).Indexes().CreateOne(ctx, mongo.IndexModel{
+					Keys:    bson.D{{Key: "expireAt", Value: 1}},
+					Options: options.Index().SetName("expireAt_ttl").SetExpireAfterSeconds(0),
+				})
+				return err
+			},
+		},
+		{
+			Version:     7,
+			Description: "backfill characters.updatedAt for Last-Modified",
+			Up: func(ctx context.Context, db *mongo.Database) error {
+				_, err := db.Collection(repositoy.CharacterCollection).UpdateMany(
+					ctx,
+					bson.M{"updatedAt": bson.M{"$exists": false}},
+					bson.M{"$currentDate": bson.M{"updatedAt": true}},
+				)
+				return err
+			},
+		},
+		{
+			Version:     8,
+			Description: "index pending outbox entries and expire delivered ones",
+			Up: func(ctx context.Context, db *mongo.Database) error {
+				_, err := db.Collection(repositoy.OutboxCollection).Indexes().CreateMany(ctx, []mongo.IndexModel{
+					{
+						// Delivered entries have no nextAttemptAt.
+						Keys:    bson.D{{Key: "nextAttemptAt", Value: 1}},
+						Options: options.Index().SetName("nextAttemptAt").SetSparse(true),
+					},
+					{
+						Keys:    bson.D{{Key: "deliveredAt", Value: 1}},
+						Options: options.Index().SetName("deliveredAt_ttl").SetExpireAfterSeconds(int32(outboxRetention.Seconds())),
+					},
+				})
+				return err
+			},
+		},
+		{
+			Version:     9,
+			Description: "index due webhook deliveries and the delivery log",
+			Up: func(ctx context.Context, db *mongo.Database) error {
+				_, err := db.Collection(repositoy.WebhookDeliveryCollection).Indexes().CreateMany(ctx, []mongo.IndexModel{
+					{
+						Keys:    bson.D{{Key: "status", Value: 1}, {Key: "nextAttemptAt", Value: 1}},
+						Options: options.Index().SetName("status_nextAttemptAt"),
+					},
+					{
+						Keys:    bson.D{{Key: "subscriptionId", Value: 1}, {Key: "createdAt", Value: -1}},
+						Options: options.Index().SetName("subscriptionId_createdAt"),
+					},
+				})
+				return err
+			},
+		},
+		{
+			Version:     10,
+			Description: "index the audit log by time, target, actor, action and request",
+			Up: func(ctx context.Context, db *mongo.Database) error {
+				_, err := db.Collection(repositoy.AuditCollection).Indexes().CreateMany(ctx, []mongo.IndexModel{
+					{
+						Keys:    bson.D{{Key: "at", Value: -1}},
+						Options: options.Index().SetName("at"),
+					},
+					{
+						Keys:    bson.D{{Key: "target", Value: 1}, {Key: "at", Value: -1}},
+						Options: options.Index().SetName("target_at"),
+					},
+					{
+						Keys:    bson.D{{Key: "actor.name", Value: 1}, {Key: "at", Value: -1}},
+						Options: options.Index().SetName("actorName_at"),
+					},
+					{
+						Keys:    bson.D{{Key: "actor.id", Value: 1}, {Key: "at", Value: -1}},
+						Options: options.Index().SetName("actorId_at").SetSparse(true),
+					},
+					{
+						Keys:    bson.D{{Key: "action", Value: 1}, {Key: "at", Value: -1}},
+						Options: options.Index().SetName("action_at"),
+					},
+					{
+						Keys:    bson.D{{Key: "requestId", Value: 1}},
+						Options: options.Index().SetName("requestId").SetSparse(true),
+					},
+				})
+				return err
+			},
+		},
+		{
+			Version:     11,
+			Description: "expire marks of empty upstream results",
+			Up: func(ctx context.Context, db *mongo.Database) error {
+				_, err := db.Collection(repositoy.EmptyMarkCollection).Indexes().CreateOne(ctx, mongo.IndexModel{
+					Keys:    bson.D{{Key: "at", Value: 1}},
+					Options: options.Index().SetName("at_ttl").SetExpireAfterSeconds(int32(emptyMarkRetention.Seconds())),
+				})
+				return err
+			},
+		},
+		{
+			Version:     12,
+			Description: "backfill characters.kiRank and characters.maxKiRank and index them for listing",
+			Up:          backfillPowerRanks,
+		},
+	}
+}
+
+// outboxRetention is how long delivered outbox entries are kept.
+const outboxRetention = 7 * 24 * time.Hour
+
+// emptyMarkRetention is how long an empty upstream result is trusted.
+const emptyMarkRetention = 24 * time.Hour
+
+func backfillNameKey(ctx context.Context, db *mongo.Database) error {
+	col := db.Collection(repositoy.CharacterCollection)
+
+	cur, err := col.Find(ctx, bson.M{}, options.Find().SetProjection(bson.M{"name": 1}))
+	if err != nil {
+		return err
+	}
+
+	var docs []struct {
+		Id   int64  `bson:"_id"`
+		Name string `bson:"name"`
+	}
+	if err := cur.All(ctx, &docs); err != nil {
+		return err
+	}
+
+	for _, doc := range docs {
+		_, err := col.UpdateOne(
+			ctx,
+			bson.M{"_id": doc.Id},
+			bson.M{"$set": bson.M{"nameKey": domain.NormalizeName(doc.Name)}},
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = col.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "nameKey", Value: 1}},
+		Options: options.Index().SetName("nameKey_unique").SetUnique(true),
+	})
+	return err
+}
+
+func backfillPowerRanks(ctx context.Context, db *mongo.Database) error {
+	col := db.Collection(repositoy.CharacterCollection)
+
+	cur, err := col.Find(ctx, bson.M{}, options.Find().SetProjection(bson.M{"ki": 1, "maxKi": 1}))
+	if err != nil {
+		return err
+	}
+
+	var docs []struct {
+		Id    int64  `bson:"_id"`
+		Ki    string `bson:"ki"`
+		MaxKi string `bson:"maxKi"`
+	}
+	if err := cur.All(ctx, &docs); err != nil {
+		return err
+	}
+
+	for _, doc := range docs {
+		_, err := col.UpdateOne(
+			ctx,
+			bson.M{"_id": doc.Id},
+			bson.M{"$set": bson.M{
+				"kiRank":    domain.LenientPowerLevel(doc.Ki).Rank(),
+				"maxKiRank": domain.LenientPowerLevel(doc.MaxKi).Rank(),
+			}},
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = col.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "kiRank", Value: 1}, {Key: "_id", Value: 1}},
+			Options: options.Index().SetName("kiRank_id"),
+		},
+		{
+			Keys:    bson.D{{Key: "maxKiRank", Value: 1}, {Key: "_id", Value: 1}},
+			Options: options.Index().SetName("maxKiRank_id"),
+		},
+	})
+	return err
+}
@@ -0,0 +1,44 @@
+package migration
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+const CollectionName = "schema_migrations"
+
+type mongoStore struct {
+	col *mongo.Collection
+}
+
+func NewMongoStore(db *mongo.Database) Store {
+	return &mongoStore{col: db.Collection(CollectionName)}
+}
+
+func (s *mongoStore) Applied(ctx context.Context) ([]Record, error) {
+	cur, err := s.col.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+
+	var records []Record
+	if err := cur.All(ctx, &records); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+func (s *mongoStore) Save(ctx context.Context, record Record) error {
+	_, err := s.col.ReplaceOne(
+		ctx,
+		bson.M{"_id": record.Version},
+		record,
+		options.Replace().SetUpsert(true),
+	)
+
+	return err
+}
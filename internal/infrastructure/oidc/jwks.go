@@ -0,0 +1,140 @@
+package oidc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	DefaultRefresh = time.Hour
+	// minRefresh bounds how often an unknown kid can force a reload, so
+	// tokens with made-up kids cannot hammer the identity provider.
+	minRefresh = 30 * time.Second
+	maxJWKSize = 1 << 20
+)
+
+// Source returns the raw JWKS document.
+type Source interface {
+	Load(ctx context.Context) ([]byte, error)
+}
+
+// NewSource reads location over HTTP(S) when it is a URL and from disk
+// otherwise.
+func NewSource(location string) Source {
+	if strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://") {
+		return URLSource{URL: location, Client: &http.Client{Timeout: 5 * time.Second}}
+	}
+
+	return FileSource{Path: location}
+}
+
+type FileSource struct {
+	Path string
+}
+
+func (s FileSource) Load(context.Context) ([]byte, error) {
+	return os.ReadFile(s.Path)
+}
+
+type URLSource struct {
+	URL    string
+	Client *http.Client
+}
+
+func (s URLSource) Load(ctx context.Context) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("jwks: unexpected status %d from %s", res.StatusCode, s.URL)
+	}
+
+	return io.ReadAll(io.LimitReader(res.Body, maxJWKSize))
+}
+
+// KeyCache keeps the last good JWKS and reloads it once it is older than
+// the refresh interval, or early when a token names a kid it does not know
+// yet (the provider rotated its keys). A failed reload keeps serving the
+// previous set.
+type KeyCache struct {
+	source  Source
+	refresh time.Duration
+	now     func() time.Time
+
+	mu       sync.RWMutex
+	set      *KeySet
+	loadedAt time.Time
+	group    singleflight.Group
+}
+
+func NewKeyCache(source Source, refresh time.Duration) *KeyCache {
+	return NewKeyCacheWithClock(source, refresh, time.Now)
+}
+
+func NewKeyCacheWithClock(source Source, refresh time.Duration, now func() time.Time) *KeyCache {
+	if refresh <= 0 {
+		refresh = DefaultRefresh
+	}
+
+	return &KeyCache{source: source, refresh: refresh, now: now}
+}
+
+// KeySet returns the cached set, loading it first if needed. A non-empty
+// kid missing from the set triggers an early reload.
+func (c *KeyCache) KeySet(ctx context.Context, kid string) (*KeySet, error) {
+	c.mu.RLock()
+	set, age := c.set, c.now().Sub(c.loadedAt)
+	c.mu.RUnlock()
+
+	stale := set == nil || age >= c.refresh
+	rotated := set != nil && kid != "" && !set.has(kid) && age >= minRefresh
+	if !stale && !rotated {
+		return set, nil
+	}
+
+	fresh, err, _ := c.group.Do("jwks", func() (any, error) {
+		return c.reload(ctx)
+	})
+	if err != nil {
+		if set != nil {
+			return set, nil
+		}
+		return nil, err
+	}
+
+	return fresh.(*KeySet), nil
+}
+
+func (c *KeyCache) reload(ctx context.Context) (*KeySet, error) {
+	data, err := c.source.Load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("jwks: %w", err)
+	}
+
+	set, err := ParseKeySet(data)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.set, c.loadedAt = set, c.now()
+	c.mu.Unlock()
+
+	return set, nil
+}
@@ -0,0 +1,242 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+	"time"
+)
+
+const defaultLeeway = 30 * time.Second
+
+// ErrInvalidToken wraps every reason a token is rejected: malformed, bad
+// signature, wrong issuer or audience, expired.
+var ErrInvalidToken = errors.New("invalid token")
+
+// ErrKeysUnavailable means the JWKS could not be loaded at all, so no token
+// can be checked.
+var ErrKeysUnavailable = errors.New("token keys unavailable")
+
+type Claims struct {
+	Subject   string
+	Issuer    string
+	Audience  []string
+	ExpiresAt time.Time
+	// Scopes merges the space separated "scope" claim and the "scp" array
+	// some providers use instead.
+	Scopes []string
+}
+
+// Config names the issuer and audience a token must carry. Both are
+// required: left empty, they match no token.
+type Config struct {
+	Issuer   string
+	Audience string
+	// Leeway absorbs clock skew on exp and nbf; zero means 30 seconds.
+	Leeway time.Duration
+	Now    func() time.Time
+}
+
+type KeyProvider interface {
+	KeySet(ctx context.Context, kid string) (*KeySet, error)
+}
+
+type Verifier struct {
+	keys KeyProvider
+	cfg  Config
+}
+
+func NewVerifier(keys KeyProvider, cfg Config) *Verifier {
+	if cfg.Leeway == 0 {
+		cfg.Leeway = defaultLeeway
+	}
+	if cfg.Now == nil {
+		cfg.Now = time.Now
+	}
+
+	return &Verifier{keys: keys, cfg: cfg}
+}
+
+type header struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	Typ string `json:"typ"`
+}
+
+type payload struct {
+	Sub   string          `json:"sub"`
+	Iss   string          `json:"iss"`
+	Aud   json.RawMessage `json:"aud"`
+	Exp   *json.Number    `json:"exp"`
+	Nbf   *json.Number    `json:"nbf"`
+	Scope string          `json:"scope"`
+	Scp   []string        `json:"scp"`
+}
+
+// Verify checks the signature of a compact JWS and its registered claims.
+// Only RS256, ES256 and HS256 are accepted; "none" never is.
+func (v *Verifier) Verify(ctx context.Context, token string) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, invalid("malformed token")
+	}
+
+	var h header
+	if err := decodeJSON(parts[0], &h); err != nil {
+		return nil, invalid("malformed header")
+	}
+
+	sig, err := decodeSegment(parts[2])
+	if err != nil {
+		return nil, invalid("malformed signature")
+	}
+
+	set, err := v.keys.KeySet(ctx, h.Kid)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrKeysUnavailable, err)
+	}
+
+	signed := []byte(parts[0] + "." + parts[1])
+	if !verifyAny(set.candidates(h.Kid, h.Alg), h.Alg, signed, sig) {
+		return nil, invalid("signature does not verify")
+	}
+
+	var p payload
+	if err := decodeJSON(parts[1], &p); err != nil {
+		return nil, invalid("malformed claims")
+	}
+
+	return v.checkClaims(p)
+}
+
+func (v *Verifier) checkClaims(p payload) (*Claims, error) {
+	now := v.cfg.Now()
+
+	if p.Exp == nil {
+		return nil, invalid("missing exp")
+	}
+	exp, err := unixTime(*p.Exp)
+	if err != nil {
+		return nil, invalid("malformed exp")
+	}
+	if !now.Before(exp.Add(v.cfg.Leeway)) {
+		return nil, invalid("token expired")
+	}
+	if p.Nbf != nil {
+		nbf, err := unixTime(*p.Nbf)
+		if err != nil {
+			return nil, invalid("malformed nbf")
+		}
+		if now.Add(v.cfg.Leeway).Before(nbf) {
+			return nil, invalid("token not valid yet")
+		}
+	}
+
+	if v.cfg.Issuer == "" || p.Iss != v.cfg.Issuer {
+		return nil, invalid("unexpected issuer")
+	}
+
+	aud, err := audience(p.Aud)
+	if err != nil {
+		return nil, invalid("malformed aud")
+	}
+	if v.cfg.Audience == "" || !slices.Contains(aud, v.cfg.Audience) {
+		return nil, invalid("unexpected audience")
+	}
+
+	scopes := strings.Fields(p.Scope)
+	for _, s := range p.Scp {
+		if !slices.Contains(scopes, s) {
+			scopes = append(scopes, s)
+		}
+	}
+
+	return &Claims{
+		Subject:   p.Sub,
+		Issuer:    p.Iss,
+		Audience:  aud,
+		ExpiresAt: exp,
+		Scopes:    scopes,
+	}, nil
+}
+
+func verifyAny(keys []Key, alg string, signed, sig []byte) bool {
+	digest := sha256.Sum256(signed)
+
+	for _, k := range keys {
+		switch pub := k.Public.(type) {
+		case *rsa.PublicKey:
+			if rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest[:], sig) == nil {
+				return true
+			}
+		case *ecdsa.PublicKey:
+			// JWS carries r||s, not the ASN.1 form.
+			if len(sig) == 64 {
+				r, s := new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:])
+				if ecdsa.Verify(pub, digest[:], r, s) {
+					return true
+				}
+			}
+		case []byte:
+			mac := hmac.New(sha256.New, pub)
+			mac.Write(signed)
+			if hmac.Equal(mac.Sum(nil), sig) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// audience accepts both forms of the aud claim: a string or an array.
+func audience(raw json.RawMessage) ([]string, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	var one string
+	if err := json.Unmarshal(raw, &one); err == nil {
+		return []string{one}, nil
+	}
+
+	var many []string
+	if err := json.Unmarshal(raw, &many); err != nil {
+		return nil, err
+	}
+
+	return many, nil
+}
+
+func unixTime(n json.Number) (time.Time, error) {
+	f, err := n.Float64()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Unix(int64(f), 0), nil
+}
+
+func decodeJSON(segment string, v any) error {
+	data, err := decodeSegment(segment)
+	if err != nil {
+		return err
+	}
+
+	dec := json.NewDecoder(strings.NewReader(string(data)))
+	dec.UseNumber()
+	return dec.Decode(v)
+}
+
+func invalid(reason string) error {
+	return fmt.Errorf("%w: %s", ErrInvalidToken, reason)
+}
@@ -0,0 +1,83 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+// natsFlushTimeout bounds a publish whose context has no deadline.
+const natsFlushTimeout = 10 * time.Second
+
+// NatsBroker publishes to a NATS server with the official client. The
+// message key travels in the Nats-Msg-Id header, which JetStream uses to
+// drop duplicates. Publish flushes and waits for the server to answer, so a
+// nil error means the server has the message. The client keeps the
+// connection up on its own and buffers while it reconnects.
+type NatsBroker struct {
+	// mu keeps publishes apart so the error the server answers one with is
+	// not mistaken for another's.
+	mu   sync.Mutex
+	conn *nats.Conn
+}
+
+// NewNatsBroker takes a nats://host[:port] URL. The server need not be up
+// yet: the client keeps trying in the background.
+func NewNatsBroker(rawURL string) (*NatsBroker, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "nats" || u.Hostname() == "" {
+		return nil, fmt.Errorf("%q is not a nats://host[:port] URL", rawURL)
+	}
+
+	conn, err := nats.Connect(rawURL,
+		nats.Name("dbz-api"),
+		nats.RetryOnFailedConnect(true),
+		nats.MaxReconnects(-1),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &NatsBroker{conn: conn}, nil
+}
+
+func (b *NatsBroker) Publish(ctx context.Context, subject string, key string, data []byte) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, natsFlushTimeout)
+		defer cancel()
+	}
+
+	msg := nats.NewMsg(subject)
+	msg.Header.Set(nats.MsgIdHdr, key)
+	msg.Data = data
+
+	// The server answers a rejected message (a permissions violation) with
+	// -ERR and keeps the connection; the client records it as the last
+	// error before it reads the answer to the flush.
+	before := b.conn.LastError()
+	if err := b.conn.PublishMsg(msg); err != nil {
+		return err
+	}
+	err := b.conn.FlushWithContext(ctx)
+	if last := b.conn.LastError(); last != nil && last != before {
+		return last
+	}
+
+	return err
+}
+
+func (b *NatsBroker) Close() error {
+	b.conn.Close()
+	return nil
+}
@@ -0,0 +1,156 @@
+// Package outbox relays the events recorded in the outbox collection to
+// sinks outside the process.
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/heaveless/dbz-api/internal/domain/event"
+)
+
+const (
+	DefaultInterval   = time.Second
+	DefaultBatch      = 100
+	DefaultMinBackoff = time.Second
+	DefaultMaxBackoff = 5 * time.Minute
+
+	// sendTimeout bounds one delivery to one sink.
+	sendTimeout = 10 * time.Second
+)
+
+type RelayOptions struct {
+	// Interval is how long the relay waits after a poll that found nothing
+	// to deliver.
+	Interval time.Duration
+	// Batch is how many entries one poll reads.
+	Batch int
+	// MinBackoff doubles after every failed attempt up to MaxBackoff.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+}
+
+// Relay delivers every outbox entry to every sink at least once. A sink that
+// fails gets the entry again after a backoff while the others are not sent
+// it twice; entries are not held back by an earlier one that keeps failing,
+// so consumers must not rely on their order. Several relays may run on the
+// same outbox, at the cost of more duplicates.
+type Relay struct {
+	repo  event.OutboxRepository
+	sinks []event.Sink
+	opts  RelayOptions
+	now   func() time.Time
+}
+
+// NewRelay uses the defaults for zero options.
+func NewRelay(repo event.OutboxRepository, sinks []event.Sink, opts RelayOptions) *Relay {
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultInterval
+	}
+	if opts.Batch <= 0 {
+		opts.Batch = DefaultBatch
+	}
+	if opts.MinBackoff <= 0 {
+		opts.MinBackoff = DefaultMinBackoff
+	}
+	if opts.MaxBackoff < opts.MinBackoff {
+		opts.MaxBackoff = max(DefaultMaxBackoff, opts.MinBackoff)
+	}
+
+	return &Relay{
+		repo:  repo,
+		sinks: sinks,
+		opts:  opts,
+		now:   func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// Run relays until ctx is done. A full batch is followed by the next one
+// right away.
+func (r *Relay) Run(ctx context.Context) {
+	for {
+		n, err := r.RelayOnce(ctx)
+		if err != nil {
+			log.Printf("[OUTBOX] relay failed: %v", err)
+		}
+		if n == r.opts.Batch && err == nil {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(r.opts.Interval):
+		}
+	}
+}
+
+// RelayOnce delivers one batch of due entries and returns how many it read.
+func (r *Relay) RelayOnce(ctx context.Context) (int, error) {
+	entries, err := r.repo.Pending(ctx, r.now(), r.opts.Batch)
+	if err != nil {
+		return 0, err
+	}
+
+	var errs []error
+	for _, entry := range entries {
+		if ctx.Err() != nil {
+			break
+		}
+		if err := r.deliver(ctx, entry); err != nil {
+			errs = append(errs, fmt.Errorf("entry %s: %w", entry.Key, err))
+		}
+	}
+
+	return len(entries), errors.Join(errs...)
+}
+
+// deliver only fails when the outcome cannot be saved; the entry is then
+// relayed again, sinks that already got it included.
+func (r *Relay) deliver(ctx context.Context, entry event.OutboxEntry) error {
+	m := entry.Message()
+
+	var delivered []string
+	var failures []error
+	for _, sink := range r.sinks {
+		if slices.Contains(entry.DeliveredTo, sink.Name()) {
+			continue
+		}
+
+		sendCtx, cancel := context.WithTimeout(ctx, sendTimeout)
+		err := sink.Send(sendCtx, m)
+		cancel()
+
+		if err != nil {
+			failures = append(failures, fmt.Errorf("%s: %w", sink.Name(), err))
+			continue
+		}
+		delivered = append(delivered, sink.Name())
+	}
+
+	if len(failures) == 0 {
+		return r.repo.Delivered(ctx, entry.Key, delivered, r.now())
+	}
+
+	reason := errors.Join(failures...).Error()
+	log.Printf("[OUTBOX] entry %s attempt %d failed: %s", entry.Key, entry.Attempts+1, reason)
+
+	return r.repo.Retry(ctx, entry.Key, delivered, r.now().Add(r.backoff(entry.Attempts)), reason)
+}
+
+// backoff is the wait after the attempts+1th failure.
+func (r *Relay) backoff(attempts int) time.Duration {
+	d := r.opts.MinBackoff
+	for range attempts {
+		if d >= r.opts.MaxBackoff/2 {
+			return r.opts.MaxBackoff
+		}
+		d *= 2
+	}
+
+	return min(d, r.opts.MaxBackoff)
+}
@@ -0,0 +1,142 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+
+	"github.com/heaveless/dbz-api/internal/domain/event"
+	"github.com/heaveless/dbz-api/internal/infrastructure/breaker"
+)
+
+// WriterSink writes each message as a line of JSON.
+type WriterSink struct {
+	name string
+	mu   sync.Mutex
+	w    io.Writer
+}
+
+func NewWriterSink(name string, w io.Writer) *WriterSink {
+	return &WriterSink{name: name, w: w}
+}
+
+func NewStdoutSink() *WriterSink {
+	return NewWriterSink("stdout", os.Stdout)
+}
+
+func (s *WriterSink) Name() string { return s.name }
+
+func (s *WriterSink) Send(_ context.Context, m event.Message) error {
+	line, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err = s.w.Write(append(line, '\n'))
+	return err
+}
+
+// FileSink appends JSON lines to a file and syncs after each one, so a
+// message is on disk before it counts as delivered.
+type FileSink struct {
+	*WriterSink
+	f *os.File
+}
+
+func NewFileSink(path string) (*FileSink, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileSink{WriterSink: NewWriterSink("file", f), f: f}, nil
+}
+
+func (s *FileSink) Send(ctx context.Context, m event.Message) error {
+	if err := s.WriterSink.Send(ctx, m); err != nil {
+		return err
+	}
+
+	return s.f.Sync()
+}
+
+func (s *FileSink) Close() error {
+	return s.f.Close()
+}
+
+// WebhookSink POSTs each message as JSON with its key in Idempotency-Key.
+// Any 2xx acknowledges it.
+type WebhookSink struct {
+	url    string
+	client breaker.ExternalClient
+}
+
+func NewWebhookSink(url string, client breaker.ExternalClient) *WebhookSink {
+	return &WebhookSink{url: url, client: client}
+}
+
+func (s *WebhookSink) Name() string { return "webhook" }
+
+func (s *WebhookSink) Send(ctx context.Context, m event.Message) error {
+	body, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", m.Key)
+	req.Header.Set("X-Event-Type", string(m.Type))
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, res.Body)
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("webhook answered %d", res.StatusCode)
+	}
+
+	return nil
+}
+
+// Broker is a message broker such as NATS or Kafka. key identifies the
+// message, for brokers that deduplicate or partition on it.
+type Broker interface {
+	Publish(ctx context.Context, subject string, key string, data []byte) error
+}
+
+// BrokerSink publishes each message as JSON on prefix.<event type>.
+type BrokerSink struct {
+	name   string
+	broker Broker
+	prefix string
+}
+
+func NewBrokerSink(name string, broker Broker, prefix string) *BrokerSink {
+	return &BrokerSink{name: name, broker: broker, prefix: prefix}
+}
+
+func (s *BrokerSink) Name() string { return s.name }
+
+func (s *BrokerSink) Send(ctx context.Context, m event.Message) error {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	return s.broker.Publish(ctx, s.prefix+"."+string(m.Type), m.Key, data)
+}
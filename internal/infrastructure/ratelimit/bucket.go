@@ -0,0 +1,77 @@
+package ratelimit
+
+import (
+	"math"
+	"time"
+)
+
+// Limit is a token bucket refilled at Rate tokens per second and holding at
+// most Burst tokens. A zero Rate disables the limit.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+func (l Limit) Enabled() bool {
+	return l.Rate > 0 && l.Burst > 0
+}
+
+// Decision is the outcome of taking one token.
+type Decision struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset is the time until the bucket is full again.
+	Reset time.Duration
+	// RetryAfter is the time until the next token, zero when allowed.
+	RetryAfter time.Duration
+}
+
+type Bucket struct {
+	limit  Limit
+	tokens float64
+	last   time.Time
+}
+
+func NewBucket(limit Limit, now time.Time) *Bucket {
+	return &Bucket{
+		limit:  limit,
+		tokens: float64(limit.Burst),
+		last:   now,
+	}
+}
+
+func (b *Bucket) Take(now time.Time) Decision {
+	b.refill(now)
+
+	d := Decision{Limit: b.limit.Burst}
+	if b.tokens >= 1 {
+		b.tokens--
+		d.Allowed = true
+	} else {
+		d.RetryAfter = b.secondsToDuration((1 - b.tokens) / b.limit.Rate)
+	}
+
+	d.Remaining = int(math.Floor(b.tokens))
+	d.Reset = b.secondsToDuration((float64(b.limit.Burst) - b.tokens) / b.limit.Rate)
+
+	return d
+}
+
+// Full reports whether the bucket has refilled completely, i.e. it carries
+// no state worth keeping.
+func (b *Bucket) Full(now time.Time) bool {
+	b.refill(now)
+	return b.tokens >= float64(b.limit.Burst)
+}
+
+func (b *Bucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens = math.Min(float64(b.limit.Burst), b.tokens+elapsed*b.limit.Rate)
+		b.last = now
+	}
+}
+
+func (b *Bucket) secondsToDuration(s float64) time.Duration {
+	return time.Duration(math.Ceil(s * float64(time.Second)))
+}
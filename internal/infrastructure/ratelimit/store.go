@@ -0,0 +1,71 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const sweepInterval = time.Minute
+
+// Store keeps one bucket per key. The in-memory store suits a single
+// instance; a shared store (e.g. Redis) can implement the same interface.
+type Store interface {
+	Take(ctx context.Context, key string, limit Limit) (Decision, error)
+}
+
+type MemoryStore struct {
+	mu        sync.Mutex
+	now       func() time.Time
+	buckets   map[string]*Bucket
+	lastSweep time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return NewMemoryStoreWithClock(time.Now)
+}
+
+func NewMemoryStoreWithClock(now func() time.Time) *MemoryStore {
+	return &MemoryStore{
+		now:       now,
+		buckets:   map[string]*Bucket{},
+		lastSweep: now(),
+	}
+}
+
+func (s *MemoryStore) Take(_ context.Context, key string, limit Limit) (Decision, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = NewBucket(limit, now)
+		s.buckets[key] = b
+	}
+
+	return b.Take(now), nil
+}
+
+func (s *MemoryStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.buckets)
+}
+
+// sweep drops refilled buckets so idle clients do not accumulate.
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < sweepInterval {
+		return
+	}
+	s.lastSweep = now
+
+	for key, b := range s.buckets {
+		if b.Full(now) {
+			delete(s.buckets, key)
+		}
+	}
+}
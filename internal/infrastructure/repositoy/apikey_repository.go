@@ -0,0 +1,109 @@
+package repositoy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	domain "github.com/heaveless/dbz-api/internal/domain/apikey"
+	"github.com/heaveless/dbz-api/internal/infrastructure/breaker"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// usageRetention is how long daily usage counters are kept; a TTL index on
+// expireAt removes them afterwards.
+const usageRetention = 30 * 24 * time.Hour
+
+type apiKeyRepository struct {
+	keys  breaker.DbCollection
+	usage breaker.DbCollection
+}
+
+func NewApiKeyRepository(keys, usage breaker.DbCollection) domain.ApiKeyRepository {
+	return &apiKeyRepository{
+		keys:  keys,
+		usage: usage,
+	}
+}
+
+func (repo *apiKeyRepository) Get(ctx context.Context, id string) (*domain.ApiKeyEntity, error) {
+	return repo.findOne(ctx, bson.M{"_id": id})
+}
+
+func (repo *apiKeyRepository) GetByHash(ctx context.Context, hash string) (*domain.ApiKeyEntity, error) {
+	return repo.findOne(ctx, bson.M{"hash": hash})
+}
+
+func (repo *apiKeyRepository) findOne(ctx context.Context, filter bson.M) (*domain.ApiKeyEntity, error) {
+	res, err := repo.keys.FindOne(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var record domain.ApiKeyEntity
+	if err := res.Decode(&record); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, fmt.Errorf("api key %w", domain.ErrNotFound)
+		}
+		return nil, err
+	}
+
+	return &record, nil
+}
+
+func (repo *apiKeyRepository) List(ctx context.Context) ([]domain.ApiKeyEntity, error) {
+	cur, err := repo.keys.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "createdAt", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+
+	var records []domain.ApiKeyEntity
+	if err := cur.All(ctx, &records); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+func (repo *apiKeyRepository) Save(ctx context.Context, record *domain.ApiKeyEntity) error {
+	_, err := repo.keys.ReplaceOne(
+		ctx,
+		bson.M{"_id": record.Id},
+		record,
+		options.Replace().SetUpsert(true),
+	)
+
+	return err
+}
+
+func (repo *apiKeyRepository) IncrementUsage(ctx context.Context, id string, day string) (int64, error) {
+	expireAt, err := time.Parse(time.DateOnly, day)
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := repo.usage.FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": id + ":" + day},
+		bson.M{
+			"$inc":         bson.M{"count": 1},
+			"$setOnInsert": bson.M{"keyId": id, "day": day, "expireAt": expireAt.Add(usageRetention)},
+		},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	var usage struct {
+		Count int64 `bson:"count"`
+	}
+	if err := res.Decode(&usage); err != nil {
+		return 0, err
+	}
+
+	return usage.Count, nil
+}
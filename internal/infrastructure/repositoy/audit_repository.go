@@ -0,0 +1,105 @@
+package repositoy
+
+import (
+	"context"
+	"time"
+
+	"github.com/heaveless/dbz-api/internal/domain/audit"
+	"github.com/heaveless/dbz-api/internal/infrastructure/breaker"
+	utils "github.com/heaveless/dbz-api/internal/utils"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type auditRepository struct {
+	client breaker.DbCollection
+	now    func() time.Time
+}
+
+// NewAuditRepository only ever inserts: the audit log is append-only.
+func NewAuditRepository(client breaker.DbCollection) audit.Repository {
+	return &auditRepository{
+		client: client,
+		now:    func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// Record keys the entry with a new ObjectID, so ids sort in the order
+// entries were recorded.
+func (repo *auditRepository) Record(ctx context.Context, e audit.Entry) error {
+	e.Id = bson.NewObjectID().Hex()
+	if e.At.IsZero() {
+		e.At = repo.now()
+	}
+	if e.RequestId == "" {
+		e.RequestId = audit.RequestIdFrom(ctx)
+	}
+	if e.Changes == nil {
+		e.Changes = []audit.Change{}
+	}
+
+	_, err := repo.client.InsertOne(ctx, &e)
+
+	return err
+}
+
+// Find breaks ties on At with the id, which follows the recording order.
+func (repo *auditRepository) Find(ctx context.Context, q audit.Query) ([]audit.Entry, int64, error) {
+	filter := auditFilter(q)
+
+	total, err := repo.client.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	cur, err := repo.client.Find(
+		ctx,
+		filter,
+		options.Find().
+			SetSort(bson.D{{Key: "at", Value: -1}, {Key: "_id", Value: -1}}).
+			SetSkip(int64(utils.Offset(q.Page, q.Limit))).
+			SetLimit(int64(q.Limit)),
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	entries := []audit.Entry{}
+	if err := cur.All(ctx, &entries); err != nil {
+		return nil, 0, err
+	}
+
+	return entries, total, nil
+}
+
+func auditFilter(q audit.Query) bson.M {
+	filter := bson.M{}
+	if q.Actor != "" {
+		filter["$or"] = bson.A{bson.M{"actor.id": q.Actor}, bson.M{"actor.name": q.Actor}}
+	}
+	if q.Action != "" {
+		filter["action"] = q.Action
+	}
+	if q.Target != "" {
+		filter["target"] = q.Target
+	}
+	if q.Field != "" {
+		filter["changes.field"] = q.Field
+	}
+	if q.RequestId != "" {
+		filter["requestId"] = q.RequestId
+	}
+
+	at := bson.M{}
+	if !q.From.IsZero() {
+		at["$gte"] = q.From
+	}
+	if !q.To.IsZero() {
+		at["$lt"] = q.To
+	}
+	if len(at) > 0 {
+		filter["at"] = at
+	}
+
+	return filter
+}
@@ -0,0 +1,85 @@
+package repositoy
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/heaveless/dbz-api/internal/domain/apikey"
+	"github.com/heaveless/dbz-api/internal/domain/audit"
+	"github.com/heaveless/dbz-api/internal/domain/webhook"
+)
+
+type auditedApiKeyRepository struct {
+	apikey.ApiKeyRepository
+	audit audit.Log
+}
+
+// NewAuditedApiKeyRepository records in log every key saved through repo,
+// attributed to the actor of the context it is saved with. Usage counters
+// are not audited.
+func NewAuditedApiKeyRepository(repo apikey.ApiKeyRepository, auditLog audit.Log) apikey.ApiKeyRepository {
+	return &auditedApiKeyRepository{ApiKeyRepository: repo, audit: auditLog}
+}
+
+// Save reads the stored key first to tell what the save changes. Once the
+// key is saved, Save succeeds: a new key's raw value is shown only once.
+func (repo *auditedApiKeyRepository) Save(ctx context.Context, k *apikey.ApiKeyEntity) error {
+	before, err := repo.Get(ctx, k.Id)
+	if err != nil && !errors.Is(err, apikey.ErrNotFound) {
+		return err
+	}
+
+	if err := repo.ApiKeyRepository.Save(ctx, k); err != nil {
+		return err
+	}
+
+	recordAudit(ctx, repo.audit, apikey.AuditAction(before, k), "apikey:"+k.Id, apikey.Diff(before, k))
+	return nil
+}
+
+type auditedSubscriptionRepository struct {
+	webhook.SubscriptionRepository
+	audit audit.Log
+}
+
+// NewAuditedSubscriptionRepository records in log every subscription saved
+// through repo, attributed to the actor of the context it is saved with.
+func NewAuditedSubscriptionRepository(repo webhook.SubscriptionRepository, auditLog audit.Log) webhook.SubscriptionRepository {
+	return &auditedSubscriptionRepository{SubscriptionRepository: repo, audit: auditLog}
+}
+
+// Save succeeds once the subscription is saved, so a new one's secret is
+// not lost to a failed audit entry.
+func (repo *auditedSubscriptionRepository) Save(ctx context.Context, s *webhook.SubscriptionEntity) error {
+	before, err := repo.Get(ctx, s.Id)
+	if err != nil && !errors.Is(err, webhook.ErrNotFound) {
+		return err
+	}
+
+	if err := repo.SubscriptionRepository.Save(ctx, s); err != nil {
+		return err
+	}
+
+	action := audit.WebhookUpdated
+	if before == nil {
+		action = audit.WebhookCreated
+	}
+
+	recordAudit(ctx, repo.audit, action, "webhook:"+s.Id, webhook.Diff(before, s))
+	return nil
+}
+
+// recordAudit runs after the change it reports was made, which a failure
+// here cannot undo, so the failure is only logged.
+func recordAudit(ctx context.Context, auditLog audit.Log, action, target string, changes []audit.Change) {
+	err := auditLog.Record(ctx, audit.Entry{
+		Actor:   audit.ActorFrom(ctx),
+		Action:  action,
+		Target:  target,
+		Changes: changes,
+	})
+	if err != nil {
+		log.Printf("[DB] failed to record %s of %s in the audit log: %v", action, target, err)
+	}
+}
@@ -0,0 +1,128 @@
+package repositoy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/heaveless/dbz-api/internal/domain/audit"
+	domain "github.com/heaveless/dbz-api/internal/domain/character"
+	"github.com/heaveless/dbz-api/internal/domain/event"
+	"github.com/heaveless/dbz-api/internal/infrastructure/breaker"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+type characterCurationRepository struct {
+	client breaker.DbCollection
+	tx     Transactor
+	events event.Publisher
+	outbox event.Outbox
+	audit  audit.Log
+}
+
+// NewCharacterCurationRepository emits CharacterCreated, CharacterUpdated
+// and CharacterDeleted like the character repository emits its events, and
+// records every change in auditLog, attributed to the actor of the context
+// it is made with. Each change and its outbox entry commit together in tx.
+func NewCharacterCurationRepository(client breaker.DbCollection, tx Transactor, events event.Publisher, outbox event.Outbox, auditLog audit.Log) domain.CurationRepository {
+	return &characterCurationRepository{
+		client: client,
+		tx:     tx,
+		events: events,
+		outbox: outbox,
+		audit:  auditLog,
+	}
+}
+
+func (repo *characterCurationRepository) GetById(ctx context.Context, id int64) (*domain.CharacterEntity, error) {
+	res, err := repo.client.FindOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return nil, err
+	}
+
+	var record domain.CharacterEntity
+	if err := res.Decode(&record); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, fmt.Errorf("character %w", domain.ErrNotFound)
+		}
+		return nil, err
+	}
+
+	return &record, nil
+}
+
+// Insert fails with ErrExists when the id or the name is taken.
+func (repo *characterCurationRepository) Insert(ctx context.Context, c *domain.CharacterEntity) error {
+	doc := *c
+	doc.SetKeys()
+
+	return repo.write(ctx, func(ctx context.Context) (*write, error) {
+		res, err := repo.client.InsertOne(ctx, &doc)
+		if err != nil {
+			return nil, err
+		}
+		// The breaker reports a duplicate key as an insert without an id.
+		if res.InsertedID == nil {
+			return nil, fmt.Errorf("character %w", domain.ErrExists)
+		}
+
+		return recordWrite(ctx, repo.outbox, event.CharacterCreated, audit.CharacterCreated, nil, &doc)
+	})
+}
+
+func (repo *characterCurationRepository) Update(ctx context.Context, before, after *domain.CharacterEntity) error {
+	doc := *after
+	doc.SetKeys()
+
+	return repo.write(ctx, func(ctx context.Context) (*write, error) {
+		res, err := repo.client.ReplaceOne(ctx, bson.M{"_id": before.Id, "updatedAt": before.UpdatedAt}, &doc)
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, fmt.Errorf("character %w", domain.ErrExists)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if res.MatchedCount == 0 {
+			return nil, fmt.Errorf("character %w", domain.ErrConflict)
+		}
+
+		return recordWrite(ctx, repo.outbox, event.CharacterUpdated, audit.CharacterUpdated, before, &doc)
+	})
+}
+
+func (repo *characterCurationRepository) Delete(ctx context.Context, before *domain.CharacterEntity) error {
+	return repo.write(ctx, func(ctx context.Context) (*write, error) {
+		res, err := repo.client.DeleteOne(ctx, bson.M{"_id": before.Id, "updatedAt": before.UpdatedAt})
+		if err != nil {
+			return nil, err
+		}
+		if res.DeletedCount == 0 {
+			return nil, fmt.Errorf("character %w", domain.ErrConflict)
+		}
+
+		return recordWrite(ctx, repo.outbox, event.CharacterDeleted, audit.CharacterDeleted, before, nil)
+	})
+}
+
+// write commits fn's change and outbox entry together, then publishes the
+// event and audits the change.
+func (repo *characterCurationRepository) write(ctx context.Context, fn func(ctx context.Context) (*write, error)) error {
+	var w *write
+	err := repo.tx.WithTransaction(ctx, func(ctx context.Context) (err error) {
+		w, err = fn(ctx)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	repo.events.Publish(w.event)
+	chr := w.after
+	if chr == nil {
+		chr = w.before
+	}
+	recordAudit(ctx, repo.audit, w.action, fmt.Sprintf("character:%d", chr.Id), domain.Diff(w.before, w.after))
+
+	return nil
+}
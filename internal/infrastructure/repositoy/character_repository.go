@@ -2,30 +2,191 @@ package repositoy
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
+	"github.com/heaveless/dbz-api/internal/domain/audit"
 	domain "github.com/heaveless/dbz-api/internal/domain/character"
+	"github.com/heaveless/dbz-api/internal/domain/event"
 	"github.com/heaveless/dbz-api/internal/infrastructure/breaker"
+	"github.com/heaveless/dbz-api/internal/utils"
 	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
 )
 
+// errCharacterStored ends the insert transaction of a character that is
+// already stored; Create refreshes it in a transaction of its own.
+var errCharacterStored = errors.New("character already stored")
+
 type characterRepository struct {
 	client breaker.DbCollection
+	tx     Transactor
+	events event.Publisher
+	outbox event.Outbox
+	audit  audit.Log
 }
 
 func NewCharacterRepository(client breaker.DbCollection) domain.CharacterRepository {
+	return NewCharacterRepositoryWithEvents(client, NoTransaction{}, event.NopPublisher{}, event.NopOutbox{})
+}
+
+// NewCharacterRepositoryWithEvents emits CharacterCached when Create stores
+// a new character and CharacterRefreshed when it replaces one. Each event is
+// recorded in outbox in the transaction of the write it reports, and
+// published in process once that commits.
+func NewCharacterRepositoryWithEvents(client breaker.DbCollection, tx Transactor, events event.Publisher, outbox event.Outbox) domain.CharacterRepository {
+	return NewCharacterRepositoryWithAudit(client, tx, events, outbox, audit.NopLog{})
+}
+
+// NewCharacterRepositoryWithAudit also records in log, as made by
+// audit.Upstream, every field Create stores or refreshes.
+func NewCharacterRepositoryWithAudit(client breaker.DbCollection, tx Transactor, events event.Publisher, outbox event.Outbox, log audit.Log) domain.CharacterRepository {
 	return &characterRepository{
 		client: client,
+		tx:     tx,
+		events: events,
+		outbox: outbox,
+		audit:  log,
 	}
 }
 
+// Create stores a new character, or refreshes the stored one when record was
+// fetched from upstream after it, keeping the fields admins curated.
+// Anything else is left as it is. The write and its outbox entry commit
+// together; if the entry cannot be recorded, nothing is written.
 func (repo *characterRepository) Create(ctx context.Context, record *domain.CharacterEntity) error {
-	_, err := repo.client.InsertOne(ctx, record)
+	doc := *record
+	doc.SetKeys()
+	if doc.UpdatedAt.IsZero() {
+		doc.UpdatedAt = time.Now().UTC().Truncate(time.Second)
+	}
+
+	var w *write
+	err := repo.tx.WithTransaction(ctx, func(ctx context.Context) (err error) {
+		w, err = repo.insert(ctx, &doc)
+		return err
+	})
+	// A failed insert ends its transaction, so the refresh needs another.
+	if errors.Is(err, errCharacterStored) {
+		err = repo.tx.WithTransaction(ctx, func(ctx context.Context) (err error) {
+			w, err = repo.refresh(ctx, &doc)
+			return err
+		})
+	}
+	if err != nil || w == nil {
+		return err
+	}
+
+	repo.events.Publish(w.event)
+	if changes := domain.Diff(w.before, w.after); len(changes) > 0 {
+		target := fmt.Sprintf("character:%d", w.after.Id)
+		recordAudit(audit.WithActor(ctx, audit.Upstream), repo.audit, w.action, target, changes)
+	}
+
+	return nil
+}
 
-	return err
+// write is what a committed write leaves to report.
+type write struct {
+	event         event.Event
+	action        string
+	before, after *domain.CharacterEntity
+}
+
+func (repo *characterRepository) insert(ctx context.Context, doc *domain.CharacterEntity) (*write, error) {
+	res, err := repo.client.InsertOne(ctx, doc)
+	if err != nil {
+		return nil, err
+	}
+	// The breaker reports a duplicate key as an insert without an id.
+	if res.InsertedID == nil {
+		return nil, errCharacterStored
+	}
+
+	return recordWrite(ctx, repo.outbox, event.CharacterCached, audit.CharacterCached, nil, doc)
+}
+
+// refresh returns no write when the stored character is as new as doc.
+func (repo *characterRepository) refresh(ctx context.Context, doc *domain.CharacterEntity) (*write, error) {
+	upd, err := repo.client.FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": doc.Id, "updatedAt": bson.M{"$lt": doc.UpdatedAt}},
+		refreshPipeline(doc),
+		options.FindOneAndUpdate().SetReturnDocument(options.Before),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var stored domain.CharacterEntity
+	if err := upd.Decode(&stored); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	// The pipeline only depends on the copy it replaced, so applying the
+	// same refresh to it gives what was written.
+	refreshed := stored
+	refreshed.Refresh(doc)
+
+	return recordWrite(ctx, repo.outbox, event.CharacterRefreshed, audit.CharacterRefreshed, &stored, &refreshed)
+}
+
+// refreshPipeline sets every field of the stored character to doc's value
+// unless an admin curated it. Values are literals so text starting with $
+// is never read as a field path.
+func refreshPipeline(doc *domain.CharacterEntity) bson.A {
+	curated := bson.M{"$ifNull": bson.A{"$overridden", bson.A{}}}
+	keep := func(field, value string) bson.M {
+		return bson.M{"$cond": bson.A{
+			bson.M{"$in": bson.A{field, curated}},
+			"$" + field,
+			bson.M{"$literal": value},
+		}}
+	}
+
+	set := bson.M{"updatedAt": doc.UpdatedAt}
+	for field, value := range doc.Fields() {
+		set[field] = keep(field, value)
+	}
+	// Keys follow the field they derive from; the condition is on that one.
+	for _, k := range []struct{ key, field, value string }{
+		{"nameKey", "name", doc.NameKey},
+		{"kiRank", "ki", doc.KiRank},
+		{"maxKiRank", "maxKi", doc.MaxKiRank},
+	} {
+		set[k.key] = bson.M{"$cond": bson.A{
+			bson.M{"$in": bson.A{k.field, curated}},
+			"$" + k.key,
+			bson.M{"$literal": k.value},
+		}}
+	}
+
+	return bson.A{bson.M{"$set": set}}
+}
+
+// recordWrite adds the event of a write to outbox within the write's
+// transaction, and returns the write to report once it commits.
+func recordWrite(ctx context.Context, outbox event.Outbox, t event.Type, action string, before, after *domain.CharacterEntity) (*write, error) {
+	chr := after
+	if chr == nil {
+		chr = before
+	}
+
+	e := event.Event{Type: t, At: time.Now().UTC(), Data: *chr}
+	if err := outbox.Record(ctx, e); err != nil {
+		return nil, err
+	}
+
+	return &write{event: e, action: action, before: before, after: after}, nil
 }
 
 func (repo *characterRepository) Get(ctx context.Context, name string) (*domain.CharacterEntity, error) {
-	res, err := repo.client.FindOne(ctx, bson.M{"name": name})
+	res, err := repo.client.FindOne(ctx, bson.M{"nameKey": domain.NormalizeName(name)})
 	if err != nil {
 		return nil, err
 	}
@@ -37,3 +198,177 @@ func (repo *characterRepository) Get(ctx context.Context, name string) (*domain.
 
 	return &record, err
 }
+
+func (repo *characterRepository) GetById(ctx context.Context, id int64) (*domain.CharacterEntity, error) {
+	res, err := repo.client.FindOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return nil, err
+	}
+
+	var record domain.CharacterEntity
+	if err := res.Decode(&record); err != nil {
+		return nil, err
+	}
+
+	return &record, nil
+}
+
+func (repo *characterRepository) List(ctx context.Context) ([]domain.CharacterEntity, error) {
+	cur, err := repo.client.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+
+	var records []domain.CharacterEntity
+	if err := cur.All(ctx, &records); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// listSort maps the sort fields of a ListQuery to stored ones.
+var listSort = map[string]string{
+	"id":    "_id",
+	"name":  "nameKey",
+	"ki":    "kiRank",
+	"maxKi": "maxKiRank",
+}
+
+// ListPage filters, sorts and pages in the query. Unknown power levels rank
+// "" and come last whichever way they are sorted, so sorting by one reads
+// the known levels first and the unknown ones after them, by id.
+func (repo *characterRepository) ListPage(ctx context.Context, q domain.ListQuery) ([]domain.CharacterEntity, int64, error) {
+	filter := bson.M{}
+	if q.MinKi != nil {
+		filter["kiRank"] = atLeast(q.MinKi)
+	}
+	if q.MinMaxKi != nil {
+		filter["maxKiRank"] = atLeast(q.MinMaxKi)
+	}
+
+	total, err := repo.client.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	field, ok := listSort[q.Sort.Field]
+	if !ok {
+		field = "_id"
+	}
+	dir := 1
+	if q.Sort.Desc {
+		dir = -1
+	}
+	// Ties go by id so pages are stable.
+	sort := bson.D{{Key: field, Value: dir}}
+	if field != "_id" {
+		sort = append(sort, bson.E{Key: "_id", Value: 1})
+	}
+
+	skip, limit := int64(utils.Offset(q.Page, q.Limit)), int64(q.Limit)
+	if field != "kiRank" && field != "maxKiRank" {
+		records, err := repo.find(ctx, filter, sort, skip, limit)
+		return records, total, err
+	}
+
+	known := bson.M{"$and": bson.A{filter, bson.M{field: bson.M{"$gt": ""}}}}
+	knownTotal, err := repo.client.CountDocuments(ctx, known)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	records := []domain.CharacterEntity{}
+	if skip < knownTotal {
+		records, err = repo.find(ctx, known, sort, skip, limit)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+	if rest := limit - int64(len(records)); rest > 0 && knownTotal < total {
+		unknown := bson.M{"$and": bson.A{filter, bson.M{field: ""}}}
+		more, err := repo.find(ctx, unknown, bson.D{{Key: "_id", Value: 1}}, max(0, skip-knownTotal), rest)
+		if err != nil {
+			return nil, 0, err
+		}
+		records = append(records, more...)
+	}
+
+	return records, total, nil
+}
+
+// atLeast matches known levels no lower than bound.
+func atLeast(bound *domain.PowerLevel) bson.M {
+	return bson.M{"$gt": "", "$gte": bound.Rank()}
+}
+
+func (repo *characterRepository) find(ctx context.Context, filter any, sort bson.D, skip, limit int64) ([]domain.CharacterEntity, error) {
+	cur, err := repo.client.Find(ctx, filter, options.Find().SetSort(sort).SetSkip(skip).SetLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+
+	var records []domain.CharacterEntity
+	if err := cur.All(ctx, &records); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+func (repo *characterRepository) GetByIds(ctx context.Context, ids []int64) ([]domain.CharacterEntity, error) {
+	cur, err := repo.client.Find(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, err
+	}
+
+	var records []domain.CharacterEntity
+	if err := cur.All(ctx, &records); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+type textSearchRecord struct {
+	domain.CharacterEntity `bson:",inline"`
+	Score                  float64 `bson:"score"`
+}
+
+func (repo *characterRepository) TextSearch(ctx context.Context, q domain.TextSearchQuery) ([]domain.TextMatch, int64, error) {
+	filter := bson.M{"$text": bson.M{"$search": q.Text}}
+	score := bson.M{"$meta": "textScore"}
+
+	total, err := repo.client.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	cur, err := repo.client.Find(
+		ctx,
+		filter,
+		options.Find().
+			SetProjection(bson.M{"score": score}).
+			SetSort(bson.D{{Key: "score", Value: score}, {Key: "_id", Value: 1}}).
+			SetSkip(int64(q.Offset)).
+			SetLimit(int64(q.Limit)),
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var records []textSearchRecord
+	if err := cur.All(ctx, &records); err != nil {
+		return nil, 0, err
+	}
+
+	matches := make([]domain.TextMatch, 0, len(records))
+	for _, r := range records {
+		matches = append(matches, domain.TextMatch{
+			Character: r.CharacterEntity,
+			Score:     r.Score,
+		})
+	}
+
+	return matches, total, nil
+}
@@ -0,0 +1,20 @@
+package repositoy
+
+import "go.mongodb.org/mongo-driver/v2/mongo/options"
+
+const (
+	CharacterCollection       = "characters"
+	PlanetCollection          = "planets"
+	TransformationCollection  = "transformations"
+	ApiKeyCollection          = "api_keys"
+	ApiKeyUsageCollection     = "api_key_usage"
+	OutboxCollection          = "outbox"
+	WebhookCollection         = "webhooks"
+	WebhookDeliveryCollection = "webhook_deliveries"
+	AuditCollection           = "audit_log"
+	EmptyMarkCollection       = "empty_marks"
+)
+
+// CaseInsensitive is the collation of the unique name index. Lookups go
+// through the normalized nameKey instead, see domain.NormalizeName.
+var CaseInsensitive = &options.Collation{Locale: "en", Strength: 2}
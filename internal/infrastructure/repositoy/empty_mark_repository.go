@@ -0,0 +1,59 @@
+package repositoy
+
+import (
+	"context"
+	"time"
+
+	"github.com/heaveless/dbz-api/internal/infrastructure/breaker"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type emptyMark struct {
+	Key string    `bson:"_id"`
+	At  time.Time `bson:"at"`
+}
+
+// EmptyMarkRepository keeps one document per marked key. Marks expire with
+// a TTL index on at, after which the lookup goes upstream again.
+type EmptyMarkRepository struct {
+	client breaker.DbCollection
+	now    func() time.Time
+}
+
+func NewEmptyMarkRepository(client breaker.DbCollection) *EmptyMarkRepository {
+	return &EmptyMarkRepository{
+		client: client,
+		now:    func() time.Time { return time.Now().UTC() },
+	}
+}
+
+func (repo *EmptyMarkRepository) Mark(ctx context.Context, key string) error {
+	_, err := repo.client.ReplaceOne(
+		ctx,
+		bson.M{"_id": key},
+		&emptyMark{Key: key, At: repo.now()},
+		options.Replace().SetUpsert(true),
+	)
+
+	return err
+}
+
+func (repo *EmptyMarkRepository) Marked(ctx context.Context, keys []string) (map[string]bool, error) {
+	cur, err := repo.client.Find(ctx, bson.M{"_id": bson.M{"$in": keys}})
+	if err != nil {
+		return nil, err
+	}
+
+	var marks []emptyMark
+	if err := cur.All(ctx, &marks); err != nil {
+		return nil, err
+	}
+
+	marked := make(map[string]bool, len(marks))
+	for _, m := range marks {
+		marked[m.Key] = true
+	}
+
+	return marked, nil
+}
@@ -0,0 +1,93 @@
+package repositoy
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/heaveless/dbz-api/internal/domain/event"
+	"github.com/heaveless/dbz-api/internal/infrastructure/breaker"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type outboxRepository struct {
+	client breaker.DbCollection
+	now    func() time.Time
+}
+
+func NewOutboxRepository(client breaker.DbCollection) event.OutboxRepository {
+	return &outboxRepository{
+		client: client,
+		now:    func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// Record keys the entry with a new ObjectID, so keys sort in the order
+// entries were recorded.
+func (repo *outboxRepository) Record(ctx context.Context, e event.Event) error {
+	data, err := json.Marshal(e.Data)
+	if err != nil {
+		return err
+	}
+
+	at := e.At
+	if at.IsZero() {
+		at = repo.now()
+	}
+
+	_, err = repo.client.InsertOne(ctx, &event.OutboxEntry{
+		Key:           bson.NewObjectID().Hex(),
+		Type:          e.Type,
+		At:            at,
+		Data:          string(data),
+		DeliveredTo:   []string{},
+		NextAttemptAt: at,
+	})
+
+	return err
+}
+
+// Pending relies on delivered entries having no nextAttemptAt.
+func (repo *outboxRepository) Pending(ctx context.Context, now time.Time, limit int) ([]event.OutboxEntry, error) {
+	cur, err := repo.client.Find(
+		ctx,
+		bson.M{"nextAttemptAt": bson.M{"$lte": now}},
+		options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}).SetLimit(int64(limit)),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []event.OutboxEntry
+	if err := cur.All(ctx, &records); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+func (repo *outboxRepository) Delivered(ctx context.Context, key string, sinks []string, at time.Time) error {
+	return repo.update(ctx, key, bson.M{
+		"$addToSet": bson.M{"deliveredTo": bson.M{"$each": sinks}},
+		"$set":      bson.M{"deliveredAt": at},
+		"$unset":    bson.M{"nextAttemptAt": "", "lastError": ""},
+	})
+}
+
+func (repo *outboxRepository) Retry(ctx context.Context, key string, sinks []string, at time.Time, reason string) error {
+	return repo.update(ctx, key, bson.M{
+		"$addToSet": bson.M{"deliveredTo": bson.M{"$each": sinks}},
+		"$inc":      bson.M{"attempts": 1},
+		"$set":      bson.M{"nextAttemptAt": at, "lastError": reason},
+	})
+}
+
+func (repo *outboxRepository) update(ctx context.Context, key string, update bson.M) error {
+	res, err := repo.client.FindOneAndUpdate(ctx, bson.M{"_id": key}, update)
+	if err != nil {
+		return err
+	}
+
+	return res.Err()
+}
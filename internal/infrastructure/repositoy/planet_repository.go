@@ -0,0 +1,76 @@
+package repositoy
+
+import (
+	"context"
+
+	domain "github.com/heaveless/dbz-api/internal/domain/planet"
+	"github.com/heaveless/dbz-api/internal/infrastructure/breaker"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type planetRepository struct {
+	client breaker.DbCollection
+}
+
+func NewPlanetRepository(client breaker.DbCollection) domain.PlanetRepository {
+	return &planetRepository{
+		client: client,
+	}
+}
+
+// Save upserts so that a planet first stored from the list endpoint can be
+// completed with its residents later.
+func (repo *planetRepository) Save(ctx context.Context, record *domain.PlanetEntity) error {
+	_, err := repo.client.ReplaceOne(
+		ctx,
+		bson.M{"_id": record.Id},
+		record,
+		options.Replace().SetUpsert(true),
+	)
+
+	return err
+}
+
+func (repo *planetRepository) Get(ctx context.Context, id int64) (*domain.PlanetEntity, error) {
+	res, err := repo.client.FindOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return nil, err
+	}
+
+	var record domain.PlanetEntity
+	if err := res.Decode(&record); err != nil {
+		return nil, err
+	}
+
+	return &record, nil
+}
+
+// GetByIds reads several planets in one query, in no particular order.
+func (repo *planetRepository) GetByIds(ctx context.Context, ids []int64) ([]domain.PlanetEntity, error) {
+	cur, err := repo.client.Find(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, err
+	}
+
+	var records []domain.PlanetEntity
+	if err := cur.All(ctx, &records); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+func (repo *planetRepository) List(ctx context.Context) ([]domain.PlanetEntity, error) {
+	cur, err := repo.client.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+
+	var records []domain.PlanetEntity
+	if err := cur.All(ctx, &records); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
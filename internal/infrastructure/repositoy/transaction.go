@@ -0,0 +1,47 @@
+package repositoy
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// Transactor runs fn in one transaction: the writes fn makes with the ctx
+// it is given are committed together or not at all.
+type Transactor interface {
+	WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type mongoTransactor struct {
+	client *mongo.Client
+}
+
+// NewMongoTransactor runs each transaction in a session of its own. MongoDB
+// only has transactions on a replica set, a single-node one included.
+func NewMongoTransactor(client *mongo.Client) Transactor {
+	return mongoTransactor{client: client}
+}
+
+// WithTransaction retries fn, as the driver does, while the transaction
+// fails with a transient error.
+func (t mongoTransactor) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	session, err := t.client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(ctx context.Context) (any, error) {
+		return nil, fn(ctx)
+	})
+
+	return err
+}
+
+// NoTransaction runs fn as it is, for repositories with nothing to record
+// next to their writes.
+type NoTransaction struct{}
+
+func (NoTransaction) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
@@ -0,0 +1,68 @@
+package repositoy
+
+import (
+	"context"
+
+	domain "github.com/heaveless/dbz-api/internal/domain/transformation"
+	"github.com/heaveless/dbz-api/internal/infrastructure/breaker"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type transformationRepository struct {
+	client breaker.DbCollection
+}
+
+func NewTransformationRepository(client breaker.DbCollection) domain.TransformationRepository {
+	return &transformationRepository{
+		client: client,
+	}
+}
+
+func (repo *transformationRepository) CreateMany(ctx context.Context, records []domain.TransformationEntity) error {
+	for i := range records {
+		if _, err := repo.client.InsertOne(ctx, &records[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (repo *transformationRepository) ListByCharacter(ctx context.Context, characterId int64) ([]domain.TransformationEntity, error) {
+	cur, err := repo.client.Find(
+		ctx,
+		bson.M{"characterId": characterId},
+		options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []domain.TransformationEntity
+	if err := cur.All(ctx, &records); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// ListByCharacters reads the transformations of several characters in one
+// query, ordered by character and then by id.
+func (repo *transformationRepository) ListByCharacters(ctx context.Context, characterIds []int64) ([]domain.TransformationEntity, error) {
+	cur, err := repo.client.Find(
+		ctx,
+		bson.M{"characterId": bson.M{"$in": characterIds}},
+		options.Find().SetSort(bson.D{{Key: "characterId", Value: 1}, {Key: "_id", Value: 1}}),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []domain.TransformationEntity
+	if err := cur.All(ctx, &records); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
@@ -0,0 +1,125 @@
+package repositoy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	domain "github.com/heaveless/dbz-api/internal/domain/webhook"
+	"github.com/heaveless/dbz-api/internal/infrastructure/breaker"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type webhookRepository struct {
+	client breaker.DbCollection
+}
+
+func NewWebhookRepository(client breaker.DbCollection) domain.SubscriptionRepository {
+	return &webhookRepository{
+		client: client,
+	}
+}
+
+func (repo *webhookRepository) Save(ctx context.Context, s *domain.SubscriptionEntity) error {
+	_, err := repo.client.ReplaceOne(ctx, bson.M{"_id": s.Id}, s, options.Replace().SetUpsert(true))
+
+	return err
+}
+
+func (repo *webhookRepository) Get(ctx context.Context, id string) (*domain.SubscriptionEntity, error) {
+	res, err := repo.client.FindOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return nil, err
+	}
+
+	var record domain.SubscriptionEntity
+	if err := res.Decode(&record); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, fmt.Errorf("webhook %w", domain.ErrNotFound)
+		}
+		return nil, err
+	}
+
+	return &record, nil
+}
+
+func (repo *webhookRepository) List(ctx context.Context) ([]domain.SubscriptionEntity, error) {
+	cur, err := repo.client.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "createdAt", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+
+	var records []domain.SubscriptionEntity
+	if err := cur.All(ctx, &records); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+type webhookDeliveryRepository struct {
+	client breaker.DbCollection
+}
+
+func NewWebhookDeliveryRepository(client breaker.DbCollection) domain.DeliveryRepository {
+	return &webhookDeliveryRepository{
+		client: client,
+	}
+}
+
+// Enqueue relies on the breaker reporting a duplicate id as success.
+func (repo *webhookDeliveryRepository) Enqueue(ctx context.Context, d *domain.DeliveryEntity) error {
+	_, err := repo.client.InsertOne(ctx, d)
+
+	return err
+}
+
+func (repo *webhookDeliveryRepository) Due(ctx context.Context, now time.Time, limit int) ([]domain.DeliveryEntity, error) {
+	return repo.find(
+		ctx,
+		bson.M{"status": domain.StatusPending, "nextAttemptAt": bson.M{"$lte": now}},
+		options.Find().SetSort(bson.D{{Key: "nextAttemptAt", Value: 1}}).SetLimit(int64(limit)),
+	)
+}
+
+func (repo *webhookDeliveryRepository) Attempted(ctx context.Context, id string, a domain.AttemptEntity, status domain.DeliveryStatus, next time.Time) error {
+	set := bson.M{"status": status}
+	update := bson.M{"$push": bson.M{"attempts": a}, "$set": set}
+	if status == domain.StatusPending {
+		set["nextAttemptAt"] = next
+	} else {
+		update["$unset"] = bson.M{"nextAttemptAt": ""}
+	}
+
+	res, err := repo.client.FindOneAndUpdate(ctx, bson.M{"_id": id}, update)
+	if err != nil {
+		return err
+	}
+
+	return res.Err()
+}
+
+func (repo *webhookDeliveryRepository) ListBySubscription(ctx context.Context, subscriptionId string, limit int) ([]domain.DeliveryEntity, error) {
+	return repo.find(
+		ctx,
+		bson.M{"subscriptionId": subscriptionId},
+		options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}}).SetLimit(int64(limit)),
+	)
+}
+
+func (repo *webhookDeliveryRepository) find(ctx context.Context, filter bson.M, opts *options.FindOptionsBuilder) ([]domain.DeliveryEntity, error) {
+	cur, err := repo.client.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []domain.DeliveryEntity
+	if err := cur.All(ctx, &records); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
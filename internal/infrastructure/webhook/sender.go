@@ -0,0 +1,118 @@
+// Package webhook sends signed webhook deliveries over HTTP.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"syscall"
+	"time"
+
+	domain "github.com/heaveless/dbz-api/internal/domain/webhook"
+	"github.com/heaveless/dbz-api/internal/infrastructure/breaker"
+)
+
+type Sender struct {
+	client breaker.ExternalClient
+	now    func() time.Time
+}
+
+func NewSender(client breaker.ExternalClient) *Sender {
+	return &Sender{client: client, now: time.Now}
+}
+
+// NewClient only connects to public addresses. The check runs on the
+// address DNS resolved to, so a subscriber's hostname cannot point the
+// sender at loopback, private, shared (CGNAT) or link-local hosts, the
+// cloud metadata endpoint among them, nor at their IPv4-mapped IPv6 forms.
+func NewClient() *http.Client {
+	return NewClientWithDialer(&net.Dialer{Timeout: 10 * time.Second, Control: publicOnly})
+}
+
+// NewClientWithDialer connects through dialer and ignores proxy settings,
+// so dialer sees every address. It does not follow redirects: a 3xx fails
+// the attempt like any other non-2xx answer.
+func NewClientWithDialer(dialer *net.Dialer) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Transport:     transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+	}
+}
+
+// nonPublic lists what the netip predicates leave out: "this network",
+// which some systems route to the host itself, and the shared address space
+// carriers and clouds use behind NAT.
+var nonPublic = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+}
+
+func publicOnly(_, address string, _ syscall.RawConn) error {
+	ap, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := ap.Addr().Unmap()
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsUnspecified() || ip.IsMulticast() {
+		return fmt.Errorf("webhook: %s is not a public address", ip)
+	}
+	for _, p := range nonPublic {
+		if p.Contains(ip) {
+			return fmt.Errorf("webhook: %s is not a public address", ip)
+		}
+	}
+
+	return nil
+}
+
+// Send POSTs the delivery payload to the subscription, signed with its
+// secret, and reports how the attempt went. The caller bounds it with ctx.
+func (s *Sender) Send(ctx context.Context, sub *domain.SubscriptionEntity, d *domain.DeliveryEntity) (attempt domain.AttemptEntity) {
+	start := s.now()
+	attempt.At = start.UTC()
+	defer func() { attempt.DurationMs = s.now().Sub(start).Milliseconds() }()
+
+	body := []byte(d.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.Url, bytes.NewReader(body))
+	if err != nil {
+		attempt.Error = err.Error()
+		return attempt
+	}
+
+	timestamp := start.Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "dbz-api-webhooks")
+	req.Header.Set(domain.HeaderEvent, string(d.Type))
+	req.Header.Set(domain.HeaderDelivery, d.Id)
+	req.Header.Set(domain.HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(domain.HeaderSignature, domain.Sign(sub.Secret, timestamp, body))
+	req.Header.Set("Idempotency-Key", d.EventKey)
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		attempt.Error = err.Error()
+		return attempt
+	}
+	defer res.Body.Close()
+
+	// Only the status is kept: the body is the subscriber's and is never
+	// read back into the delivery log.
+	attempt.StatusCode = res.StatusCode
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		attempt.Error = "unexpected status " + strconv.Itoa(res.StatusCode)
+	}
+	_, _ = io.Copy(io.Discard, res.Body)
+
+	return attempt
+}
@@ -0,0 +1,17 @@
+package utils
+
+import "math"
+
+// Offset is how many items come before page (1-based) when pages hold
+// limit items. It saturates at math.MaxInt rather than overflowing, so a
+// huge page number just lands past the end.
+func Offset(page, limit int) int {
+	if page <= 1 || limit <= 0 {
+		return 0
+	}
+	if page-1 > math.MaxInt/limit {
+		return math.MaxInt
+	}
+
+	return (page - 1) * limit
+}
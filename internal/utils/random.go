@@ -0,0 +1,14 @@
+package utils
+
+import "crypto/rand"
+
+// RandomString reads n random bytes and returns them as encode renders
+// them, e.g. hex.EncodeToString for ids or base64 for secrets.
+func RandomString(n int, encode func([]byte) string) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return encode(b), nil
+}
@@ -0,0 +1,260 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	apikeyapp "github.com/heaveless/dbz-api/internal/application/apikey"
+	apikey "github.com/heaveless/dbz-api/internal/domain/apikey"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockApiKeyRepository struct {
+	mock.Mock
+}
+
+func (m *MockApiKeyRepository) Get(ctx context.Context, id string) (*apikey.ApiKeyEntity, error) {
+	args := m.Called(ctx, id)
+
+	var k *apikey.ApiKeyEntity
+	if v := args.Get(0); v != nil {
+		k = v.(*apikey.ApiKeyEntity)
+	}
+
+	return k, args.Error(1)
+}
+
+func (m *MockApiKeyRepository) GetByHash(ctx context.Context, hash string) (*apikey.ApiKeyEntity, error) {
+	args := m.Called(ctx, hash)
+
+	var k *apikey.ApiKeyEntity
+	if v := args.Get(0); v != nil {
+		k = v.(*apikey.ApiKeyEntity)
+	}
+
+	return k, args.Error(1)
+}
+
+func (m *MockApiKeyRepository) List(ctx context.Context) ([]apikey.ApiKeyEntity, error) {
+	args := m.Called(ctx)
+
+	var ks []apikey.ApiKeyEntity
+	if v := args.Get(0); v != nil {
+		ks = v.([]apikey.ApiKeyEntity)
+	}
+
+	return ks, args.Error(1)
+}
+
+func (m *MockApiKeyRepository) Save(ctx context.Context, k *apikey.ApiKeyEntity) error {
+	args := m.Called(ctx, k)
+	return args.Error(0)
+}
+
+func (m *MockApiKeyRepository) IncrementUsage(ctx context.Context, id string, day string) (int64, error) {
+	args := m.Called(ctx, id, day)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+var apiKeyNow = time.Date(2026, time.October, 19, 15, 30, 0, 0, time.UTC)
+
+func newApiKeyService(repo *MockApiKeyRepository) *apikeyapp.ApiKeyService {
+	return apikeyapp.NewApiKeyServiceWithClock(repo, func() time.Time { return apiKeyNow })
+}
+
+func TestApiKeyService_Create_StoresOnlyTheHash(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockApiKeyRepository)
+
+	var saved *apikey.ApiKeyEntity
+	repo.On("Save", ctx, mock.Anything).Run(func(args mock.Arguments) {
+		saved = args.Get(1).(*apikey.ApiKeyEntity)
+	}).Return(nil)
+
+	issued, err := newApiKeyService(repo).Create(ctx, apikey.NewApiKey{
+		Name:       "frontend",
+		Scopes:     []string{apikey.ScopeRead, apikey.ScopeRead},
+		DailyQuota: 1000,
+	})
+
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(issued.Key, "dbz_"))
+	assert.Equal(t, issued.Key[:12], issued.Prefix)
+	assert.Equal(t, []string{apikey.ScopeRead}, issued.Scopes)
+	assert.Equal(t, int64(1000), issued.DailyQuota)
+	assert.Equal(t, apiKeyNow, issued.CreatedAt)
+
+	require.NotNil(t, saved)
+	assert.Equal(t, apikeyapp.HashKey(issued.Key), saved.Hash)
+	assert.NotContains(t, fmt.Sprintf("%+v", *saved), issued.Key)
+}
+
+func TestApiKeyService_Authenticate(t *testing.T) {
+	ctx := context.Background()
+	stored := &apikey.ApiKeyEntity{Id: "k1", Name: "frontend", Scopes: []string{apikey.ScopeRead}, DailyQuota: 2}
+
+	tests := []struct {
+		name    string
+		setup   func(repo *MockApiKeyRepository)
+		wantErr error
+	}{
+		{
+			name: "within quota",
+			setup: func(repo *MockApiKeyRepository) {
+				repo.On("GetByHash", ctx, apikeyapp.HashKey("secret")).Return(stored, nil)
+				repo.On("IncrementUsage", ctx, "k1", "2026-10-19").Return(int64(2), nil)
+			},
+		},
+		{
+			name: "unknown key",
+			setup: func(repo *MockApiKeyRepository) {
+				repo.On("GetByHash", ctx, mock.Anything).Return(nil, fmt.Errorf("api key %w", apikey.ErrNotFound))
+			},
+			wantErr: apikey.ErrInvalidKey,
+		},
+		{
+			name: "revoked key",
+			setup: func(repo *MockApiKeyRepository) {
+				revoked := *stored
+				revoked.RevokedAt = &apiKeyNow
+				repo.On("GetByHash", ctx, mock.Anything).Return(&revoked, nil)
+			},
+			wantErr: apikey.ErrInvalidKey,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockApiKeyRepository)
+			tt.setup(repo)
+
+			p, err := newApiKeyService(repo).Authenticate(ctx, "secret", apikey.ScopeRead)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, p)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, &apikey.Principal{KeyId: "k1", Name: "frontend", Scopes: []string{apikey.ScopeRead}}, p)
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestApiKeyService_Authenticate_QuotaExceeded(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockApiKeyRepository)
+	repo.On("GetByHash", ctx, mock.Anything).Return(&apikey.ApiKeyEntity{Id: "k1", Scopes: []string{apikey.ScopeRead}, DailyQuota: 2}, nil)
+	repo.On("IncrementUsage", ctx, "k1", "2026-10-19").Return(int64(3), nil)
+
+	_, err := newApiKeyService(repo).Authenticate(ctx, "secret", apikey.ScopeRead)
+
+	var exceeded *apikey.QuotaExceededError
+	require.ErrorAs(t, err, &exceeded)
+	assert.Equal(t, int64(2), exceeded.Quota)
+	assert.Equal(t, time.Date(2026, time.October, 20, 0, 0, 0, 0, time.UTC), exceeded.Reset)
+}
+
+func TestApiKeyService_Authenticate_UnlimitedSkipsUsage(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockApiKeyRepository)
+	repo.On("GetByHash", ctx, mock.Anything).Return(&apikey.ApiKeyEntity{Id: "k1", Scopes: []string{apikey.ScopeAdmin}}, nil)
+
+	p, err := newApiKeyService(repo).Authenticate(ctx, "secret", apikey.ScopeRead)
+
+	require.NoError(t, err)
+	assert.True(t, p.HasScope(apikey.ScopeRead))
+	repo.AssertNotCalled(t, "IncrementUsage", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestApiKeyService_Authenticate_MissingScopeSpendsNoQuota(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockApiKeyRepository)
+	repo.On("GetByHash", ctx, mock.Anything).Return(&apikey.ApiKeyEntity{Id: "k1", Scopes: []string{apikey.ScopeRead}, DailyQuota: 2}, nil)
+
+	_, err := newApiKeyService(repo).Authenticate(ctx, "secret", apikey.ScopeAdmin)
+
+	assert.ErrorIs(t, err, apikey.ErrScopeMissing)
+	repo.AssertNotCalled(t, "IncrementUsage", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestApiKeyService_Authenticate_StoreError(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockApiKeyRepository)
+	repo.On("GetByHash", ctx, mock.Anything).Return(nil, errors.New("db down"))
+
+	_, err := newApiKeyService(repo).Authenticate(ctx, "secret", apikey.ScopeRead)
+
+	assert.EqualError(t, err, "db down")
+}
+
+func TestApiKeyService_Rotate(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockApiKeyRepository)
+	stored := &apikey.ApiKeyEntity{Id: "k1", Hash: "old", Scopes: []string{apikey.ScopeRead}, DailyQuota: 10}
+	repo.On("Get", ctx, "k1").Return(stored, nil)
+	repo.On("Save", ctx, stored).Return(nil)
+
+	issued, err := newApiKeyService(repo).Rotate(ctx, "k1")
+
+	require.NoError(t, err)
+	assert.Equal(t, "k1", issued.Id)
+	assert.Equal(t, apikeyapp.HashKey(issued.Key), stored.Hash)
+	assert.Equal(t, &apiKeyNow, issued.RotatedAt)
+	assert.Equal(t, int64(10), issued.DailyQuota)
+}
+
+func TestApiKeyService_Rotate_Revoked(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockApiKeyRepository)
+	repo.On("Get", ctx, "k1").Return(&apikey.ApiKeyEntity{Id: "k1", RevokedAt: &apiKeyNow}, nil)
+
+	_, err := newApiKeyService(repo).Rotate(ctx, "k1")
+
+	assert.ErrorIs(t, err, apikey.ErrRevoked)
+	repo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestApiKeyService_Revoke_IsIdempotent(t *testing.T) {
+	ctx := context.Background()
+	earlier := apiKeyNow.Add(-time.Hour)
+	repo := new(MockApiKeyRepository)
+	repo.On("Get", ctx, "k1").Return(&apikey.ApiKeyEntity{Id: "k1", RevokedAt: &earlier}, nil)
+
+	dto, err := newApiKeyService(repo).Revoke(ctx, "k1")
+
+	require.NoError(t, err)
+	assert.Equal(t, &earlier, dto.RevokedAt)
+	repo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestApiKeyService_EnsureKey(t *testing.T) {
+	ctx := context.Background()
+	hash := apikeyapp.HashKey("bootstrap-secret")
+
+	t.Run("stores an unknown key", func(t *testing.T) {
+		repo := new(MockApiKeyRepository)
+		repo.On("GetByHash", ctx, hash).Return(nil, apikey.ErrNotFound)
+		repo.On("Save", ctx, mock.MatchedBy(func(k *apikey.ApiKeyEntity) bool {
+			return k.Hash == hash && k.Prefix == "bootstrap-se" && k.Scopes[0] == apikey.ScopeAdmin
+		})).Return(nil)
+
+		require.NoError(t, newApiKeyService(repo).EnsureKey(ctx, "bootstrap-secret", "admin", []string{apikey.ScopeAdmin}))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("keeps a known key", func(t *testing.T) {
+		repo := new(MockApiKeyRepository)
+		repo.On("GetByHash", ctx, hash).Return(&apikey.ApiKeyEntity{Id: "k1"}, nil)
+
+		require.NoError(t, newApiKeyService(repo).EnsureKey(ctx, "bootstrap-secret", "admin", []string{apikey.ScopeAdmin}))
+		repo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,58 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	app "github.com/heaveless/dbz-api/internal/application/audit"
+	domain "github.com/heaveless/dbz-api/internal/domain/audit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAuditRepository struct {
+	mock.Mock
+}
+
+func (m *MockAuditRepository) Record(ctx context.Context, e domain.Entry) error {
+	args := m.Called(ctx, e)
+	return args.Error(0)
+}
+
+func (m *MockAuditRepository) Find(ctx context.Context, q domain.Query) ([]domain.Entry, int64, error) {
+	args := m.Called(ctx, q)
+
+	var entries []domain.Entry
+	if v := args.Get(0); v != nil {
+		entries = v.([]domain.Entry)
+	}
+
+	return entries, args.Get(1).(int64), args.Error(2)
+}
+
+func TestAuditService_Query(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockAuditRepository)
+	q := domain.Query{Target: "character:2", Field: "affiliation", Page: 2, Limit: 1}
+	entries := []domain.Entry{{Id: "e1", Action: domain.CharacterUpdated, Target: "character:2"}}
+
+	repo.On("Find", ctx, q).Return(entries, int64(3), nil)
+
+	page, err := app.NewAuditService(repo).Query(ctx, q)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.EntryPageDTO{Items: entries, Page: 2, Limit: 1, Total: 3}, page)
+}
+
+func TestAuditService_Query_Error(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockAuditRepository)
+
+	repo.On("Find", ctx, mock.Anything).Return(nil, int64(0), errors.New("db down"))
+
+	page, err := app.NewAuditService(repo).Query(ctx, domain.Query{Page: 1, Limit: 20})
+
+	assert.Nil(t, page)
+	assert.EqualError(t, err, "db down")
+}
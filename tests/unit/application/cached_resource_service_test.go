@@ -0,0 +1,308 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/heaveless/dbz-api/internal/application"
+	"github.com/heaveless/dbz-api/internal/domain/audit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type widget struct {
+	Id   string
+	Name string
+}
+
+type widgetDTO struct {
+	Label string
+}
+
+type MockWidgetStore struct {
+	mock.Mock
+}
+
+func (m *MockWidgetStore) Get(ctx context.Context, key string) (*widget, error) {
+	args := m.Called(ctx, key)
+
+	var w *widget
+	if v := args.Get(0); v != nil {
+		w = v.(*widget)
+	}
+
+	return w, args.Error(1)
+}
+
+func (m *MockWidgetStore) Create(ctx context.Context, w *widget) error {
+	args := m.Called(ctx, w)
+	return args.Error(0)
+}
+
+type widgetMapper struct{}
+
+func (widgetMapper) ToDTO(w *widget) *widgetDTO {
+	return &widgetDTO{Label: w.Id + ":" + w.Name}
+}
+
+type recordingMetrics struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (r *recordingMetrics) record(event, resource string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, resource+"."+event)
+}
+
+func (r *recordingMetrics) Hit(resource string)       { r.record("hit", resource) }
+func (r *recordingMetrics) Miss(resource string)      { r.record("miss", resource) }
+func (r *recordingMetrics) Failure(resource string)   { r.record("failure", resource) }
+func (r *recordingMetrics) SaveError(resource string) { r.record("save_error", resource) }
+
+func (r *recordingMetrics) Events() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.events...)
+}
+
+func newWidgetService(
+	repo, upstream *MockWidgetStore,
+	opts application.CacheOptions[string, widget],
+) *application.CachedResourceService[string, widget, widgetDTO] {
+	return application.NewCachedResourceService[string, widget, widgetDTO](repo, upstream, widgetMapper{}, opts)
+}
+
+func TestCachedResourceService_Hit(t *testing.T) {
+	repo, upstream := new(MockWidgetStore), new(MockWidgetStore)
+	metrics := &recordingMetrics{}
+	svc := newWidgetService(repo, upstream, application.CacheOptions[string, widget]{Name: "widget", Metrics: metrics})
+
+	repo.On("Get", mock.Anything, "a").Return(&widget{Id: "a", Name: "Anvil"}, nil)
+
+	dto, err := svc.Get(context.Background(), "a")
+
+	require.NoError(t, err)
+	assert.Equal(t, &widgetDTO{Label: "a:Anvil"}, dto)
+	assert.Equal(t, []string{"widget.hit"}, metrics.Events())
+	upstream.AssertNotCalled(t, "Get", mock.Anything, mock.Anything)
+	time.Sleep(20 * time.Millisecond)
+	repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestCachedResourceService_MissSavesInBackground(t *testing.T) {
+	repo, upstream := new(MockWidgetStore), new(MockWidgetStore)
+	metrics := &recordingMetrics{}
+	saved := make(chan *widget, 1)
+	svc := newWidgetService(repo, upstream, application.CacheOptions[string, widget]{
+		Name:      "widget",
+		Metrics:   metrics,
+		AfterSave: func(w *widget) { saved <- w },
+	})
+
+	fromUpstream := &widget{Id: "b", Name: "Bolt"}
+	repo.On("Get", mock.Anything, "b").Return(nil, errors.New("not found"))
+	upstream.On("Get", mock.Anything, "b").Return(fromUpstream, nil)
+	repo.On("Create", mock.Anything, fromUpstream).Return(nil)
+
+	dto, err := svc.Get(context.Background(), "b")
+
+	require.NoError(t, err)
+	assert.Equal(t, "b:Bolt", dto.Label)
+
+	select {
+	case w := <-saved:
+		assert.Same(t, fromUpstream, w)
+	case <-time.After(time.Second):
+		t.Fatal("upstream result was not saved")
+	}
+	assert.Equal(t, []string{"widget.miss"}, metrics.Events())
+}
+
+func TestCachedResourceService_WriteBehindAlwaysSavesHits(t *testing.T) {
+	repo, upstream := new(MockWidgetStore), new(MockWidgetStore)
+	saved := make(chan struct{}, 1)
+	svc := newWidgetService(repo, upstream, application.CacheOptions[string, widget]{
+		WriteBehind: application.WriteBehindAlways,
+		AfterSave:   func(*widget) { saved <- struct{}{} },
+	})
+
+	stored := &widget{Id: "c", Name: "Cog"}
+	repo.On("Get", mock.Anything, "c").Return(stored, nil)
+	repo.On("Create", mock.Anything, stored).Return(nil)
+
+	_, err := svc.Get(context.Background(), "c")
+
+	require.NoError(t, err)
+	select {
+	case <-saved:
+	case <-time.After(time.Second):
+		t.Fatal("hit was not re-submitted")
+	}
+}
+
+func TestCachedResourceService_SaveKeepsRequestValues(t *testing.T) {
+	repo, upstream := new(MockWidgetStore), new(MockWidgetStore)
+	saved := make(chan struct{}, 1)
+	svc := newWidgetService(repo, upstream, application.CacheOptions[string, widget]{
+		AfterSave: func(*widget) { saved <- struct{}{} },
+	})
+
+	var requestId string
+	repo.On("Get", mock.Anything, "e").Return(nil, errors.New("not found"))
+	upstream.On("Get", mock.Anything, "e").Return(&widget{Id: "e"}, nil)
+	repo.
+		On("Create", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			ctx := args.Get(0).(context.Context)
+			requestId = audit.RequestIdFrom(ctx)
+			assert.NoError(t, ctx.Err(), "the save outlives the request")
+		}).
+		Return(nil)
+
+	ctx, cancel := context.WithCancel(audit.WithRequestId(context.Background(), "req-1"))
+	_, err := svc.Get(ctx, "e")
+	cancel()
+
+	require.NoError(t, err)
+	select {
+	case <-saved:
+	case <-time.After(time.Second):
+		t.Fatal("upstream result was not saved")
+	}
+	assert.Equal(t, "req-1", requestId)
+}
+
+func TestCachedResourceService_SaveErrorIsCounted(t *testing.T) {
+	repo, upstream := new(MockWidgetStore), new(MockWidgetStore)
+	metrics := &recordingMetrics{}
+	svc := newWidgetService(repo, upstream, application.CacheOptions[string, widget]{Name: "widget", Metrics: metrics})
+
+	repo.On("Get", mock.Anything, "d").Return(nil, errors.New("db down"))
+	upstream.On("Get", mock.Anything, "d").Return(&widget{Id: "d"}, nil)
+	repo.On("Create", mock.Anything, mock.Anything).Return(errors.New("db down"))
+
+	_, err := svc.Get(context.Background(), "d")
+
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return len(metrics.Events()) == 2
+	}, time.Second, 5*time.Millisecond)
+	assert.Equal(t, []string{"widget.miss", "widget.save_error"}, metrics.Events())
+}
+
+func TestCachedResourceService_Failure(t *testing.T) {
+	repo, upstream := new(MockWidgetStore), new(MockWidgetStore)
+	metrics := &recordingMetrics{}
+	svc := newWidgetService(repo, upstream, application.CacheOptions[string, widget]{Name: "widget", Metrics: metrics})
+
+	repo.On("Get", mock.Anything, "e").Return(nil, errors.New("db down"))
+	upstream.On("Get", mock.Anything, "e").Return(nil, errors.New("api down"))
+
+	dto, err := svc.Get(context.Background(), "e")
+
+	assert.Nil(t, dto)
+	assert.EqualError(t, err, "api down")
+	assert.Equal(t, []string{"widget.failure"}, metrics.Events())
+	repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestCachedResourceService_SharesLookupsByKey(t *testing.T) {
+	repo, upstream := new(MockWidgetStore), new(MockWidgetStore)
+	svc := newWidgetService(repo, upstream, application.CacheOptions[string, widget]{
+		Key: strings.ToLower,
+	})
+
+	release := make(chan struct{})
+	repo.
+		On("Get", mock.Anything, mock.Anything).
+		Run(func(mock.Arguments) { <-release }).
+		Return(&widget{Id: "f", Name: "Flange"}, nil).
+		Once()
+
+	var wg sync.WaitGroup
+	for _, key := range []string{"F", "f", "F"} {
+		wg.Add(1)
+		go func(key string) {
+			defer wg.Done()
+			dto, err := svc.Get(context.Background(), key)
+			assert.NoError(t, err)
+			assert.Equal(t, "f:Flange", dto.Label)
+		}(key)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	repo.AssertNumberOfCalls(t, "Get", 1)
+}
+
+func TestCachedResourceService_SharedLookupOutlivesItsFirstCaller(t *testing.T) {
+	repo, upstream := new(MockWidgetStore), new(MockWidgetStore)
+	svc := newWidgetService(repo, upstream, application.CacheOptions[string, widget]{})
+
+	release := make(chan struct{})
+	repo.
+		On("Get", mock.Anything, "g").
+		Run(func(args mock.Arguments) {
+			<-release
+			assert.NoError(t, args.Get(0).(context.Context).Err())
+		}).
+		Return(&widget{Id: "g", Name: "Gasket"}, nil).
+		Once()
+
+	first, cancel := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := svc.Get(first, "g")
+		firstErr <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	second := make(chan *widgetDTO, 1)
+	go func() {
+		dto, err := svc.Get(context.Background(), "g")
+		assert.NoError(t, err)
+		second <- dto
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	cancel()
+	assert.ErrorIs(t, <-firstErr, context.Canceled)
+
+	close(release)
+	assert.Equal(t, "g:Gasket", (<-second).Label)
+	repo.AssertNumberOfCalls(t, "Get", 1)
+}
+
+func TestCachedResourceService_UpstreamBudget(t *testing.T) {
+	repo, upstream := new(MockWidgetStore), new(MockWidgetStore)
+	svc := newWidgetService(repo, upstream, application.CacheOptions[string, widget]{})
+
+	spent := 0
+	ctx := application.WithUpstreamBudget(context.Background(), func(context.Context) error {
+		spent++
+		return &application.BudgetExceededError{RetryAfter: time.Second}
+	})
+
+	repo.On("Get", mock.Anything, "hit").Return(&widget{Id: "hit"}, nil)
+	repo.On("Get", mock.Anything, "miss").Return(nil, errors.New("not found"))
+
+	_, err := svc.Get(ctx, "hit")
+	require.NoError(t, err)
+	assert.Equal(t, 0, spent)
+
+	_, err = svc.Get(ctx, "miss")
+	var exceeded *application.BudgetExceededError
+	require.ErrorAs(t, err, &exceeded)
+	assert.Equal(t, time.Second, exceeded.RetryAfter)
+	assert.Equal(t, 1, spent)
+	upstream.AssertNotCalled(t, "Get", mock.Anything, mock.Anything)
+}
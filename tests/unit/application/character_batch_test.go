@@ -0,0 +1,140 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	app "github.com/heaveless/dbz-api/internal/application/character"
+	domain "github.com/heaveless/dbz-api/internal/domain/character"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCharacterService_GetById_FallbackToApi(t *testing.T) {
+	repo := new(MockCharacterRepository)
+	api := new(MockCharacterApi)
+	svc := app.NewCharacterService(repo, api)
+
+	entity := &domain.CharacterEntity{Id: 3, Name: "Vegeta"}
+	saved := make(chan struct{})
+
+	repo.On("GetById", mock.Anything, int64(3)).Return(nil, errors.New("no documents"))
+	api.On("GetById", mock.Anything, int64(3)).Return(entity, nil)
+	repo.On("Create", mock.Anything, entity).Run(func(mock.Arguments) { close(saved) }).Return(nil)
+
+	dto, err := svc.GetById(context.Background(), 3)
+
+	require.NoError(t, err)
+	assert.Equal(t, "Vegeta", dto.Name)
+
+	select {
+	case <-saved:
+	case <-time.After(time.Second):
+		t.Fatal("character was not saved")
+	}
+}
+
+func TestCharacterService_GetBatch_KeepsOrderAndPartialFailures(t *testing.T) {
+	repo := new(MockCharacterRepository)
+	api := new(MockCharacterApi)
+	svc := app.NewCharacterService(repo, api)
+
+	notFound := fmt.Errorf("character %w", domain.ErrNotFound)
+
+	repo.On("Get", mock.Anything, "Goku").Return(&domain.CharacterEntity{Id: 1, Name: "Goku"}, nil)
+	repo.On("Get", mock.Anything, "Nobody").Return(nil, errors.New("no documents"))
+	api.On("Get", mock.Anything, "Nobody").Return(nil, notFound)
+	repo.On("GetById", mock.Anything, int64(3)).Return(&domain.CharacterEntity{Id: 3, Name: "Vegeta"}, nil)
+	repo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	results := svc.GetBatch(context.Background(), []domain.CharacterRef{
+		{Name: "Goku"},
+		{Name: "Nobody"},
+		{Id: 3},
+	})
+
+	require.Len(t, results, 3)
+	assert.Equal(t, "Goku", results[0].Character.Name)
+	assert.NoError(t, results[0].Err)
+	assert.Equal(t, domain.CharacterRef{Name: "Nobody"}, results[1].Ref)
+	assert.Nil(t, results[1].Character)
+	assert.ErrorIs(t, results[1].Err, domain.ErrNotFound)
+	assert.Equal(t, "Vegeta", results[2].Character.Name)
+}
+
+func TestCharacterService_GetBatch_BoundsParallelism(t *testing.T) {
+	repo := new(MockCharacterRepository)
+	api := new(MockCharacterApi)
+	svc := app.NewCharacterService(repo, api)
+
+	var running, peak atomic.Int32
+	repo.
+		On("GetById", mock.Anything, mock.Anything).
+		Run(func(mock.Arguments) {
+			n := running.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			running.Add(-1)
+		}).
+		Return(&domain.CharacterEntity{Id: 1, Name: "Goku"}, nil)
+	repo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	refs := make([]domain.CharacterRef, 20)
+	for i := range refs {
+		refs[i] = domain.CharacterRef{Id: int64(i + 1)}
+	}
+
+	results := svc.GetBatch(context.Background(), refs)
+
+	assert.Len(t, results, 20)
+	assert.LessOrEqual(t, peak.Load(), int32(4))
+	assert.Greater(t, peak.Load(), int32(1))
+}
+
+func TestCharacterService_GetByIds_OneReadThenFallbackForMissing(t *testing.T) {
+	repo := new(MockCharacterRepository)
+	api := new(MockCharacterApi)
+	svc := app.NewCharacterService(repo, api)
+
+	repo.On("GetByIds", mock.Anything, []int64{1, 3, 404}).Return([]domain.CharacterEntity{
+		{Id: 3, Name: "Vegeta"},
+		{Id: 1, Name: "Goku"},
+	}, nil)
+	repo.On("GetById", mock.Anything, int64(404)).Return(nil, errors.New("no documents"))
+	api.On("GetById", mock.Anything, int64(404)).Return(nil, fmt.Errorf("character %w", domain.ErrNotFound))
+
+	results := svc.GetByIds(context.Background(), []int64{1, 3, 404})
+
+	require.Len(t, results, 3)
+	assert.Equal(t, "Goku", results[0].Character.Name)
+	assert.Equal(t, "Vegeta", results[1].Character.Name)
+	assert.Equal(t, domain.CharacterRef{Id: 404}, results[2].Ref)
+	assert.ErrorIs(t, results[2].Err, domain.ErrNotFound)
+	repo.AssertNotCalled(t, "GetById", mock.Anything, int64(1))
+	repo.AssertNotCalled(t, "GetById", mock.Anything, int64(3))
+}
+
+func TestCharacterService_GetByIds_StoreDownFallsBackPerId(t *testing.T) {
+	repo := new(MockCharacterRepository)
+	api := new(MockCharacterApi)
+	svc := app.NewCharacterService(repo, api)
+
+	repo.On("GetByIds", mock.Anything, []int64{1}).Return(nil, errors.New("db down"))
+	repo.On("GetById", mock.Anything, int64(1)).Return(&domain.CharacterEntity{Id: 1, Name: "Goku"}, nil)
+	repo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	results := svc.GetByIds(context.Background(), []int64{1})
+
+	require.NoError(t, results[0].Err)
+	assert.Equal(t, "Goku", results[0].Character.Name)
+}
@@ -0,0 +1,82 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	app "github.com/heaveless/dbz-api/internal/application/character"
+	domain "github.com/heaveless/dbz-api/internal/domain/character"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func names(page *domain.CharacterPageDTO) []string {
+	out := make([]string, 0, len(page.Items))
+	for _, c := range page.Items {
+		out = append(out, c.Name)
+	}
+	return out
+}
+
+func mustLevel(t *testing.T, raw string) *domain.PowerLevel {
+	t.Helper()
+
+	level, err := domain.ParsePowerLevel(raw)
+	require.NoError(t, err)
+	return &level
+}
+
+func TestCharacterService_List_PagesInTheStore(t *testing.T) {
+	repo := new(MockCharacterRepository)
+	svc := app.NewCharacterService(repo, new(MockCharacterApi))
+
+	q := domain.ListQuery{
+		MinKi: mustLevel(t, "1 Million"),
+		Sort:  domain.SortOrder{Field: "maxKi", Desc: true},
+		Page:  2,
+		Limit: 2,
+	}
+	repo.
+		On("ListPage", mock.Anything, q).
+		Return([]domain.CharacterEntity{
+			{Id: 2, Name: "Vegeta", Ki: "54.000.000"},
+			{Id: 3, Name: "Piccolo", Ki: "2.000.000"},
+		}, int64(5), nil)
+
+	page, err := svc.List(context.Background(), q)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Vegeta", "Piccolo"}, names(page))
+	assert.Equal(t, int64(5), page.Total)
+	assert.Equal(t, 2, page.Page)
+	assert.Equal(t, 2, page.Limit)
+	repo.AssertNotCalled(t, "List", mock.Anything)
+}
+
+func TestCharacterService_List_EmptyPage(t *testing.T) {
+	repo := new(MockCharacterRepository)
+	svc := app.NewCharacterService(repo, new(MockCharacterApi))
+
+	repo.On("ListPage", mock.Anything, mock.Anything).Return(nil, int64(5), nil)
+
+	page, err := svc.List(context.Background(), domain.ListQuery{Page: 9, Limit: 2})
+
+	require.NoError(t, err)
+	assert.NotNil(t, page.Items)
+	assert.Empty(t, page.Items)
+	assert.Equal(t, int64(5), page.Total)
+}
+
+func TestCharacterService_List_RepoError(t *testing.T) {
+	repo := new(MockCharacterRepository)
+	svc := app.NewCharacterService(repo, new(MockCharacterApi))
+
+	repo.On("ListPage", mock.Anything, mock.Anything).Return(nil, int64(0), errors.New("db error"))
+
+	page, err := svc.List(context.Background(), domain.ListQuery{Page: 1, Limit: 10})
+
+	assert.Nil(t, page)
+	assert.EqualError(t, err, "db error")
+}
@@ -3,6 +3,7 @@ package application_test
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -27,6 +28,61 @@ func (m *MockCharacterRepository) Get(ctx context.Context, name string) (*domain
 	return chr, args.Error(1)
 }
 
+func (m *MockCharacterRepository) GetById(ctx context.Context, id int64) (*domain.CharacterEntity, error) {
+	args := m.Called(ctx, id)
+
+	var chr *domain.CharacterEntity
+	if v := args.Get(0); v != nil {
+		chr = v.(*domain.CharacterEntity)
+	}
+
+	return chr, args.Error(1)
+}
+
+func (m *MockCharacterRepository) List(ctx context.Context) ([]domain.CharacterEntity, error) {
+	args := m.Called(ctx)
+
+	var records []domain.CharacterEntity
+	if v := args.Get(0); v != nil {
+		records = v.([]domain.CharacterEntity)
+	}
+
+	return records, args.Error(1)
+}
+
+func (m *MockCharacterRepository) ListPage(ctx context.Context, q domain.ListQuery) ([]domain.CharacterEntity, int64, error) {
+	args := m.Called(ctx, q)
+
+	var records []domain.CharacterEntity
+	if v := args.Get(0); v != nil {
+		records = v.([]domain.CharacterEntity)
+	}
+
+	return records, args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockCharacterRepository) GetByIds(ctx context.Context, ids []int64) ([]domain.CharacterEntity, error) {
+	args := m.Called(ctx, ids)
+
+	var records []domain.CharacterEntity
+	if v := args.Get(0); v != nil {
+		records = v.([]domain.CharacterEntity)
+	}
+
+	return records, args.Error(1)
+}
+
+func (m *MockCharacterRepository) TextSearch(ctx context.Context, q domain.TextSearchQuery) ([]domain.TextMatch, int64, error) {
+	args := m.Called(ctx, q)
+
+	var matches []domain.TextMatch
+	if v := args.Get(0); v != nil {
+		matches = v.([]domain.TextMatch)
+	}
+
+	return matches, args.Get(1).(int64), args.Error(2)
+}
+
 func (m *MockCharacterRepository) Create(ctx context.Context, c *domain.CharacterEntity) error {
 	args := m.Called(ctx, c)
 	return args.Error(0)
@@ -47,6 +103,17 @@ func (m *MockCharacterApi) Get(ctx context.Context, name string) (*domain.Charac
 	return chr, args.Error(1)
 }
 
+func (m *MockCharacterApi) GetById(ctx context.Context, id int64) (*domain.CharacterEntity, error) {
+	args := m.Called(ctx, id)
+
+	var chr *domain.CharacterEntity
+	if v := args.Get(0); v != nil {
+		chr = v.(*domain.CharacterEntity)
+	}
+
+	return chr, args.Error(1)
+}
+
 func TestCharacterService_GetByName_FromRepo(t *testing.T) {
 	ctx := context.Background()
 	repo := new(MockCharacterRepository)
@@ -69,10 +136,6 @@ func TestCharacterService_GetByName_FromRepo(t *testing.T) {
 		On("Get", mock.Anything, "Goku").
 		Return(entity, nil)
 
-	repo.
-		On("Create", mock.Anything, entity).
-		Return(nil)
-
 	api.AssertNotCalled(t, "Get", mock.Anything, mock.Anything)
 
 	dto, err := svc.GetByName(ctx, "Goku")
@@ -89,7 +152,8 @@ func TestCharacterService_GetByName_FromRepo(t *testing.T) {
 
 	time.Sleep(10 * time.Millisecond)
 
-	repo.AssertCalled(t, "Create", mock.Anything, entity)
+	// A stored character is not written back.
+	repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
 	repo.AssertExpectations(t)
 }
 
@@ -160,3 +224,171 @@ func TestCharacterService_GetByName_ErrorWhenRepoAndApiFail(t *testing.T) {
 	repo.AssertExpectations(t)
 	api.AssertExpectations(t)
 }
+
+func TestCharacterService_GetByName_SharesConcurrentLookups(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockCharacterRepository)
+	api := new(MockCharacterApi)
+
+	svc := app.NewCharacterService(repo, api)
+
+	entity := &domain.CharacterEntity{Id: 1, Name: "Goku"}
+	release := make(chan struct{})
+
+	repo.
+		On("Get", mock.Anything, mock.Anything).
+		Run(func(mock.Arguments) { <-release }).
+		Return(entity, nil).
+		Once()
+
+	repo.
+		On("Create", mock.Anything, entity).
+		Return(nil)
+
+	names := []string{"Goku", "goku", " GOKU "}
+	results := make(chan *domain.CharacterDTO, len(names))
+
+	for _, name := range names {
+		go func(name string) {
+			dto, err := svc.GetByName(ctx, name)
+			assert.NoError(t, err)
+			results <- dto
+		}(name)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+
+	for range names {
+		dto := <-results
+		assert.Equal(t, entity.Id, dto.Id)
+	}
+
+	repo.AssertNumberOfCalls(t, "Get", 1)
+	api.AssertNotCalled(t, "Get", mock.Anything, mock.Anything)
+}
+
+func TestCharacterService_Search_BuildsIndexFromRepo(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockCharacterRepository)
+	api := new(MockCharacterApi)
+
+	svc := app.NewCharacterService(repo, api)
+
+	repo.
+		On("List", mock.Anything).
+		Return([]domain.CharacterEntity{{Id: 2, Name: "Vegeta"}, {Id: 5, Name: "Freezer"}}, nil).
+		Once()
+
+	matches, err := svc.Search(ctx, "Vejeta", 5)
+	assert.NoError(t, err)
+	assert.Equal(t, "Vegeta", matches[0].Name)
+
+	_, err = svc.Search(ctx, "Frezza", 5)
+	assert.NoError(t, err)
+
+	repo.AssertNumberOfCalls(t, "List", 1)
+	api.AssertNotCalled(t, "Get", mock.Anything, mock.Anything)
+}
+
+func TestCharacterService_Search_RepoError(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockCharacterRepository)
+	api := new(MockCharacterApi)
+
+	svc := app.NewCharacterService(repo, api)
+
+	repo.
+		On("List", mock.Anything).
+		Return(nil, errors.New("db error"))
+
+	matches, err := svc.Search(ctx, "Goku", 5)
+	assert.Nil(t, matches)
+	assert.EqualError(t, err, "db error")
+}
+
+func TestCharacterService_SearchText_PaginatesAndHighlights(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockCharacterRepository)
+	api := new(MockCharacterApi)
+
+	svc := app.NewCharacterService(repo, api)
+
+	goku := domain.CharacterEntity{
+		Id:          1,
+		Name:        "Goku",
+		Race:        "Saiyan",
+		Affiliation: "Z Fighter",
+		Description: "El protagonista de la serie, conocido por su gran poder & su personalidad amigable.",
+	}
+
+	repo.
+		On("TextSearch", mock.Anything, domain.TextSearchQuery{Text: "saiyan poder", Offset: 10, Limit: 10}).
+		Return([]domain.TextMatch{{Character: goku, Score: 4.5}}, int64(11), nil)
+
+	res, err := svc.SearchText(ctx, "saiyan poder", 2, 10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, res.Page)
+	assert.Equal(t, 10, res.Limit)
+	assert.Equal(t, int64(11), res.Total)
+	assert.Len(t, res.Items, 1)
+
+	item := res.Items[0]
+	assert.Equal(t, "Goku", item.Character.Name)
+	assert.Equal(t, goku.Description, item.Character.Description)
+	assert.Equal(t, 4.5, item.Score)
+	assert.Equal(t, "<em>Saiyan</em>", item.Highlights["race"])
+	assert.Equal(t,
+		"El protagonista de la serie, conocido por su gran <em>poder</em> &amp; su personalidad amigable.",
+		item.Highlights["description"],
+	)
+	assert.NotContains(t, item.Highlights, "name")
+	assert.NotContains(t, item.Highlights, "affiliation")
+}
+
+func TestCharacterService_SearchText_CutsLongDescriptions(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockCharacterRepository)
+	api := new(MockCharacterApi)
+
+	svc := app.NewCharacterService(repo, api)
+
+	words := make([]string, 0, 100)
+	for i := 0; i < 100; i++ {
+		words = append(words, "palabra")
+	}
+	words[60] = "Namekiano"
+
+	entity := domain.CharacterEntity{Id: 3, Name: "Piccolo", Description: strings.Join(words, " ")}
+
+	repo.
+		On("TextSearch", mock.Anything, mock.Anything).
+		Return([]domain.TextMatch{{Character: entity, Score: 1}}, int64(1), nil)
+
+	res, err := svc.SearchText(ctx, "namekiano -goku", 1, 10)
+
+	assert.NoError(t, err)
+	snippet := res.Items[0].Highlights["description"]
+	assert.True(t, strings.HasPrefix(snippet, "…"))
+	assert.True(t, strings.HasSuffix(snippet, "…"))
+	assert.Contains(t, snippet, "<em>Namekiano</em>")
+	assert.Less(t, len(snippet), len(entity.Description))
+}
+
+func TestCharacterService_SearchText_RepoError(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockCharacterRepository)
+	api := new(MockCharacterApi)
+
+	svc := app.NewCharacterService(repo, api)
+
+	repo.
+		On("TextSearch", mock.Anything, mock.Anything).
+		Return(nil, int64(0), errors.New("db error"))
+
+	res, err := svc.SearchText(ctx, "goku", 1, 10)
+
+	assert.Nil(t, res)
+	assert.EqualError(t, err, "db error")
+}
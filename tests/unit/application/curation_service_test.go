@@ -0,0 +1,152 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	app "github.com/heaveless/dbz-api/internal/application/character"
+	domain "github.com/heaveless/dbz-api/internal/domain/character"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockCurationRepository struct {
+	mock.Mock
+}
+
+func (m *MockCurationRepository) GetById(ctx context.Context, id int64) (*domain.CharacterEntity, error) {
+	args := m.Called(ctx, id)
+
+	var chr *domain.CharacterEntity
+	if v := args.Get(0); v != nil {
+		chr = v.(*domain.CharacterEntity)
+	}
+
+	return chr, args.Error(1)
+}
+
+func (m *MockCurationRepository) Insert(ctx context.Context, c *domain.CharacterEntity) error {
+	args := m.Called(ctx, c)
+	return args.Error(0)
+}
+
+func (m *MockCurationRepository) Update(ctx context.Context, before, after *domain.CharacterEntity) error {
+	args := m.Called(ctx, before, after)
+	return args.Error(0)
+}
+
+func (m *MockCurationRepository) Delete(ctx context.Context, before *domain.CharacterEntity) error {
+	args := m.Called(ctx, before)
+	return args.Error(0)
+}
+
+func storedVegeta() *domain.CharacterEntity {
+	return &domain.CharacterEntity{
+		Id:          2,
+		Name:        "Vegeta",
+		Ki:          "54.000.000",
+		MaxKi:       "19.84 Septillion",
+		Race:        "Saiyan",
+		Affiliation: "Army of Frieza",
+		UpdatedAt:   time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		Overridden:  []string{"ki"},
+	}
+}
+
+func TestCurationService_Create(t *testing.T) {
+	repo := new(MockCurationRepository)
+	svc := app.NewCurationService(repo)
+
+	var stored *domain.CharacterEntity
+	repo.On("Insert", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { stored = args.Get(1).(*domain.CharacterEntity) }).
+		Return(nil)
+
+	chr, err := svc.Create(context.Background(), 1001, domain.CharacterPatch{"name": "Gogeta", "ki": "unknown", "race": "Saiyan"})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(1001), stored.Id)
+	assert.Equal(t, "Gogeta", stored.Name)
+	assert.False(t, stored.UpdatedAt.IsZero())
+	assert.Equal(t, []string{"ki", "name", "race"}, chr.Overridden)
+	assert.Equal(t, "Gogeta", chr.Name)
+	assert.NotEmpty(t, chr.ETag)
+}
+
+func TestCurationService_Create_Exists(t *testing.T) {
+	repo := new(MockCurationRepository)
+	svc := app.NewCurationService(repo)
+
+	repo.On("Insert", mock.Anything, mock.Anything).Return(fmt.Errorf("character %w", domain.ErrExists))
+
+	chr, err := svc.Create(context.Background(), 1, domain.CharacterPatch{"name": "Goku"})
+
+	assert.Nil(t, chr)
+	assert.ErrorIs(t, err, domain.ErrExists)
+}
+
+func TestCurationService_Update_CuratesOnlyPatchedFields(t *testing.T) {
+	repo := new(MockCurationRepository)
+	svc := app.NewCurationService(repo)
+	before := storedVegeta()
+
+	repo.On("GetById", mock.Anything, int64(2)).Return(before, nil)
+	var after *domain.CharacterEntity
+	repo.On("Update", mock.Anything, before, mock.Anything).
+		Run(func(args mock.Arguments) { after = args.Get(2).(*domain.CharacterEntity) }).
+		Return(nil)
+
+	chr, err := svc.Update(context.Background(), 2, domain.CharacterPatch{"affiliation": "Z Fighter"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Z Fighter", after.Affiliation)
+	assert.Equal(t, "Army of Frieza", before.Affiliation, "before is left untouched")
+	assert.Equal(t, []string{"ki"}, before.Overridden)
+	assert.Equal(t, []string{"affiliation", "ki"}, after.Overridden)
+	assert.True(t, after.UpdatedAt.After(before.UpdatedAt))
+	assert.Equal(t, []string{"affiliation", "ki"}, chr.Overridden)
+}
+
+func TestCurationService_Update_NotFound(t *testing.T) {
+	repo := new(MockCurationRepository)
+	svc := app.NewCurationService(repo)
+
+	repo.On("GetById", mock.Anything, int64(9)).Return(nil, fmt.Errorf("character %w", domain.ErrNotFound))
+
+	_, err := svc.Update(context.Background(), 9, domain.CharacterPatch{"name": "Nobody"})
+
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCurationService_Delete(t *testing.T) {
+	repo := new(MockCurationRepository)
+	svc := app.NewCurationService(repo)
+	before := storedVegeta()
+
+	repo.On("GetById", mock.Anything, int64(2)).Return(before, nil)
+	repo.On("Delete", mock.Anything, before).Return(nil)
+
+	chr, err := svc.Delete(context.Background(), 2)
+
+	require.NoError(t, err)
+	assert.Equal(t, "Vegeta", chr.Name)
+	repo.AssertExpectations(t)
+}
+
+func TestCurationService_Delete_Error(t *testing.T) {
+	repo := new(MockCurationRepository)
+	svc := app.NewCurationService(repo)
+
+	repo.On("GetById", mock.Anything, int64(2)).Return(storedVegeta(), nil)
+	repo.On("Delete", mock.Anything, mock.Anything).Return(errors.New("db down"))
+
+	chr, err := svc.Delete(context.Background(), 2)
+
+	assert.Nil(t, chr)
+	assert.EqualError(t, err, "db down")
+}
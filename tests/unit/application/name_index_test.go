@@ -0,0 +1,101 @@
+package application_test
+
+import (
+	"testing"
+
+	app "github.com/heaveless/dbz-api/internal/application/character"
+	domain "github.com/heaveless/dbz-api/internal/domain/character"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestIndex() *app.NameIndex {
+	idx := app.NewNameIndex()
+	idx.Rebuild([]domain.CharacterEntity{
+		{Id: 1, Name: "Goku"},
+		{Id: 2, Name: "Vegeta"},
+		{Id: 3, Name: "Piccolo"},
+		{Id: 4, Name: "Gohan"},
+		{Id: 5, Name: "Freezer"},
+		{Id: 6, Name: "Goten"},
+		{Id: 7, Name: "Master Roshi"},
+		{Id: 8, Name: "Android 17"},
+	})
+	return idx
+}
+
+func TestNameIndex_Search(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		expected string
+	}{
+		{name: "exact", query: "Goku", expected: "Goku"},
+		{name: "case and whitespace", query: "  gOKU ", expected: "Goku"},
+		{name: "misspelled vegeta", query: "Vejeta", expected: "Vegeta"},
+		{name: "misspelled freezer", query: "Frezza", expected: "Freezer"},
+		{name: "misspelled piccolo", query: "Picolo", expected: "Piccolo"},
+		{name: "prefix", query: "pic", expected: "Piccolo"},
+		{name: "later word prefix", query: "roshi", expected: "Master Roshi"},
+	}
+
+	idx := newTestIndex()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches := idx.Search(tt.query, 3)
+
+			require.NotEmpty(t, matches)
+			assert.Equal(t, tt.expected, matches[0].Name)
+		})
+	}
+}
+
+func TestNameIndex_Search_PrefixRanking(t *testing.T) {
+	idx := newTestIndex()
+
+	matches := idx.Search("go", 10)
+
+	require.Len(t, matches, 3)
+	assert.Equal(t, "Goku", matches[0].Name)
+	assert.Equal(t, int64(1), matches[0].Id)
+	for _, m := range matches {
+		assert.Greater(t, m.Score, 0.9)
+	}
+}
+
+func TestNameIndex_Search_ExactScoresHighest(t *testing.T) {
+	idx := newTestIndex()
+
+	matches := idx.Search("goku", 10)
+
+	require.NotEmpty(t, matches)
+	assert.Equal(t, 1.0, matches[0].Score)
+}
+
+func TestNameIndex_Search_RespectsLimit(t *testing.T) {
+	idx := newTestIndex()
+
+	assert.Len(t, idx.Search("go", 2), 2)
+	assert.Empty(t, idx.Search("go", 0))
+}
+
+func TestNameIndex_Search_NoMatch(t *testing.T) {
+	idx := newTestIndex()
+
+	assert.Empty(t, idx.Search("zzzzzz", 5))
+	assert.Empty(t, idx.Search("   ", 5))
+}
+
+func TestNameIndex_Add(t *testing.T) {
+	idx := app.NewNameIndex()
+
+	assert.True(t, idx.BuiltAt().IsZero())
+	assert.Empty(t, idx.Search("krillin", 5))
+
+	idx.Add(9, "Krillin")
+
+	matches := idx.Search("krilin", 5)
+	require.Len(t, matches, 1)
+	assert.Equal(t, "Krillin", matches[0].Name)
+}
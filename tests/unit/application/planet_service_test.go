@@ -0,0 +1,339 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	planetapp "github.com/heaveless/dbz-api/internal/application/planet"
+	domain "github.com/heaveless/dbz-api/internal/domain/character"
+	planet "github.com/heaveless/dbz-api/internal/domain/planet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+type MockPlanetRepository struct {
+	mock.Mock
+}
+
+func (m *MockPlanetRepository) Get(ctx context.Context, id int64) (*planet.PlanetEntity, error) {
+	args := m.Called(ctx, id)
+
+	var p *planet.PlanetEntity
+	if v := args.Get(0); v != nil {
+		p = v.(*planet.PlanetEntity)
+	}
+
+	return p, args.Error(1)
+}
+
+func (m *MockPlanetRepository) GetByIds(ctx context.Context, ids []int64) ([]planet.PlanetEntity, error) {
+	args := m.Called(ctx, ids)
+
+	var ps []planet.PlanetEntity
+	if v := args.Get(0); v != nil {
+		ps = v.([]planet.PlanetEntity)
+	}
+
+	return ps, args.Error(1)
+}
+
+func (m *MockPlanetRepository) List(ctx context.Context) ([]planet.PlanetEntity, error) {
+	args := m.Called(ctx)
+
+	var ps []planet.PlanetEntity
+	if v := args.Get(0); v != nil {
+		ps = v.([]planet.PlanetEntity)
+	}
+
+	return ps, args.Error(1)
+}
+
+func (m *MockPlanetRepository) Save(ctx context.Context, p *planet.PlanetEntity) error {
+	args := m.Called(ctx, p)
+	return args.Error(0)
+}
+
+type MockPlanetApi struct {
+	mock.Mock
+}
+
+func (m *MockPlanetApi) Get(ctx context.Context, id int64) (*planet.PlanetDetail, error) {
+	args := m.Called(ctx, id)
+
+	var d *planet.PlanetDetail
+	if v := args.Get(0); v != nil {
+		d = v.(*planet.PlanetDetail)
+	}
+
+	return d, args.Error(1)
+}
+
+func (m *MockPlanetApi) List(ctx context.Context) ([]planet.PlanetEntity, error) {
+	args := m.Called(ctx)
+
+	var ps []planet.PlanetEntity
+	if v := args.Get(0); v != nil {
+		ps = v.([]planet.PlanetEntity)
+	}
+
+	return ps, args.Error(1)
+}
+
+func TestPlanetService_GetById_FromRepo(t *testing.T) {
+	repo := new(MockPlanetRepository)
+	api := new(MockPlanetApi)
+	svc := planetapp.NewPlanetService(repo, api, new(MockCharacterRepository), nil)
+
+	repo.
+		On("Get", mock.Anything, int64(1)).
+		Return(&planet.PlanetEntity{Id: 1, Name: "Namek"}, nil)
+
+	dto, err := svc.GetById(context.Background(), 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, "Namek", dto.Name)
+	api.AssertNotCalled(t, "Get", mock.Anything, mock.Anything)
+	repo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestPlanetService_GetById_FallbackToApiAndSave(t *testing.T) {
+	repo := new(MockPlanetRepository)
+	api := new(MockPlanetApi)
+	chars := new(MockCharacterRepository)
+	svc := planetapp.NewPlanetService(repo, api, chars, nil)
+
+	detail := &planet.PlanetDetail{
+		Planet:     planet.PlanetEntity{Id: 3, Name: "Vegeta", IsDestroyed: true, CharacterIds: []int64{2}},
+		Characters: []domain.CharacterEntity{{Id: 2, Name: "Vegeta"}},
+	}
+
+	repo.On("Get", mock.Anything, int64(3)).Return(nil, mongo.ErrNoDocuments)
+	api.On("Get", mock.Anything, int64(3)).Return(detail, nil)
+	repo.On("Save", mock.Anything, &detail.Planet).Return(nil)
+	chars.On("Create", mock.Anything, &detail.Characters[0]).Return(nil)
+
+	dto, err := svc.GetById(context.Background(), 3)
+
+	require.NoError(t, err)
+	assert.True(t, dto.IsDestroyed)
+
+	time.Sleep(10 * time.Millisecond)
+
+	repo.AssertExpectations(t)
+	chars.AssertExpectations(t)
+}
+
+func TestPlanetService_GetById_NotFound(t *testing.T) {
+	repo := new(MockPlanetRepository)
+	api := new(MockPlanetApi)
+	svc := planetapp.NewPlanetService(repo, api, new(MockCharacterRepository), nil)
+
+	repo.On("Get", mock.Anything, int64(99)).Return(nil, mongo.ErrNoDocuments)
+	api.On("Get", mock.Anything, int64(99)).Return(nil, planet.ErrNotFound)
+
+	dto, err := svc.GetById(context.Background(), 99)
+
+	assert.Nil(t, dto)
+	assert.ErrorIs(t, err, planet.ErrNotFound)
+}
+
+func TestPlanetService_List_FromRepo(t *testing.T) {
+	repo := new(MockPlanetRepository)
+	api := new(MockPlanetApi)
+	marks := &memoryMarks{marked: map[string]bool{"planet list:fetched": true}}
+	svc := planetapp.NewPlanetService(repo, api, new(MockCharacterRepository), marks)
+
+	repo.
+		On("List", mock.Anything).
+		Return([]planet.PlanetEntity{{Id: 1, Name: "Namek"}, {Id: 2, Name: "Tierra"}}, nil)
+
+	dtos, err := svc.List(context.Background())
+
+	require.NoError(t, err)
+	assert.Len(t, dtos, 2)
+	api.AssertNotCalled(t, "List", mock.Anything)
+}
+
+func TestPlanetService_List_GoesUpstreamUntilFetched(t *testing.T) {
+	repo := new(MockPlanetRepository)
+	api := new(MockPlanetApi)
+	marks := &memoryMarks{}
+	svc := planetapp.NewPlanetService(repo, api, new(MockCharacterRepository), marks)
+
+	api.On("List", mock.Anything).Return([]planet.PlanetEntity{{Id: 1, Name: "Namek"}}, nil).Once()
+	repo.On("Save", mock.Anything, mock.Anything).Return(nil)
+	repo.On("List", mock.Anything).Return([]planet.PlanetEntity{{Id: 1, Name: "Namek"}}, nil)
+
+	dtos, err := svc.List(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, "Namek", dtos[0].Name)
+	repo.AssertNotCalled(t, "List", mock.Anything)
+
+	time.Sleep(10 * time.Millisecond)
+
+	repo.AssertNumberOfCalls(t, "Save", 1)
+	_, err = svc.List(context.Background())
+	require.NoError(t, err)
+	api.AssertNumberOfCalls(t, "List", 1)
+	repo.AssertNumberOfCalls(t, "List", 1)
+}
+
+// A planet stored by GetById must not pass for the whole list.
+func TestPlanetService_List_AfterGetByIdLoadsFullList(t *testing.T) {
+	repo := new(MockPlanetRepository)
+	api := new(MockPlanetApi)
+	chars := new(MockCharacterRepository)
+	svc := planetapp.NewPlanetService(repo, api, chars, &memoryMarks{})
+
+	namek := planet.PlanetEntity{Id: 3, Name: "Namek"}
+	repo.On("Get", mock.Anything, int64(3)).Return(nil, mongo.ErrNoDocuments)
+	api.On("Get", mock.Anything, int64(3)).Return(&planet.PlanetDetail{Planet: namek}, nil)
+	repo.On("Save", mock.Anything, mock.Anything).Return(nil)
+	repo.On("List", mock.Anything).Return([]planet.PlanetEntity{namek}, nil)
+	api.On("List", mock.Anything).Return([]planet.PlanetEntity{{Id: 1, Name: "Tierra"}, {Id: 2, Name: "Vegeta"}, namek}, nil)
+
+	_, err := svc.GetById(context.Background(), 3)
+	require.NoError(t, err)
+	time.Sleep(10 * time.Millisecond)
+
+	dtos, err := svc.List(context.Background())
+
+	require.NoError(t, err)
+	assert.Len(t, dtos, 3)
+	api.AssertCalled(t, "List", mock.Anything)
+}
+
+func TestPlanetService_List_RemembersAnEmptyApi(t *testing.T) {
+	repo := new(MockPlanetRepository)
+	api := new(MockPlanetApi)
+	svc := planetapp.NewPlanetService(repo, api, new(MockCharacterRepository), &memoryMarks{})
+
+	repo.On("List", mock.Anything).Return([]planet.PlanetEntity{}, nil)
+	api.On("List", mock.Anything).Return([]planet.PlanetEntity{}, nil).Once()
+
+	_, err := svc.List(context.Background())
+	require.NoError(t, err)
+
+	time.Sleep(10 * time.Millisecond)
+
+	dtos, err := svc.List(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, dtos)
+	api.AssertNumberOfCalls(t, "List", 1)
+	repo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestPlanetService_Characters_FromRepo(t *testing.T) {
+	repo := new(MockPlanetRepository)
+	api := new(MockPlanetApi)
+	chars := new(MockCharacterRepository)
+	svc := planetapp.NewPlanetService(repo, api, chars, nil)
+
+	repo.
+		On("Get", mock.Anything, int64(1)).
+		Return(&planet.PlanetEntity{Id: 1, Name: "Namek", CharacterIds: []int64{3}}, nil)
+	chars.
+		On("GetByIds", mock.Anything, []int64{3}).
+		Return([]domain.CharacterEntity{{Id: 3, Name: "Piccolo"}}, nil)
+
+	dtos, err := svc.Characters(context.Background(), 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, "Piccolo", dtos[0].Name)
+	api.AssertNotCalled(t, "Get", mock.Anything, mock.Anything)
+}
+
+func TestPlanetService_Characters_ResidentsNotLoaded(t *testing.T) {
+	repo := new(MockPlanetRepository)
+	api := new(MockPlanetApi)
+	chars := new(MockCharacterRepository)
+	svc := planetapp.NewPlanetService(repo, api, chars, nil)
+
+	detail := &planet.PlanetDetail{
+		Planet:     planet.PlanetEntity{Id: 1, Name: "Namek", CharacterIds: []int64{3}},
+		Characters: []domain.CharacterEntity{{Id: 3, Name: "Piccolo"}},
+	}
+
+	repo.On("Get", mock.Anything, int64(1)).Return(&planet.PlanetEntity{Id: 1, Name: "Namek"}, nil)
+	api.On("Get", mock.Anything, int64(1)).Return(detail, nil)
+	repo.On("Save", mock.Anything, mock.Anything).Return(nil)
+	chars.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	dtos, err := svc.Characters(context.Background(), 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, "Piccolo", dtos[0].Name)
+	chars.AssertNotCalled(t, "GetByIds", mock.Anything, mock.Anything)
+
+	time.Sleep(10 * time.Millisecond)
+
+	repo.AssertCalled(t, "Save", mock.Anything, &detail.Planet)
+}
+
+func TestPlanetService_CharactersByPlanets_OneReadPerLevel(t *testing.T) {
+	repo := new(MockPlanetRepository)
+	api := new(MockPlanetApi)
+	chars := new(MockCharacterRepository)
+	svc := planetapp.NewPlanetService(repo, api, chars, nil)
+
+	repo.On("GetByIds", mock.Anything, []int64{1, 2, 3}).Return([]planet.PlanetEntity{
+		{Id: 2, Name: "Tierra", CharacterIds: []int64{1, 2}},
+		{Id: 1, Name: "Namek", CharacterIds: []int64{3}},
+		{Id: 3, Name: "Vegeta", CharacterIds: []int64{}},
+	}, nil)
+	chars.On("GetByIds", mock.Anything, []int64{1, 2, 3}).Return([]domain.CharacterEntity{
+		{Id: 3, Name: "Piccolo"}, {Id: 2, Name: "Vegeta"}, {Id: 1, Name: "Goku"},
+	}, nil)
+
+	results := svc.CharactersByPlanets(context.Background(), []int64{1, 2, 3})
+
+	require.Len(t, results, 3)
+	assert.Equal(t, int64(1), results[0].PlanetId)
+	assert.Equal(t, "Piccolo", results[0].Residents[0].Name)
+	assert.Equal(t, "Goku", results[1].Residents[0].Name)
+	assert.Equal(t, "Vegeta", results[1].Residents[1].Name)
+	assert.NotNil(t, results[2].Residents)
+	assert.Empty(t, results[2].Residents)
+	repo.AssertNotCalled(t, "Get", mock.Anything, mock.Anything)
+	api.AssertNotCalled(t, "Get", mock.Anything, mock.Anything)
+}
+
+func TestPlanetService_CharactersByPlanets_ErrorsStayPerPlanet(t *testing.T) {
+	repo := new(MockPlanetRepository)
+	api := new(MockPlanetApi)
+	chars := new(MockCharacterRepository)
+	svc := planetapp.NewPlanetService(repo, api, chars, nil)
+
+	repo.On("GetByIds", mock.Anything, []int64{1, 404}).Return([]planet.PlanetEntity{
+		{Id: 1, Name: "Namek", CharacterIds: []int64{3}},
+	}, nil)
+	chars.On("GetByIds", mock.Anything, []int64{3}).Return([]domain.CharacterEntity{{Id: 3, Name: "Piccolo"}}, nil)
+	repo.On("Get", mock.Anything, int64(404)).Return(nil, mongo.ErrNoDocuments)
+	api.On("Get", mock.Anything, int64(404)).Return(nil, planet.ErrNotFound)
+
+	results := svc.CharactersByPlanets(context.Background(), []int64{1, 404})
+
+	require.Len(t, results, 2)
+	require.NoError(t, results[0].Err)
+	assert.Equal(t, "Piccolo", results[0].Residents[0].Name)
+	assert.ErrorIs(t, results[1].Err, planet.ErrNotFound)
+}
+
+func TestPlanetService_Characters_ApiError(t *testing.T) {
+	repo := new(MockPlanetRepository)
+	api := new(MockPlanetApi)
+	svc := planetapp.NewPlanetService(repo, api, new(MockCharacterRepository), nil)
+
+	repo.On("Get", mock.Anything, int64(1)).Return(nil, errors.New("db error"))
+	api.On("Get", mock.Anything, int64(1)).Return(nil, errors.New("api error"))
+
+	dtos, err := svc.Characters(context.Background(), 1)
+
+	assert.Nil(t, dtos)
+	assert.EqualError(t, err, "api error")
+}
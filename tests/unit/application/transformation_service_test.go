@@ -0,0 +1,248 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	transformationapp "github.com/heaveless/dbz-api/internal/application/transformation"
+	transformation "github.com/heaveless/dbz-api/internal/domain/transformation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockTransformationRepository struct {
+	mock.Mock
+}
+
+func (m *MockTransformationRepository) ListByCharacter(ctx context.Context, characterId int64) ([]transformation.TransformationEntity, error) {
+	args := m.Called(ctx, characterId)
+
+	var ts []transformation.TransformationEntity
+	if v := args.Get(0); v != nil {
+		ts = v.([]transformation.TransformationEntity)
+	}
+
+	return ts, args.Error(1)
+}
+
+func (m *MockTransformationRepository) ListByCharacters(ctx context.Context, characterIds []int64) ([]transformation.TransformationEntity, error) {
+	args := m.Called(ctx, characterIds)
+
+	var ts []transformation.TransformationEntity
+	if v := args.Get(0); v != nil {
+		ts = v.([]transformation.TransformationEntity)
+	}
+
+	return ts, args.Error(1)
+}
+
+func (m *MockTransformationRepository) CreateMany(ctx context.Context, ts []transformation.TransformationEntity) error {
+	args := m.Called(ctx, ts)
+	return args.Error(0)
+}
+
+type MockTransformationApi struct {
+	mock.Mock
+}
+
+func (m *MockTransformationApi) ListByCharacter(ctx context.Context, characterId int64) ([]transformation.TransformationEntity, error) {
+	args := m.Called(ctx, characterId)
+
+	var ts []transformation.TransformationEntity
+	if v := args.Get(0); v != nil {
+		ts = v.([]transformation.TransformationEntity)
+	}
+
+	return ts, args.Error(1)
+}
+
+func TestTransformationService_ListByCharacter_FromRepo(t *testing.T) {
+	repo := new(MockTransformationRepository)
+	api := new(MockTransformationApi)
+	svc := transformationapp.NewTransformationService(repo, api, nil)
+
+	repo.
+		On("ListByCharacter", mock.Anything, int64(1)).
+		Return([]transformation.TransformationEntity{{Id: 1, CharacterId: 1, Name: "Goku SSJ", Ki: "3 Billion"}}, nil)
+
+	dtos, err := svc.ListByCharacter(context.Background(), 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, "Goku SSJ", dtos[0].Name)
+	assert.Equal(t, int64(1), dtos[0].CharacterId)
+	api.AssertNotCalled(t, "ListByCharacter", mock.Anything, mock.Anything)
+}
+
+func TestTransformationService_ListByCharacter_FallbackToApiAndSave(t *testing.T) {
+	repo := new(MockTransformationRepository)
+	api := new(MockTransformationApi)
+	svc := transformationapp.NewTransformationService(repo, api, nil)
+
+	fromApi := []transformation.TransformationEntity{{Id: 7, CharacterId: 2, Name: "Vegeta SSJ"}}
+
+	repo.On("ListByCharacter", mock.Anything, int64(2)).Return([]transformation.TransformationEntity{}, nil)
+	api.On("ListByCharacter", mock.Anything, int64(2)).Return(fromApi, nil)
+	repo.On("CreateMany", mock.Anything, fromApi).Return(nil)
+
+	dtos, err := svc.ListByCharacter(context.Background(), 2)
+
+	require.NoError(t, err)
+	assert.Len(t, dtos, 1)
+
+	time.Sleep(10 * time.Millisecond)
+
+	repo.AssertExpectations(t)
+}
+
+func TestTransformationService_ListByCharacter_NotFound(t *testing.T) {
+	repo := new(MockTransformationRepository)
+	api := new(MockTransformationApi)
+	svc := transformationapp.NewTransformationService(repo, api, nil)
+
+	repo.On("ListByCharacter", mock.Anything, int64(404)).Return(nil, nil)
+	api.On("ListByCharacter", mock.Anything, int64(404)).Return(nil, transformation.ErrNotFound)
+
+	dtos, err := svc.ListByCharacter(context.Background(), 404)
+
+	assert.Nil(t, dtos)
+	assert.ErrorIs(t, err, transformation.ErrNotFound)
+	repo.AssertNotCalled(t, "CreateMany", mock.Anything, mock.Anything)
+}
+
+func TestTransformationService_ListByCharacters_OneReadThenApiForMissing(t *testing.T) {
+	repo := new(MockTransformationRepository)
+	api := new(MockTransformationApi)
+	svc := transformationapp.NewTransformationService(repo, api, nil)
+
+	fromApi := []transformation.TransformationEntity{{Id: 7, CharacterId: 2, Name: "Vegeta SSJ"}}
+
+	repo.On("ListByCharacters", mock.Anything, []int64{1, 2}).Return([]transformation.TransformationEntity{
+		{Id: 1, CharacterId: 1, Name: "Goku SSJ"},
+		{Id: 2, CharacterId: 1, Name: "Goku SSJ2"},
+	}, nil)
+	repo.On("ListByCharacter", mock.Anything, int64(2)).Return(nil, nil)
+	api.On("ListByCharacter", mock.Anything, int64(2)).Return(fromApi, nil)
+	repo.On("CreateMany", mock.Anything, fromApi).Return(nil)
+
+	results := svc.ListByCharacters(context.Background(), []int64{1, 2})
+
+	require.Len(t, results, 2)
+	require.NoError(t, results[0].Err)
+	require.Len(t, results[0].Transformations, 2)
+	assert.Equal(t, "Goku SSJ2", results[0].Transformations[1].Name)
+	require.NoError(t, results[1].Err)
+	require.Len(t, results[1].Transformations, 1)
+	assert.Equal(t, "Vegeta SSJ", results[1].Transformations[0].Name)
+	repo.AssertNotCalled(t, "ListByCharacter", mock.Anything, int64(1))
+	api.AssertNotCalled(t, "ListByCharacter", mock.Anything, int64(1))
+}
+
+func TestTransformationService_ListByCharacters_StoreDownFallsBackPerCharacter(t *testing.T) {
+	repo := new(MockTransformationRepository)
+	api := new(MockTransformationApi)
+	svc := transformationapp.NewTransformationService(repo, api, nil)
+
+	repo.On("ListByCharacters", mock.Anything, []int64{1}).Return(nil, errors.New("db down"))
+	repo.On("ListByCharacter", mock.Anything, int64(1)).Return(nil, errors.New("db down"))
+	api.On("ListByCharacter", mock.Anything, int64(1)).Return([]transformation.TransformationEntity{{Id: 1, CharacterId: 1}}, nil)
+	repo.On("CreateMany", mock.Anything, mock.Anything).Return(nil)
+
+	results := svc.ListByCharacters(context.Background(), []int64{1})
+
+	require.NoError(t, results[0].Err)
+	assert.Len(t, results[0].Transformations, 1)
+}
+
+func TestTransformationService_ListByCharacters_ErrorsStayPerCharacter(t *testing.T) {
+	repo := new(MockTransformationRepository)
+	api := new(MockTransformationApi)
+	svc := transformationapp.NewTransformationService(repo, api, nil)
+
+	repo.On("ListByCharacters", mock.Anything, []int64{1, 404}).Return([]transformation.TransformationEntity{
+		{Id: 1, CharacterId: 1, Name: "Goku SSJ"},
+	}, nil)
+	repo.On("ListByCharacter", mock.Anything, int64(404)).Return(nil, nil)
+	api.On("ListByCharacter", mock.Anything, int64(404)).Return(nil, transformation.ErrNotFound)
+
+	results := svc.ListByCharacters(context.Background(), []int64{1, 404})
+
+	require.NoError(t, results[0].Err)
+	assert.Equal(t, "Goku SSJ", results[0].Transformations[0].Name)
+	assert.ErrorIs(t, results[1].Err, transformation.ErrNotFound)
+}
+
+func TestTransformationService_ListByCharacters_MarkedCharactersSkipTheApi(t *testing.T) {
+	repo := new(MockTransformationRepository)
+	api := new(MockTransformationApi)
+	svc := transformationapp.NewTransformationService(repo, api, &memoryMarks{})
+
+	repo.On("ListByCharacter", mock.Anything, int64(2)).Return(nil, nil).Once()
+	api.On("ListByCharacter", mock.Anything, int64(2)).Return([]transformation.TransformationEntity{}, nil).Once()
+	_, err := svc.ListByCharacter(context.Background(), 2)
+	require.NoError(t, err)
+
+	time.Sleep(10 * time.Millisecond)
+
+	repo.On("ListByCharacters", mock.Anything, []int64{2}).Return(nil, nil)
+
+	results := svc.ListByCharacters(context.Background(), []int64{2})
+
+	require.NoError(t, results[0].Err)
+	assert.NotNil(t, results[0].Transformations)
+	assert.Empty(t, results[0].Transformations)
+	repo.AssertNumberOfCalls(t, "ListByCharacter", 1)
+	api.AssertNumberOfCalls(t, "ListByCharacter", 1)
+}
+
+type memoryMarks struct {
+	mu     sync.Mutex
+	marked map[string]bool
+}
+
+func (m *memoryMarks) Mark(_ context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.marked == nil {
+		m.marked = map[string]bool{}
+	}
+	m.marked[key] = true
+	return nil
+}
+
+func (m *memoryMarks) Marked(_ context.Context, keys []string) (map[string]bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	marked := map[string]bool{}
+	for _, key := range keys {
+		if m.marked[key] {
+			marked[key] = true
+		}
+	}
+	return marked, nil
+}
+
+func TestTransformationService_ListByCharacter_RemembersNone(t *testing.T) {
+	repo := new(MockTransformationRepository)
+	api := new(MockTransformationApi)
+	svc := transformationapp.NewTransformationService(repo, api, &memoryMarks{})
+
+	repo.On("ListByCharacter", mock.Anything, int64(5)).Return(nil, nil)
+	api.On("ListByCharacter", mock.Anything, int64(5)).Return([]transformation.TransformationEntity{}, nil).Once()
+
+	dtos, err := svc.ListByCharacter(context.Background(), 5)
+	require.NoError(t, err)
+	assert.Empty(t, dtos)
+
+	time.Sleep(10 * time.Millisecond)
+
+	dtos, err = svc.ListByCharacter(context.Background(), 5)
+	require.NoError(t, err)
+	assert.NotNil(t, dtos)
+	assert.Empty(t, dtos)
+	api.AssertNumberOfCalls(t, "ListByCharacter", 1)
+	repo.AssertNotCalled(t, "CreateMany", mock.Anything, mock.Anything)
+}
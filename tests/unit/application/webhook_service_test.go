@@ -0,0 +1,304 @@
+package application_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	webhookapp "github.com/heaveless/dbz-api/internal/application/webhook"
+	"github.com/heaveless/dbz-api/internal/domain/event"
+	webhook "github.com/heaveless/dbz-api/internal/domain/webhook"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockSubscriptionRepository struct {
+	mock.Mock
+}
+
+func (m *MockSubscriptionRepository) Save(ctx context.Context, s *webhook.SubscriptionEntity) error {
+	args := m.Called(ctx, s)
+	return args.Error(0)
+}
+
+func (m *MockSubscriptionRepository) Get(ctx context.Context, id string) (*webhook.SubscriptionEntity, error) {
+	args := m.Called(ctx, id)
+
+	var s *webhook.SubscriptionEntity
+	if v := args.Get(0); v != nil {
+		s = v.(*webhook.SubscriptionEntity)
+	}
+
+	return s, args.Error(1)
+}
+
+func (m *MockSubscriptionRepository) List(ctx context.Context) ([]webhook.SubscriptionEntity, error) {
+	args := m.Called(ctx)
+
+	var ss []webhook.SubscriptionEntity
+	if v := args.Get(0); v != nil {
+		ss = v.([]webhook.SubscriptionEntity)
+	}
+
+	return ss, args.Error(1)
+}
+
+type MockDeliveryRepository struct {
+	mock.Mock
+}
+
+func (m *MockDeliveryRepository) Enqueue(ctx context.Context, d *webhook.DeliveryEntity) error {
+	args := m.Called(ctx, d)
+	return args.Error(0)
+}
+
+func (m *MockDeliveryRepository) Due(ctx context.Context, now time.Time, limit int) ([]webhook.DeliveryEntity, error) {
+	args := m.Called(ctx, now, limit)
+
+	var ds []webhook.DeliveryEntity
+	if v := args.Get(0); v != nil {
+		ds = v.([]webhook.DeliveryEntity)
+	}
+
+	return ds, args.Error(1)
+}
+
+func (m *MockDeliveryRepository) Attempted(ctx context.Context, id string, a webhook.AttemptEntity, status webhook.DeliveryStatus, next time.Time) error {
+	args := m.Called(ctx, id, a, status, next)
+	return args.Error(0)
+}
+
+func (m *MockDeliveryRepository) ListBySubscription(ctx context.Context, subscriptionId string, limit int) ([]webhook.DeliveryEntity, error) {
+	args := m.Called(ctx, subscriptionId, limit)
+
+	var ds []webhook.DeliveryEntity
+	if v := args.Get(0); v != nil {
+		ds = v.([]webhook.DeliveryEntity)
+	}
+
+	return ds, args.Error(1)
+}
+
+type MockWebhookSender struct {
+	mock.Mock
+}
+
+func (m *MockWebhookSender) Send(ctx context.Context, sub *webhook.SubscriptionEntity, d *webhook.DeliveryEntity) webhook.AttemptEntity {
+	args := m.Called(ctx, sub, d)
+	return args.Get(0).(webhook.AttemptEntity)
+}
+
+var webhookNow = time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+func newWebhookService(subs *MockSubscriptionRepository, deliveries *MockDeliveryRepository, sender *MockWebhookSender) *webhookapp.WebhookService {
+	return webhookapp.NewWebhookServiceWithClock(subs, deliveries, sender, webhookapp.Options{
+		MinBackoff:  time.Minute,
+		MaxBackoff:  10 * time.Minute,
+		MaxAttempts: 3,
+	}, func() time.Time { return webhookNow })
+}
+
+func TestWebhookService_Register(t *testing.T) {
+	ctx := context.Background()
+	subs := new(MockSubscriptionRepository)
+
+	var saved *webhook.SubscriptionEntity
+	subs.On("Save", ctx, mock.Anything).
+		Run(func(args mock.Arguments) { saved = args.Get(1).(*webhook.SubscriptionEntity) }).
+		Return(nil)
+
+	svc := newWebhookService(subs, nil, nil)
+
+	created, err := svc.Register(ctx, webhook.NewSubscription{Url: "https://partner.example/hooks", Events: []event.Type{event.CharacterCached}})
+
+	require.NoError(t, err)
+	assert.Regexp(t, `^[0-9a-f]{16}$`, created.Id)
+	assert.True(t, strings.HasPrefix(created.Secret, "whsec_"), created.Secret)
+	assert.Equal(t, saved.Secret, created.Secret)
+	assert.Equal(t, "https://partner.example/hooks", saved.Url)
+	assert.Equal(t, []event.Type{event.CharacterCached}, saved.Events)
+	assert.Equal(t, webhookNow, saved.CreatedAt)
+}
+
+func TestWebhookService_Register_KeepsGivenSecret(t *testing.T) {
+	ctx := context.Background()
+	subs := new(MockSubscriptionRepository)
+	subs.On("Save", ctx, mock.Anything).Return(nil)
+
+	created, err := newWebhookService(subs, nil, nil).Register(ctx, webhook.NewSubscription{Url: "https://partner.example", Secret: "a-shared-secret-of-ours"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "a-shared-secret-of-ours", created.Secret)
+	assert.Equal(t, []event.Type{}, created.Events)
+}
+
+func TestWebhookService_Send_EnqueuesForInterestedSubscriptions(t *testing.T) {
+	ctx := context.Background()
+	subs := new(MockSubscriptionRepository)
+	deliveries := new(MockDeliveryRepository)
+
+	subs.On("List", ctx).Return([]webhook.SubscriptionEntity{
+		{Id: "all"},
+		{Id: "cached", Events: []event.Type{event.CharacterCached}},
+		{Id: "refreshed", Events: []event.Type{event.CharacterRefreshed}},
+	}, nil)
+
+	var enqueued []*webhook.DeliveryEntity
+	deliveries.On("Enqueue", ctx, mock.Anything).
+		Run(func(args mock.Arguments) { enqueued = append(enqueued, args.Get(1).(*webhook.DeliveryEntity)) }).
+		Return(nil)
+
+	m := event.Message{Key: "k1", Type: event.CharacterCached, At: webhookNow, Data: json.RawMessage(`{"id":1}`)}
+	err := newWebhookService(subs, deliveries, nil).Send(ctx, m)
+
+	require.NoError(t, err)
+	require.Len(t, enqueued, 2)
+	assert.Equal(t, "k1:all", enqueued[0].Id)
+	assert.Equal(t, "k1:cached", enqueued[1].Id)
+	assert.Equal(t, webhook.StatusPending, enqueued[0].Status)
+	assert.Equal(t, webhookNow, enqueued[0].NextAttemptAt)
+	assert.JSONEq(t, `{"key":"k1","type":"CharacterCached","at":"2024-05-01T10:00:00Z","data":{"id":1}}`, enqueued[0].Payload)
+}
+
+func TestWebhookService_Send_FailsWhenEnqueueFails(t *testing.T) {
+	ctx := context.Background()
+	subs := new(MockSubscriptionRepository)
+	deliveries := new(MockDeliveryRepository)
+
+	subs.On("List", ctx).Return([]webhook.SubscriptionEntity{{Id: "all"}}, nil)
+	deliveries.On("Enqueue", ctx, mock.Anything).Return(errors.New("db down"))
+
+	err := newWebhookService(subs, deliveries, nil).Send(ctx, event.Message{Key: "k1", Type: event.CharacterCached})
+
+	assert.EqualError(t, err, "db down")
+}
+
+func TestWebhookService_DispatchOnce(t *testing.T) {
+	failed := webhook.AttemptEntity{StatusCode: 500, Error: "unexpected status 500"}
+	ok := webhook.AttemptEntity{StatusCode: 200}
+
+	tests := []struct {
+		name     string
+		previous int
+		attempt  webhook.AttemptEntity
+		status   webhook.DeliveryStatus
+		next     time.Time
+	}{
+		{name: "delivered", attempt: ok, status: webhook.StatusDelivered},
+		{name: "first failure", attempt: failed, status: webhook.StatusPending, next: webhookNow.Add(time.Minute)},
+		{name: "second failure backs off", previous: 1, attempt: failed, status: webhook.StatusPending, next: webhookNow.Add(2 * time.Minute)},
+		{name: "last attempt", previous: 2, attempt: failed, status: webhook.StatusFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			subs := new(MockSubscriptionRepository)
+			deliveries := new(MockDeliveryRepository)
+			sender := new(MockWebhookSender)
+
+			d := webhook.DeliveryEntity{Id: "k1:s1", SubscriptionId: "s1", Attempts: make([]webhook.AttemptEntity, tt.previous)}
+			sub := &webhook.SubscriptionEntity{Id: "s1", Url: "https://partner.example"}
+
+			deliveries.On("Due", ctx, webhookNow, webhookapp.DefaultBatch).Return([]webhook.DeliveryEntity{d}, nil)
+			subs.On("Get", ctx, "s1").Return(sub, nil)
+			sender.On("Send", mock.Anything, sub, mock.Anything).Return(tt.attempt)
+			deliveries.On("Attempted", ctx, "k1:s1", tt.attempt, tt.status, tt.next).Return(nil)
+
+			n, err := newWebhookService(subs, deliveries, sender).DispatchOnce(ctx)
+
+			require.NoError(t, err)
+			assert.Equal(t, 1, n)
+			deliveries.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWebhookService_DispatchOnce_DropsDeliveriesOfRemovedSubscriptions(t *testing.T) {
+	ctx := context.Background()
+	subs := new(MockSubscriptionRepository)
+	deliveries := new(MockDeliveryRepository)
+	sender := new(MockWebhookSender)
+
+	deliveries.On("Due", ctx, webhookNow, webhookapp.DefaultBatch).Return([]webhook.DeliveryEntity{{Id: "k1:s1", SubscriptionId: "s1"}}, nil)
+	subs.On("Get", ctx, "s1").Return(nil, fmt.Errorf("webhook %w", webhook.ErrNotFound))
+	deliveries.On("Attempted", ctx, "k1:s1", mock.MatchedBy(func(a webhook.AttemptEntity) bool {
+		return a.Error == "the subscription no longer exists"
+	}), webhook.StatusFailed, time.Time{}).Return(nil)
+
+	_, err := newWebhookService(subs, deliveries, sender).DispatchOnce(ctx)
+
+	require.NoError(t, err)
+	deliveries.AssertExpectations(t)
+	sender.AssertNotCalled(t, "Send", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestWebhookService_Test(t *testing.T) {
+	tests := []struct {
+		name    string
+		attempt webhook.AttemptEntity
+		status  webhook.DeliveryStatus
+	}{
+		{name: "accepted", attempt: webhook.AttemptEntity{StatusCode: 200}, status: webhook.StatusDelivered},
+		{name: "rejected", attempt: webhook.AttemptEntity{StatusCode: 401, Error: "unexpected status 401"}, status: webhook.StatusFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			subs := new(MockSubscriptionRepository)
+			deliveries := new(MockDeliveryRepository)
+			sender := new(MockWebhookSender)
+			sub := &webhook.SubscriptionEntity{Id: "s1", Events: []event.Type{event.CharacterCached}}
+
+			subs.On("Get", ctx, "s1").Return(sub, nil)
+			sender.On("Send", mock.Anything, sub, mock.MatchedBy(func(d *webhook.DeliveryEntity) bool {
+				return d.Type == webhook.TestEvent && strings.Contains(d.Payload, `"type":"WebhookTest"`)
+			})).Return(tt.attempt)
+			deliveries.On("Enqueue", ctx, mock.Anything).Return(nil)
+
+			d, err := newWebhookService(subs, deliveries, sender).Test(ctx, "s1")
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.status, d.Status)
+			assert.Len(t, d.Attempts, 1)
+			assert.Nil(t, d.NextAttemptAt)
+		})
+	}
+}
+
+func TestWebhookService_Deliveries_NotFound(t *testing.T) {
+	ctx := context.Background()
+	subs := new(MockSubscriptionRepository)
+	subs.On("Get", ctx, "nope").Return(nil, fmt.Errorf("webhook %w", webhook.ErrNotFound))
+
+	_, err := newWebhookService(subs, new(MockDeliveryRepository), nil).Deliveries(ctx, "nope")
+
+	assert.ErrorIs(t, err, webhook.ErrNotFound)
+}
+
+func TestWebhookService_Deliveries(t *testing.T) {
+	ctx := context.Background()
+	subs := new(MockSubscriptionRepository)
+	deliveries := new(MockDeliveryRepository)
+
+	subs.On("Get", ctx, "s1").Return(&webhook.SubscriptionEntity{Id: "s1"}, nil)
+	deliveries.On("ListBySubscription", ctx, "s1", 50).Return([]webhook.DeliveryEntity{
+		{Id: "k2:s1", Status: webhook.StatusPending, NextAttemptAt: webhookNow, Attempts: []webhook.AttemptEntity{{StatusCode: 500, DurationMs: 12}}},
+		{Id: "k1:s1", Status: webhook.StatusDelivered},
+	}, nil)
+
+	ds, err := newWebhookService(subs, deliveries, nil).Deliveries(ctx, "s1")
+
+	require.NoError(t, err)
+	require.Len(t, ds, 2)
+	assert.Equal(t, &webhookNow, ds[0].NextAttemptAt)
+	assert.Equal(t, []webhook.AttemptDTO{{StatusCode: 500, DurationMs: 12}}, ds[0].Attempts)
+	assert.Nil(t, ds[1].NextAttemptAt)
+	assert.Empty(t, ds[1].Attempts)
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/heaveless/dbz-api/internal/bootstrap"
 	"github.com/stretchr/testify/assert"
@@ -26,10 +27,24 @@ func TestNewEnv_LoadsConfigFromDotEnv(t *testing.T) {
 	envContent := []byte(`
 APP_ENV=development
 APP_PORT=8080
+GRPC_PORT=9090
 DB_HOST=localhost
 DB_PORT=27017
 DB_NAME=dbz
 API_URI=https://example.com
+HTTP_CACHE_CONTROL=private, max-age=10
+HTTP_COMPRESS_MIN_SIZE=2048
+GRAPHQL_MAX_DEPTH=6
+GRAPHQL_MAX_COMPLEXITY=300
+EVENTS_HISTORY=128
+EVENTS_CLIENT_BUFFER=16
+EVENTS_HEARTBEAT=30s
+OUTBOX_SINKS=stdout,nats
+OUTBOX_NATS_URL=nats://localhost:4222
+OUTBOX_INTERVAL=500ms
+OUTBOX_BATCH=20
+WEBHOOKS_ENABLED=true
+WEBHOOKS_MAX_ATTEMPTS=4
 `)
 	err = os.WriteFile(filepath.Join(tempDir, ".env"), envContent, 0o644)
 	require.NoError(t, err)
@@ -46,11 +61,118 @@ API_URI=https://example.com
 	require.NotNil(t, env)
 	assert.Equal(t, "development", env.AppEnv)
 	assert.Equal(t, "8080", env.AppPort)
+	assert.Equal(t, "9090", env.GrpcPort)
 	assert.Equal(t, "localhost", env.DBHost)
 	assert.Equal(t, "27017", env.DBPort)
 	assert.Equal(t, "dbz", env.DBName)
 	assert.Equal(t, "https://example.com", env.ApiUri)
+	assert.Equal(t, "private, max-age=10", env.HttpCacheControl)
+	assert.Equal(t, 2048, env.HttpCompressMinSize)
+	assert.Equal(t, 6, env.GraphqlMaxDepth)
+	assert.Equal(t, 300, env.GraphqlMaxComplexity)
+	assert.Equal(t, 128, env.EventsHistory)
+	assert.Equal(t, 16, env.EventsClientBuffer)
+	assert.Equal(t, 30*time.Second, env.EventsHeartbeat)
+	assert.Equal(t, "stdout,nats", env.OutboxSinks)
+	assert.Equal(t, "nats://localhost:4222", env.OutboxNatsUrl)
+	assert.Equal(t, 500*time.Millisecond, env.OutboxInterval)
+	assert.Equal(t, 20, env.OutboxBatch)
+	assert.True(t, env.WebhooksEnabled)
+	assert.Equal(t, 4, env.WebhooksMaxAttempts)
 
 	logOutput := buf.String()
 	assert.Contains(t, logOutput, "The App is running in development env")
 }
+
+func TestNewEnv_LoadsRateLimits(t *testing.T) {
+	tempDir := t.TempDir()
+
+	origWD, err := os.Getwd()
+	require.NoError(t, err)
+
+	require.NoError(t, os.Chdir(tempDir))
+	t.Cleanup(func() {
+		_ = os.Chdir(origWD)
+	})
+
+	envContent := []byte(`
+APP_ENV=test
+RATE_LIMIT_IP_RPS=2.5
+RATE_LIMIT_IP_BURST=10
+RATE_LIMIT_UPSTREAM_RPS=1
+RATE_LIMIT_UPSTREAM_BURST=3
+UPSTREAM_RPS=10
+UPSTREAM_MAX_IN_FLIGHT=4
+UPSTREAM_WAIT=true
+`)
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, ".env"), envContent, 0o644))
+
+	env := bootstrap.NewEnv()
+
+	assert.Equal(t, 2.5, env.RateLimitIpRps)
+	assert.Equal(t, 10, env.RateLimitIpBurst)
+	assert.Zero(t, env.RateLimitKeyRps)
+	assert.Equal(t, 1.0, env.RateLimitUpstreamRps)
+	assert.Equal(t, 3, env.RateLimitUpstreamBurst)
+	assert.Equal(t, 10.0, env.UpstreamRps)
+	assert.Zero(t, env.UpstreamBurst)
+	assert.Equal(t, 4, env.UpstreamMaxInFlight)
+	assert.True(t, env.UpstreamWait)
+}
+
+func TestNewEnv_LoadsAuth(t *testing.T) {
+	tempDir := t.TempDir()
+
+	origWD, err := os.Getwd()
+	require.NoError(t, err)
+
+	require.NoError(t, os.Chdir(tempDir))
+	t.Cleanup(func() {
+		_ = os.Chdir(origWD)
+	})
+
+	envContent := []byte(`
+APP_ENV=test
+AUTH_ENABLED=true
+AUTH_ADMIN_KEY=bootstrap-secret
+`)
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, ".env"), envContent, 0o644))
+
+	env := bootstrap.NewEnv()
+
+	assert.True(t, env.AuthEnabled)
+	assert.Equal(t, "bootstrap-secret", env.AuthAdminKey)
+}
+
+func TestNewEnv_LoadsOidc(t *testing.T) {
+	tempDir := t.TempDir()
+
+	origWD, err := os.Getwd()
+	require.NoError(t, err)
+
+	require.NoError(t, os.Chdir(tempDir))
+	t.Cleanup(func() {
+		_ = os.Chdir(origWD)
+	})
+
+	envContent := []byte(`
+APP_ENV=test
+OIDC_ENABLED=true
+OIDC_ISSUER=https://idp.example.com
+OIDC_AUDIENCE=dbz-api
+OIDC_JWKS=./jwks.json
+OIDC_JWKS_REFRESH=15m
+OIDC_API_SCOPES=dbz.read
+`)
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, ".env"), envContent, 0o644))
+
+	env := bootstrap.NewEnv()
+
+	assert.True(t, env.OidcEnabled)
+	assert.Equal(t, "https://idp.example.com", env.OidcIssuer)
+	assert.Equal(t, "dbz-api", env.OidcAudience)
+	assert.Equal(t, "./jwks.json", env.OidcJwks)
+	assert.Equal(t, 15*time.Minute, env.OidcJwksRefresh)
+	assert.Equal(t, "dbz.read", env.OidcApiScopes)
+	assert.Empty(t, env.OidcAdminScopes)
+}
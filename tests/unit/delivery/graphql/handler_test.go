@@ -0,0 +1,357 @@
+package graphql_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	graphqldelivery "github.com/heaveless/dbz-api/internal/delivery/graphql"
+	domain "github.com/heaveless/dbz-api/internal/domain/character"
+	planet "github.com/heaveless/dbz-api/internal/domain/planet"
+	transformation "github.com/heaveless/dbz-api/internal/domain/transformation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockCharacterService struct {
+	mock.Mock
+}
+
+func (m *MockCharacterService) GetByName(ctx context.Context, name string) (*domain.CharacterDTO, error) {
+	args := m.Called(ctx, name)
+
+	var chr *domain.CharacterDTO
+	if v := args.Get(0); v != nil {
+		chr = v.(*domain.CharacterDTO)
+	}
+	return chr, args.Error(1)
+}
+
+func (m *MockCharacterService) GetByIds(ctx context.Context, ids []int64) []domain.CharacterResult {
+	args := m.Called(ctx, ids)
+	return args.Get(0).([]domain.CharacterResult)
+}
+
+func (m *MockCharacterService) List(ctx context.Context, q domain.ListQuery) (*domain.CharacterPageDTO, error) {
+	args := m.Called(ctx, q)
+
+	var page *domain.CharacterPageDTO
+	if v := args.Get(0); v != nil {
+		page = v.(*domain.CharacterPageDTO)
+	}
+	return page, args.Error(1)
+}
+
+type MockPlanetService struct {
+	mock.Mock
+}
+
+func (m *MockPlanetService) GetById(ctx context.Context, id int64) (*planet.PlanetDTO, error) {
+	args := m.Called(ctx, id)
+
+	var p *planet.PlanetDTO
+	if v := args.Get(0); v != nil {
+		p = v.(*planet.PlanetDTO)
+	}
+	return p, args.Error(1)
+}
+
+func (m *MockPlanetService) List(ctx context.Context) ([]planet.PlanetDTO, error) {
+	args := m.Called(ctx)
+
+	var ps []planet.PlanetDTO
+	if v := args.Get(0); v != nil {
+		ps = v.([]planet.PlanetDTO)
+	}
+	return ps, args.Error(1)
+}
+
+func (m *MockPlanetService) CharactersByPlanets(ctx context.Context, planetIds []int64) []planet.PlanetResidents {
+	return m.Called(ctx, planetIds).Get(0).([]planet.PlanetResidents)
+}
+
+type MockTransformationService struct {
+	mock.Mock
+}
+
+func (m *MockTransformationService) ListByCharacters(ctx context.Context, characterIds []int64) []transformation.CharacterTransformations {
+	return m.Called(ctx, characterIds).Get(0).([]transformation.CharacterTransformations)
+}
+
+var (
+	goku    = domain.CharacterDTO{Id: 1, Name: "Goku", Ki: "60.000.000", MaxKi: "90 Septillion", Race: "Saiyan"}
+	vegeta  = domain.CharacterDTO{Id: 2, Name: "Vegeta", Ki: "54.000.000", MaxKi: "19.84 Septillion", Race: "Saiyan"}
+	piccolo = domain.CharacterDTO{Id: 3, Name: "Piccolo", Ki: "2.000.000", MaxKi: "500.000.000", Race: "Namekian"}
+)
+
+type mocks struct {
+	characters      *MockCharacterService
+	planets         *MockPlanetService
+	transformations *MockTransformationService
+}
+
+func setup(limits graphqldelivery.Limits) (*gin.Engine, mocks) {
+	m := mocks{new(MockCharacterService), new(MockPlanetService), new(MockTransformationService)}
+	h := graphqldelivery.NewHandler(graphqldelivery.Services{
+		Characters:      m.characters,
+		Planets:         m.planets,
+		Transformations: m.transformations,
+	}, limits)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/graphql", h.Serve)
+	r.POST("/graphql", h.Serve)
+	return r, m
+}
+
+type response struct {
+	Data   map[string]any `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+func post(t *testing.T, r *gin.Engine, query string, vars map[string]any) (int, response) {
+	t.Helper()
+
+	body, err := json.Marshal(map[string]any{"query": query, "variables": vars})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var res response
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res), w.Body.String())
+	return w.Code, res
+}
+
+func TestGraphQL_RosterBatchesTransformations(t *testing.T) {
+	r, m := setup(graphqldelivery.Limits{})
+
+	m.characters.On("List", mock.Anything, mock.MatchedBy(func(q domain.ListQuery) bool {
+		return q.Page == 1 && q.Limit == 3
+	})).Return(&domain.CharacterPageDTO{Items: []domain.CharacterDTO{goku, vegeta, piccolo}, Page: 1, Limit: 3, Total: 3}, nil)
+	m.transformations.On("ListByCharacters", mock.Anything, []int64{1, 2, 3}).Return([]transformation.CharacterTransformations{
+		{CharacterId: 1, Transformations: []transformation.TransformationDTO{{Id: 1, CharacterId: 1, Name: "Goku SSJ"}, {Id: 2, CharacterId: 1, Name: "Goku SSJ2"}}},
+		{CharacterId: 2, Transformations: []transformation.TransformationDTO{{Id: 7, CharacterId: 2, Name: "Vegeta SSJ"}}},
+		{CharacterId: 3, Transformations: []transformation.TransformationDTO{}},
+	})
+
+	code, res := post(t, r, `{ characters(limit: 3) { totalPages items { name transformations { name } } } }`, nil)
+
+	require.Equal(t, http.StatusOK, code)
+	require.Empty(t, res.Errors)
+	page := res.Data["characters"].(map[string]any)
+	items := page["items"].([]any)
+	require.Len(t, items, 3)
+	assert.Len(t, items[0].(map[string]any)["transformations"], 2)
+	assert.Equal(t, "Vegeta SSJ", items[1].(map[string]any)["transformations"].([]any)[0].(map[string]any)["name"])
+	assert.Empty(t, items[2].(map[string]any)["transformations"])
+	assert.EqualValues(t, 1, page["totalPages"])
+	m.transformations.AssertNumberOfCalls(t, "ListByCharacters", 1)
+}
+
+func TestGraphQL_CharactersByIdBatchesEachLevel(t *testing.T) {
+	r, m := setup(graphqldelivery.Limits{})
+
+	m.characters.On("GetByIds", mock.Anything, []int64{1, 2, 404}).Return([]domain.CharacterResult{
+		{Ref: domain.CharacterRef{Id: 1}, Character: &goku},
+		{Ref: domain.CharacterRef{Id: 2}, Character: &vegeta},
+		{Ref: domain.CharacterRef{Id: 404}, Err: domain.ErrNotFound},
+	})
+	m.transformations.On("ListByCharacters", mock.Anything, []int64{1, 2}).Return([]transformation.CharacterTransformations{
+		{CharacterId: 1, Transformations: []transformation.TransformationDTO{{Id: 1, CharacterId: 1, Name: "Goku SSJ"}}},
+		{CharacterId: 2, Transformations: []transformation.TransformationDTO{{Id: 7, CharacterId: 2, Name: "Vegeta SSJ"}}},
+	})
+
+	code, res := post(t, r, `query($ids: [Int!]!) { charactersById(ids: $ids) { name transformations { character { name } } } }`,
+		map[string]any{"ids": []int{1, 2, 404}})
+
+	require.Equal(t, http.StatusOK, code)
+	require.Empty(t, res.Errors)
+	chrs := res.Data["charactersById"].([]any)
+	require.Len(t, chrs, 3)
+	assert.Equal(t, "Vegeta", chrs[1].(map[string]any)["name"])
+	assert.Nil(t, chrs[2])
+	owner := chrs[0].(map[string]any)["transformations"].([]any)[0].(map[string]any)["character"]
+	assert.Equal(t, "Goku", owner.(map[string]any)["name"])
+
+	// The owners of the transformations were already loaded on the first
+	// level, so the loader serves them without another call.
+	m.characters.AssertNumberOfCalls(t, "GetByIds", 1)
+	m.transformations.AssertNumberOfCalls(t, "ListByCharacters", 1)
+}
+
+func TestGraphQL_PlanetsBatchResidents(t *testing.T) {
+	r, m := setup(graphqldelivery.Limits{})
+
+	m.planets.On("List", mock.Anything).Return([]planet.PlanetDTO{{Id: 1, Name: "Namek"}, {Id: 2, Name: "Tierra"}}, nil)
+	m.planets.On("CharactersByPlanets", mock.Anything, []int64{1, 2}).Return([]planet.PlanetResidents{
+		{PlanetId: 1, Residents: []domain.CharacterDTO{piccolo}},
+		{PlanetId: 2, Err: errors.New("api down")},
+	})
+
+	code, res := post(t, r, `{ planets { name residents { name } } }`, nil)
+
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, res.Errors, 1)
+	planets := res.Data["planets"].([]any)
+	require.Len(t, planets, 2)
+	assert.Equal(t, "Piccolo", planets[0].(map[string]any)["residents"].([]any)[0].(map[string]any)["name"])
+	// The failed planet only nulls its own residents.
+	assert.Nil(t, planets[1].(map[string]any)["residents"])
+	m.planets.AssertNumberOfCalls(t, "CharactersByPlanets", 1)
+}
+
+func TestGraphQL_CharacterByName(t *testing.T) {
+	r, m := setup(graphqldelivery.Limits{})
+	m.characters.On("GetByName", mock.Anything, "Goku").Return(&goku, nil)
+	m.characters.On("GetByName", mock.Anything, "Nobody").Return(nil, domain.ErrNotFound)
+
+	_, res := post(t, r, `{ goku: character(name: "Goku") { id maxKi } nobody: character(name: "Nobody") { id } }`, nil)
+
+	require.Empty(t, res.Errors)
+	assert.Equal(t, "90 Septillion", res.Data["goku"].(map[string]any)["maxKi"])
+	assert.Nil(t, res.Data["nobody"])
+}
+
+func TestGraphQL_HidesInternalErrors(t *testing.T) {
+	r, m := setup(graphqldelivery.Limits{})
+	m.planets.On("GetById", mock.Anything, int64(1)).Return(&planet.PlanetDTO{Id: 1, Name: "Namek"}, nil)
+	m.planets.On("CharactersByPlanets", mock.Anything, []int64{1}).Return([]planet.PlanetResidents{
+		{PlanetId: 1, Err: errors.New("mongo: connection refused")},
+	})
+
+	code, res := post(t, r, `{ namek: planet(id: 1) { name } residents: planet(id: 1) { residents { name } } }`, nil)
+
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, res.Errors, 1)
+	assert.Equal(t, "Service temporarily unavailable, please try again later.", res.Errors[0].Message)
+	assert.Equal(t, "Namek", res.Data["namek"].(map[string]any)["name"])
+	assert.Nil(t, res.Data["residents"].(map[string]any)["residents"])
+}
+
+func TestGraphQL_InvalidArguments(t *testing.T) {
+	r, _ := setup(graphqldelivery.Limits{})
+
+	queries := map[string]string{
+		"id and name":    `{ character(id: 1, name: "Goku") { id } }`,
+		"markup in name": `{ character(name: "<b>Goku</b>") { id } }`,
+		"bad power":      `{ characters(minKi: "unknown") { total } }`,
+		"bad sort":       `{ characters(sort: "race") { total } }`,
+	}
+
+	for name, query := range queries {
+		t.Run(name, func(t *testing.T) {
+			_, res := post(t, r, query, nil)
+			assert.Len(t, res.Errors, 1)
+		})
+	}
+}
+
+func TestGraphQL_RejectsBeforeRunning(t *testing.T) {
+	r, m := setup(graphqldelivery.Limits{MaxDepth: 4, MaxComplexity: 200})
+
+	tests := []struct {
+		name    string
+		query   string
+		message string
+	}{
+		{name: "syntax", query: `{ character(id: 1) { name }`},
+		{name: "unknown field", query: `{ character(id: 1) { power } }`},
+		{
+			name:    "too deep",
+			query:   `{ character(id: 1) { transformations { character { transformations { name } } } } }`,
+			message: "the query is 5 levels deep, the limit is 4",
+		},
+		{
+			name:    "too deep through fragments",
+			query:   `{ character(id: 1) { ...forms } } fragment forms on Character { transformations { character { transformations { name } } } }`,
+			message: "the query is 5 levels deep, the limit is 4",
+		},
+		{
+			name:    "too complex",
+			query:   `{ characters(limit: 50) { items { name transformations { name ki } } } }`,
+			message: "the query has a complexity of 1151, the limit is 200",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, res := post(t, r, tt.query, nil)
+
+			assert.Equal(t, http.StatusBadRequest, code)
+			require.NotEmpty(t, res.Errors)
+			if tt.message != "" {
+				assert.Equal(t, tt.message, res.Errors[0].Message)
+			}
+		})
+	}
+
+	m.characters.AssertNotCalled(t, "GetByIds", mock.Anything, mock.Anything)
+	m.characters.AssertNotCalled(t, "List", mock.Anything, mock.Anything)
+}
+
+func TestGraphQL_ComplexityFollowsVariables(t *testing.T) {
+	r, m := setup(graphqldelivery.Limits{MaxComplexity: 100})
+	m.characters.On("List", mock.Anything, mock.Anything).Return(&domain.CharacterPageDTO{Page: 1, Limit: 2}, nil)
+
+	query := `query($limit: Int) { characters(limit: $limit) { items { transformations { name } } } }`
+
+	code, _ := post(t, r, query, map[string]any{"limit": 50})
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	code, _ = post(t, r, query, map[string]any{"limit": 2})
+	assert.Equal(t, http.StatusOK, code)
+}
+
+func TestGraphQL_IntrospectionIsNotLimited(t *testing.T) {
+	r, _ := setup(graphqldelivery.Limits{MaxDepth: 2})
+
+	code, res := post(t, r, `{ __schema { queryType { fields { name type { ofType { ofType { name } } } } } } }`, nil)
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Empty(t, res.Errors)
+}
+
+func TestGraphQL_Get(t *testing.T) {
+	r, m := setup(graphqldelivery.Limits{})
+	m.planets.On("List", mock.Anything).Return([]planet.PlanetDTO{{Id: 1, Name: "Namek"}, {Id: 2, Name: "Vegeta", IsDestroyed: true}}, nil)
+
+	q := url.Values{"query": {`query Planets { planets { name isDestroyed } }`}, "operationName": {"Planets"}}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/graphql?"+q.Encode(), nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"data":{"planets":[{"name":"Namek","isDestroyed":false},{"name":"Vegeta","isDestroyed":true}]}}`, w.Body.String())
+}
+
+func TestGraphQL_MalformedRequests(t *testing.T) {
+	r, _ := setup(graphqldelivery.Limits{})
+
+	requests := map[string]*http.Request{
+		"body is not json": httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewBufferString("{ planets { name } }")),
+		"no query":         httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewBufferString(`{"variables":{}}`)),
+		"bad variables":    httptest.NewRequest(http.MethodGet, "/graphql?query=%7Bplanets%7Bname%7D%7D&variables=nope", nil),
+	}
+
+	for name, req := range requests {
+		t.Run(name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Contains(t, w.Body.String(), `"errors"`)
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package grpc_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	grpcdelivery "github.com/heaveless/dbz-api/internal/delivery/grpc"
+	"github.com/heaveless/dbz-api/internal/delivery/pb/dbzv1"
+	"github.com/heaveless/dbz-api/internal/domain/apikey"
+	"github.com/heaveless/dbz-api/internal/infrastructure/oidc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type fakeKeys map[string]*apikey.Principal
+
+func (f fakeKeys) Authenticate(_ context.Context, key string, scope string) (*apikey.Principal, error) {
+	switch key {
+	case "spent":
+		return nil, &apikey.QuotaExceededError{Quota: 10, Reset: time.Now().Add(time.Hour)}
+	case "broken":
+		return nil, errors.New("db down")
+	}
+
+	p, ok := f[key]
+	if !ok {
+		return nil, apikey.ErrInvalidKey
+	}
+	if !p.HasScope(scope) {
+		return nil, apikey.ErrScopeMissing
+	}
+	return p, nil
+}
+
+const (
+	readerToken = "h.reader.s"
+	otherToken  = "h.other.s"
+	downToken   = "h.down.s"
+)
+
+type fakeTokens struct{}
+
+func (fakeTokens) Verify(_ context.Context, token string) (*oidc.Claims, error) {
+	switch token {
+	case readerToken:
+		return &oidc.Claims{Subject: "svc-reports", Scopes: []string{"dbz.read"}}, nil
+	case otherToken:
+		return &oidc.Claims{Subject: "svc-other", Scopes: []string{"other"}}, nil
+	case downToken:
+		return nil, oidc.ErrKeysUnavailable
+	}
+
+	return nil, oidc.ErrInvalidToken
+}
+
+func TestAuth_Unary(t *testing.T) {
+	svc := new(MockCharacterService)
+	svc.On("GetById", mock.Anything, int64(1)).Return(goku, nil)
+
+	auth := &grpcdelivery.Auth{
+		Keys: fakeKeys{
+			"reader":  {KeyId: "k1", Scopes: []string{apikey.ScopeRead}},
+			"nothing": {KeyId: "k2"},
+		},
+		KeyScope:    apikey.ScopeRead,
+		Tokens:      fakeTokens{},
+		TokenScopes: []string{"dbz.read"},
+	}
+	conn := dial(t, grpcdelivery.NewServer(grpcdelivery.NewCharacterServer(svc), auth, nil))
+	client := dbzv1.NewCharacterServiceClient(conn)
+
+	tests := []struct {
+		name string
+		md   []string
+		code codes.Code
+	}{
+		{name: "no credentials", code: codes.Unauthenticated},
+		{name: "api key header", md: []string{"x-api-key", "reader"}, code: codes.OK},
+		{name: "api key as bearer", md: []string{"authorization", "Bearer reader"}, code: codes.OK},
+		{name: "unknown key", md: []string{"x-api-key", "stolen"}, code: codes.Unauthenticated},
+		{name: "key without scope", md: []string{"x-api-key", "nothing"}, code: codes.PermissionDenied},
+		{name: "spent quota", md: []string{"x-api-key", "spent"}, code: codes.ResourceExhausted},
+		{name: "key store down", md: []string{"x-api-key", "broken"}, code: codes.Unavailable},
+		{name: "token", md: []string{"authorization", "Bearer " + readerToken}, code: codes.OK},
+		{name: "token without scope", md: []string{"authorization", "Bearer " + otherToken}, code: codes.PermissionDenied},
+		{name: "invalid token", md: []string{"authorization", "Bearer h.forged.s"}, code: codes.Unauthenticated},
+		{name: "keys unavailable", md: []string{"authorization", "Bearer " + downToken}, code: codes.Unavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.AppendToOutgoingContext(context.Background(), tt.md...)
+
+			_, err := client.GetById(ctx, &dbzv1.GetByIdRequest{Id: 1})
+
+			assert.Equal(t, tt.code, status.Code(err), "%v", err)
+		})
+	}
+
+	_, err := healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{})
+	require.NoError(t, err, "health checks need no credentials")
+}
+
+func TestAuth_TokensOnly(t *testing.T) {
+	auth := &grpcdelivery.Auth{Tokens: fakeTokens{}}
+	client := dbzv1.NewCharacterServiceClient(dial(t, grpcdelivery.NewServer(grpcdelivery.NewCharacterServer(new(MockCharacterService)), auth, nil)))
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-api-key", "reader")
+	_, err := client.GetById(ctx, &dbzv1.GetByIdRequest{Id: 1})
+
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
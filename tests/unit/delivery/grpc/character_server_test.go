@@ -0,0 +1,221 @@
+package grpc_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/heaveless/dbz-api/internal/application"
+	grpcdelivery "github.com/heaveless/dbz-api/internal/delivery/grpc"
+	"github.com/heaveless/dbz-api/internal/delivery/pb/dbzv1"
+	domain "github.com/heaveless/dbz-api/internal/domain/character"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type MockCharacterService struct {
+	mock.Mock
+}
+
+func (m *MockCharacterService) GetByName(ctx context.Context, name string) (*domain.CharacterDTO, error) {
+	args := m.Called(ctx, name)
+
+	var chr *domain.CharacterDTO
+	if v := args.Get(0); v != nil {
+		chr = v.(*domain.CharacterDTO)
+	}
+	return chr, args.Error(1)
+}
+
+func (m *MockCharacterService) GetById(ctx context.Context, id int64) (*domain.CharacterDTO, error) {
+	args := m.Called(ctx, id)
+
+	var chr *domain.CharacterDTO
+	if v := args.Get(0); v != nil {
+		chr = v.(*domain.CharacterDTO)
+	}
+	return chr, args.Error(1)
+}
+
+func (m *MockCharacterService) List(ctx context.Context, q domain.ListQuery) (*domain.CharacterPageDTO, error) {
+	args := m.Called(ctx, q)
+
+	var page *domain.CharacterPageDTO
+	if v := args.Get(0); v != nil {
+		page = v.(*domain.CharacterPageDTO)
+	}
+	return page, args.Error(1)
+}
+
+func (m *MockCharacterService) GetBatch(ctx context.Context, refs []domain.CharacterRef) []domain.CharacterResult {
+	args := m.Called(ctx, refs)
+	return args.Get(0).([]domain.CharacterResult)
+}
+
+var goku = &domain.CharacterDTO{Id: 1, Name: "Goku", Ki: "60.000.000", MaxKi: "90 Septillion", Race: "Saiyan"}
+
+// dial serves srv over an in-memory listener and returns a client
+// connection to it.
+func dial(t *testing.T, srv *grpc.Server) *grpc.ClientConn {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return conn
+}
+
+func newClient(t *testing.T, svc *MockCharacterService) dbzv1.CharacterServiceClient {
+	return dbzv1.NewCharacterServiceClient(dial(t, grpcdelivery.NewServer(grpcdelivery.NewCharacterServer(svc), nil, nil)))
+}
+
+func TestCharacterServer_GetByName(t *testing.T) {
+	svc := new(MockCharacterService)
+	svc.On("GetByName", mock.Anything, "Goku").Return(goku, nil)
+	svc.On("GetByName", mock.Anything, "Nobody").Return(nil, domain.ErrNotFound)
+	client := newClient(t, svc)
+
+	chr, err := client.GetByName(context.Background(), &dbzv1.GetByNameRequest{Name: "Goku"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), chr.GetId())
+	assert.Equal(t, "90 Septillion", chr.GetMaxKi())
+
+	_, err = client.GetByName(context.Background(), &dbzv1.GetByNameRequest{Name: "Nobody"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	svc.AssertExpectations(t)
+}
+
+func TestCharacterServer_InvalidArguments(t *testing.T) {
+	client := newClient(t, new(MockCharacterService))
+	ctx := context.Background()
+
+	calls := map[string]func() error{
+		"blank name": func() error {
+			_, err := client.GetByName(ctx, &dbzv1.GetByNameRequest{Name: "  "})
+			return err
+		},
+		"markup in name": func() error {
+			_, err := client.GetByName(ctx, &dbzv1.GetByNameRequest{Name: "<b>Goku</b>"})
+			return err
+		},
+		"zero id": func() error {
+			_, err := client.GetById(ctx, &dbzv1.GetByIdRequest{})
+			return err
+		},
+		"unknown power level": func() error {
+			_, err := client.List(ctx, &dbzv1.ListRequest{MinKi: "unknown"})
+			return err
+		},
+		"bad sort": func() error {
+			_, err := client.List(ctx, &dbzv1.ListRequest{Sort: "race"})
+			return err
+		},
+		"negative page": func() error {
+			_, err := client.List(ctx, &dbzv1.ListRequest{Page: -1})
+			return err
+		},
+		"empty batch": func() error {
+			_, err := client.BatchGet(ctx, &dbzv1.BatchGetRequest{})
+			return err
+		},
+		"ref without id or name": func() error {
+			_, err := client.BatchGet(ctx, &dbzv1.BatchGetRequest{Refs: []*dbzv1.CharacterRef{{}}})
+			return err
+		},
+	}
+
+	for name, call := range calls {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, codes.InvalidArgument, status.Code(call()))
+		})
+	}
+}
+
+func TestCharacterServer_GetById_MapsErrors(t *testing.T) {
+	tests := []struct {
+		err  error
+		code codes.Code
+	}{
+		{err: domain.ErrNotFound, code: codes.NotFound},
+		{err: &application.BudgetExceededError{RetryAfter: time.Second}, code: codes.ResourceExhausted},
+		{err: context.DeadlineExceeded, code: codes.DeadlineExceeded},
+		{err: errors.New("upstream down"), code: codes.Unavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code.String(), func(t *testing.T) {
+			svc := new(MockCharacterService)
+			svc.On("GetById", mock.Anything, int64(7)).Return(nil, tt.err)
+
+			_, err := newClient(t, svc).GetById(context.Background(), &dbzv1.GetByIdRequest{Id: 7})
+
+			assert.Equal(t, tt.code, status.Code(err))
+		})
+	}
+}
+
+func TestCharacterServer_List(t *testing.T) {
+	svc := new(MockCharacterService)
+	svc.On("List", mock.Anything, mock.MatchedBy(func(q domain.ListQuery) bool {
+		return q.Page == 1 && q.Limit == 50 && q.MinKi != nil && q.Sort.Field == "maxKi" && q.Sort.Desc
+	})).Return(&domain.CharacterPageDTO{Items: []domain.CharacterDTO{*goku}, Page: 1, Limit: 50, Total: 51}, nil)
+
+	page, err := newClient(t, svc).List(context.Background(), &dbzv1.ListRequest{MinKi: "1.000.000", Sort: "-maxKi", Limit: 500})
+
+	require.NoError(t, err)
+	require.Len(t, page.GetData(), 1)
+	assert.Equal(t, "Goku", page.GetData()[0].GetName())
+	assert.Equal(t, int64(2), page.GetMeta().GetTotalPages())
+	svc.AssertExpectations(t)
+}
+
+func TestCharacterServer_BatchGet(t *testing.T) {
+	svc := new(MockCharacterService)
+	svc.On("GetBatch", mock.Anything, []domain.CharacterRef{{Id: 1}, {Name: "Nobody"}}).Return([]domain.CharacterResult{
+		{Ref: domain.CharacterRef{Id: 1}, Character: goku},
+		{Ref: domain.CharacterRef{Name: "Nobody"}, Err: domain.ErrNotFound},
+	})
+
+	res, err := newClient(t, svc).BatchGet(context.Background(), &dbzv1.BatchGetRequest{Refs: []*dbzv1.CharacterRef{
+		{Ref: &dbzv1.CharacterRef_Id{Id: 1}},
+		{Ref: &dbzv1.CharacterRef_Name{Name: "Nobody"}},
+	}})
+
+	require.NoError(t, err)
+	require.Len(t, res.GetResults(), 2)
+	assert.Equal(t, int32(codes.OK), res.GetResults()[0].GetCode())
+	assert.Equal(t, "Goku", res.GetResults()[0].GetCharacter().GetName())
+	assert.Equal(t, int32(codes.NotFound), res.GetResults()[1].GetCode())
+	assert.Equal(t, "Nobody", res.GetResults()[1].GetRef().GetName())
+	assert.Nil(t, res.GetResults()[1].GetCharacter())
+	svc.AssertExpectations(t)
+}
+
+func TestNewServer_Health(t *testing.T) {
+	conn := dial(t, grpcdelivery.NewServer(grpcdelivery.NewCharacterServer(new(MockCharacterService)), nil, nil))
+	client := healthpb.NewHealthClient(conn)
+
+	for _, service := range []string{"", "dbz.v1.CharacterService"} {
+		res, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		require.NoError(t, err, service)
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, res.GetStatus(), service)
+	}
+}
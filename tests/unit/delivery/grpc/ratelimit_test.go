@@ -0,0 +1,71 @@
+package grpc_test
+
+import (
+	"context"
+	"testing"
+
+	grpcdelivery "github.com/heaveless/dbz-api/internal/delivery/grpc"
+	"github.com/heaveless/dbz-api/internal/delivery/http/middleware"
+	"github.com/heaveless/dbz-api/internal/delivery/pb/dbzv1"
+	"github.com/heaveless/dbz-api/internal/domain/apikey"
+	"github.com/heaveless/dbz-api/internal/infrastructure/ratelimit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestNewServer_RateLimitsPerAddress(t *testing.T) {
+	svc := new(MockCharacterService)
+	svc.On("GetById", mock.Anything, int64(1)).Return(goku, nil)
+	limits := &middleware.RateLimit{
+		Store: ratelimit.NewMemoryStore(),
+		PerIP: ratelimit.Limit{Rate: 0.001, Burst: 1},
+	}
+	conn := dial(t, grpcdelivery.NewServer(grpcdelivery.NewCharacterServer(svc), nil, limits))
+	client := dbzv1.NewCharacterServiceClient(conn)
+
+	_, err := client.GetById(context.Background(), &dbzv1.GetByIdRequest{Id: 1})
+	require.NoError(t, err)
+
+	var header metadata.MD
+	_, err = client.GetById(context.Background(), &dbzv1.GetByIdRequest{Id: 1}, grpc.Header(&header))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.NotEmpty(t, header.Get("retry-after"))
+
+	_, err = healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{})
+	assert.NoError(t, err, "health checks are not limited")
+}
+
+func TestNewServer_RateLimitsPerCaller(t *testing.T) {
+	svc := new(MockCharacterService)
+	svc.On("GetById", mock.Anything, int64(1)).Return(goku, nil)
+	auth := &grpcdelivery.Auth{
+		Keys: fakeKeys{
+			"first":  {KeyId: "k1", Scopes: []string{apikey.ScopeRead}},
+			"second": {KeyId: "k2", Scopes: []string{apikey.ScopeRead}},
+		},
+		KeyScope: apikey.ScopeRead,
+	}
+	limits := &middleware.RateLimit{
+		Store:  ratelimit.NewMemoryStore(),
+		PerKey: ratelimit.Limit{Rate: 0.001, Burst: 1},
+	}
+	client := dbzv1.NewCharacterServiceClient(dial(t, grpcdelivery.NewServer(grpcdelivery.NewCharacterServer(svc), auth, limits)))
+	as := func(key string) context.Context {
+		return metadata.AppendToOutgoingContext(context.Background(), "x-api-key", key)
+	}
+
+	_, err := client.GetById(as("first"), &dbzv1.GetByIdRequest{Id: 1})
+	require.NoError(t, err)
+
+	_, err = client.GetById(as("first"), &dbzv1.GetByIdRequest{Id: 1})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	_, err = client.GetById(as("second"), &dbzv1.GetByIdRequest{Id: 1})
+	assert.NoError(t, err, "each caller has its own bucket")
+}
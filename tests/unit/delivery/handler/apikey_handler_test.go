@@ -0,0 +1,218 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heaveless/dbz-api/internal/delivery/http/handler"
+	"github.com/heaveless/dbz-api/internal/delivery/http/validation"
+	apikey "github.com/heaveless/dbz-api/internal/domain/apikey"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockApiKeyService struct {
+	mock.Mock
+}
+
+func (m *MockApiKeyService) Create(ctx context.Context, req apikey.NewApiKey) (*apikey.IssuedApiKeyDTO, error) {
+	args := m.Called(ctx, req)
+
+	var k *apikey.IssuedApiKeyDTO
+	if v := args.Get(0); v != nil {
+		k = v.(*apikey.IssuedApiKeyDTO)
+	}
+
+	return k, args.Error(1)
+}
+
+func (m *MockApiKeyService) List(ctx context.Context) ([]apikey.ApiKeyDTO, error) {
+	args := m.Called(ctx)
+
+	var ks []apikey.ApiKeyDTO
+	if v := args.Get(0); v != nil {
+		ks = v.([]apikey.ApiKeyDTO)
+	}
+
+	return ks, args.Error(1)
+}
+
+func (m *MockApiKeyService) Rotate(ctx context.Context, id string) (*apikey.IssuedApiKeyDTO, error) {
+	args := m.Called(ctx, id)
+
+	var k *apikey.IssuedApiKeyDTO
+	if v := args.Get(0); v != nil {
+		k = v.(*apikey.IssuedApiKeyDTO)
+	}
+
+	return k, args.Error(1)
+}
+
+func (m *MockApiKeyService) Revoke(ctx context.Context, id string) (*apikey.ApiKeyDTO, error) {
+	args := m.Called(ctx, id)
+
+	var k *apikey.ApiKeyDTO
+	if v := args.Get(0); v != nil {
+		k = v.(*apikey.ApiKeyDTO)
+	}
+
+	return k, args.Error(1)
+}
+
+var frontendKey = apikey.ApiKeyDTO{
+	Id:         "3f2a9c1d7e5b4a60",
+	Name:       "frontend",
+	Prefix:     "dbz_q8Zr1x0P",
+	Scopes:     []string{apikey.ScopeRead},
+	DailyQuota: 1000,
+	CreatedAt:  time.Date(2026, time.October, 19, 15, 30, 0, 0, time.UTC),
+}
+
+func setupApiKeyRouter(svc *MockApiKeyService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	h := handler.NewApiKeyHandler(svc)
+
+	r := gin.New()
+	r.GET("/admin/keys", h.List)
+	r.POST("/admin/keys", h.Create)
+	r.POST("/admin/keys/:id/rotate", h.Rotate)
+	r.DELETE("/admin/keys/:id", h.Revoke)
+
+	return r
+}
+
+func TestApiKeyHandler_Create_OK(t *testing.T) {
+	svc := new(MockApiKeyService)
+	svc.On("Create", mock.Anything, apikey.NewApiKey{Name: "frontend", Scopes: []string{"read"}, DailyQuota: 1000}).
+		Return(&apikey.IssuedApiKeyDTO{ApiKeyDTO: frontendKey, Key: "dbz_q8Zr1x0Psecret"}, nil)
+
+	req, _ := http.NewRequest(http.MethodPost, "/admin/keys", bytes.NewBufferString(`{"name":"frontend","scopes":["read"],"dailyQuota":1000}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	setupApiKeyRouter(svc).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var resp struct {
+		Data apikey.IssuedApiKeyDTO `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "dbz_q8Zr1x0Psecret", resp.Data.Key)
+	svc.AssertExpectations(t)
+}
+
+func TestApiKeyHandler_Create_FieldErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []validation.FieldError
+	}{
+		{
+			name: "missing fields",
+			body: `{}`,
+			want: []validation.FieldError{{Field: "name", Code: "required"}, {Field: "scopes", Code: "required"}},
+		},
+		{
+			name: "unknown scope",
+			body: `{"name":"frontend","scopes":["root"]}`,
+			want: []validation.FieldError{{Field: "scopes[0]", Code: "invalid"}},
+		},
+		{
+			name: "negative quota",
+			body: `{"name":"frontend","scopes":["read"],"dailyQuota":-1}`,
+			want: []validation.FieldError{{Field: "dailyQuota", Code: "too_short"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := new(MockApiKeyService)
+
+			req, _ := http.NewRequest(http.MethodPost, "/admin/keys", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			setupApiKeyRouter(svc).ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+
+			var resp struct {
+				Errors []validation.FieldError `json:"errors"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			assert.Equal(t, tt.want, resp.Errors)
+			svc.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestApiKeyHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		setup  func(svc *MockApiKeyService)
+		status int
+	}{
+		{
+			name:   "rotate unknown key",
+			method: http.MethodPost,
+			path:   "/admin/keys/k9/rotate",
+			setup: func(svc *MockApiKeyService) {
+				svc.On("Rotate", mock.Anything, "k9").Return(nil, apikey.ErrNotFound)
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "rotate revoked key",
+			method: http.MethodPost,
+			path:   "/admin/keys/k1/rotate",
+			setup: func(svc *MockApiKeyService) {
+				svc.On("Rotate", mock.Anything, "k1").Return(nil, apikey.ErrRevoked)
+			},
+			status: http.StatusConflict,
+		},
+		{
+			name:   "revoke with store down",
+			method: http.MethodDelete,
+			path:   "/admin/keys/k1",
+			setup: func(svc *MockApiKeyService) {
+				svc.On("Revoke", mock.Anything, "k1").Return(nil, errors.New("db down"))
+			},
+			status: http.StatusServiceUnavailable,
+		},
+		{
+			name:   "list with store down",
+			method: http.MethodGet,
+			path:   "/admin/keys",
+			setup: func(svc *MockApiKeyService) {
+				svc.On("List", mock.Anything).Return(nil, errors.New("db down"))
+			},
+			status: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := new(MockApiKeyService)
+			tt.setup(svc)
+
+			req, _ := http.NewRequest(tt.method, tt.path, nil)
+			w := httptest.NewRecorder()
+
+			setupApiKeyRouter(svc).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.status, w.Code)
+		})
+	}
+}
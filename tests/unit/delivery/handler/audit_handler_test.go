@@ -0,0 +1,118 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heaveless/dbz-api/internal/delivery/http/handler"
+	"github.com/heaveless/dbz-api/internal/domain/audit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockAuditService struct {
+	mock.Mock
+}
+
+func (m *MockAuditService) Query(ctx context.Context, q audit.Query) (*audit.EntryPageDTO, error) {
+	args := m.Called(ctx, q)
+
+	var page *audit.EntryPageDTO
+	if v := args.Get(0); v != nil {
+		page = v.(*audit.EntryPageDTO)
+	}
+
+	return page, args.Error(1)
+}
+
+var affiliationChange = audit.Entry{
+	Id:        "6650a1f0c2a4b1e3d4f5a6b7",
+	At:        time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+	Actor:     audit.Actor{Id: "k1", Name: "ops"},
+	Action:    audit.CharacterUpdated,
+	Target:    "character:2",
+	Changes:   []audit.Change{{Field: "affiliation", Before: "Army of Frieza", After: "Z Fighter"}},
+	RequestId: "req-1",
+}
+
+func serveAudit(svc *MockAuditService, target string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.GET("/admin/audit", handler.NewAuditHandler(svc).List)
+
+	req, _ := http.NewRequest(http.MethodGet, target, nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	return w
+}
+
+func TestAuditHandler_List_OK(t *testing.T) {
+	svc := new(MockAuditService)
+	svc.On("Query", mock.Anything, audit.Query{
+		Actor:  "ops",
+		Target: "character:2",
+		Field:  "affiliation",
+		From:   time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		Page:   1,
+		Limit:  20,
+	}).Return(&audit.EntryPageDTO{Items: []audit.Entry{affiliationChange}, Page: 1, Limit: 20, Total: 1}, nil)
+
+	w := serveAudit(svc, "/admin/audit?actor=ops&target=character:2&field=affiliation&from=2024-05-01T02:00:00%2B02:00")
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var body struct {
+		Data []audit.Entry  `json:"data"`
+		Meta map[string]int `json:"meta"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, []audit.Entry{affiliationChange}, body.Data)
+	assert.Equal(t, 1, body.Meta["total"])
+	svc.AssertExpectations(t)
+}
+
+func TestAuditHandler_List_LimitIsCapped(t *testing.T) {
+	svc := new(MockAuditService)
+	svc.
+		On("Query", mock.Anything, audit.Query{Page: 3, Limit: 100}).
+		Return(&audit.EntryPageDTO{Items: []audit.Entry{}, Page: 3, Limit: 100}, nil)
+
+	w := serveAudit(svc, "/admin/audit?page=3&limit=1000")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	svc.AssertExpectations(t)
+}
+
+func TestAuditHandler_List_BadRequest(t *testing.T) {
+	for _, target := range []string{
+		"/admin/audit?from=yesterday",
+		"/admin/audit?to=2024-05-01",
+		"/admin/audit?page=0",
+	} {
+		t.Run(target, func(t *testing.T) {
+			svc := new(MockAuditService)
+
+			w := serveAudit(svc, target)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			svc.AssertNotCalled(t, "Query", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestAuditHandler_List_ServiceError(t *testing.T) {
+	svc := new(MockAuditService)
+	svc.On("Query", mock.Anything, mock.Anything).Return(nil, errors.New("db down"))
+
+	w := serveAudit(svc, "/admin/audit")
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
@@ -0,0 +1,254 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heaveless/dbz-api/internal/delivery/http/handler"
+	"github.com/heaveless/dbz-api/internal/delivery/http/middleware"
+	"github.com/heaveless/dbz-api/internal/delivery/http/validation"
+	"github.com/heaveless/dbz-api/internal/domain/apikey"
+	"github.com/heaveless/dbz-api/internal/domain/audit"
+	domain "github.com/heaveless/dbz-api/internal/domain/character"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockCurationService struct {
+	mock.Mock
+}
+
+func (m *MockCurationService) Create(ctx context.Context, id int64, p domain.CharacterPatch) (*domain.CuratedCharacterDTO, error) {
+	args := m.Called(ctx, id, p)
+
+	var chr *domain.CuratedCharacterDTO
+	if v := args.Get(0); v != nil {
+		chr = v.(*domain.CuratedCharacterDTO)
+	}
+
+	return chr, args.Error(1)
+}
+
+func (m *MockCurationService) Update(ctx context.Context, id int64, p domain.CharacterPatch) (*domain.CuratedCharacterDTO, error) {
+	args := m.Called(ctx, id, p)
+
+	var chr *domain.CuratedCharacterDTO
+	if v := args.Get(0); v != nil {
+		chr = v.(*domain.CuratedCharacterDTO)
+	}
+
+	return chr, args.Error(1)
+}
+
+func (m *MockCurationService) Delete(ctx context.Context, id int64) (*domain.CuratedCharacterDTO, error) {
+	args := m.Called(ctx, id)
+
+	var chr *domain.CuratedCharacterDTO
+	if v := args.Get(0); v != nil {
+		chr = v.(*domain.CuratedCharacterDTO)
+	}
+
+	return chr, args.Error(1)
+}
+
+var curatedVegeta = domain.CuratedCharacterDTO{
+	CharacterDTO: domain.CharacterDTO{
+		Id:          2,
+		Name:        "Vegeta",
+		Ki:          "54.000.000",
+		MaxKi:       "19.84 Septillion",
+		Race:        "Saiyan",
+		Gender:      "Male",
+		Affiliation: "Z Fighter",
+	},
+	Overridden: []string{"affiliation"},
+}
+
+type opsAuthenticator struct{}
+
+func (opsAuthenticator) Authenticate(context.Context, string, string) (*apikey.Principal, error) {
+	return &apikey.Principal{KeyId: "k1", Name: "ops", Scopes: []string{apikey.ScopeAdmin}}, nil
+}
+
+func setupCharacterAdminRouter(svc *MockCurationService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	h := handler.NewCharacterAdminHandler(svc)
+
+	r := gin.New()
+	admin := r.Group("/admin", middleware.Authenticated(opsAuthenticator{}, apikey.ScopeAdmin))
+	admin.POST("/characters", h.Create)
+	admin.PUT("/characters/:id", h.Replace)
+	admin.PATCH("/characters/:id", h.Patch)
+	admin.DELETE("/characters/:id", h.Delete)
+
+	return r
+}
+
+func serveCharacterAdmin(svc *MockCurationService, method, path, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-api-key", "dbz_ops")
+	w := httptest.NewRecorder()
+
+	setupCharacterAdminRouter(svc).ServeHTTP(w, req)
+
+	return w
+}
+
+func byOps(ctx context.Context) bool {
+	return audit.ActorFrom(ctx) == audit.Actor{Id: "k1", Name: "ops"}
+}
+
+func TestCharacterAdminHandler_Create_OK(t *testing.T) {
+	svc := new(MockCurationService)
+	svc.On("Create", mock.MatchedBy(byOps), int64(1001), domain.CharacterPatch{
+		"name": "Gogeta", "ki": "unknown", "maxKi": "unknown", "race": "Saiyan",
+		"gender": "", "image": "", "affiliation": "", "description": "",
+	}).Return(&curatedVegeta, nil)
+
+	w := serveCharacterAdmin(svc, http.MethodPost, "/admin/characters", `{"id":1001,"name":"Gogeta","ki":"unknown","maxKi":"unknown","race":"Saiyan"}`)
+
+	assert.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	svc.AssertExpectations(t)
+}
+
+func TestCharacterAdminHandler_Create_FieldErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []validation.FieldError
+	}{
+		{
+			name: "missing id",
+			body: `{"name":"Gogeta","ki":"1","maxKi":"1","race":"Saiyan"}`,
+			want: []validation.FieldError{{Field: "id", Code: validation.CodeRequired}},
+		},
+		{
+			name: "missing fields",
+			body: `{"id":1001}`,
+			want: []validation.FieldError{
+				{Field: "name", Code: validation.CodeRequired},
+				{Field: "ki", Code: validation.CodeRequired},
+				{Field: "maxKi", Code: validation.CodeRequired},
+				{Field: "race", Code: validation.CodeRequired},
+			},
+		},
+		{
+			name: "not a power level",
+			body: `{"id":1001,"name":"Gogeta","ki":"over 9000!","maxKi":"1","race":"Saiyan"}`,
+			want: []validation.FieldError{{Field: "ki", Code: validation.CodeInvalid}},
+		},
+		{
+			name: "not an image url",
+			body: `{"id":1001,"name":"Gogeta","ki":"1","maxKi":"1","race":"Saiyan","image":"gogeta.png"}`,
+			want: []validation.FieldError{{Field: "image", Code: validation.CodeInvalid}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := new(MockCurationService)
+
+			w := serveCharacterAdmin(svc, http.MethodPost, "/admin/characters", tt.body)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+
+			var resp struct {
+				Errors []validation.FieldError `json:"errors"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			assert.Equal(t, tt.want, resp.Errors)
+			svc.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestCharacterAdminHandler_Replace_SetsEveryField(t *testing.T) {
+	svc := new(MockCurationService)
+	svc.On("Update", mock.MatchedBy(byOps), int64(2), domain.CharacterPatch{
+		"name": "Vegeta", "ki": "54.000.000", "maxKi": "19.84 Septillion", "race": "Saiyan",
+		"gender": "", "image": "", "affiliation": "Z Fighter", "description": "",
+	}).Return(&curatedVegeta, nil)
+
+	w := serveCharacterAdmin(svc, http.MethodPut, "/admin/characters/2",
+		`{"name":"Vegeta","ki":"54.000.000","maxKi":"19.84 Septillion","race":"Saiyan","affiliation":"Z Fighter"}`)
+
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	svc.AssertExpectations(t)
+}
+
+func TestCharacterAdminHandler_Replace_IdMismatch(t *testing.T) {
+	svc := new(MockCurationService)
+
+	w := serveCharacterAdmin(svc, http.MethodPut, "/admin/characters/2",
+		`{"id":3,"name":"Vegeta","ki":"1","maxKi":"1","race":"Saiyan"}`)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `{"message":"The data submitted is invalid.","errors":[{"field":"id","code":"invalid"}]}`, w.Body.String())
+}
+
+func TestCharacterAdminHandler_Patch_SetsOnlyGivenFields(t *testing.T) {
+	svc := new(MockCurationService)
+	svc.On("Update", mock.MatchedBy(byOps), int64(2), domain.CharacterPatch{"affiliation": "Z Fighter", "description": ""}).
+		Return(&curatedVegeta, nil)
+
+	w := serveCharacterAdmin(svc, http.MethodPatch, "/admin/characters/2", `{"affiliation":"Z Fighter","description":""}`)
+
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var resp struct {
+		Data domain.CuratedCharacterDTO `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, []string{"affiliation"}, resp.Data.Overridden)
+	svc.AssertExpectations(t)
+}
+
+func TestCharacterAdminHandler_Patch_Empty(t *testing.T) {
+	svc := new(MockCurationService)
+
+	w := serveCharacterAdmin(svc, http.MethodPatch, "/admin/characters/2", `{}`)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `{"message":"The data submitted is invalid.","errors":[{"field":"body","code":"required"}]}`, w.Body.String())
+}
+
+func TestCharacterAdminHandler_Errors(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{err: fmt.Errorf("character %w", domain.ErrNotFound), want: http.StatusNotFound},
+		{err: fmt.Errorf("character %w", domain.ErrConflict), want: http.StatusConflict},
+		{err: fmt.Errorf("character %w", domain.ErrExists), want: http.StatusConflict},
+		{err: fmt.Errorf("db down"), want: http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			svc := new(MockCurationService)
+			svc.On("Delete", mock.Anything, int64(2)).Return(nil, tt.err)
+
+			w := serveCharacterAdmin(svc, http.MethodDelete, "/admin/characters/2", "")
+
+			assert.Equal(t, tt.want, w.Code)
+		})
+	}
+}
+
+func TestCharacterAdminHandler_Delete_BadId(t *testing.T) {
+	svc := new(MockCurationService)
+
+	w := serveCharacterAdmin(svc, http.MethodDelete, "/admin/characters/goku", "")
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	svc.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
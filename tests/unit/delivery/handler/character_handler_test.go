@@ -5,15 +5,23 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/heaveless/dbz-api/internal/application"
 	"github.com/heaveless/dbz-api/internal/delivery/http/handler"
+	"github.com/heaveless/dbz-api/internal/delivery/pb/dbzv1"
 	domain "github.com/heaveless/dbz-api/internal/domain/character"
+	"github.com/heaveless/dbz-api/internal/infrastructure/breaker"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 )
 
 type MockCharacterService struct {
@@ -31,10 +39,61 @@ func (m *MockCharacterService) GetByName(ctx context.Context, name string) (*dom
 	return chr, args.Error(1)
 }
 
+func (m *MockCharacterService) GetById(ctx context.Context, id int64) (*domain.CharacterDTO, error) {
+	args := m.Called(ctx, id)
+
+	var chr *domain.CharacterDTO
+	if v := args.Get(0); v != nil {
+		chr = v.(*domain.CharacterDTO)
+	}
+
+	return chr, args.Error(1)
+}
+
+func (m *MockCharacterService) Search(ctx context.Context, query string, limit int) ([]domain.CharacterMatchDTO, error) {
+	args := m.Called(ctx, query, limit)
+
+	var matches []domain.CharacterMatchDTO
+	if v := args.Get(0); v != nil {
+		matches = v.([]domain.CharacterMatchDTO)
+	}
+
+	return matches, args.Error(1)
+}
+
+func (m *MockCharacterService) SearchText(ctx context.Context, query string, page, limit int) (*domain.CharacterTextSearchDTO, error) {
+	args := m.Called(ctx, query, page, limit)
+
+	var res *domain.CharacterTextSearchDTO
+	if v := args.Get(0); v != nil {
+		res = v.(*domain.CharacterTextSearchDTO)
+	}
+
+	return res, args.Error(1)
+}
+
+func (m *MockCharacterService) List(ctx context.Context, q domain.ListQuery) (*domain.CharacterPageDTO, error) {
+	args := m.Called(ctx, q)
+
+	var res *domain.CharacterPageDTO
+	if v := args.Get(0); v != nil {
+		res = v.(*domain.CharacterPageDTO)
+	}
+
+	return res, args.Error(1)
+}
+
+func (m *MockCharacterService) GetBatch(ctx context.Context, refs []domain.CharacterRef) []domain.CharacterResult {
+	args := m.Called(ctx, refs)
+	return args.Get(0).([]domain.CharacterResult)
+}
+
 func setupRouter(h *handler.CharacterHandler) *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	r := gin.New()
+	r.GET("/characters", h.List)
 	r.POST("/characters", h.GetOne)
+	r.GET("/characters/search", h.Search)
 	return r
 }
 
@@ -61,6 +120,72 @@ func TestCharacterHandler_GetOne_InvalidBody(t *testing.T) {
 	svc.AssertNotCalled(t, "GetByName", mock.Anything, mock.Anything)
 }
 
+func TestCharacterHandler_GetOne_FieldErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		body  string
+		field string
+		code  string
+	}{
+		{name: "missing", body: `{}`, field: "name", code: "required"},
+		{name: "empty", body: `{"name":""}`, field: "name", code: "required"},
+		{name: "whitespace", body: `{"name":"   "}`, field: "name", code: "blank"},
+		{name: "too long", body: `{"name":"` + strings.Repeat("a", 10*1024) + `"}`, field: "name", code: "too_long"},
+		{name: "control character", body: `{"name":"Goku\u0000"}`, field: "name", code: "invalid_characters"},
+		{name: "markup", body: `{"name":"<script>"}`, field: "name", code: "invalid_characters"},
+		{name: "wrong type", body: `{"name":42}`, field: "name", code: "invalid_type"},
+		{name: "malformed json", body: `{"name":`, field: "body", code: "malformed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := new(MockCharacterService)
+			router := setupRouter(handler.NewCharacterHandler(svc))
+
+			req, _ := http.NewRequest(http.MethodPost, "/characters", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+
+			var resp struct {
+				Message string `json:"message"`
+				Errors  []struct {
+					Field string `json:"field"`
+					Code  string `json:"code"`
+				} `json:"errors"`
+			}
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			assert.Equal(t, "The data submitted is invalid.", resp.Message)
+			if assert.Len(t, resp.Errors, 1) {
+				assert.Equal(t, tt.field, resp.Errors[0].Field)
+				assert.Equal(t, tt.code, resp.Errors[0].Code)
+			}
+
+			svc.AssertNotCalled(t, "GetByName", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestCharacterHandler_GetOne_AcceptsRealNames(t *testing.T) {
+	for _, name := range []string{"Mr. Satan", "Android 17", "Zamasu (Fused)", "Vegetto", "Kaiō-shin", "Mr. Popo"} {
+		svc := new(MockCharacterService)
+		router := setupRouter(handler.NewCharacterHandler(svc))
+		svc.On("GetByName", mock.Anything, name).Return(&domain.CharacterDTO{Name: name}, nil)
+
+		body, _ := json.Marshal(map[string]string{"name": name})
+		req, _ := http.NewRequest(http.MethodPost, "/characters", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusFound, w.Code, name)
+	}
+}
+
 func TestCharacterHandler_GetOne_ServiceError(t *testing.T) {
 	svc := new(MockCharacterService)
 	h := handler.NewCharacterHandler(svc)
@@ -123,3 +248,443 @@ func TestCharacterHandler_GetOne_OK(t *testing.T) {
 
 	svc.AssertExpectations(t)
 }
+
+func TestCharacterHandler_GetOneLegacy_KeepsTheOriginalShape(t *testing.T) {
+	svc := new(MockCharacterService)
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/characters", handler.NewCharacterHandler(svc).GetOneLegacy)
+
+	svc.
+		On("GetByName", mock.Anything, "Goku").
+		Return(&domain.CharacterDTO{Id: 1, Name: "Goku", Description: "Saiyan raised on Earth."}, nil)
+
+	req, _ := http.NewRequest(http.MethodPost, "/characters", bytes.NewBufferString(`{"name":"Goku"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	var resp struct {
+		Data map[string]any `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	keys := []string{}
+	for k := range resp.Data {
+		keys = append(keys, k)
+	}
+	assert.ElementsMatch(t, []string{"Id", "Name", "Ki", "MaxKi", "Race", "Gender", "Image", "Affiliation"}, keys)
+}
+
+func TestCharacterHandler_GetOne_NotFoundWithSuggestions(t *testing.T) {
+	svc := new(MockCharacterService)
+	h := handler.NewCharacterHandler(svc)
+	router := setupRouter(h)
+
+	svc.
+		On("GetByName", mock.Anything, "Vejeta").
+		Return((*domain.CharacterDTO)(nil), fmt.Errorf("character %w", domain.ErrNotFound))
+
+	svc.
+		On("Search", mock.Anything, "Vejeta", 3).
+		Return([]domain.CharacterMatchDTO{{Id: 2, Name: "Vegeta", Score: 0.83}}, nil)
+
+	body := bytes.NewBufferString(`{"name":"Vejeta"}`)
+
+	req, _ := http.NewRequest(http.MethodPost, "/characters", body)
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	var resp struct {
+		Message     string   `json:"message"`
+		Suggestions []string `json:"suggestions"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.NoError(t, err)
+	assert.Equal(t, "character not found", resp.Message)
+	assert.Equal(t, []string{"Vegeta"}, resp.Suggestions)
+
+	svc.AssertExpectations(t)
+}
+
+func TestCharacterHandler_Search_OK(t *testing.T) {
+	svc := new(MockCharacterService)
+	h := handler.NewCharacterHandler(svc)
+	router := setupRouter(h)
+
+	expected := []domain.CharacterMatchDTO{
+		{Id: 5, Name: "Freezer", Score: 0.6},
+	}
+
+	svc.
+		On("Search", mock.Anything, "Frezza", 5).
+		Return(expected, nil)
+
+	req, _ := http.NewRequest(http.MethodGet, "/characters/search?q=Frezza&limit=5", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp struct {
+		Data []domain.CharacterMatchDTO `json:"data"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, resp.Data)
+
+	svc.AssertExpectations(t)
+}
+
+func TestCharacterHandler_Search_InvalidQuery(t *testing.T) {
+	tests := []string{
+		"/characters/search",
+		"/characters/search?q=goku&limit=0",
+		"/characters/search?q=goku&limit=abc",
+	}
+
+	for _, target := range tests {
+		t.Run(target, func(t *testing.T) {
+			svc := new(MockCharacterService)
+			h := handler.NewCharacterHandler(svc)
+			router := setupRouter(h)
+
+			req, _ := http.NewRequest(http.MethodGet, target, nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			svc.AssertNotCalled(t, "Search", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestCharacterHandler_Search_QueryFollowsNameRules(t *testing.T) {
+	tests := map[string]string{
+		"/characters/search?q=":                                         "required",
+		"/characters/search?q=%20%20":                                   "blank",
+		"/characters/search?q=" + strings.Repeat("a", 65):               "too_long",
+		"/characters/search?q=%3Cscript%3E":                             "invalid_characters",
+		"/characters/search?mode=text&q=" + strings.Repeat("a", 65):     "too_long",
+		"/characters/search?mode=text&q=%7B%22%24where%22%3A%221%22%7D": "invalid_characters",
+	}
+
+	for target, code := range tests {
+		t.Run(target, func(t *testing.T) {
+			svc := new(MockCharacterService)
+			router := setupRouter(handler.NewCharacterHandler(svc))
+
+			req, _ := http.NewRequest(http.MethodGet, target, nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.JSONEq(t, `{"message":"The data submitted is invalid.","errors":[{"field":"q","code":"`+code+`"}]}`, w.Body.String())
+			svc.AssertNotCalled(t, "Search", mock.Anything, mock.Anything, mock.Anything)
+			svc.AssertNotCalled(t, "SearchText", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestCharacterHandler_Search_TextMode(t *testing.T) {
+	svc := new(MockCharacterService)
+	h := handler.NewCharacterHandler(svc)
+	router := setupRouter(h)
+
+	svc.
+		On("SearchText", mock.Anything, "saiyan", 2, 5).
+		Return(&domain.CharacterTextSearchDTO{
+			Items: []domain.CharacterTextMatchDTO{{
+				Character:  domain.CharacterDTO{Id: 1, Name: "Goku"},
+				Score:      2.5,
+				Highlights: map[string]string{"race": "<em>Saiyan</em>"},
+			}},
+			Page:  2,
+			Limit: 5,
+			Total: 11,
+		}, nil)
+
+	req, _ := http.NewRequest(http.MethodGet, "/characters/search?q=saiyan&mode=text&page=2&limit=5", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp struct {
+		Data []domain.CharacterTextMatchDTO `json:"data"`
+		Meta map[string]int                 `json:"meta"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.NoError(t, err)
+	assert.Len(t, resp.Data, 1)
+	assert.Equal(t, "<em>Saiyan</em>", resp.Data[0].Highlights["race"])
+	assert.Equal(t, map[string]int{"page": 2, "limit": 5, "total": 11, "totalPages": 3}, resp.Meta)
+
+	svc.AssertExpectations(t)
+}
+
+func TestCharacterHandler_Search_UnknownMode(t *testing.T) {
+	svc := new(MockCharacterService)
+	h := handler.NewCharacterHandler(svc)
+	router := setupRouter(h)
+
+	req, _ := http.NewRequest(http.MethodGet, "/characters/search?q=goku&mode=regex", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	svc.AssertNotCalled(t, "SearchText", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCharacterHandler_List_OK(t *testing.T) {
+	svc := new(MockCharacterService)
+	h := handler.NewCharacterHandler(svc)
+	router := setupRouter(h)
+
+	svc.
+		On("List", mock.Anything, mock.MatchedBy(func(q domain.ListQuery) bool {
+			return q.MinKi != nil && q.MinKi.Raw == "60.000.000" &&
+				q.MinMaxKi == nil &&
+				q.Sort == domain.SortOrder{Field: "maxKi", Desc: true} &&
+				q.Page == 1 && q.Limit == 10
+		})).
+		Return(&domain.CharacterPageDTO{
+			Items: []domain.CharacterDTO{{Id: 1, Name: "Goku"}},
+			Page:  1,
+			Limit: 10,
+			Total: 1,
+		}, nil)
+
+	req, _ := http.NewRequest(http.MethodGet, "/characters?minKi=60.000.000&sort=-maxKi", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp struct {
+		Data []domain.CharacterDTO `json:"data"`
+		Meta map[string]int        `json:"meta"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.NoError(t, err)
+	assert.Len(t, resp.Data, 1)
+	assert.Equal(t, 1, resp.Meta["totalPages"])
+
+	svc.AssertExpectations(t)
+}
+
+func TestCharacterHandler_List_InvalidQuery(t *testing.T) {
+	tests := []string{
+		"/characters?minKi=lots",
+		"/characters?minMaxKi=unknown",
+		"/characters?sort=-race",
+		"/characters?page=0",
+	}
+
+	for _, target := range tests {
+		t.Run(target, func(t *testing.T) {
+			svc := new(MockCharacterService)
+			h := handler.NewCharacterHandler(svc)
+			router := setupRouter(h)
+
+			req, _ := http.NewRequest(http.MethodGet, target, nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			svc.AssertNotCalled(t, "List", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestCharacterHandler_Batch_OK(t *testing.T) {
+	svc := new(MockCharacterService)
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/characters/batch", handler.NewCharacterHandler(svc).Batch)
+
+	refs := []domain.CharacterRef{{Name: "Goku"}, {Id: 9999}}
+	svc.
+		On("GetBatch", mock.Anything, refs).
+		Return([]domain.CharacterResult{
+			{Ref: refs[0], Character: &domain.CharacterDTO{Id: 1, Name: "Goku"}},
+			{Ref: refs[1], Err: fmt.Errorf("character %w", domain.ErrNotFound)},
+		})
+
+	body := bytes.NewBufferString(`{"items":[{"name":"Goku"},{"id":9999}]}`)
+	req, _ := http.NewRequest(http.MethodPost, "/characters/batch", body)
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp struct {
+		Data []struct {
+			Id      int64                `json:"id"`
+			Name    string               `json:"name"`
+			Status  int                  `json:"status"`
+			Data    *domain.CharacterDTO `json:"data"`
+			Message string               `json:"message"`
+		} `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	if assert.Len(t, resp.Data, 2) {
+		assert.Equal(t, "Goku", resp.Data[0].Name)
+		assert.Equal(t, http.StatusOK, resp.Data[0].Status)
+		assert.Equal(t, int64(1), resp.Data[0].Data.Id)
+		assert.Equal(t, int64(9999), resp.Data[1].Id)
+		assert.Equal(t, http.StatusNotFound, resp.Data[1].Status)
+		assert.Nil(t, resp.Data[1].Data)
+		assert.Equal(t, "character not found", resp.Data[1].Message)
+	}
+
+	svc.AssertExpectations(t)
+}
+
+func TestCharacterHandler_Batch_FieldErrors(t *testing.T) {
+	tooMany := `{"items":[` + strings.Repeat(`{"id":1},`, 50) + `{"id":1}]}`
+
+	tests := []struct {
+		name  string
+		body  string
+		field string
+		code  string
+	}{
+		{name: "missing items", body: `{}`, field: "items", code: "required"},
+		{name: "empty items", body: `{"items":[]}`, field: "items", code: "too_short"},
+		{name: "too many items", body: tooMany, field: "items", code: "too_long"},
+		{name: "neither id nor name", body: `{"items":[{"name":"Goku"},{}]}`, field: "items[1]", code: "required"},
+		{name: "both id and name", body: `{"items":[{"id":1,"name":"Goku"}]}`, field: "items[0]", code: "ambiguous"},
+		{name: "invalid name", body: `{"items":[{"name":"<b>"}]}`, field: "items[0].name", code: "invalid_characters"},
+		{name: "negative id", body: `{"items":[{"id":-1}]}`, field: "items[0].id", code: "too_short"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := new(MockCharacterService)
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.POST("/characters/batch", handler.NewCharacterHandler(svc).Batch)
+
+			req, _ := http.NewRequest(http.MethodPost, "/characters/batch", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+
+			var resp struct {
+				Errors []struct {
+					Field string `json:"field"`
+					Code  string `json:"code"`
+				} `json:"errors"`
+			}
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			if assert.Len(t, resp.Errors, 1) {
+				assert.Equal(t, tt.field, resp.Errors[0].Field)
+				assert.Equal(t, tt.code, resp.Errors[0].Code)
+			}
+
+			svc.AssertNotCalled(t, "GetBatch", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestCharacterHandler_GetOne_UpstreamBudgetExceeded(t *testing.T) {
+	svc := new(MockCharacterService)
+	router := setupRouter(handler.NewCharacterHandler(svc))
+
+	svc.
+		On("GetByName", mock.Anything, "Goku").
+		Return(nil, &application.BudgetExceededError{RetryAfter: 1500 * time.Millisecond})
+
+	req, _ := http.NewRequest(http.MethodPost, "/characters", bytes.NewBufferString(`{"name":"Goku"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "2", w.Header().Get("Retry-After"))
+	assert.JSONEq(t, `{"message":"Too many requests, please try again later."}`, w.Body.String())
+	svc.AssertNotCalled(t, "Search", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCharacterHandler_Batch_LimitedItemsSetRetryAfter(t *testing.T) {
+	svc := new(MockCharacterService)
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/characters/batch", handler.NewCharacterHandler(svc).Batch)
+
+	refs := []domain.CharacterRef{{Id: 1}, {Id: 2}}
+	svc.
+		On("GetBatch", mock.Anything, refs).
+		Return([]domain.CharacterResult{
+			{Ref: refs[0], Err: &application.BudgetExceededError{RetryAfter: time.Second}},
+			{Ref: refs[1], Err: &breaker.UpstreamLimitedError{Reason: "rate", RetryAfter: 2500 * time.Millisecond}},
+		})
+
+	req, _ := http.NewRequest(http.MethodPost, "/characters/batch", bytes.NewBufferString(`{"items":[{"id":1},{"id":2}]}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "3", w.Header().Get("Retry-After"))
+	var resp struct {
+		Data []struct {
+			Status int `json:"status"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	if assert.Len(t, resp.Data, 2) {
+		assert.Equal(t, http.StatusTooManyRequests, resp.Data[0].Status)
+		assert.Equal(t, http.StatusServiceUnavailable, resp.Data[1].Status)
+	}
+}
+
+func TestCharacterHandler_GetById_Protobuf(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	svc := new(MockCharacterService)
+	svc.On("GetById", mock.Anything, int64(1)).Return(&domain.CharacterDTO{Id: 1, Name: "Goku"}, nil)
+	svc.On("GetById", mock.Anything, int64(2)).Return(nil, domain.ErrNotFound)
+
+	r := gin.New()
+	r.GET("/characters/:id", handler.NewCharacterHandler(svc).GetById)
+
+	req, _ := http.NewRequest(http.MethodGet, "/characters/1", nil)
+	req.Header.Set("Accept", "application/x-protobuf")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/x-protobuf", w.Header().Get("Content-Type"))
+	var chr dbzv1.CharacterResponse
+	require.NoError(t, proto.Unmarshal(w.Body.Bytes(), &chr))
+	assert.Equal(t, "Goku", chr.GetData().GetName())
+
+	req, _ = http.NewRequest(http.MethodGet, "/characters/2", nil)
+	req.Header.Set("Accept", "application/x-protobuf")
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	var msg dbzv1.Error
+	require.NoError(t, proto.Unmarshal(w.Body.Bytes(), &msg))
+	assert.Equal(t, domain.ErrNotFound.Error(), msg.GetMessage())
+}
@@ -0,0 +1,146 @@
+package handler_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heaveless/dbz-api/internal/delivery/http/handler"
+	"github.com/heaveless/dbz-api/internal/delivery/http/middleware"
+	domain "github.com/heaveless/dbz-api/internal/domain/character"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var cachedGoku = &domain.CharacterDTO{
+	Id:        1,
+	Name:      "Goku",
+	ETag:      `"abc123"`,
+	UpdatedAt: time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC),
+}
+
+func getById(h *handler.CharacterHandler, headers map[string]string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(middleware.Compress(middleware.DefaultCompressMinSize))
+	r.GET("/characters/:id", h.GetById)
+
+	req, _ := http.NewRequest(http.MethodGet, "/characters/1", nil)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	return w
+}
+
+func TestCharacterHandler_GetById_SetsValidators(t *testing.T) {
+	svc := new(MockCharacterService)
+	svc.On("GetById", mock.Anything, int64(1)).Return(cachedGoku, nil)
+
+	w := getById(handler.NewCharacterHandler(svc), nil)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Regexp(t, `^"[0-9a-f]{32}"$`, w.Header().Get("ETag"))
+	assert.Equal(t, "Thu, 01 Oct 2026 12:00:00 GMT", w.Header().Get("Last-Modified"))
+	assert.Equal(t, handler.DefaultCacheControl, w.Header().Get("Cache-Control"))
+	assert.Contains(t, w.Body.String(), `"name":"Goku"`)
+	svc.AssertExpectations(t)
+}
+
+func TestCharacterHandler_GetById_ETagPerRepresentation(t *testing.T) {
+	svc := new(MockCharacterService)
+	svc.On("GetById", mock.Anything, int64(1)).Return(cachedGoku, nil)
+	h := handler.NewCharacterHandler(svc)
+
+	representations := []map[string]string{
+		nil,
+		{"Accept": "application/msgpack"},
+		{"Accept": "application/x-protobuf"},
+		{"Accept-Encoding": "gzip"},
+		{"Accept": "application/msgpack", "Accept-Encoding": "br"},
+	}
+
+	seen := map[string]bool{}
+	for _, headers := range representations {
+		etag := getById(h, headers).Header().Get("ETag")
+		assert.Equal(t, etag, getById(h, headers).Header().Get("ETag"), "%v is stable", headers)
+		assert.False(t, seen[etag], "%v has a tag of its own", headers)
+		seen[etag] = true
+	}
+
+	jsonTag := getById(h, nil).Header().Get("ETag")
+	w := getById(h, map[string]string{"Accept": "application/msgpack", "If-None-Match": jsonTag})
+	assert.Equal(t, http.StatusOK, w.Code, "the JSON tag does not validate MessagePack")
+}
+
+func TestCharacterHandler_GetById_Conditional(t *testing.T) {
+	svc := new(MockCharacterService)
+	svc.On("GetById", mock.Anything, int64(1)).Return(cachedGoku, nil)
+	etag := getById(handler.NewCharacterHandler(svc), nil).Header().Get("ETag")
+
+	tests := []struct {
+		name    string
+		headers map[string]string
+		status  int
+	}{
+		{name: "matching etag", headers: map[string]string{"If-None-Match": etag}, status: http.StatusNotModified},
+		{name: "weak etag in a list", headers: map[string]string{"If-None-Match": `"zzz", W/` + etag}, status: http.StatusNotModified},
+		{name: "wildcard", headers: map[string]string{"If-None-Match": "*"}, status: http.StatusNotModified},
+		{name: "stale etag", headers: map[string]string{"If-None-Match": `"zzz"`}, status: http.StatusOK},
+		{name: "not modified since", headers: map[string]string{"If-Modified-Since": "Thu, 01 Oct 2026 12:00:00 GMT"}, status: http.StatusNotModified},
+		{name: "modified since", headers: map[string]string{"If-Modified-Since": "Wed, 30 Sep 2026 12:00:00 GMT"}, status: http.StatusOK},
+		{name: "malformed date", headers: map[string]string{"If-Modified-Since": "yesterday"}, status: http.StatusOK},
+		{
+			name: "etag wins over date",
+			headers: map[string]string{
+				"If-None-Match":     `"zzz"`,
+				"If-Modified-Since": "Thu, 01 Oct 2026 12:00:00 GMT",
+			},
+			status: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := new(MockCharacterService)
+			svc.On("GetById", mock.Anything, int64(1)).Return(cachedGoku, nil)
+
+			w := getById(handler.NewCharacterHandler(svc), tt.headers)
+
+			assert.Equal(t, tt.status, w.Code)
+			assert.Equal(t, etag, w.Header().Get("ETag"))
+			if tt.status == http.StatusNotModified {
+				assert.Empty(t, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestCharacterHandler_GetById_CustomCacheControl(t *testing.T) {
+	svc := new(MockCharacterService)
+	svc.On("GetById", mock.Anything, int64(1)).Return(cachedGoku, nil)
+
+	w := getById(handler.NewCharacterHandlerWithCacheControl(svc, "no-cache"), nil)
+
+	assert.Equal(t, "no-cache", w.Header().Get("Cache-Control"))
+}
+
+func TestCharacterHandler_GetOne_NeverNotModified(t *testing.T) {
+	svc := new(MockCharacterService)
+	svc.On("GetByName", mock.Anything, "Goku").Return(cachedGoku, nil)
+	router := setupRouter(handler.NewCharacterHandler(svc))
+
+	req, _ := http.NewRequest(http.MethodPost, "/characters", bytes.NewBufferString(`{"name":"Goku"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-None-Match", "*")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusFound, w.Code)
+	assert.NotEmpty(t, w.Header().Get("ETag"))
+}
@@ -0,0 +1,418 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	graphqldelivery "github.com/heaveless/dbz-api/internal/delivery/graphql"
+	server "github.com/heaveless/dbz-api/internal/delivery/http"
+	"github.com/heaveless/dbz-api/internal/delivery/http/handler"
+	apikey "github.com/heaveless/dbz-api/internal/domain/apikey"
+	"github.com/heaveless/dbz-api/internal/domain/audit"
+	domain "github.com/heaveless/dbz-api/internal/domain/character"
+	"github.com/heaveless/dbz-api/internal/domain/event"
+	"github.com/heaveless/dbz-api/internal/domain/planet"
+	"github.com/heaveless/dbz-api/internal/domain/transformation"
+	webhook "github.com/heaveless/dbz-api/internal/domain/webhook"
+	"github.com/heaveless/dbz-api/internal/infrastructure/eventbus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Run `go test ./tests/unit/delivery/handler -run Contract -update` after an
+// intended change of the public response shape.
+var update = flag.Bool("update", false, "rewrite golden files")
+
+var goku = domain.CharacterDTO{
+	Id:          1,
+	Name:        "Goku",
+	Ki:          "60.000.000",
+	MaxKi:       "90 Septillion",
+	Race:        "Saiyan",
+	Gender:      "Male",
+	Image:       "https://dragonball-api.com/characters/goku_normal.webp",
+	Affiliation: "Z Fighter",
+	Description: "El protagonista de la serie.",
+}
+
+// A character with every optional field empty pins down the omitempty rules.
+var bare = domain.CharacterDTO{
+	Id:    2,
+	Name:  "Nappa",
+	Ki:    "4.000",
+	MaxKi: "4.000",
+	Race:  "Saiyan",
+}
+
+type contractServices struct {
+	characters      *MockCharacterService
+	planets         *MockPlanetService
+	transformations *MockTransformationService
+	apiKeys         *MockApiKeyService
+	webhooks        *MockWebhookService
+	curation        *MockCurationService
+	audit           *MockAuditService
+}
+
+func setupContractServer() (*gin.Engine, contractServices) {
+	gin.SetMode(gin.TestMode)
+
+	svcs := contractServices{
+		characters:      new(MockCharacterService),
+		planets:         new(MockPlanetService),
+		transformations: new(MockTransformationService),
+		apiKeys:         new(MockApiKeyService),
+		webhooks:        new(MockWebhookService),
+		curation:        new(MockCurationService),
+		audit:           new(MockAuditService),
+	}
+
+	r := server.NewServer(server.Handlers{
+		Character:      handler.NewCharacterHandler(svcs.characters),
+		Planet:         handler.NewPlanetHandler(svcs.planets),
+		Transformation: handler.NewTransformationHandler(svcs.transformations),
+		ApiKey:         handler.NewApiKeyHandler(svcs.apiKeys),
+		Webhook:        handler.NewWebhookHandler(svcs.webhooks),
+		CharacterAdmin: handler.NewCharacterAdminHandler(svcs.curation),
+		Audit:          handler.NewAuditHandler(svcs.audit),
+		Event:          handler.NewEventHandler(eventbus.NewBus(0)),
+		GraphQL:        graphqldelivery.NewHandler(graphqldelivery.Services{}, graphqldelivery.Limits{}),
+	}, server.Middleware{})
+
+	return r, svcs
+}
+
+func assertGolden(t *testing.T, name string, body []byte) {
+	t.Helper()
+
+	var pretty bytes.Buffer
+	require.NoError(t, json.Indent(&pretty, body, "", "  "))
+	pretty.WriteByte('\n')
+
+	path := filepath.Join("testdata", name+".golden.json")
+	if *update {
+		require.NoError(t, os.WriteFile(path, pretty.Bytes(), 0o644))
+	}
+
+	want, err := os.ReadFile(path)
+	require.NoError(t, err, "missing golden file, run with -update")
+	assert.Equal(t, string(want), pretty.String(), "response shape of %s changed", name)
+}
+
+type contractCase struct {
+	name   string
+	method string
+	// route is the documented path template the request is served by.
+	route  string
+	path   string
+	body   string
+	status int
+	setup  func(contractServices)
+}
+
+func (tt contractCase) serve(t *testing.T) *httptest.ResponseRecorder {
+	t.Helper()
+
+	router, svcs := setupContractServer()
+	tt.setup(svcs)
+
+	var body io.Reader
+	if tt.body != "" {
+		body = bytes.NewBufferString(tt.body)
+	}
+	req, _ := http.NewRequest(tt.method, tt.path, body)
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, tt.status, w.Code, w.Body.String())
+	return w
+}
+
+var contractCases = []contractCase{
+	{
+		name:   "character_get_one",
+		route:  "/v1/characters",
+		method: http.MethodPost,
+		path:   "/v1/characters",
+		body:   `{"name":"Goku"}`,
+		status: http.StatusFound,
+		setup: func(s contractServices) {
+			s.characters.On("GetByName", mock.Anything, "Goku").Return(&goku, nil)
+		},
+	},
+	{
+		name:   "character_get_one_bare",
+		route:  "/v1/characters",
+		method: http.MethodPost,
+		path:   "/v1/characters",
+		body:   `{"name":"Nappa"}`,
+		status: http.StatusFound,
+		setup: func(s contractServices) {
+			s.characters.On("GetByName", mock.Anything, "Nappa").Return(&bare, nil)
+		},
+	},
+	{
+		name:   "character_get_by_id",
+		route:  "/v1/characters/{id}",
+		method: http.MethodGet,
+		path:   "/v1/characters/1",
+		status: http.StatusOK,
+		setup: func(s contractServices) {
+			s.characters.On("GetById", mock.Anything, int64(1)).Return(&goku, nil)
+		},
+	},
+	{
+		name:   "character_batch",
+		route:  "/v1/characters/batch",
+		method: http.MethodPost,
+		path:   "/v1/characters/batch",
+		body:   `{"items":[{"name":"Goku"},{"id":9999},{"name":"Nappa"}]}`,
+		status: http.StatusOK,
+		setup: func(s contractServices) {
+			s.characters.On("GetBatch", mock.Anything, mock.Anything).Return([]domain.CharacterResult{
+				{Ref: domain.CharacterRef{Name: "Goku"}, Character: &goku},
+				{Ref: domain.CharacterRef{Id: 9999}, Err: fmt.Errorf("character %w", domain.ErrNotFound)},
+				{Ref: domain.CharacterRef{Name: "Nappa"}, Err: errors.New("service temporarily unavailable, please try again later")},
+			})
+		},
+	},
+	{
+		name:   "character_list",
+		route:  "/v1/characters",
+		method: http.MethodGet,
+		path:   "/v1/characters?limit=2",
+		status: http.StatusOK,
+		setup: func(s contractServices) {
+			s.characters.On("List", mock.Anything, mock.Anything).Return(&domain.CharacterPageDTO{
+				Items: []domain.CharacterDTO{goku, bare},
+				Page:  1,
+				Limit: 2,
+				Total: 5,
+			}, nil)
+		},
+	},
+	{
+		name:   "character_search",
+		route:  "/v1/characters/search",
+		method: http.MethodGet,
+		path:   "/v1/characters/search?q=Vejeta",
+		status: http.StatusOK,
+		setup: func(s contractServices) {
+			s.characters.On("Search", mock.Anything, "Vejeta", 10).
+				Return([]domain.CharacterMatchDTO{{Id: 3, Name: "Vegeta", Score: 0.83}}, nil)
+		},
+	},
+	{
+		name:   "character_search_text",
+		route:  "/v1/characters/search",
+		method: http.MethodGet,
+		path:   "/v1/characters/search?q=saiyan&mode=text",
+		status: http.StatusOK,
+		setup: func(s contractServices) {
+			s.characters.On("SearchText", mock.Anything, "saiyan", 1, 10).Return(&domain.CharacterTextSearchDTO{
+				Items: []domain.CharacterTextMatchDTO{
+					{Character: goku, Score: 2.5, Highlights: map[string]string{"race": "<em>Saiyan</em>"}},
+					{Character: bare, Score: 1.1},
+				},
+				Page:  1,
+				Limit: 10,
+				Total: 2,
+			}, nil)
+		},
+	},
+	{
+		name:   "character_transformations",
+		route:  "/v1/characters/{id}/transformations",
+		method: http.MethodGet,
+		path:   "/v1/characters/1/transformations",
+		status: http.StatusOK,
+		setup: func(s contractServices) {
+			s.transformations.On("ListByCharacter", mock.Anything, int64(1)).Return([]transformation.TransformationDTO{
+				{Id: 1, CharacterId: 1, Name: "Goku SSJ", Image: "https://dragonball-api.com/transformaciones/goku_ssj.webp", Ki: "3 Billion"},
+				{Id: 2, CharacterId: 1, Name: "Goku SSJ2", Ki: "6 Billion"},
+			}, nil)
+		},
+	},
+	{
+		name:   "planet_list",
+		route:  "/v1/planets",
+		method: http.MethodGet,
+		path:   "/v1/planets",
+		status: http.StatusOK,
+		setup: func(s contractServices) {
+			s.planets.On("List", mock.Anything).Return([]planet.PlanetDTO{
+				{Id: 1, Name: "Namek", IsDestroyed: true, Description: "Planeta natal de los namekianos.", Image: "https://dragonball-api.com/planetas/Namek.webp"},
+				{Id: 2, Name: "Tierra"},
+			}, nil)
+		},
+	},
+	{
+		name:   "planet_characters",
+		route:  "/v1/planets/{id}/characters",
+		method: http.MethodGet,
+		path:   "/v1/planets/2/characters",
+		status: http.StatusOK,
+		setup: func(s contractServices) {
+			s.planets.On("Characters", mock.Anything, int64(2)).Return([]domain.CharacterDTO{goku}, nil)
+		},
+	},
+	{
+		name:   "admin_key_create",
+		route:  "/admin/keys",
+		method: http.MethodPost,
+		path:   "/admin/keys",
+		body:   `{"name":"frontend","scopes":["read"],"dailyQuota":1000}`,
+		status: http.StatusCreated,
+		setup: func(s contractServices) {
+			s.apiKeys.On("Create", mock.Anything, mock.Anything).
+				Return(&apikey.IssuedApiKeyDTO{ApiKeyDTO: frontendKey, Key: "dbz_q8Zr1x0Psecret"}, nil)
+		},
+	},
+	{
+		name:   "admin_key_list",
+		route:  "/admin/keys",
+		method: http.MethodGet,
+		path:   "/admin/keys",
+		status: http.StatusOK,
+		setup: func(s contractServices) {
+			revoked := frontendKey
+			revokedAt := revoked.CreatedAt.Add(time.Hour)
+			revoked.RevokedAt = &revokedAt
+			s.apiKeys.On("List", mock.Anything).Return([]apikey.ApiKeyDTO{frontendKey, revoked}, nil)
+		},
+	},
+	{
+		name:   "admin_webhook_create",
+		route:  "/admin/webhooks",
+		method: http.MethodPost,
+		path:   "/admin/webhooks",
+		body:   `{"url":"https://partner.example/hooks","events":["CharacterCached"]}`,
+		status: http.StatusCreated,
+		setup: func(s contractServices) {
+			s.webhooks.On("Register", mock.Anything, mock.Anything).
+				Return(&webhook.CreatedSubscriptionDTO{SubscriptionDTO: partnerWebhook, Secret: "whsec_q8Zr1x0Psecret"}, nil)
+		},
+	},
+	{
+		name:   "admin_webhook_list",
+		route:  "/admin/webhooks",
+		method: http.MethodGet,
+		path:   "/admin/webhooks",
+		status: http.StatusOK,
+		setup: func(s contractServices) {
+			s.webhooks.On("List", mock.Anything).Return([]webhook.SubscriptionDTO{partnerWebhook}, nil)
+		},
+	},
+	{
+		name:   "admin_webhook_test",
+		route:  "/admin/webhooks/{id}/test",
+		method: http.MethodPost,
+		path:   "/admin/webhooks/9b1e4c7a2d3f5e60/test",
+		status: http.StatusOK,
+		setup: func(s contractServices) {
+			s.webhooks.On("Test", mock.Anything, "9b1e4c7a2d3f5e60").Return(&webhookTestDelivery, nil)
+		},
+	},
+	{
+		name:   "admin_webhook_deliveries",
+		route:  "/admin/webhooks/{id}/deliveries",
+		method: http.MethodGet,
+		path:   "/admin/webhooks/9b1e4c7a2d3f5e60/deliveries",
+		status: http.StatusOK,
+		setup: func(s contractServices) {
+			s.webhooks.On("Deliveries", mock.Anything, "9b1e4c7a2d3f5e60").
+				Return([]webhook.DeliveryDTO{webhookRetriedDelivery, webhookTestDelivery}, nil)
+		},
+	},
+	{
+		name:   "admin_character_create",
+		route:  "/admin/characters",
+		method: http.MethodPost,
+		path:   "/admin/characters",
+		body:   `{"id":1001,"name":"Gogeta","ki":"unknown","maxKi":"unknown","race":"Saiyan"}`,
+		status: http.StatusCreated,
+		setup: func(s contractServices) {
+			gogeta := domain.CuratedCharacterDTO{
+				CharacterDTO: domain.CharacterDTO{Id: 1001, Name: "Gogeta", Ki: "unknown", MaxKi: "unknown", Race: "Saiyan"},
+				Overridden:   domain.CuratedFields,
+			}
+			s.curation.On("Create", mock.Anything, int64(1001), mock.Anything).Return(&gogeta, nil)
+		},
+	},
+	{
+		name:   "admin_character_patch",
+		route:  "/admin/characters/{id}",
+		method: http.MethodPatch,
+		path:   "/admin/characters/2",
+		body:   `{"affiliation":"Z Fighter"}`,
+		status: http.StatusOK,
+		setup: func(s contractServices) {
+			s.curation.On("Update", mock.Anything, int64(2), domain.CharacterPatch{"affiliation": "Z Fighter"}).Return(&curatedVegeta, nil)
+		},
+	},
+	{
+		name:   "admin_audit_list",
+		route:  "/admin/audit",
+		method: http.MethodGet,
+		path:   "/admin/audit?target=character:2&field=affiliation",
+		status: http.StatusOK,
+		setup: func(s contractServices) {
+			s.audit.On("Query", mock.Anything, mock.Anything).Return(&audit.EntryPageDTO{
+				Items: []audit.Entry{affiliationChange},
+				Page:  1,
+				Limit: 20,
+				Total: 1,
+			}, nil)
+		},
+	},
+}
+
+var webhookTestDelivery = webhook.DeliveryDTO{
+	Id:       "test-5f0c:9b1e4c7a2d3f5e60",
+	EventKey: "test-5f0c",
+	Type:     webhook.TestEvent,
+	Status:   webhook.StatusDelivered,
+	Attempts: []webhook.AttemptDTO{
+		{At: partnerWebhook.CreatedAt.Add(time.Minute), StatusCode: http.StatusNoContent, DurationMs: 42},
+	},
+	CreatedAt: partnerWebhook.CreatedAt.Add(time.Minute),
+}
+
+// A delivery still being retried pins down nextAttemptAt and failed attempts.
+var webhookRetriedDelivery = func() webhook.DeliveryDTO {
+	next := partnerWebhook.CreatedAt.Add(2*time.Minute + 10*time.Second)
+	return webhook.DeliveryDTO{
+		Id:       "6710f3a2c9e77b0001a1b2c3:9b1e4c7a2d3f5e60",
+		EventKey: "6710f3a2c9e77b0001a1b2c3",
+		Type:     event.CharacterCached,
+		Status:   webhook.StatusPending,
+		Attempts: []webhook.AttemptDTO{
+			{At: partnerWebhook.CreatedAt.Add(2 * time.Minute), StatusCode: http.StatusBadGateway, Error: "unexpected status 502", DurationMs: 130},
+		},
+		NextAttemptAt: &next,
+		CreatedAt:     partnerWebhook.CreatedAt.Add(2 * time.Minute),
+	}
+}()
+
+func TestContract_V1(t *testing.T) {
+	for _, tt := range contractCases {
+		t.Run(tt.name, func(t *testing.T) {
+			w := tt.serve(t)
+			assertGolden(t, tt.name, w.Body.Bytes())
+		})
+	}
+}
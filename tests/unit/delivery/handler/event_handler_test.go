@@ -0,0 +1,181 @@
+package handler_test
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heaveless/dbz-api/internal/delivery/http/handler"
+	"github.com/heaveless/dbz-api/internal/domain/event"
+	"github.com/heaveless/dbz-api/internal/infrastructure/eventbus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupEventServer(t *testing.T, bus *eventbus.Bus, opts handler.EventStreamOptions) *httptest.Server {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/events", handler.NewEventHandlerWithOptions(bus, opts).Stream)
+
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+// openStream connects and returns a reader positioned after the retry
+// field.
+func openStream(t *testing.T, url string, header http.Header) *bufio.Reader {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	require.NoError(t, err)
+	for k, v := range header {
+		req.Header[k] = v
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { res.Body.Close() })
+
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "text/event-stream", res.Header.Get("Content-Type"))
+	assert.Equal(t, "no-cache", res.Header.Get("Cache-Control"))
+
+	r := bufio.NewReader(res.Body)
+	assert.Equal(t, []string{"retry: 3000"}, readFrame(t, r))
+
+	return r
+}
+
+// readFrame returns the lines of the next frame, up to the blank line.
+func readFrame(t *testing.T, r *bufio.Reader) []string {
+	t.Helper()
+
+	var lines []string
+	for {
+		line, err := r.ReadString('\n')
+		require.NoError(t, err)
+
+		line = strings.TrimSuffix(line, "\n")
+		if line == "" {
+			return lines
+		}
+		lines = append(lines, line)
+	}
+}
+
+func TestEventHandler_StreamsPublishedEvents(t *testing.T) {
+	bus := eventbus.NewBus(0)
+	srv := setupEventServer(t, bus, handler.EventStreamOptions{})
+
+	r := openStream(t, srv.URL+"/events", nil)
+	bus.Publish(event.Event{
+		Type: event.UpstreamUnavailable,
+		At:   time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+		Data: event.UpstreamFailure{Host: "dragonball-api.com", Reason: "status 503"},
+	})
+
+	assert.Equal(t, []string{
+		"id: 1",
+		"event: UpstreamUnavailable",
+		`data: {"at":"2024-05-01T10:00:00Z","data":{"host":"dragonball-api.com","reason":"status 503"}}`,
+	}, readFrame(t, r))
+}
+
+func TestEventHandler_ResumesAfterLastEventId(t *testing.T) {
+	tests := []struct {
+		name   string
+		url    string
+		header http.Header
+	}{
+		{name: "header", url: "/events", header: http.Header{"Last-Event-Id": {"1"}}},
+		{name: "query", url: "/events?lastEventId=1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bus := eventbus.NewBus(0)
+			srv := setupEventServer(t, bus, handler.EventStreamOptions{})
+			for _, typ := range []event.Type{event.CharacterCached, event.CharacterRefreshed, event.CharacterCached} {
+				bus.Publish(event.Event{Type: typ})
+			}
+
+			r := openStream(t, srv.URL+tt.url, tt.header)
+
+			assert.Equal(t, "id: 2", readFrame(t, r)[0])
+			assert.Equal(t, "id: 3", readFrame(t, r)[0])
+
+			bus.Publish(event.Event{Type: event.CharacterCached})
+			assert.Equal(t, "id: 4", readFrame(t, r)[0])
+		})
+	}
+}
+
+func TestEventHandler_FiltersTypes(t *testing.T) {
+	bus := eventbus.NewBus(0)
+	srv := setupEventServer(t, bus, handler.EventStreamOptions{})
+
+	r := openStream(t, srv.URL+"/events?types=CharacterRefreshed,,UpstreamUnavailable", nil)
+	for _, typ := range []event.Type{event.CharacterCached, event.CharacterRefreshed, event.CharacterCached, event.UpstreamUnavailable} {
+		bus.Publish(event.Event{Type: typ})
+	}
+
+	assert.Equal(t, []string{"id: 2", "event: CharacterRefreshed"}, readFrame(t, r)[:2])
+	assert.Equal(t, []string{"id: 4", "event: UpstreamUnavailable"}, readFrame(t, r)[:2])
+}
+
+func TestEventHandler_SendsHeartbeats(t *testing.T) {
+	srv := setupEventServer(t, eventbus.NewBus(0), handler.EventStreamOptions{Heartbeat: 10 * time.Millisecond})
+
+	r := openStream(t, srv.URL+"/events", nil)
+
+	assert.Equal(t, []string{": heartbeat"}, readFrame(t, r))
+}
+
+func TestEventHandler_RejectsBadRequests(t *testing.T) {
+	tests := []struct {
+		name   string
+		url    string
+		header http.Header
+		body   string
+	}{
+		{
+			name:   "last event id",
+			url:    "/events",
+			header: http.Header{"Last-Event-Id": {"abc"}},
+			body:   `{"message":"Last-Event-ID must be the id of an event."}`,
+		},
+		{
+			name: "unknown type",
+			url:  "/events?types=CharacterCached,CharacterExploded",
+			body: `{"message":"The event type \"CharacterExploded\" does not exist."}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.GET("/events", handler.NewEventHandler(eventbus.NewBus(0)).Stream)
+
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			for k, v := range tt.header {
+				req.Header[k] = v
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.JSONEq(t, tt.body, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,345 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/heaveless/dbz-api/internal/delivery/http/openapi"
+	apikey "github.com/heaveless/dbz-api/internal/domain/apikey"
+	domain "github.com/heaveless/dbz-api/internal/domain/character"
+	"github.com/heaveless/dbz-api/internal/domain/planet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// errorCases complement the golden contract cases with the documented
+// error responses.
+var errorCases = []contractCase{
+	{
+		name:   "character_get_one_invalid_body",
+		route:  "/v1/characters",
+		method: http.MethodPost,
+		path:   "/v1/characters",
+		body:   `{"nombre":"Goku"}`,
+		status: http.StatusBadRequest,
+		setup:  func(contractServices) {},
+	},
+	{
+		name:   "character_get_one_not_found",
+		route:  "/v1/characters",
+		method: http.MethodPost,
+		path:   "/v1/characters",
+		body:   `{"name":"Vejeta"}`,
+		status: http.StatusNotFound,
+		setup: func(s contractServices) {
+			s.characters.On("GetByName", mock.Anything, "Vejeta").Return(nil, fmt.Errorf("character %w", domain.ErrNotFound))
+			s.characters.On("Search", mock.Anything, "Vejeta", 3).Return([]domain.CharacterMatchDTO{{Id: 3, Name: "Vegeta", Score: 0.83}}, nil)
+		},
+	},
+	{
+		name:   "character_search_missing_query",
+		route:  "/v1/characters/search",
+		method: http.MethodGet,
+		path:   "/v1/characters/search",
+		status: http.StatusBadRequest,
+		setup:  func(contractServices) {},
+	},
+	{
+		name:   "planet_not_found",
+		route:  "/v1/planets/{id}",
+		method: http.MethodGet,
+		path:   "/v1/planets/99",
+		status: http.StatusNotFound,
+		setup: func(s contractServices) {
+			s.planets.On("GetById", mock.Anything, int64(99)).Return(nil, fmt.Errorf("planet %w", planet.ErrNotFound))
+		},
+	},
+	{
+		name:   "planet_list_unavailable",
+		route:  "/v1/planets",
+		method: http.MethodGet,
+		path:   "/v1/planets",
+		status: http.StatusServiceUnavailable,
+		setup: func(s contractServices) {
+			s.planets.On("List", mock.Anything).Return(nil, errors.New("service temporarily unavailable, please try again later"))
+		},
+	},
+	{
+		name:   "admin_key_rotate_revoked",
+		route:  "/admin/keys/{id}/rotate",
+		method: http.MethodPost,
+		path:   "/admin/keys/k1/rotate",
+		status: http.StatusConflict,
+		setup: func(s contractServices) {
+			s.apiKeys.On("Rotate", mock.Anything, "k1").Return(nil, apikey.ErrRevoked)
+		},
+	},
+	{
+		name:   "admin_character_create_invalid",
+		route:  "/admin/characters",
+		method: http.MethodPost,
+		path:   "/admin/characters",
+		body:   `{"name":"Gogeta"}`,
+		status: http.StatusBadRequest,
+		setup:  func(contractServices) {},
+	},
+	{
+		name:   "admin_character_delete_conflict",
+		route:  "/admin/characters/{id}",
+		method: http.MethodDelete,
+		path:   "/admin/characters/2",
+		status: http.StatusConflict,
+		setup: func(s contractServices) {
+			s.curation.On("Delete", mock.Anything, int64(2)).Return(nil, fmt.Errorf("character %w", domain.ErrConflict))
+		},
+	},
+	{
+		name:   "admin_audit_bad_time",
+		route:  "/admin/audit",
+		method: http.MethodGet,
+		path:   "/admin/audit?from=yesterday",
+		status: http.StatusBadRequest,
+		setup:  func(contractServices) {},
+	},
+}
+
+func loadOpenAPI(t *testing.T) map[string]any {
+	t.Helper()
+
+	var doc map[string]any
+	require.NoError(t, json.Unmarshal(openapi.Document(), &doc))
+	return doc
+}
+
+func TestOpenAPI_DocumentsEveryRoute(t *testing.T) {
+	router, _ := setupContractServer()
+	paths := loadOpenAPI(t)["paths"].(map[string]any)
+
+	for _, route := range router.Routes() {
+		segments := strings.Split(route.Path, "/")
+		for i, s := range segments {
+			if strings.HasPrefix(s, ":") {
+				segments[i] = "{" + s[1:] + "}"
+			}
+		}
+		path := strings.Join(segments, "/")
+
+		item, ok := paths[path].(map[string]any)
+		if !assert.True(t, ok, "%s is not documented", path) {
+			continue
+		}
+		assert.Contains(t, item, strings.ToLower(route.Method), "%s %s is not documented", route.Method, path)
+	}
+}
+
+func TestOpenAPI_MarksLegacyRoutesDeprecated(t *testing.T) {
+	paths := loadOpenAPI(t)["paths"].(map[string]any)
+
+	legacy := paths["/characters"].(map[string]any)["post"].(map[string]any)
+	current := paths["/v1/characters"].(map[string]any)["post"].(map[string]any)
+
+	assert.Equal(t, true, legacy["deprecated"])
+	assert.NotContains(t, current, "deprecated")
+	assert.Equal(t, current["responses"], legacy["responses"])
+}
+
+func TestOpenAPI_ServesDocumentAndDocs(t *testing.T) {
+	router, _ := setupContractServer()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/openapi.json", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.JSONEq(t, string(openapi.Document()), w.Body.String())
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/docs", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `spec-url="/openapi.json"`)
+}
+
+func TestOpenAPI_ResponsesMatchSchema(t *testing.T) {
+	doc := loadOpenAPI(t)
+
+	for _, tt := range append(append([]contractCase{}, contractCases...), errorCases...) {
+		t.Run(tt.name, func(t *testing.T) {
+			w := tt.serve(t)
+
+			schema := responseSchema(t, doc, tt.route, tt.method, tt.status)
+
+			var body any
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			assert.Empty(t, validateSchema(doc, schema, body, "$"), w.Body.String())
+		})
+	}
+}
+
+func responseSchema(t *testing.T, doc map[string]any, route, method string, status int) map[string]any {
+	t.Helper()
+
+	item, ok := doc["paths"].(map[string]any)[route].(map[string]any)
+	require.True(t, ok, "%s is not documented", route)
+	op, ok := item[strings.ToLower(method)].(map[string]any)
+	require.True(t, ok, "%s %s is not documented", method, route)
+	res, ok := op["responses"].(map[string]any)[strconv.Itoa(status)].(map[string]any)
+	require.True(t, ok, "%s %s does not document status %d", method, route, status)
+
+	res = resolveRef(doc, res)
+	media, ok := res["content"].(map[string]any)["application/json"].(map[string]any)
+	require.True(t, ok, "%s %s %d has no JSON body", method, route, status)
+
+	return media["schema"].(map[string]any)
+}
+
+func resolveRef(doc map[string]any, node map[string]any) map[string]any {
+	ref, ok := node["$ref"].(string)
+	if !ok {
+		return node
+	}
+
+	var cur any = doc
+	for _, part := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+		cur = cur.(map[string]any)[part]
+	}
+
+	return resolveRef(doc, cur.(map[string]any))
+}
+
+// validateSchema checks the subset of JSON Schema used by the document:
+// $ref, oneOf, type, const, enum, required, properties,
+// additionalProperties, items, minimum and maximum.
+func validateSchema(doc map[string]any, schema map[string]any, value any, at string) []string {
+	schema = resolveRef(doc, schema)
+
+	if oneOf, ok := schema["oneOf"].([]any); ok {
+		matched := 0
+		for _, s := range oneOf {
+			if len(validateSchema(doc, s.(map[string]any), value, at)) == 0 {
+				matched++
+			}
+		}
+		if matched != 1 {
+			return []string{fmt.Sprintf("%s: matches %d oneOf branches", at, matched)}
+		}
+		return nil
+	}
+
+	if t, ok := schema["type"].(string); ok && !hasType(t, value) {
+		return []string{fmt.Sprintf("%s: expected %s, got %T", at, t, value)}
+	}
+
+	if c, ok := schema["const"]; ok && c != value {
+		return []string{fmt.Sprintf("%s: expected %v", at, c)}
+	}
+
+	if enum, ok := schema["enum"].([]any); ok {
+		found := false
+		for _, e := range enum {
+			found = found || e == value
+		}
+		if !found {
+			return []string{fmt.Sprintf("%s: %v is not one of %v", at, value, enum)}
+		}
+	}
+
+	var errs []string
+	switch v := value.(type) {
+	case map[string]any:
+		errs = append(errs, validateObject(doc, schema, v, at)...)
+	case []any:
+		if items, ok := schema["items"].(map[string]any); ok {
+			for i, item := range v {
+				errs = append(errs, validateSchema(doc, items, item, fmt.Sprintf("%s[%d]", at, i))...)
+			}
+		}
+	case float64:
+		if m, ok := schema["minimum"].(float64); ok && v < m {
+			errs = append(errs, fmt.Sprintf("%s: %v is below %v", at, v, m))
+		}
+		if m, ok := schema["maximum"].(float64); ok && v > m {
+			errs = append(errs, fmt.Sprintf("%s: %v is above %v", at, v, m))
+		}
+	}
+
+	return errs
+}
+
+func validateObject(doc map[string]any, schema map[string]any, obj map[string]any, at string) []string {
+	var errs []string
+
+	for _, r := range asSlice(schema["required"]) {
+		if _, ok := obj[r.(string)]; !ok {
+			errs = append(errs, fmt.Sprintf("%s: missing %s", at, r))
+		}
+	}
+
+	props, _ := schema["properties"].(map[string]any)
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if p, ok := props[k].(map[string]any); ok {
+			errs = append(errs, validateSchema(doc, p, obj[k], at+"."+k)...)
+			continue
+		}
+
+		switch extra := schema["additionalProperties"].(type) {
+		case bool:
+			if !extra {
+				errs = append(errs, fmt.Sprintf("%s: unexpected property %s", at, k))
+			}
+		case map[string]any:
+			errs = append(errs, validateSchema(doc, extra, obj[k], at+"."+k)...)
+		}
+	}
+
+	return errs
+}
+
+func hasType(t string, value any) bool {
+	switch t {
+	case "object":
+		_, ok := value.(map[string]any)
+		return ok
+	case "array":
+		_, ok := value.([]any)
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	case "integer":
+		n, ok := value.(float64)
+		return ok && n == math.Trunc(n)
+	case "null":
+		return value == nil
+	}
+
+	return false
+}
+
+func asSlice(v any) []any {
+	s, _ := v.([]any)
+	return s
+}
@@ -0,0 +1,184 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heaveless/dbz-api/internal/application"
+	"github.com/heaveless/dbz-api/internal/delivery/http/handler"
+	domain "github.com/heaveless/dbz-api/internal/domain/character"
+	planet "github.com/heaveless/dbz-api/internal/domain/planet"
+	"github.com/heaveless/dbz-api/internal/infrastructure/breaker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPlanetService struct {
+	mock.Mock
+}
+
+func (m *MockPlanetService) GetById(ctx context.Context, id int64) (*planet.PlanetDTO, error) {
+	args := m.Called(ctx, id)
+
+	var p *planet.PlanetDTO
+	if v := args.Get(0); v != nil {
+		p = v.(*planet.PlanetDTO)
+	}
+
+	return p, args.Error(1)
+}
+
+func (m *MockPlanetService) List(ctx context.Context) ([]planet.PlanetDTO, error) {
+	args := m.Called(ctx)
+
+	var ps []planet.PlanetDTO
+	if v := args.Get(0); v != nil {
+		ps = v.([]planet.PlanetDTO)
+	}
+
+	return ps, args.Error(1)
+}
+
+func (m *MockPlanetService) Characters(ctx context.Context, id int64) ([]domain.CharacterDTO, error) {
+	args := m.Called(ctx, id)
+
+	var cs []domain.CharacterDTO
+	if v := args.Get(0); v != nil {
+		cs = v.([]domain.CharacterDTO)
+	}
+
+	return cs, args.Error(1)
+}
+
+func setupPlanetRouter(h *handler.PlanetHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/planets", h.List)
+	r.GET("/planets/:id", h.GetOne)
+	r.GET("/planets/:id/characters", h.Characters)
+	return r
+}
+
+func TestPlanetHandler_List_OK(t *testing.T) {
+	svc := new(MockPlanetService)
+	router := setupPlanetRouter(handler.NewPlanetHandler(svc))
+
+	svc.On("List", mock.Anything).Return([]planet.PlanetDTO{{Id: 1, Name: "Namek"}}, nil)
+
+	req, _ := http.NewRequest(http.MethodGet, "/planets", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp struct {
+		Data []planet.PlanetDTO `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "Namek", resp.Data[0].Name)
+}
+
+func TestPlanetHandler_GetOne_OK(t *testing.T) {
+	svc := new(MockPlanetService)
+	router := setupPlanetRouter(handler.NewPlanetHandler(svc))
+
+	svc.On("GetById", mock.Anything, int64(1)).Return(&planet.PlanetDTO{Id: 1, Name: "Namek"}, nil)
+
+	req, _ := http.NewRequest(http.MethodGet, "/planets/1", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	svc.AssertExpectations(t)
+}
+
+func TestPlanetHandler_GetOne_NotFound(t *testing.T) {
+	svc := new(MockPlanetService)
+	router := setupPlanetRouter(handler.NewPlanetHandler(svc))
+
+	svc.On("GetById", mock.Anything, int64(99)).Return(nil, planet.ErrNotFound)
+
+	req, _ := http.NewRequest(http.MethodGet, "/planets/99", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestPlanetHandler_GetOne_InvalidId(t *testing.T) {
+	svc := new(MockPlanetService)
+	router := setupPlanetRouter(handler.NewPlanetHandler(svc))
+
+	req, _ := http.NewRequest(http.MethodGet, "/planets/namek", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	svc.AssertNotCalled(t, "GetById", mock.Anything, mock.Anything)
+}
+
+func TestPlanetHandler_Characters_OK(t *testing.T) {
+	svc := new(MockPlanetService)
+	router := setupPlanetRouter(handler.NewPlanetHandler(svc))
+
+	svc.On("Characters", mock.Anything, int64(1)).Return([]domain.CharacterDTO{{Id: 3, Name: "Piccolo"}}, nil)
+
+	req, _ := http.NewRequest(http.MethodGet, "/planets/1/characters", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp struct {
+		Data []domain.CharacterDTO `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "Piccolo", resp.Data[0].Name)
+}
+
+func TestPlanetHandler_Characters_Unavailable(t *testing.T) {
+	svc := new(MockPlanetService)
+	router := setupPlanetRouter(handler.NewPlanetHandler(svc))
+
+	svc.On("Characters", mock.Anything, int64(1)).Return(nil, errors.New("service temporarily unavailable"))
+
+	req, _ := http.NewRequest(http.MethodGet, "/planets/1/characters", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
+
+func TestPlanetHandler_GetOne_UpstreamBudgetExceeded(t *testing.T) {
+	svc := new(MockPlanetService)
+	router := setupPlanetRouter(handler.NewPlanetHandler(svc))
+
+	svc.On("GetById", mock.Anything, int64(1)).Return(nil, &application.BudgetExceededError{RetryAfter: time.Second})
+
+	req, _ := http.NewRequest(http.MethodGet, "/planets/1", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "1", w.Header().Get("Retry-After"))
+}
+
+func TestPlanetHandler_GetOne_UpstreamLimited(t *testing.T) {
+	svc := new(MockPlanetService)
+	router := setupPlanetRouter(handler.NewPlanetHandler(svc))
+
+	svc.On("GetById", mock.Anything, int64(1)).Return(nil, &breaker.UpstreamLimitedError{Reason: "rate", RetryAfter: 1500 * time.Millisecond})
+
+	req, _ := http.NewRequest(http.MethodGet, "/planets/1", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Equal(t, "2", w.Header().Get("Retry-After"))
+}
@@ -0,0 +1,71 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heaveless/dbz-api/internal/delivery/http/handler"
+	transformation "github.com/heaveless/dbz-api/internal/domain/transformation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTransformationService struct {
+	mock.Mock
+}
+
+func (m *MockTransformationService) ListByCharacter(ctx context.Context, characterId int64) ([]transformation.TransformationDTO, error) {
+	args := m.Called(ctx, characterId)
+
+	var ts []transformation.TransformationDTO
+	if v := args.Get(0); v != nil {
+		ts = v.([]transformation.TransformationDTO)
+	}
+
+	return ts, args.Error(1)
+}
+
+func setupTransformationRouter(h *handler.TransformationHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/characters/:id/transformations", h.ListByCharacter)
+	return r
+}
+
+func TestTransformationHandler_ListByCharacter_OK(t *testing.T) {
+	svc := new(MockTransformationService)
+	router := setupTransformationRouter(handler.NewTransformationHandler(svc))
+
+	svc.
+		On("ListByCharacter", mock.Anything, int64(1)).
+		Return([]transformation.TransformationDTO{{Id: 1, CharacterId: 1, Name: "Goku SSJ"}}, nil)
+
+	req, _ := http.NewRequest(http.MethodGet, "/characters/1/transformations", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp struct {
+		Data []transformation.TransformationDTO `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "Goku SSJ", resp.Data[0].Name)
+}
+
+func TestTransformationHandler_ListByCharacter_NotFound(t *testing.T) {
+	svc := new(MockTransformationService)
+	router := setupTransformationRouter(handler.NewTransformationHandler(svc))
+
+	svc.On("ListByCharacter", mock.Anything, int64(999)).Return(nil, transformation.ErrNotFound)
+
+	req, _ := http.NewRequest(http.MethodGet, "/characters/999/transformations", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
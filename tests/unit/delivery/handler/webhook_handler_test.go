@@ -0,0 +1,216 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heaveless/dbz-api/internal/delivery/http/handler"
+	"github.com/heaveless/dbz-api/internal/delivery/http/validation"
+	"github.com/heaveless/dbz-api/internal/domain/event"
+	webhook "github.com/heaveless/dbz-api/internal/domain/webhook"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockWebhookService struct {
+	mock.Mock
+}
+
+func (m *MockWebhookService) Register(ctx context.Context, req webhook.NewSubscription) (*webhook.CreatedSubscriptionDTO, error) {
+	args := m.Called(ctx, req)
+
+	var s *webhook.CreatedSubscriptionDTO
+	if v := args.Get(0); v != nil {
+		s = v.(*webhook.CreatedSubscriptionDTO)
+	}
+
+	return s, args.Error(1)
+}
+
+func (m *MockWebhookService) List(ctx context.Context) ([]webhook.SubscriptionDTO, error) {
+	args := m.Called(ctx)
+
+	var ss []webhook.SubscriptionDTO
+	if v := args.Get(0); v != nil {
+		ss = v.([]webhook.SubscriptionDTO)
+	}
+
+	return ss, args.Error(1)
+}
+
+func (m *MockWebhookService) Test(ctx context.Context, id string) (*webhook.DeliveryDTO, error) {
+	args := m.Called(ctx, id)
+
+	var d *webhook.DeliveryDTO
+	if v := args.Get(0); v != nil {
+		d = v.(*webhook.DeliveryDTO)
+	}
+
+	return d, args.Error(1)
+}
+
+func (m *MockWebhookService) Deliveries(ctx context.Context, id string) ([]webhook.DeliveryDTO, error) {
+	args := m.Called(ctx, id)
+
+	var ds []webhook.DeliveryDTO
+	if v := args.Get(0); v != nil {
+		ds = v.([]webhook.DeliveryDTO)
+	}
+
+	return ds, args.Error(1)
+}
+
+var partnerWebhook = webhook.SubscriptionDTO{
+	Id:        "9b1e4c7a2d3f5e60",
+	Url:       "https://partner.example/hooks",
+	Events:    []event.Type{event.CharacterCached},
+	CreatedAt: time.Date(2026, time.October, 19, 15, 30, 0, 0, time.UTC),
+}
+
+func setupWebhookRouter(svc *MockWebhookService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	h := handler.NewWebhookHandler(svc)
+
+	r := gin.New()
+	r.GET("/admin/webhooks", h.List)
+	r.POST("/admin/webhooks", h.Create)
+	r.POST("/admin/webhooks/:id/test", h.Test)
+	r.GET("/admin/webhooks/:id/deliveries", h.Deliveries)
+
+	return r
+}
+
+func TestWebhookHandler_Create_OK(t *testing.T) {
+	svc := new(MockWebhookService)
+	svc.On("Register", mock.Anything, webhook.NewSubscription{Url: "https://partner.example/hooks", Events: []event.Type{event.CharacterCached}}).
+		Return(&webhook.CreatedSubscriptionDTO{SubscriptionDTO: partnerWebhook, Secret: "whsec_abc"}, nil)
+
+	req, _ := http.NewRequest(http.MethodPost, "/admin/webhooks", bytes.NewBufferString(`{"url":"https://partner.example/hooks","events":["CharacterCached"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	setupWebhookRouter(svc).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var resp struct {
+		Data webhook.CreatedSubscriptionDTO `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "whsec_abc", resp.Data.Secret)
+	svc.AssertExpectations(t)
+}
+
+func TestWebhookHandler_Create_FieldErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []validation.FieldError
+	}{
+		{
+			name: "missing url",
+			body: `{}`,
+			want: []validation.FieldError{{Field: "url", Code: validation.CodeRequired}},
+		},
+		{
+			name: "not an http url",
+			body: `{"url":"ftp://partner.example"}`,
+			want: []validation.FieldError{{Field: "url", Code: validation.CodeInvalid}},
+		},
+		{
+			name: "unknown event",
+			body: `{"url":"https://partner.example","events":["UpstreamUnavailable"]}`,
+			want: []validation.FieldError{{Field: "events[0]", Code: validation.CodeInvalid}},
+		},
+		{
+			name: "short secret",
+			body: `{"url":"https://partner.example","secret":"short"}`,
+			want: []validation.FieldError{{Field: "secret", Code: validation.CodeTooShort}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := new(MockWebhookService)
+
+			req, _ := http.NewRequest(http.MethodPost, "/admin/webhooks", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			setupWebhookRouter(svc).ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+
+			var resp struct {
+				Errors []validation.FieldError `json:"errors"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			assert.Equal(t, tt.want, resp.Errors)
+			svc.AssertNotCalled(t, "Register", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestWebhookHandler_List_OK(t *testing.T) {
+	svc := new(MockWebhookService)
+	svc.On("List", mock.Anything).Return([]webhook.SubscriptionDTO{partnerWebhook}, nil)
+
+	req, _ := http.NewRequest(http.MethodGet, "/admin/webhooks", nil)
+	w := httptest.NewRecorder()
+
+	setupWebhookRouter(svc).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), "secret")
+}
+
+func TestWebhookHandler_Test(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{name: "sent", status: http.StatusOK},
+		{name: "unknown subscription", err: fmt.Errorf("webhook %w", webhook.ErrNotFound), status: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := new(MockWebhookService)
+			if tt.err != nil {
+				svc.On("Test", mock.Anything, "9b1e4c7a2d3f5e60").Return(nil, tt.err)
+			} else {
+				svc.On("Test", mock.Anything, "9b1e4c7a2d3f5e60").Return(&webhook.DeliveryDTO{Id: "t1:9b1e4c7a2d3f5e60", Status: webhook.StatusFailed}, nil)
+			}
+
+			req, _ := http.NewRequest(http.MethodPost, "/admin/webhooks/9b1e4c7a2d3f5e60/test", nil)
+			w := httptest.NewRecorder()
+
+			setupWebhookRouter(svc).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.status, w.Code)
+		})
+	}
+}
+
+func TestWebhookHandler_Deliveries_OK(t *testing.T) {
+	svc := new(MockWebhookService)
+	svc.On("Deliveries", mock.Anything, "9b1e4c7a2d3f5e60").Return([]webhook.DeliveryDTO{{Id: "k1:9b1e4c7a2d3f5e60", Status: webhook.StatusDelivered}}, nil)
+
+	req, _ := http.NewRequest(http.MethodGet, "/admin/webhooks/9b1e4c7a2d3f5e60/deliveries", nil)
+	w := httptest.NewRecorder()
+
+	setupWebhookRouter(svc).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"status":"delivered"`)
+}
@@ -0,0 +1,107 @@
+package middleware_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heaveless/dbz-api/internal/delivery/http/middleware"
+	"github.com/heaveless/dbz-api/internal/domain/apikey"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeAuthenticator map[string]*apikey.Principal
+
+func (f fakeAuthenticator) Authenticate(_ context.Context, key string, scope string) (*apikey.Principal, error) {
+	switch key {
+	case "spent":
+		return nil, &apikey.QuotaExceededError{Quota: 10, Reset: time.Now().Add(90 * time.Minute)}
+	case "broken":
+		return nil, errors.New("db down")
+	}
+
+	p, ok := f[key]
+	if !ok {
+		return nil, apikey.ErrInvalidKey
+	}
+	if !p.HasScope(scope) {
+		return nil, apikey.ErrScopeMissing
+	}
+	return p, nil
+}
+
+func setupAuthRouter(scope string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	auth := fakeAuthenticator{
+		"reader": {KeyId: "k1", Scopes: []string{apikey.ScopeRead}},
+		"admin":  {KeyId: "k2", Scopes: []string{apikey.ScopeAdmin}},
+	}
+
+	r := gin.New()
+	r.GET("/ping", middleware.Authenticated(auth, scope), func(c *gin.Context) {
+		p, _ := middleware.PrincipalFrom(c)
+		c.String(http.StatusOK, p.KeyId)
+	})
+
+	return r
+}
+
+func TestAuthenticated(t *testing.T) {
+	tests := []struct {
+		name   string
+		scope  string
+		header string
+		value  string
+		status int
+		body   string
+	}{
+		{name: "missing key", scope: apikey.ScopeRead, status: http.StatusUnauthorized},
+		{name: "unknown key", scope: apikey.ScopeRead, header: "X-API-Key", value: "nope", status: http.StatusUnauthorized},
+		{name: "header key", scope: apikey.ScopeRead, header: "X-API-Key", value: "reader", status: http.StatusOK, body: "k1"},
+		{name: "bearer key", scope: apikey.ScopeRead, header: "Authorization", value: "Bearer reader", status: http.StatusOK, body: "k1"},
+		{name: "missing scope", scope: apikey.ScopeAdmin, header: "X-API-Key", value: "reader", status: http.StatusForbidden},
+		{name: "admin has every scope", scope: apikey.ScopeRead, header: "X-API-Key", value: "admin", status: http.StatusOK, body: "k2"},
+		{name: "quota spent", scope: apikey.ScopeRead, header: "X-API-Key", value: "spent", status: http.StatusTooManyRequests},
+		{name: "store down", scope: apikey.ScopeRead, header: "X-API-Key", value: "broken", status: http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, "/ping", nil)
+			if tt.header != "" {
+				req.Header.Set(tt.header, tt.value)
+			}
+			w := httptest.NewRecorder()
+
+			setupAuthRouter(tt.scope).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.status, w.Code)
+			if tt.body != "" {
+				assert.Equal(t, tt.body, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestAuthenticated_Headers(t *testing.T) {
+	r := setupAuthRouter(apikey.ScopeRead)
+
+	req, _ := http.NewRequest(http.MethodGet, "/ping", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, `Bearer realm="dbz-api"`, w.Header().Get("WWW-Authenticate"))
+	assert.JSONEq(t, `{"message":"`+middleware.MissingKeyMessage+`"}`, w.Body.String())
+
+	req, _ = http.NewRequest(http.MethodGet, "/ping", nil)
+	req.Header.Set("X-API-Key", "spent")
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, "5400", w.Header().Get("Retry-After"))
+}
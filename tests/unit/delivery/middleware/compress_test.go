@@ -0,0 +1,153 @@
+package middleware_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/gin-gonic/gin"
+	"github.com/heaveless/dbz-api/internal/delivery/http/middleware"
+	"github.com/klauspost/compress/gzip"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var largeBody = strings.Repeat("Kamehameha! ", 200)
+
+func compressRouter(minSize int) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(middleware.Compress(minSize))
+	r.GET("/large", func(c *gin.Context) {
+		c.String(http.StatusOK, largeBody)
+	})
+	r.GET("/small", func(c *gin.Context) {
+		c.String(http.StatusOK, "ok")
+	})
+	r.GET("/encoded", func(c *gin.Context) {
+		c.Header("Content-Encoding", "gzip")
+		c.String(http.StatusOK, largeBody)
+	})
+	r.GET("/not-modified", func(c *gin.Context) {
+		c.AbortWithStatus(http.StatusNotModified)
+	})
+	r.GET("/stream", func(c *gin.Context) {
+		_, _ = c.Writer.WriteString("data: 1\n\n")
+		c.Writer.Flush()
+		_, _ = c.Writer.WriteString("data: 2\n\n")
+	})
+
+	return r
+}
+
+func requestEncoding(r http.Handler, path, acceptEncoding string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(http.MethodGet, path, nil)
+	if acceptEncoding != "" {
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	return w
+}
+
+func decode(t *testing.T, encoding string, body []byte) string {
+	t.Helper()
+
+	var r io.Reader
+	switch encoding {
+	case "gzip":
+		gr, err := gzip.NewReader(bytes.NewReader(body))
+		require.NoError(t, err)
+		r = gr
+	case "br":
+		r = brotli.NewReader(bytes.NewReader(body))
+	case "zstd":
+		zr, err := zstd.NewReader(bytes.NewReader(body))
+		require.NoError(t, err)
+		defer zr.Close()
+		r = zr
+	default:
+		return string(body)
+	}
+
+	out, err := io.ReadAll(r)
+	require.NoError(t, err)
+
+	return string(out)
+}
+
+func TestCompress_NegotiatesEncoding(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		want           string
+	}{
+		{name: "gzip only", acceptEncoding: "gzip", want: "gzip"},
+		{name: "brotli only", acceptEncoding: "br", want: "br"},
+		{name: "zstd only", acceptEncoding: "zstd", want: "zstd"},
+		{name: "ties go to zstd", acceptEncoding: "gzip, deflate, br, zstd", want: "zstd"},
+		{name: "quality wins over preference", acceptEncoding: "zstd;q=0.5, gzip", want: "gzip"},
+		{name: "wildcard", acceptEncoding: "*", want: "zstd"},
+		{name: "wildcard minus refused", acceptEncoding: "*, zstd;q=0", want: "br"},
+		{name: "none supported", acceptEncoding: "deflate", want: ""},
+		{name: "no header", acceptEncoding: "", want: ""},
+	}
+
+	r := compressRouter(middleware.DefaultCompressMinSize)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := requestEncoding(r, "/large", tt.acceptEncoding)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tt.want, w.Header().Get("Content-Encoding"))
+			assert.Equal(t, "Accept-Encoding", w.Header().Get("Vary"))
+			assert.Equal(t, largeBody, decode(t, tt.want, w.Body.Bytes()))
+			if tt.want != "" {
+				assert.Less(t, w.Body.Len(), len(largeBody))
+			}
+		})
+	}
+}
+
+func TestCompress_LeavesSmallBodiesAlone(t *testing.T) {
+	w := requestEncoding(compressRouter(middleware.DefaultCompressMinSize), "/small", "gzip")
+
+	assert.Empty(t, w.Header().Get("Content-Encoding"))
+	assert.Equal(t, "ok", w.Body.String())
+}
+
+func TestCompress_MinSizeIsConfigurable(t *testing.T) {
+	w := requestEncoding(compressRouter(1), "/small", "gzip")
+
+	assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+	assert.Equal(t, "ok", decode(t, "gzip", w.Body.Bytes()))
+}
+
+func TestCompress_KeepsExistingEncoding(t *testing.T) {
+	w := requestEncoding(compressRouter(middleware.DefaultCompressMinSize), "/encoded", "zstd")
+
+	assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+	assert.Equal(t, largeBody, w.Body.String())
+}
+
+func TestCompress_NotModifiedHasNoBody(t *testing.T) {
+	w := requestEncoding(compressRouter(1), "/not-modified", "gzip")
+
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Header().Get("Content-Encoding"))
+	assert.Empty(t, w.Body.String())
+}
+
+func TestCompress_FlushStreamsBelowMinSize(t *testing.T) {
+	w := requestEncoding(compressRouter(middleware.DefaultCompressMinSize), "/stream", "gzip")
+
+	assert.True(t, w.Flushed)
+	assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+	assert.Equal(t, "data: 1\n\ndata: 2\n\n", decode(t, "gzip", w.Body.Bytes()))
+}
@@ -0,0 +1,46 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heaveless/dbz-api/internal/delivery/http/middleware"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeprecated_SetsHeaders(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	legacy := r.Group("", middleware.Deprecated(middleware.Deprecation{
+		Since:           time.Date(2026, time.November, 1, 0, 0, 0, 0, time.UTC),
+		Sunset:          time.Date(2027, time.May, 1, 0, 0, 0, 0, time.UTC),
+		SuccessorPrefix: "/v1/",
+	}))
+	legacy.POST("/characters", func(c *gin.Context) {
+		c.Status(http.StatusFound)
+	})
+	r.POST("/v1/characters", func(c *gin.Context) {
+		c.Status(http.StatusFound)
+	})
+
+	req, _ := http.NewRequest(http.MethodPost, "/characters", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusFound, w.Code)
+	assert.Equal(t, "@1793491200", w.Header().Get("Deprecation"))
+	assert.Equal(t, "Sat, 01 May 2027 00:00:00 GMT", w.Header().Get("Sunset"))
+	assert.Equal(t, `</v1/characters>; rel="successor-version"`, w.Header().Get("Link"))
+
+	req, _ = http.NewRequest(http.MethodPost, "/v1/characters", nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Empty(t, w.Header().Get("Deprecation"))
+	assert.Empty(t, w.Header().Get("Sunset"))
+	assert.Empty(t, w.Header().Get("Link"))
+}
@@ -0,0 +1,106 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heaveless/dbz-api/internal/delivery/http/middleware"
+	"github.com/heaveless/dbz-api/internal/domain/apikey"
+	"github.com/heaveless/dbz-api/internal/infrastructure/oidc"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	readerToken = "h.reader.s"
+	bareToken   = "h.bare.s"
+	badToken    = "h.bad.s"
+	downToken   = "h.down.s"
+)
+
+type fakeVerifier struct{}
+
+func (fakeVerifier) Verify(_ context.Context, token string) (*oidc.Claims, error) {
+	switch token {
+	case readerToken:
+		return &oidc.Claims{Subject: "svc-reports", Scopes: []string{"dbz.read"}}, nil
+	case bareToken:
+		return &oidc.Claims{Subject: "svc-other", Scopes: []string{"admin"}}, nil
+	case downToken:
+		return nil, oidc.ErrKeysUnavailable
+	}
+
+	return nil, oidc.ErrInvalidToken
+}
+
+func setupJWTRouter(fallback gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.GET("/ping", middleware.BearerJWT(middleware.JWTAuth{
+		Verifier: fakeVerifier{},
+		Scopes:   []string{"dbz.read"},
+		Fallback: fallback,
+	}), func(c *gin.Context) {
+		p, _ := middleware.PrincipalFrom(c)
+		if claims, ok := middleware.ClaimsFrom(c); ok {
+			c.String(http.StatusOK, "jwt:"+claims.Subject)
+			return
+		}
+		c.String(http.StatusOK, "key:"+p.KeyId)
+	})
+
+	return r
+}
+
+func TestBearerJWT(t *testing.T) {
+	apiKeys := middleware.Authenticated(fakeAuthenticator{
+		"reader": {KeyId: "k1", Scopes: []string{apikey.ScopeRead}},
+	}, apikey.ScopeRead)
+
+	tests := []struct {
+		name     string
+		fallback gin.HandlerFunc
+		header   string
+		value    string
+		status   int
+		body     string
+		www      string
+	}{
+		{name: "valid token", header: "Authorization", value: "Bearer " + readerToken, status: http.StatusOK, body: "jwt:svc-reports"},
+		{name: "invalid token", header: "Authorization", value: "Bearer " + badToken, status: http.StatusUnauthorized, www: `Bearer realm="dbz-api", error="invalid_token"`},
+		{
+			name:   "scope matched literally",
+			header: "Authorization", value: "Bearer " + bareToken,
+			status: http.StatusForbidden,
+			www:    `Bearer realm="dbz-api", error="insufficient_scope", scope="dbz.read"`,
+		},
+		{name: "keys unavailable", header: "Authorization", value: "Bearer " + downToken, status: http.StatusServiceUnavailable},
+		{name: "no token without fallback", status: http.StatusUnauthorized},
+		{name: "api key header falls back", fallback: apiKeys, header: "X-API-Key", value: "reader", status: http.StatusOK, body: "key:k1"},
+		{name: "api key as bearer falls back", fallback: apiKeys, header: "Authorization", value: "Bearer reader", status: http.StatusOK, body: "key:k1"},
+		{name: "fallback rejects", fallback: apiKeys, header: "X-API-Key", value: "nope", status: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, "/ping", nil)
+			if tt.header != "" {
+				req.Header.Set(tt.header, tt.value)
+			}
+			w := httptest.NewRecorder()
+
+			setupJWTRouter(tt.fallback).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.status, w.Code)
+			if tt.body != "" {
+				assert.Equal(t, tt.body, w.Body.String())
+			}
+			if tt.www != "" {
+				assert.Equal(t, tt.www, w.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
@@ -0,0 +1,228 @@
+package middleware_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heaveless/dbz-api/internal/application"
+	"github.com/heaveless/dbz-api/internal/delivery/http/middleware"
+	"github.com/heaveless/dbz-api/internal/domain/apikey"
+	"github.com/heaveless/dbz-api/internal/infrastructure/ratelimit"
+	"github.com/stretchr/testify/assert"
+)
+
+type failingStore struct{}
+
+func (failingStore) Take(context.Context, string, ratelimit.Limit) (ratelimit.Decision, error) {
+	return ratelimit.Decision{}, errors.New("store down")
+}
+
+type nameStore struct{}
+
+func (nameStore) Get(context.Context, string) (*string, error) {
+	return nil, errors.New("not stored")
+}
+
+func (nameStore) Create(context.Context, *string) error {
+	return nil
+}
+
+type echoUpstream struct{}
+
+func (echoUpstream) Get(_ context.Context, key string) (*string, error) {
+	return &key, nil
+}
+
+type echoMapper struct{}
+
+func (echoMapper) ToDTO(s *string) *string {
+	return s
+}
+
+func setupRateLimitRouter(rl middleware.RateLimit) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	// Every lookup misses the local store, so each request spends upstream budget.
+	svc := application.NewCachedResourceService[string, string, string](
+		nameStore{}, echoUpstream{}, echoMapper{},
+		application.CacheOptions[string, string]{},
+	)
+
+	r := gin.New()
+	r.GET("/ping", middleware.RateLimited(rl), func(c *gin.Context) {
+		c.String(http.StatusOK, "pong")
+	})
+	r.GET("/lookup/:name", middleware.RateLimited(rl), func(c *gin.Context) {
+		_, err := svc.Get(c.Request.Context(), c.Param("name"))
+		var exceeded *application.BudgetExceededError
+		if errors.As(err, &exceeded) {
+			c.Status(http.StatusTooManyRequests)
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+	return r
+}
+
+func get(r *gin.Engine, path string, header ...string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(http.MethodGet, path, nil)
+	req.RemoteAddr = "10.0.0.1:1234"
+	for i := 0; i+1 < len(header); i += 2 {
+		req.Header.Set(header[i], header[i+1])
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestRateLimited_PerIP(t *testing.T) {
+	r := setupRateLimitRouter(middleware.RateLimit{
+		Store: ratelimit.NewMemoryStore(),
+		PerIP: ratelimit.Limit{Rate: 0.5, Burst: 2},
+	})
+
+	w := get(r, "/ping")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "2", w.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "1", w.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "2", w.Header().Get("RateLimit-Reset"))
+
+	assert.Equal(t, http.StatusOK, get(r, "/ping").Code)
+
+	w = get(r, "/ping")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "0", w.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "2", w.Header().Get("Retry-After"))
+	assert.JSONEq(t, `{"message":"Too many requests, please try again later."}`, w.Body.String())
+}
+
+// knownKeys accepts secret-a and secret-b, with the read scope.
+type knownKeys struct{}
+
+func (knownKeys) Authenticate(_ context.Context, key string, _ string) (*apikey.Principal, error) {
+	switch key {
+	case "secret-a", "secret-b":
+		return &apikey.Principal{KeyId: "id-" + key, Name: key, Scopes: []string{apikey.ScopeRead}}, nil
+	}
+
+	return nil, apikey.ErrInvalidKey
+}
+
+func setupAuthenticatedRateLimitRouter(rl middleware.RateLimit) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.GET("/ping",
+		middleware.RateLimited(rl),
+		middleware.Authenticated(knownKeys{}, apikey.ScopeRead),
+		middleware.CallerRateLimited(rl),
+		func(c *gin.Context) { c.String(http.StatusOK, "pong") },
+	)
+	return r
+}
+
+func TestCallerRateLimited_KeysHaveTheirOwnBucket(t *testing.T) {
+	r := setupAuthenticatedRateLimitRouter(middleware.RateLimit{
+		Store:  ratelimit.NewMemoryStore(),
+		PerIP:  ratelimit.Limit{Rate: 100, Burst: 100},
+		PerKey: ratelimit.Limit{Rate: 0.1, Burst: 2},
+	})
+
+	w := get(r, "/ping", "X-API-Key", "secret-a")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "2", w.Header().Get("RateLimit-Limit"))
+
+	assert.Equal(t, http.StatusOK, get(r, "/ping", "Authorization", "Bearer secret-a").Code)
+	assert.Equal(t, http.StatusTooManyRequests, get(r, "/ping", "X-API-Key", "secret-a").Code)
+	assert.Equal(t, http.StatusOK, get(r, "/ping", "X-API-Key", "secret-b").Code)
+}
+
+func TestRateLimited_MadeUpKeysShareTheIpBucket(t *testing.T) {
+	store := ratelimit.NewMemoryStore()
+	r := setupAuthenticatedRateLimitRouter(middleware.RateLimit{
+		Store:  store,
+		PerIP:  ratelimit.Limit{Rate: 0.1, Burst: 2},
+		PerKey: ratelimit.Limit{Rate: 100, Burst: 100},
+	})
+
+	assert.Equal(t, http.StatusUnauthorized, get(r, "/ping", "X-API-Key", "made-up-1").Code)
+	assert.Equal(t, http.StatusUnauthorized, get(r, "/ping", "X-API-Key", "made-up-2").Code)
+	assert.Equal(t, http.StatusTooManyRequests, get(r, "/ping", "X-API-Key", "made-up-3").Code)
+	assert.Equal(t, http.StatusTooManyRequests, get(r, "/ping", "X-API-Key", "secret-a").Code, "valid keys are held back by their IP too")
+	assert.Equal(t, 1, store.Len(), "made-up keys never get buckets")
+}
+
+func TestCallerRateLimited_UpstreamBudgetFollowsTheCaller(t *testing.T) {
+	store := ratelimit.NewMemoryStore()
+	rl := middleware.RateLimit{
+		Store:    store,
+		Upstream: ratelimit.Limit{Rate: 0.1, Burst: 1},
+	}
+	svc := application.NewCachedResourceService[string, string, string](
+		nameStore{}, echoUpstream{}, echoMapper{},
+		application.CacheOptions[string, string]{},
+	)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/lookup/:name",
+		middleware.RateLimited(rl),
+		middleware.Authenticated(knownKeys{}, apikey.ScopeRead),
+		middleware.CallerRateLimited(rl),
+		func(c *gin.Context) {
+			if _, err := svc.Get(c.Request.Context(), c.Param("name")); err != nil {
+				c.Status(http.StatusTooManyRequests)
+				return
+			}
+			c.Status(http.StatusOK)
+		},
+	)
+
+	assert.Equal(t, http.StatusOK, get(r, "/lookup/goku", "X-API-Key", "secret-a").Code)
+	assert.Equal(t, http.StatusTooManyRequests, get(r, "/lookup/vegeta", "X-API-Key", "secret-a").Code)
+	assert.Equal(t, http.StatusOK, get(r, "/lookup/gohan", "X-API-Key", "secret-b").Code)
+}
+
+func TestCallerKey(t *testing.T) {
+	assert.Equal(t, "key:k1", middleware.CallerKey(&apikey.Principal{KeyId: "k1", Name: "ops"}))
+	assert.Equal(t, "sub:user-1", middleware.CallerKey(&apikey.Principal{Name: "user-1"}))
+}
+
+func TestRateLimited_UpstreamBudgetOnlyCountsMisses(t *testing.T) {
+	r := setupRateLimitRouter(middleware.RateLimit{
+		Store:    ratelimit.NewMemoryStore(),
+		PerIP:    ratelimit.Limit{Rate: 100, Burst: 100},
+		Upstream: ratelimit.Limit{Rate: 0.1, Burst: 2},
+	})
+
+	assert.Equal(t, http.StatusOK, get(r, "/lookup/goku").Code)
+	assert.Equal(t, http.StatusOK, get(r, "/ping").Code)
+	assert.Equal(t, http.StatusOK, get(r, "/lookup/vegeta").Code)
+	assert.Equal(t, http.StatusTooManyRequests, get(r, "/lookup/gohan").Code)
+	assert.Equal(t, http.StatusOK, get(r, "/ping").Code)
+}
+
+func TestRateLimited_FailsOpenWhenStoreFails(t *testing.T) {
+	r := setupRateLimitRouter(middleware.RateLimit{
+		Store:    failingStore{},
+		PerIP:    ratelimit.Limit{Rate: 1, Burst: 1},
+		Upstream: ratelimit.Limit{Rate: 1, Burst: 1},
+	})
+
+	for range 3 {
+		w := get(r, "/lookup/goku")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Header().Get("RateLimit-Limit"))
+	}
+}
+
+func TestRetryAfterSeconds(t *testing.T) {
+	assert.Equal(t, "1", middleware.RetryAfterSeconds(0))
+	assert.Equal(t, "1", middleware.RetryAfterSeconds(200*time.Millisecond))
+	assert.Equal(t, "3", middleware.RetryAfterSeconds(2100*time.Millisecond))
+}
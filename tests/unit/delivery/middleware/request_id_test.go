@@ -0,0 +1,55 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heaveless/dbz-api/internal/delivery/http/middleware"
+	"github.com/heaveless/dbz-api/internal/domain/audit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestId(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name   string
+		header string
+		keep   bool
+	}{
+		{name: "client id", header: "req-42.a:b_c", keep: true},
+		{name: "no id"},
+		{name: "malformed id", header: "bad id\n"},
+		{name: "id too long", header: strings.Repeat("a", 129)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var seen string
+			r := gin.New()
+			r.Use(middleware.RequestId())
+			r.GET("/", func(c *gin.Context) {
+				seen = audit.RequestIdFrom(c.Request.Context())
+				c.Status(http.StatusNoContent)
+			})
+
+			req, _ := http.NewRequest(http.MethodGet, "/", nil)
+			if tt.header != "" {
+				req.Header.Set(middleware.RequestIdHeader, tt.header)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			id := w.Header().Get(middleware.RequestIdHeader)
+			assert.Equal(t, seen, id)
+			if tt.keep {
+				assert.Equal(t, tt.header, id)
+			} else {
+				assert.Len(t, id, 32)
+			}
+		})
+	}
+}
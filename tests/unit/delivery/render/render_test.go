@@ -0,0 +1,164 @@
+package render_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heaveless/dbz-api/internal/delivery/http/render"
+	"github.com/heaveless/dbz-api/internal/delivery/pb/dbzv1"
+	domain "github.com/heaveless/dbz-api/internal/domain/character"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ugorji/go/codec"
+	"google.golang.org/protobuf/proto"
+)
+
+var goku = &domain.CharacterDTO{Id: 1, Name: "Goku", Ki: "60.000.000", MaxKi: "90 Septillion", Race: "Saiyan"}
+
+func serve(accept string, body any) *httptest.ResponseRecorder {
+	return serveStatus(http.StatusOK, accept, body)
+}
+
+func serveStatus(status int, accept string, body any) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/", func(c *gin.Context) {
+		c.Header("ETag", `"v1"`)
+		render.Respond(c, status, body)
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/", nil)
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	return w
+}
+
+func TestRespond_Negotiates(t *testing.T) {
+	tests := []struct {
+		name   string
+		accept string
+		want   string
+	}{
+		{name: "no accept header", accept: "", want: "application/json; charset=utf-8"},
+		{name: "anything", accept: "*/*", want: "application/json; charset=utf-8"},
+		{name: "msgpack", accept: "application/msgpack", want: "application/msgpack"},
+		{name: "protobuf", accept: "application/x-protobuf", want: "application/x-protobuf"},
+		{name: "quality order", accept: "application/json;q=0.5, application/msgpack", want: "application/msgpack"},
+		{name: "specific range beats wildcard", accept: "application/*, application/json;q=0", want: "application/msgpack"},
+		{name: "nothing acceptable", accept: "text/html", want: "application/json; charset=utf-8"},
+		{name: "malformed quality ignored", accept: "application/msgpack;q=high, application/x-protobuf", want: "application/x-protobuf"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(tt.accept, gin.H{"data": goku})
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tt.want, w.Header().Get("Content-Type"))
+			assert.Equal(t, "Accept", w.Header().Get("Vary"))
+		})
+	}
+}
+
+func TestRespond_MsgPackKeepsJSONShape(t *testing.T) {
+	w := serve("application/msgpack", gin.H{"data": goku})
+
+	var h codec.MsgpackHandle
+	h.RawToString = true
+
+	var got map[string]any
+	require.NoError(t, codec.NewDecoderBytes(w.Body.Bytes(), &h).Decode(&got))
+
+	data, ok := got["data"].(map[any]any)
+	require.True(t, ok, "%#v", got)
+	assert.Equal(t, "Goku", data["name"])
+	assert.Equal(t, "90 Septillion", data["maxKi"])
+	assert.NotContains(t, data, "gender", "omitempty applies")
+	assert.NotContains(t, data, "ETag", "json:\"-\" applies")
+}
+
+func TestRespond_Protobuf(t *testing.T) {
+	t.Run("character", func(t *testing.T) {
+		w := serve("application/x-protobuf", gin.H{"data": goku})
+
+		var got dbzv1.CharacterResponse
+		require.NoError(t, proto.Unmarshal(w.Body.Bytes(), &got))
+		assert.Equal(t, int64(1), got.GetData().GetId())
+		assert.Equal(t, "90 Septillion", got.GetData().GetMaxKi())
+	})
+
+	t.Run("page", func(t *testing.T) {
+		body := gin.H{
+			"data": []domain.CharacterDTO{*goku},
+			"meta": render.PageMeta{Page: 2, Limit: 1, Total: 3, TotalPages: 3},
+		}
+		w := serve("application/x-protobuf", body)
+
+		var got dbzv1.CharacterPage
+		require.NoError(t, proto.Unmarshal(w.Body.Bytes(), &got))
+		require.Len(t, got.GetData(), 1)
+		assert.Equal(t, "Goku", got.GetData()[0].GetName())
+		assert.Equal(t, int32(2), got.GetMeta().GetPage())
+		assert.Equal(t, int64(3), got.GetMeta().GetTotalPages())
+	})
+
+	t.Run("error with suggestions", func(t *testing.T) {
+		w := serve("application/x-protobuf", gin.H{"message": "character not found", "suggestions": []string{"Goku"}})
+
+		var got dbzv1.Error
+		require.NoError(t, proto.Unmarshal(w.Body.Bytes(), &got))
+		assert.Equal(t, "character not found", got.GetMessage())
+		assert.Equal(t, []string{"Goku"}, got.GetSuggestions())
+	})
+}
+
+func TestRespond_FallsBackWhenFormatHasNoSchema(t *testing.T) {
+	body := gin.H{"message": "Invalid request.", "errors": []string{"name"}}
+
+	w := serveStatus(http.StatusBadRequest, "application/x-protobuf, application/msgpack;q=0.5", body)
+	assert.Equal(t, "application/msgpack", w.Header().Get("Content-Type"))
+
+	w = serveStatus(http.StatusBadRequest, "application/x-protobuf", body)
+	assert.Equal(t, http.StatusBadRequest, w.Code, "errors keep their status")
+	assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+	assert.Equal(t, "Invalid request.", got["message"])
+}
+
+func TestRespond_NotAcceptable(t *testing.T) {
+	body := gin.H{"data": []string{"Namek"}}
+
+	w := serve("application/x-protobuf", body)
+
+	assert.Equal(t, http.StatusNotAcceptable, w.Code)
+	assert.Equal(t, "application/x-protobuf", w.Header().Get("Content-Type"))
+	assert.Empty(t, w.Header().Get("ETag"))
+	var got dbzv1.Error
+	require.NoError(t, proto.Unmarshal(w.Body.Bytes(), &got))
+	assert.NotEmpty(t, got.GetMessage())
+
+	w = serve("application/x-protobuf, */*;q=0.1", body)
+
+	assert.Equal(t, http.StatusOK, w.Code, "the client also takes anything else")
+	assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+}
+
+func TestPreferences(t *testing.T) {
+	prefs := render.Preferences("gzip;q=0.5, BR, zstd;q=0.9, *;q=0")
+
+	assert.Equal(t, []render.Preference{
+		{Value: "br", Q: 1},
+		{Value: "zstd", Q: 0.9},
+		{Value: "gzip", Q: 0.5},
+		{Value: "*", Q: 0},
+	}, prefs)
+}
@@ -6,11 +6,16 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/gin-gonic/gin"
+	graphqldelivery "github.com/heaveless/dbz-api/internal/delivery/graphql"
+	server "github.com/heaveless/dbz-api/internal/delivery/http"
 	"github.com/heaveless/dbz-api/internal/delivery/http/handler"
+	"github.com/heaveless/dbz-api/internal/delivery/http/middleware"
 	domain "github.com/heaveless/dbz-api/internal/domain/character"
+	"github.com/heaveless/dbz-api/internal/infrastructure/eventbus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -30,6 +35,55 @@ func (m *MockCharacterService) GetByName(ctx context.Context, name string) (*dom
 	return chr, args.Error(1)
 }
 
+func (m *MockCharacterService) GetById(ctx context.Context, id int64) (*domain.CharacterDTO, error) {
+	args := m.Called(ctx, id)
+
+	var chr *domain.CharacterDTO
+	if v := args.Get(0); v != nil {
+		chr = v.(*domain.CharacterDTO)
+	}
+
+	return chr, args.Error(1)
+}
+
+func (m *MockCharacterService) Search(ctx context.Context, query string, limit int) ([]domain.CharacterMatchDTO, error) {
+	args := m.Called(ctx, query, limit)
+
+	var matches []domain.CharacterMatchDTO
+	if v := args.Get(0); v != nil {
+		matches = v.([]domain.CharacterMatchDTO)
+	}
+
+	return matches, args.Error(1)
+}
+
+func (m *MockCharacterService) SearchText(ctx context.Context, query string, page, limit int) (*domain.CharacterTextSearchDTO, error) {
+	args := m.Called(ctx, query, page, limit)
+
+	var res *domain.CharacterTextSearchDTO
+	if v := args.Get(0); v != nil {
+		res = v.(*domain.CharacterTextSearchDTO)
+	}
+
+	return res, args.Error(1)
+}
+
+func (m *MockCharacterService) List(ctx context.Context, q domain.ListQuery) (*domain.CharacterPageDTO, error) {
+	args := m.Called(ctx, q)
+
+	var res *domain.CharacterPageDTO
+	if v := args.Get(0); v != nil {
+		res = v.(*domain.CharacterPageDTO)
+	}
+
+	return res, args.Error(1)
+}
+
+func (m *MockCharacterService) GetBatch(ctx context.Context, refs []domain.CharacterRef) []domain.CharacterResult {
+	args := m.Called(ctx, refs)
+	return args.Get(0).([]domain.CharacterResult)
+}
+
 func TestCharacterHandler_GetOne_Direct(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
@@ -67,3 +121,149 @@ func TestCharacterHandler_GetOne_Direct(t *testing.T) {
 
 	svc.AssertExpectations(t)
 }
+
+func TestNewServer_RegistersRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := server.NewServer(server.Handlers{
+		Character:      handler.NewCharacterHandler(new(MockCharacterService)),
+		Planet:         handler.NewPlanetHandler(nil),
+		Transformation: handler.NewTransformationHandler(nil),
+		Event:          handler.NewEventHandler(eventbus.NewBus(0)),
+		GraphQL:        graphqldelivery.NewHandler(graphqldelivery.Services{}, graphqldelivery.Limits{}),
+	}, server.Middleware{})
+
+	routes := map[string]bool{}
+	for _, route := range r.Routes() {
+		routes[route.Method+" "+route.Path] = true
+	}
+
+	for _, expected := range []string{
+		"GET /health",
+		"POST /characters",
+		"GET /v1/characters",
+		"POST /v1/characters",
+		"POST /v1/characters/batch",
+		"GET /v1/characters/:id",
+		"GET /v1/characters/search",
+		"GET /v1/characters/:id/transformations",
+		"GET /v1/planets",
+		"GET /v1/planets/:id",
+		"GET /v1/planets/:id/characters",
+		"GET /events",
+		"GET /graphql",
+		"POST /graphql",
+	} {
+		assert.True(t, routes[expected], expected)
+	}
+
+	// Only POST /characters predates /v1; later routes are versioned only.
+	for _, unexpected := range []string{
+		"GET /characters",
+		"POST /characters/batch",
+		"GET /characters/search",
+		"GET /characters/:id",
+		"GET /planets",
+	} {
+		assert.False(t, routes[unexpected], unexpected)
+	}
+}
+
+func TestNewServer_DeprecatesLegacyRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	svc := new(MockCharacterService)
+	svc.On("GetByName", mock.Anything, "Goku").Return(&domain.CharacterDTO{Id: 1, Name: "Goku"}, nil)
+
+	r := server.NewServer(server.Handlers{
+		Character:      handler.NewCharacterHandler(svc),
+		Planet:         handler.NewPlanetHandler(nil),
+		Transformation: handler.NewTransformationHandler(nil),
+	}, server.Middleware{})
+
+	tests := []struct {
+		path       string
+		deprecated bool
+	}{
+		{path: "/characters", deprecated: true},
+		{path: "/v1/characters", deprecated: false},
+	}
+
+	for _, tt := range tests {
+		req, _ := http.NewRequest(http.MethodPost, tt.path, bytes.NewBufferString(`{"name":"Goku"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusFound, w.Code, tt.path)
+		if tt.deprecated {
+			assert.JSONEq(t, `{"data":{"Id":1,"Name":"Goku","Ki":"","MaxKi":"","Race":"","Gender":"","Image":"","Affiliation":""}}`, w.Body.String())
+			assert.NotEmpty(t, w.Header().Get("Deprecation"), tt.path)
+			assert.NotEmpty(t, w.Header().Get("Sunset"), tt.path)
+			assert.Equal(t, `</v1/characters>; rel="successor-version"`, w.Header().Get("Link"), tt.path)
+		} else {
+			assert.Empty(t, w.Header().Get("Deprecation"), tt.path)
+		}
+	}
+}
+
+func TestNewServer_AdminRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handlers := server.Handlers{
+		Character:      handler.NewCharacterHandler(new(MockCharacterService)),
+		Planet:         handler.NewPlanetHandler(nil),
+		Transformation: handler.NewTransformationHandler(nil),
+	}
+
+	hasAdmin := func(r *gin.Engine) bool {
+		for _, route := range r.Routes() {
+			if strings.HasPrefix(route.Path, "/admin") {
+				return true
+			}
+		}
+		return false
+	}
+
+	assert.False(t, hasAdmin(server.NewServer(handlers, server.Middleware{})))
+
+	handlers.ApiKey = handler.NewApiKeyHandler(nil)
+	handlers.Webhook = handler.NewWebhookHandler(nil)
+	denyAdmin := func(c *gin.Context) {
+		c.AbortWithStatus(http.StatusForbidden)
+	}
+	r := server.NewServer(handlers, server.Middleware{Admin: []gin.HandlerFunc{denyAdmin}})
+	assert.True(t, hasAdmin(r))
+
+	for _, path := range []string{"/admin/keys", "/admin/webhooks", "/debug/vars", "/v1/characters/search"} {
+		req, _ := http.NewRequest(http.MethodGet, path, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		if !strings.HasPrefix(path, "/v1") {
+			assert.Equal(t, http.StatusForbidden, w.Code, path)
+		} else {
+			assert.Equal(t, http.StatusBadRequest, w.Code, path)
+		}
+	}
+}
+
+func TestNewServer_GlobalMiddlewareRunsEverywhere(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := server.NewServer(server.Handlers{
+		Character:      handler.NewCharacterHandler(new(MockCharacterService)),
+		Planet:         handler.NewPlanetHandler(nil),
+		Transformation: handler.NewTransformationHandler(nil),
+	}, server.Middleware{Global: []gin.HandlerFunc{middleware.Compress(1)}})
+
+	for _, path := range []string{"/health", "/openapi.json", "/v1/characters/search"} {
+		req, _ := http.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Accept-Encoding", "gzip")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"), path)
+	}
+}
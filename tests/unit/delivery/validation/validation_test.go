@@ -0,0 +1,67 @@
+package validation_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/heaveless/dbz-api/internal/delivery/http/validation"
+	"github.com/stretchr/testify/assert"
+)
+
+type request struct {
+	Name  string `json:"name" binding:"required,notblank,max=8,charactername"`
+	Alias string `json:"alias" binding:"omitempty,min=2"`
+}
+
+func TestIsCharacterName(t *testing.T) {
+	valid := []string{"Goku", "Mr. Satan", "Android 17", "Zamasu (Fused)", "Kaiō-shin", "Kefla's", "Ｇｏｋｕ"}
+	invalid := []string{"Goku\x00", "Goku\n", "<b>", "Goku;", "Vegeta$", "😀"}
+
+	for _, s := range valid {
+		assert.True(t, validation.IsCharacterName(s), s)
+	}
+	for _, s := range invalid {
+		assert.False(t, validation.IsCharacterName(s), s)
+	}
+}
+
+func TestErrors_MapsRulesToCodes(t *testing.T) {
+	validation.Register()
+
+	tests := []struct {
+		req      request
+		expected []validation.FieldError
+	}{
+		{req: request{}, expected: []validation.FieldError{{Field: "name", Code: "required"}}},
+		{req: request{Name: "  "}, expected: []validation.FieldError{{Field: "name", Code: "blank"}}},
+		{req: request{Name: "Vegeta Blue"}, expected: []validation.FieldError{{Field: "name", Code: "too_long"}}},
+		{req: request{Name: "Goku!"}, expected: []validation.FieldError{{Field: "name", Code: "invalid_characters"}}},
+		{
+			req: request{Name: "", Alias: "x"},
+			expected: []validation.FieldError{
+				{Field: "name", Code: "required"},
+				{Field: "alias", Code: "too_short"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		err := binding.Validator.ValidateStruct(&tt.req)
+		assert.Equal(t, tt.expected, validation.Errors(err))
+	}
+}
+
+func TestErrors_NonValidationError(t *testing.T) {
+	assert.Equal(t,
+		[]validation.FieldError{{Field: "body", Code: "malformed"}},
+		validation.Errors(errors.New("unexpected EOF")),
+	)
+}
+
+func TestRegister_IsIdempotent(t *testing.T) {
+	assert.NotPanics(t, func() {
+		validation.Register()
+		validation.Register()
+	})
+}
@@ -0,0 +1,48 @@
+package apikey_test
+
+import (
+	"testing"
+	"time"
+
+	domain "github.com/heaveless/dbz-api/internal/domain/apikey"
+	"github.com/heaveless/dbz-api/internal/domain/audit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditAction(t *testing.T) {
+	at := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	key := domain.ApiKeyEntity{Id: "k1", Name: "ci"}
+	rotated := key
+	rotated.RotatedAt = &at
+	revoked := key
+	revoked.RevokedAt = &at
+	renamed := key
+	renamed.Name = "deploy"
+
+	tests := []struct {
+		name          string
+		before, after *domain.ApiKeyEntity
+		want          string
+	}{
+		{name: "new key", before: nil, after: &key, want: audit.ApiKeyCreated},
+		{name: "rotated", before: &key, after: &rotated, want: audit.ApiKeyRotated},
+		{name: "revoked", before: &key, after: &revoked, want: audit.ApiKeyRevoked},
+		{name: "anything else", before: &key, after: &renamed, want: audit.ApiKeyUpdated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, domain.AuditAction(tt.before, tt.after))
+		})
+	}
+}
+
+func TestDiff_LeavesTheHashOut(t *testing.T) {
+	before := &domain.ApiKeyEntity{Id: "k1", Name: "ci", Hash: "old", Prefix: "dbz_aaaa", Scopes: []string{"read"}}
+	after := &domain.ApiKeyEntity{Id: "k1", Name: "ci", Hash: "new", Prefix: "dbz_bbbb", Scopes: []string{"read", "admin"}}
+
+	assert.Equal(t, []audit.Change{
+		{Field: "prefix", Before: "dbz_aaaa", After: "dbz_bbbb"},
+		{Field: "scopes", Before: "read", After: "read,admin"},
+	}, domain.Diff(before, after))
+}
@@ -0,0 +1,57 @@
+package character_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/heaveless/dbz-api/internal/domain/audit"
+	domain "github.com/heaveless/dbz-api/internal/domain/character"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCharacterEntity_Curate(t *testing.T) {
+	vegeta := domain.CharacterEntity{Id: 2, Name: "Vegeta", Affiliation: "Army of Frieza", Overridden: []string{"ki"}}
+
+	vegeta.Curate(domain.CharacterPatch{"affiliation": "Z Fighter", "ki": "54.000.000", "unknown": "x"})
+
+	assert.Equal(t, "Z Fighter", vegeta.Affiliation)
+	assert.Equal(t, "54.000.000", vegeta.Ki)
+	assert.Equal(t, "Vegeta", vegeta.Name)
+	assert.Equal(t, []string{"affiliation", "ki"}, vegeta.Overridden)
+}
+
+func TestCharacterEntity_Refresh(t *testing.T) {
+	updatedAt := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	vegeta := domain.CharacterEntity{Id: 2, Name: "Vegeta", NameKey: "vegeta", Affiliation: "Z Fighter", Overridden: []string{"affiliation"}}
+
+	vegeta.Refresh(&domain.CharacterEntity{Id: 2, Name: "Vegeta IV", Affiliation: "Army of Frieza", UpdatedAt: updatedAt})
+
+	assert.Equal(t, "Vegeta IV", vegeta.Name)
+	assert.Equal(t, "vegeta iv", vegeta.NameKey)
+	assert.Equal(t, "Z Fighter", vegeta.Affiliation)
+	assert.Equal(t, updatedAt, vegeta.UpdatedAt)
+}
+
+func TestCharacterEntity_Fields(t *testing.T) {
+	goku := domain.CharacterEntity{Id: 1, Name: "Goku", Race: "Saiyan"}
+
+	fields := goku.Fields()
+
+	assert.Len(t, fields, len(domain.CuratedFields))
+	assert.Equal(t, "Goku", fields["name"])
+	assert.Equal(t, "Saiyan", fields["race"])
+	assert.Equal(t, "", fields["affiliation"])
+}
+
+func TestDiff(t *testing.T) {
+	before := &domain.CharacterEntity{Id: 2, Name: "Vegeta", Affiliation: "Army of Frieza"}
+	after := &domain.CharacterEntity{Id: 2, Name: "Vegeta", Affiliation: "Z Fighter", Gender: "Male"}
+
+	assert.Equal(t, []audit.Change{
+		{Field: "gender", After: "Male"},
+		{Field: "affiliation", Before: "Army of Frieza", After: "Z Fighter"},
+	}, domain.Diff(before, after))
+
+	assert.Equal(t, []audit.Change{{Field: "name", Before: "Vegeta"}, {Field: "affiliation", Before: "Army of Frieza"}}, domain.Diff(before, nil))
+	assert.Empty(t, domain.Diff(before, before))
+}
@@ -0,0 +1,29 @@
+package character_test
+
+import (
+	"testing"
+	"time"
+
+	domain "github.com/heaveless/dbz-api/internal/domain/character"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCharacterEntity_ETag(t *testing.T) {
+	goku := domain.CharacterEntity{Id: 1, Name: "Goku", Ki: "60.000.000"}
+	etag := goku.ETag()
+
+	assert.Regexp(t, `^"[0-9a-f]{32}"$`, etag)
+
+	refetched := goku
+	refetched.UpdatedAt = time.Now()
+	assert.Equal(t, etag, refetched.ETag(), "refetching alone keeps the etag")
+
+	changed := goku
+	changed.Ki = "90.000.000"
+	assert.NotEqual(t, etag, changed.ETag())
+
+	// Field boundaries are part of the hash.
+	a := domain.CharacterEntity{Name: "ab", Ki: "c"}
+	b := domain.CharacterEntity{Name: "a", Ki: "bc"}
+	assert.NotEqual(t, a.ETag(), b.ETag())
+}
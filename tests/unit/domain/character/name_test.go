@@ -0,0 +1,45 @@
+package character_test
+
+import (
+	"testing"
+
+	domain "github.com/heaveless/dbz-api/internal/domain/character"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeName(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "already normalized", input: "goku", expected: "goku"},
+		{name: "title case", input: "Goku", expected: "goku"},
+		{name: "upper case", input: "GOKU", expected: "goku"},
+		{name: "surrounding whitespace", input: "  Goku \t", expected: "goku"},
+		{name: "inner whitespace collapsed", input: "Master   Roshi", expected: "master roshi"},
+		{name: "accents folded", input: "Piccolo Dáimáö", expected: "piccolo daimao"},
+		{name: "combining marks folded", input: "Vegéta", expected: "vegeta"},
+		{name: "fullwidth compatibility form", input: "ＧＯＫＵ", expected: "goku"},
+		{name: "ligature compatibility form", input: "ﬁre", expected: "fire"},
+		{name: "german sharp s case folded", input: "Straße", expected: "strasse"},
+		{name: "non-breaking space", input: "Android\u00a017", expected: "android 17"},
+		{name: "empty", input: "", expected: ""},
+		{name: "only whitespace", input: "   ", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, domain.NormalizeName(tt.input))
+		})
+	}
+}
+
+func TestNormalizeName_Idempotent(t *testing.T) {
+	inputs := []string{"Goku", " Vegéta ", "ＧＯＫＵ", "Straße"}
+
+	for _, input := range inputs {
+		once := domain.NormalizeName(input)
+		assert.Equal(t, once, domain.NormalizeName(once), input)
+	}
+}
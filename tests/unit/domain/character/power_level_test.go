@@ -0,0 +1,147 @@
+package character_test
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	domain "github.com/heaveless/dbz-api/internal/domain/character"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePowerLevel(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "plain integer", input: "9000", expected: "9000"},
+		{name: "dot thousands", input: "60.000.000", expected: "60000000"},
+		{name: "single dot thousands group", input: "500.000", expected: "500000"},
+		{name: "comma thousands", input: "1,000,000", expected: "1000000"},
+		{name: "named magnitude", input: "90 Septillion", expected: "90000000000000000000000000"},
+		{name: "decimal with magnitude", input: "2.5 Billion", expected: "2500000000"},
+		{name: "decimal comma with magnitude", input: "19,84 Septillion", expected: "19840000000000000000000000"},
+		{name: "grouped with magnitude", input: "1.000.000 Trillion", expected: "1000000000000000000"},
+		{name: "plural magnitude", input: "3 Billions", expected: "3000000000"},
+		{name: "case and whitespace", input: "  11.7   SEPTILLION ", expected: "11700000000000000000000000"},
+		{name: "no space before word", input: "5Million", expected: "5000000"},
+		{name: "googol", input: "1 Googol", expected: "1" + strings.Repeat("0", 100)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			level, err := domain.ParsePowerLevel(tt.input)
+
+			require.NoError(t, err)
+			assert.True(t, level.Known())
+			assert.Equal(t, tt.input, level.Raw)
+			assert.Equal(t, tt.expected, level.Value().String())
+		})
+	}
+}
+
+func TestParsePowerLevel_Unknown(t *testing.T) {
+	for _, input := range []string{"unknown", "Unknown", " ", ""} {
+		level, err := domain.ParsePowerLevel(input)
+
+		require.NoError(t, err)
+		assert.False(t, level.Known(), input)
+		assert.Nil(t, level.Value())
+		assert.Equal(t, input, level.String())
+	}
+}
+
+func TestParsePowerLevel_Invalid(t *testing.T) {
+	for _, input := range []string{"lots", "90 Bazillion", "-5", "1.2.3 Billion x"} {
+		_, err := domain.ParsePowerLevel(input)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidPowerLevel, input)
+	}
+}
+
+func TestPowerLevel_Cmp(t *testing.T) {
+	ordered := []string{
+		"unknown",
+		"9000",
+		"60.000.000",
+		"2.5 Billion",
+		"3 Billion",
+		"90 Septillion",
+		"1 Googol",
+		"2 Googolplex",
+		"5 Googolplex",
+	}
+
+	for i := 0; i+1 < len(ordered); i++ {
+		a, err := domain.ParsePowerLevel(ordered[i])
+		require.NoError(t, err)
+		b, err := domain.ParsePowerLevel(ordered[i+1])
+		require.NoError(t, err)
+
+		assert.Equal(t, -1, a.Cmp(b), "%s < %s", ordered[i], ordered[i+1])
+		assert.Equal(t, 1, b.Cmp(a), "%s > %s", ordered[i+1], ordered[i])
+	}
+
+	same1, _ := domain.ParsePowerLevel("1.000.000")
+	same2, _ := domain.ParsePowerLevel("1 Million")
+	assert.Equal(t, 0, same1.Cmp(same2))
+}
+
+func TestPowerLevel_ValueIsCopy(t *testing.T) {
+	level, err := domain.ParsePowerLevel("9000")
+	require.NoError(t, err)
+
+	level.Value().Add(level.Value(), big.NewInt(1))
+
+	assert.Equal(t, "9000", level.Value().String())
+}
+
+func TestPowerLevel_GoogolplexHasNoValue(t *testing.T) {
+	level, err := domain.ParsePowerLevel("69 Googolplex")
+
+	require.NoError(t, err)
+	assert.True(t, level.Known())
+	assert.Nil(t, level.Value())
+}
+
+func TestPowerLevel_RankOrdersLikeCmp(t *testing.T) {
+	raws := []string{"unknown", "0", "9", "530.000", "2.000.000", "60.000.000", "2.5 Billion", "19.84 Septillion", "90 Septillion", "1 Googol", "2 Googolplex"}
+
+	for i, a := range raws {
+		for _, b := range raws[i:] {
+			pa, pb := domain.LenientPowerLevel(a), domain.LenientPowerLevel(b)
+			assert.Equal(t, pa.Cmp(pb), strings.Compare(pa.Rank(), pb.Rank()), "%s vs %s", a, b)
+		}
+	}
+	assert.Empty(t, domain.LenientPowerLevel("unknown").Rank())
+}
+
+func TestLenientPowerLevel(t *testing.T) {
+	assert.False(t, domain.LenientPowerLevel("lots").Known())
+	assert.Equal(t, "lots", domain.LenientPowerLevel("lots").Raw)
+	assert.True(t, domain.LenientPowerLevel("9000").Known())
+}
+
+func TestParseSortOrder(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected domain.SortOrder
+	}{
+		{input: "", expected: domain.SortOrder{Field: "id"}},
+		{input: "name", expected: domain.SortOrder{Field: "name"}},
+		{input: "-maxKi", expected: domain.SortOrder{Field: "maxKi", Desc: true}},
+		{input: "ki", expected: domain.SortOrder{Field: "ki"}},
+	}
+
+	for _, tt := range tests {
+		order, err := domain.ParseSortOrder(tt.input)
+
+		assert.NoError(t, err)
+		assert.Equal(t, tt.expected, order)
+	}
+
+	_, err := domain.ParseSortOrder("-race")
+	assert.Error(t, err)
+}
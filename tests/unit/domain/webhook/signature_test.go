@@ -0,0 +1,54 @@
+package webhook_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/heaveless/dbz-api/internal/domain/event"
+	domain "github.com/heaveless/dbz-api/internal/domain/webhook"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSign(t *testing.T) {
+	// echo -n '1714557600.{"key":"k"}' | openssl dgst -sha256 -hmac whsec_test
+	got := domain.Sign("whsec_test", 1714557600, []byte(`{"key":"k"}`))
+
+	assert.Equal(t, "sha256=1091994563f169e9d623180f7295a5971c0a919cdbc6c560f39d4c19fded0c81", got)
+}
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{"key":"k"}`)
+	now := time.Unix(1714557600, 0)
+	signature := domain.Sign("whsec_test", now.Unix(), body)
+
+	tests := []struct {
+		name      string
+		secret    string
+		signature string
+		timestamp int64
+		body      []byte
+		ok        bool
+	}{
+		{name: "valid", secret: "whsec_test", signature: signature, timestamp: now.Unix(), body: body, ok: true},
+		{name: "within tolerance", secret: "whsec_test", signature: domain.Sign("whsec_test", now.Unix()-200, body), timestamp: now.Unix() - 200, body: body, ok: true},
+		{name: "other secret", secret: "whsec_other", signature: signature, timestamp: now.Unix(), body: body},
+		{name: "tampered body", secret: "whsec_test", signature: signature, timestamp: now.Unix(), body: []byte(`{"key":"x"}`)},
+		{name: "tampered timestamp", secret: "whsec_test", signature: signature, timestamp: now.Unix() + 1, body: body},
+		{name: "replayed", secret: "whsec_test", signature: domain.Sign("whsec_test", now.Unix()-600, body), timestamp: now.Unix() - 600, body: body},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.ok, domain.Verify(tt.secret, tt.signature, tt.timestamp, tt.body, now, 5*time.Minute))
+		})
+	}
+}
+
+func TestSubscriptionEntity_Wants(t *testing.T) {
+	all := domain.SubscriptionEntity{}
+	cached := domain.SubscriptionEntity{Events: []event.Type{event.CharacterCached}}
+
+	assert.True(t, all.Wants(event.CharacterRefreshed))
+	assert.True(t, cached.Wants(event.CharacterCached))
+	assert.False(t, cached.Wants(event.CharacterRefreshed))
+}
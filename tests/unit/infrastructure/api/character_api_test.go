@@ -7,8 +7,11 @@ import (
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
+	domain "github.com/heaveless/dbz-api/internal/domain/character"
 	api "github.com/heaveless/dbz-api/internal/infrastructure/api"
+	"github.com/heaveless/dbz-api/internal/infrastructure/breaker"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -55,6 +58,23 @@ func TestCharacterApi_Get_ClientError(t *testing.T) {
 	assert.EqualError(t, err, "service temporarily unavailable, please try again later")
 }
 
+func TestCharacterApi_Get_UpstreamLimited(t *testing.T) {
+	ctx := context.Background()
+	mockClient := new(MockExternalClient)
+
+	limited := &breaker.UpstreamLimitedError{Reason: "rate", RetryAfter: time.Second}
+	mockClient.
+		On("Do", mock.AnythingOfType("*http.Request")).
+		Return((*http.Response)(nil), limited)
+
+	sut := api.NewCharacterApi("http://test.com", mockClient)
+
+	res, err := sut.Get(ctx, "Goku")
+
+	assert.Nil(t, res)
+	assert.ErrorIs(t, err, breaker.ErrUpstreamLimited)
+}
+
 func TestCharacterApi_Get_UnexpectedStatus(t *testing.T) {
 	ctx := context.Background()
 	mockClient := new(MockExternalClient)
@@ -116,4 +136,38 @@ func TestCharacterApi_Get_NotFound(t *testing.T) {
 
 	assert.Nil(t, res)
 	assert.EqualError(t, err, "character not found")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestCharacterApi_GetById_OK(t *testing.T) {
+	mockClient := new(MockExternalClient)
+
+	mockClient.
+		On("Do", mock.MatchedBy(func(r *http.Request) bool {
+			return r.URL.String() == "http://test.com/api/characters/3"
+		})).
+		Return(jsonResponse(200, `{"id":3,"name":"Vegeta","originPlanet":{"id":2},"transformations":[]}`), nil)
+
+	sut := api.NewCharacterApi("http://test.com", mockClient)
+
+	res, err := sut.GetById(context.Background(), 3)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), res.Id)
+	assert.Equal(t, "Vegeta", res.Name)
+}
+
+func TestCharacterApi_GetById_NotFound(t *testing.T) {
+	mockClient := new(MockExternalClient)
+
+	mockClient.
+		On("Do", mock.AnythingOfType("*http.Request")).
+		Return(jsonResponse(404, `{"message":"Character not found"}`), nil)
+
+	sut := api.NewCharacterApi("http://test.com", mockClient)
+
+	res, err := sut.GetById(context.Background(), 9999)
+
+	assert.Nil(t, res)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
 }
@@ -0,0 +1,117 @@
+package api_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	planet "github.com/heaveless/dbz-api/internal/domain/planet"
+	api "github.com/heaveless/dbz-api/internal/infrastructure/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func jsonResponse(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func TestPlanetApi_Get_OK(t *testing.T) {
+	mockClient := new(MockExternalClient)
+
+	body := `{"id":3,"name":"Vegeta","isDestroyed":true,"characters":[{"id":2,"name":"Vegeta"},{"id":30,"name":"Tarble"}]}`
+
+	mockClient.
+		On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			return req.URL.String() == "http://test.com/api/planets/3"
+		})).
+		Return(jsonResponse(200, body), nil)
+
+	sut := api.NewPlanetApi("http://test.com", mockClient)
+
+	res, err := sut.Get(context.Background(), 3)
+
+	require.NoError(t, err)
+	assert.Equal(t, "Vegeta", res.Planet.Name)
+	assert.True(t, res.Planet.IsDestroyed)
+	assert.Equal(t, []int64{2, 30}, res.Planet.CharacterIds)
+	assert.Len(t, res.Characters, 2)
+}
+
+func TestPlanetApi_Get_NoResidents(t *testing.T) {
+	mockClient := new(MockExternalClient)
+
+	mockClient.
+		On("Do", mock.AnythingOfType("*http.Request")).
+		Return(jsonResponse(200, `{"id":9,"name":"Kaio","characters":[]}`), nil)
+
+	sut := api.NewPlanetApi("http://test.com", mockClient)
+
+	res, err := sut.Get(context.Background(), 9)
+
+	require.NoError(t, err)
+	assert.NotNil(t, res.Planet.CharacterIds)
+	assert.Empty(t, res.Planet.CharacterIds)
+}
+
+func TestPlanetApi_Get_NotFound(t *testing.T) {
+	mockClient := new(MockExternalClient)
+
+	mockClient.
+		On("Do", mock.AnythingOfType("*http.Request")).
+		Return(jsonResponse(404, `{"message":"Planet not found"}`), nil)
+
+	sut := api.NewPlanetApi("http://test.com", mockClient)
+
+	res, err := sut.Get(context.Background(), 99)
+
+	assert.Nil(t, res)
+	assert.ErrorIs(t, err, planet.ErrNotFound)
+	assert.EqualError(t, err, "planet not found")
+}
+
+func TestPlanetApi_List_FollowsPages(t *testing.T) {
+	mockClient := new(MockExternalClient)
+
+	mockClient.
+		On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			return req.URL.Query().Get("page") == "1"
+		})).
+		Return(jsonResponse(200, `{"items":[{"id":1,"name":"Namek"}],"meta":{"totalPages":2}}`), nil)
+
+	mockClient.
+		On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			return req.URL.Query().Get("page") == "2"
+		})).
+		Return(jsonResponse(200, `{"items":[{"id":2,"name":"Tierra"}],"meta":{"totalPages":2}}`), nil)
+
+	sut := api.NewPlanetApi("http://test.com", mockClient)
+
+	res, err := sut.List(context.Background())
+
+	require.NoError(t, err)
+	assert.Len(t, res, 2)
+	assert.Equal(t, "Tierra", res[1].Name)
+	assert.Nil(t, res[0].CharacterIds)
+	mockClient.AssertNumberOfCalls(t, "Do", 2)
+}
+
+func TestPlanetApi_List_UnexpectedStatus(t *testing.T) {
+	mockClient := new(MockExternalClient)
+
+	mockClient.
+		On("Do", mock.AnythingOfType("*http.Request")).
+		Return(jsonResponse(500, ""), nil)
+
+	sut := api.NewPlanetApi("http://test.com", mockClient)
+
+	res, err := sut.List(context.Background())
+
+	assert.Nil(t, res)
+	assert.EqualError(t, err, "unexpected status code: 500")
+}
@@ -0,0 +1,50 @@
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	transformation "github.com/heaveless/dbz-api/internal/domain/transformation"
+	api "github.com/heaveless/dbz-api/internal/infrastructure/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransformationApi_ListByCharacter_OK(t *testing.T) {
+	mockClient := new(MockExternalClient)
+
+	body := `{"id":1,"name":"Goku","transformations":[{"id":1,"name":"Goku SSJ","ki":"3 Billion"},{"id":2,"name":"Goku SSJ2","ki":"6 Billion"}]}`
+
+	mockClient.
+		On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			return req.URL.String() == "http://test.com/api/characters/1"
+		})).
+		Return(jsonResponse(200, body), nil)
+
+	sut := api.NewTransformationApi("http://test.com", mockClient)
+
+	res, err := sut.ListByCharacter(context.Background(), 1)
+
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+	assert.Equal(t, "Goku SSJ2", res[1].Name)
+	assert.Equal(t, int64(1), res[0].CharacterId)
+	assert.Equal(t, int64(1), res[1].CharacterId)
+}
+
+func TestTransformationApi_ListByCharacter_NotFound(t *testing.T) {
+	mockClient := new(MockExternalClient)
+
+	mockClient.
+		On("Do", mock.AnythingOfType("*http.Request")).
+		Return(jsonResponse(404, ""), nil)
+
+	sut := api.NewTransformationApi("http://test.com", mockClient)
+
+	res, err := sut.ListByCharacter(context.Background(), 999)
+
+	assert.Nil(t, res)
+	assert.ErrorIs(t, err, transformation.ErrNotFound)
+}
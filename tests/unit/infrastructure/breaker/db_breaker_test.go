@@ -120,3 +120,149 @@ func TestBreaker_InsertOne_Error(t *testing.T) {
 
 	mockCol.AssertExpectations(t)
 }
+
+func TestBreaker_Find_OK(t *testing.T) {
+	ctx := context.Background()
+	mockCol := new(MockMongoCollection)
+	mockCur := new(MockCursor)
+
+	mockCol.
+		On("Find", ctx, mock.Anything).
+		Return(mockCur, nil)
+
+	cb := breaker.NewDbCollectionWithBreaker(mockCol, time.Millisecond*50)
+
+	res, err := cb.Find(ctx, map[string]any{})
+	assert.NoError(t, err)
+	assert.Equal(t, mockCur, res)
+
+	mockCol.AssertExpectations(t)
+}
+
+func TestBreaker_Find_Error(t *testing.T) {
+	ctx := context.Background()
+	mockCol := new(MockMongoCollection)
+
+	mockCol.
+		On("Find", ctx, mock.Anything).
+		Return(nil, errors.New("find error"))
+
+	cb := breaker.NewDbCollectionWithBreaker(mockCol, time.Millisecond*50)
+
+	res, err := cb.Find(ctx, map[string]any{})
+	assert.Nil(t, res)
+	assert.EqualError(t, err, "find error")
+
+	mockCol.AssertExpectations(t)
+}
+
+func TestBreaker_CountDocuments_OK(t *testing.T) {
+	ctx := context.Background()
+	mockCol := new(MockMongoCollection)
+
+	mockCol.
+		On("CountDocuments", ctx, mock.Anything).
+		Return(int64(7), nil)
+
+	cb := breaker.NewDbCollectionWithBreaker(mockCol, time.Millisecond*50)
+
+	n, err := cb.CountDocuments(ctx, map[string]any{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), n)
+
+	mockCol.AssertExpectations(t)
+}
+
+func TestBreaker_CountDocuments_Error(t *testing.T) {
+	ctx := context.Background()
+	mockCol := new(MockMongoCollection)
+
+	mockCol.
+		On("CountDocuments", ctx, mock.Anything).
+		Return(int64(0), errors.New("count error"))
+
+	cb := breaker.NewDbCollectionWithBreaker(mockCol, time.Millisecond*50)
+
+	n, err := cb.CountDocuments(ctx, map[string]any{})
+	assert.Zero(t, n)
+	assert.EqualError(t, err, "count error")
+
+	mockCol.AssertExpectations(t)
+}
+
+func TestBreaker_FindOneAndUpdate_OK(t *testing.T) {
+	ctx := context.Background()
+	mockCol := new(MockMongoCollection)
+	mockSR := new(MockSingleResultWrapper)
+
+	mockCol.
+		On("FindOneAndUpdate", ctx, mock.Anything, mock.Anything).
+		Return(mockSR, nil)
+
+	mockSR.
+		On("Err").
+		Return(nil)
+
+	cb := breaker.NewDbCollectionWithBreaker(mockCol, time.Millisecond*50)
+
+	res, err := cb.FindOneAndUpdate(ctx, map[string]any{"_id": "k1"}, map[string]any{"$inc": map[string]any{"count": 1}})
+	assert.NoError(t, err)
+	assert.Equal(t, mockSR, res)
+
+	mockCol.AssertExpectations(t)
+	mockSR.AssertExpectations(t)
+}
+
+func TestBreaker_FindOneAndUpdate_Error(t *testing.T) {
+	ctx := context.Background()
+	mockCol := new(MockMongoCollection)
+	mockSR := new(MockSingleResultWrapper)
+
+	mockCol.
+		On("FindOneAndUpdate", ctx, mock.Anything, mock.Anything).
+		Return(mockSR, nil)
+
+	mockSR.
+		On("Err").
+		Return(errors.New("update error"))
+
+	cb := breaker.NewDbCollectionWithBreaker(mockCol, time.Millisecond*50)
+
+	res, err := cb.FindOneAndUpdate(ctx, map[string]any{"_id": "k1"}, map[string]any{})
+	assert.Nil(t, res)
+	assert.EqualError(t, err, "update error")
+
+	mockCol.AssertExpectations(t)
+}
+
+func TestBreaker_DeleteOne_OK(t *testing.T) {
+	ctx := context.Background()
+	mockCol := new(MockMongoCollection)
+
+	mockCol.
+		On("DeleteOne", ctx, mock.Anything).
+		Return(&mongo.DeleteResult{DeletedCount: 1}, nil)
+
+	cb := breaker.NewDbCollectionWithBreaker(mockCol, time.Millisecond*50)
+
+	res, err := cb.DeleteOne(ctx, map[string]any{"_id": 1})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), res.DeletedCount)
+
+	mockCol.AssertExpectations(t)
+}
+
+func TestBreaker_DeleteOne_Error(t *testing.T) {
+	ctx := context.Background()
+	mockCol := new(MockMongoCollection)
+
+	mockCol.
+		On("DeleteOne", ctx, mock.Anything).
+		Return(nil, errors.New("delete error"))
+
+	cb := breaker.NewDbCollectionWithBreaker(mockCol, time.Millisecond*50)
+
+	res, err := cb.DeleteOne(ctx, map[string]any{"_id": 1})
+	assert.Nil(t, res)
+	assert.EqualError(t, err, "delete error")
+}
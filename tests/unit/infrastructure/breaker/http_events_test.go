@@ -0,0 +1,69 @@
+package breaker_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/heaveless/dbz-api/internal/domain/event"
+	"github.com/heaveless/dbz-api/internal/infrastructure/breaker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordedEvents []event.Event
+
+func (r *recordedEvents) Publish(e event.Event) { *r = append(*r, e) }
+
+type scriptedClient struct {
+	results []any
+}
+
+func (s *scriptedClient) Do(req *http.Request) (*http.Response, error) {
+	next := s.results[0]
+	s.results = s.results[1:]
+
+	if err, ok := next.(error); ok {
+		return nil, err
+	}
+	return &http.Response{StatusCode: next.(int), Body: io.NopCloser(bytes.NewReader(nil)), Request: req}, nil
+}
+
+func TestHttpWithEvents_PublishesOncePerOutage(t *testing.T) {
+	var events recordedEvents
+	next := &scriptedClient{results: []any{
+		http.StatusOK,
+		errors.New("connection refused"),
+		http.StatusBadGateway,
+		http.StatusNotFound,
+		http.StatusServiceUnavailable,
+	}}
+	c := breaker.NewHttpWithEvents(next, &events)
+
+	for range 5 {
+		res, _ := c.Do(newLimiterRequest(t, context.Background()))
+		if res != nil {
+			res.Body.Close()
+		}
+	}
+
+	require.Len(t, events, 2)
+	assert.Equal(t, event.UpstreamUnavailable, events[0].Type)
+	assert.Equal(t, event.UpstreamFailure{Host: "example.com", Reason: "connection refused"}, events[0].Data)
+	assert.Equal(t, event.UpstreamFailure{Host: "example.com", Reason: "status 503"}, events[1].Data)
+}
+
+func TestHttpWithEvents_IgnoresCancelledCalls(t *testing.T) {
+	var events recordedEvents
+	c := breaker.NewHttpWithEvents(&scriptedClient{results: []any{context.Canceled}}, &events)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := c.Do(newLimiterRequest(t, ctx))
+
+	assert.Error(t, err)
+	assert.Empty(t, events)
+}
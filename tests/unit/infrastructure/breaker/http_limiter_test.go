@@ -0,0 +1,186 @@
+package breaker_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/heaveless/dbz-api/internal/infrastructure/breaker"
+)
+
+type fakeExternalClient struct {
+	mu    sync.Mutex
+	calls int
+	err   error
+}
+
+func (f *fakeExternalClient) Do(req *http.Request) (*http.Response, error) {
+	f.mu.Lock()
+	f.calls++
+	f.mu.Unlock()
+
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewBufferString("ok")),
+		Request:    req,
+	}, nil
+}
+
+func newLimiterRequest(t *testing.T, ctx context.Context) *http.Request {
+	t.Helper()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://example.com", nil)
+	require.NoError(t, err)
+	return req
+}
+
+func TestHttpWithLimiter_FailsFastWhenRateSpent(t *testing.T) {
+	now := time.Unix(0, 0)
+	next := &fakeExternalClient{}
+	c := breaker.NewHttpWithLimiterClock(next, breaker.OutboundLimit{Rate: 1, Burst: 2}, func() time.Time { return now })
+
+	for i := 0; i < 2; i++ {
+		res, err := c.Do(newLimiterRequest(t, context.Background()))
+		require.NoError(t, err)
+		res.Body.Close()
+	}
+
+	_, err := c.Do(newLimiterRequest(t, context.Background()))
+
+	var limited *breaker.UpstreamLimitedError
+	require.ErrorAs(t, err, &limited)
+	assert.True(t, errors.Is(err, breaker.ErrUpstreamLimited))
+	assert.Equal(t, "rate", limited.Reason)
+	assert.Equal(t, time.Second, limited.RetryAfter)
+	assert.Equal(t, 2, next.calls)
+
+	now = now.Add(time.Second)
+	res, err := c.Do(newLimiterRequest(t, context.Background()))
+	require.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, 3, next.calls)
+}
+
+func TestHttpWithLimiter_WaitsForToken(t *testing.T) {
+	next := &fakeExternalClient{}
+	c := breaker.NewHttpWithLimiter(next, breaker.OutboundLimit{Rate: 50, Burst: 1, Wait: true})
+
+	start := time.Now()
+	for i := 0; i < 2; i++ {
+		res, err := c.Do(newLimiterRequest(t, context.Background()))
+		require.NoError(t, err)
+		res.Body.Close()
+	}
+
+	assert.GreaterOrEqual(t, time.Since(start), 15*time.Millisecond)
+	assert.Equal(t, 2, next.calls)
+}
+
+func TestHttpWithLimiter_WaitHonoursContext(t *testing.T) {
+	now := time.Unix(0, 0)
+	next := &fakeExternalClient{}
+	c := breaker.NewHttpWithLimiterClock(next, breaker.OutboundLimit{Rate: 0.01, Burst: 1, Wait: true}, func() time.Time { return now })
+
+	res, err := c.Do(newLimiterRequest(t, context.Background()))
+	require.NoError(t, err)
+	res.Body.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err = c.Do(newLimiterRequest(t, ctx))
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 1, next.calls)
+}
+
+func TestHttpWithLimiter_CapsInFlightUntilBodyClosed(t *testing.T) {
+	next := &fakeExternalClient{}
+	c := breaker.NewHttpWithLimiter(next, breaker.OutboundLimit{MaxInFlight: 1})
+
+	res, err := c.Do(newLimiterRequest(t, context.Background()))
+	require.NoError(t, err)
+
+	_, err = c.Do(newLimiterRequest(t, context.Background()))
+	var limited *breaker.UpstreamLimitedError
+	require.ErrorAs(t, err, &limited)
+	assert.Equal(t, "in-flight", limited.Reason)
+
+	require.NoError(t, res.Body.Close())
+	require.NoError(t, res.Body.Close())
+
+	res, err = c.Do(newLimiterRequest(t, context.Background()))
+	require.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, 2, next.calls)
+}
+
+func TestHttpWithLimiter_InFlightRejectionKeepsRateToken(t *testing.T) {
+	next := &fakeExternalClient{}
+	now := time.Unix(0, 0)
+	c := breaker.NewHttpWithLimiterClock(next, breaker.OutboundLimit{Rate: 1, Burst: 2, MaxInFlight: 1}, func() time.Time { return now })
+
+	res, err := c.Do(newLimiterRequest(t, context.Background()))
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		_, err = c.Do(newLimiterRequest(t, context.Background()))
+		var limited *breaker.UpstreamLimitedError
+		require.ErrorAs(t, err, &limited)
+		assert.Equal(t, "in-flight", limited.Reason)
+	}
+	res.Body.Close()
+
+	res, err = c.Do(newLimiterRequest(t, context.Background()))
+	require.NoError(t, err, "the rejected calls spent no token")
+	res.Body.Close()
+	assert.Equal(t, 2, next.calls)
+}
+
+func TestHttpWithLimiter_ReleasesSlotOnError(t *testing.T) {
+	next := &fakeExternalClient{err: errors.New("boom")}
+	c := breaker.NewHttpWithLimiter(next, breaker.OutboundLimit{MaxInFlight: 1})
+
+	_, err := c.Do(newLimiterRequest(t, context.Background()))
+	assert.EqualError(t, err, "boom")
+
+	_, err = c.Do(newLimiterRequest(t, context.Background()))
+	assert.EqualError(t, err, "boom")
+	assert.Equal(t, 2, next.calls)
+}
+
+func TestHttpWithLimiter_WaitsForInFlightSlot(t *testing.T) {
+	next := &fakeExternalClient{}
+	c := breaker.NewHttpWithLimiter(next, breaker.OutboundLimit{MaxInFlight: 1, Wait: true})
+
+	res, err := c.Do(newLimiterRequest(t, context.Background()))
+	require.NoError(t, err)
+
+	done := make(chan error, 1)
+	go func() {
+		res, err := c.Do(newLimiterRequest(t, context.Background()))
+		if err == nil {
+			res.Body.Close()
+		}
+		done <- err
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("second request should wait for the slot")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	res.Body.Close()
+	assert.NoError(t, <-done)
+}
@@ -0,0 +1,16 @@
+package breaker_test
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCursor struct {
+	mock.Mock
+}
+
+func (m *MockCursor) All(ctx context.Context, results any) error {
+	args := m.Called(ctx, results)
+	return args.Error(0)
+}
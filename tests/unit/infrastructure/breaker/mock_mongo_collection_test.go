@@ -33,6 +33,32 @@ func (m *MockMongoCollection) FindOne(
 	return sr, args.Error(1)
 }
 
+func (m *MockMongoCollection) Find(
+	ctx context.Context,
+	filter any,
+	opts ...options.Lister[options.FindOptions],
+) (breaker.Cursor, error) {
+
+	args := m.Called(ctx, filter)
+
+	var cur breaker.Cursor
+	if v := args.Get(0); v != nil {
+		cur = v.(breaker.Cursor)
+	}
+
+	return cur, args.Error(1)
+}
+
+func (m *MockMongoCollection) CountDocuments(
+	ctx context.Context,
+	filter any,
+	opts ...options.Lister[options.CountOptions],
+) (int64, error) {
+
+	args := m.Called(ctx, filter)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 func (m *MockMongoCollection) InsertOne(
 	ctx context.Context,
 	document any,
@@ -48,3 +74,53 @@ func (m *MockMongoCollection) InsertOne(
 
 	return res, args.Error(1)
 }
+
+func (m *MockMongoCollection) ReplaceOne(
+	ctx context.Context,
+	filter any,
+	replacement any,
+	opts ...options.Lister[options.ReplaceOptions],
+) (*mongo.UpdateResult, error) {
+
+	args := m.Called(ctx, filter, replacement)
+
+	var res *mongo.UpdateResult
+	if v := args.Get(0); v != nil {
+		res = v.(*mongo.UpdateResult)
+	}
+
+	return res, args.Error(1)
+}
+
+func (m *MockMongoCollection) FindOneAndUpdate(
+	ctx context.Context,
+	filter any,
+	update any,
+	opts ...options.Lister[options.FindOneAndUpdateOptions],
+) (breaker.SingleResult, error) {
+
+	args := m.Called(ctx, filter, update)
+
+	var sr breaker.SingleResult
+	if v := args.Get(0); v != nil {
+		sr = v.(breaker.SingleResult)
+	}
+
+	return sr, args.Error(1)
+}
+
+func (m *MockMongoCollection) DeleteOne(
+	ctx context.Context,
+	filter any,
+	opts ...options.Lister[options.DeleteOneOptions],
+) (*mongo.DeleteResult, error) {
+
+	args := m.Called(ctx, filter)
+
+	var res *mongo.DeleteResult
+	if v := args.Get(0); v != nil {
+		res = v.(*mongo.DeleteResult)
+	}
+
+	return res, args.Error(1)
+}
@@ -0,0 +1,102 @@
+package eventbus_test
+
+import (
+	"testing"
+
+	"github.com/heaveless/dbz-api/internal/domain/event"
+	"github.com/heaveless/dbz-api/internal/infrastructure/eventbus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func publish(b *eventbus.Bus, n int) {
+	for range n {
+		b.Publish(event.Event{Type: event.CharacterCached})
+	}
+}
+
+func ids(events []event.Event) []uint64 {
+	out := make([]uint64, 0, len(events))
+	for _, e := range events {
+		out = append(out, e.Id)
+	}
+	return out
+}
+
+func TestBus_DeliversInOrderWithIds(t *testing.T) {
+	b := eventbus.NewBus(0)
+	missed, sub := b.Subscribe(0, 0)
+	defer sub.Close()
+
+	publish(b, 3)
+
+	assert.Empty(t, missed)
+	for want := uint64(1); want <= 3; want++ {
+		e := <-sub.Events()
+		assert.Equal(t, want, e.Id)
+		assert.False(t, e.At.IsZero())
+	}
+}
+
+func TestBus_ReplaysAfterLastEventId(t *testing.T) {
+	b := eventbus.NewBus(4)
+	publish(b, 6)
+
+	tests := []struct {
+		name   string
+		after  uint64
+		replay []uint64
+	}{
+		{name: "fresh client", after: 0, replay: []uint64{}},
+		{name: "resume", after: 4, replay: []uint64{5, 6}},
+		{name: "up to date", after: 6, replay: []uint64{}},
+		{name: "older than history", after: 1, replay: []uint64{3, 4, 5, 6}},
+		{name: "id from a previous process", after: 99, replay: []uint64{3, 4, 5, 6}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			missed, sub := b.Subscribe(tt.after, 0)
+			defer sub.Close()
+
+			assert.Equal(t, tt.replay, ids(missed))
+		})
+	}
+}
+
+func TestBus_DropsSubscriberThatFallsBehind(t *testing.T) {
+	b := eventbus.NewBus(0)
+	_, slow := b.Subscribe(0, 2)
+	_, fast := b.Subscribe(0, 10)
+	defer fast.Close()
+
+	publish(b, 3)
+
+	var got []uint64
+	for e := range slow.Events() {
+		got = append(got, e.Id)
+	}
+	assert.Equal(t, []uint64{1, 2}, got)
+	assert.True(t, slow.Dropped())
+
+	assert.Len(t, fast.Events(), 3)
+	assert.False(t, fast.Dropped())
+
+	// The dropped client resumes from the history without a gap.
+	missed, again := b.Subscribe(got[len(got)-1], 2)
+	defer again.Close()
+	assert.Equal(t, []uint64{3}, ids(missed))
+}
+
+func TestBus_CloseEndsSubscription(t *testing.T) {
+	b := eventbus.NewBus(0)
+	_, sub := b.Subscribe(0, 0)
+
+	sub.Close()
+	sub.Close()
+	publish(b, 1)
+
+	_, open := <-sub.Events()
+	require.False(t, open)
+	assert.False(t, sub.Dropped())
+}
@@ -0,0 +1,36 @@
+package metrics_test
+
+import (
+	"expvar"
+	"testing"
+
+	"github.com/heaveless/dbz-api/internal/infrastructure/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpvarMetrics_CountsPerResource(t *testing.T) {
+	m := metrics.NewExpvarMetrics("test_cache_counts")
+
+	m.Hit("character")
+	m.Hit("character")
+	m.Miss("character")
+	m.Failure("planet")
+	m.SaveError("planet")
+
+	assert.Equal(t, int64(2), m.Value("character.hit"))
+	assert.Equal(t, int64(1), m.Value("character.miss"))
+	assert.Equal(t, int64(1), m.Value("planet.failure"))
+	assert.Equal(t, int64(1), m.Value("planet.save_error"))
+	assert.Equal(t, int64(0), m.Value("planet.hit"))
+}
+
+func TestExpvarMetrics_ReusesPublishedMap(t *testing.T) {
+	first := metrics.NewExpvarMetrics("test_cache_reuse")
+	first.Miss("character")
+
+	second := metrics.NewExpvarMetrics("test_cache_reuse")
+	second.Miss("character")
+
+	assert.Equal(t, int64(2), first.Value("character.miss"))
+	assert.NotNil(t, expvar.Get("test_cache_reuse"))
+}
@@ -0,0 +1,189 @@
+package migration_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/heaveless/dbz-api/internal/infrastructure/migration"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+type MockStore struct {
+	mock.Mock
+}
+
+func (m *MockStore) Applied(ctx context.Context) ([]migration.Record, error) {
+	args := m.Called(ctx)
+
+	var records []migration.Record
+	if v := args.Get(0); v != nil {
+		records = v.([]migration.Record)
+	}
+
+	return records, args.Error(1)
+}
+
+func (m *MockStore) Save(ctx context.Context, record migration.Record) error {
+	args := m.Called(ctx, record)
+	return args.Error(0)
+}
+
+func step(version int, calls *[]int, err error) migration.Migration {
+	return migration.Migration{
+		Version:     version,
+		Description: "step",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			*calls = append(*calls, version)
+			return err
+		},
+	}
+}
+
+func TestRunner_Up_AppliesPendingInOrder(t *testing.T) {
+	ctx := context.Background()
+	store := new(MockStore)
+	var calls []int
+
+	store.
+		On("Applied", ctx).
+		Return([]migration.Record{{Version: 1}}, nil)
+
+	store.
+		On("Save", ctx, mock.AnythingOfType("migration.Record")).
+		Return(nil)
+
+	runner, err := migration.NewRunner(nil, store, []migration.Migration{
+		step(3, &calls, nil),
+		step(1, &calls, nil),
+		step(2, &calls, nil),
+	})
+	require.NoError(t, err)
+
+	applied, err := runner.Up(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 3}, calls)
+	require.Len(t, applied, 2)
+	assert.Equal(t, 2, applied[0].Version)
+	assert.Equal(t, 3, applied[1].Version)
+	store.AssertNumberOfCalls(t, "Save", 2)
+}
+
+func TestRunner_Up_NothingPending(t *testing.T) {
+	ctx := context.Background()
+	store := new(MockStore)
+	var calls []int
+
+	store.
+		On("Applied", ctx).
+		Return([]migration.Record{{Version: 1}, {Version: 2}}, nil)
+
+	runner, err := migration.NewRunner(nil, store, []migration.Migration{
+		step(1, &calls, nil),
+		step(2, &calls, nil),
+	})
+	require.NoError(t, err)
+
+	applied, err := runner.Up(ctx)
+
+	assert.NoError(t, err)
+	assert.Empty(t, applied)
+	assert.Empty(t, calls)
+	store.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestRunner_Up_StopsOnFailure(t *testing.T) {
+	ctx := context.Background()
+	store := new(MockStore)
+	var calls []int
+
+	store.
+		On("Applied", ctx).
+		Return(nil, nil)
+
+	store.
+		On("Save", ctx, mock.AnythingOfType("migration.Record")).
+		Return(nil)
+
+	runner, err := migration.NewRunner(nil, store, []migration.Migration{
+		step(1, &calls, nil),
+		step(2, &calls, errors.New("index error")),
+		step(3, &calls, nil),
+	})
+	require.NoError(t, err)
+
+	applied, err := runner.Up(ctx)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "index error")
+	assert.Equal(t, []int{1, 2}, calls)
+	require.Len(t, applied, 1)
+	store.AssertNumberOfCalls(t, "Save", 1)
+}
+
+func TestRunner_Up_StoreError(t *testing.T) {
+	ctx := context.Background()
+	store := new(MockStore)
+	var calls []int
+
+	store.
+		On("Applied", ctx).
+		Return(nil, errors.New("db error"))
+
+	runner, err := migration.NewRunner(nil, store, []migration.Migration{step(1, &calls, nil)})
+	require.NoError(t, err)
+
+	_, err = runner.Up(ctx)
+
+	assert.Error(t, err)
+	assert.Empty(t, calls)
+}
+
+func TestRunner_Status(t *testing.T) {
+	ctx := context.Background()
+	store := new(MockStore)
+	var calls []int
+	appliedAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	store.
+		On("Applied", ctx).
+		Return([]migration.Record{{Version: 1, AppliedAt: appliedAt}}, nil)
+
+	runner, err := migration.NewRunner(nil, store, []migration.Migration{
+		step(2, &calls, nil),
+		step(1, &calls, nil),
+	})
+	require.NoError(t, err)
+
+	statuses, err := runner.Status(ctx)
+
+	assert.NoError(t, err)
+	require.Len(t, statuses, 2)
+	assert.Equal(t, 1, statuses[0].Version)
+	assert.True(t, statuses[0].Applied)
+	assert.Equal(t, appliedAt, statuses[0].AppliedAt)
+	assert.Equal(t, 2, statuses[1].Version)
+	assert.False(t, statuses[1].Applied)
+	assert.Empty(t, calls)
+}
+
+func TestNewRunner_RejectsDuplicateVersions(t *testing.T) {
+	var calls []int
+
+	_, err := migration.NewRunner(nil, new(MockStore), []migration.Migration{
+		step(1, &calls, nil),
+		step(1, &calls, nil),
+	})
+
+	assert.Error(t, err)
+}
+
+func TestAll_VersionsAreUniqueAndPositive(t *testing.T) {
+	_, err := migration.NewRunner(nil, new(MockStore), migration.All())
+	assert.NoError(t, err)
+}
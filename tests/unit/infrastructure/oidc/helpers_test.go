@@ -0,0 +1,109 @@
+package oidc_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testKeys are generated locally; no identity provider is involved.
+type testKeys struct {
+	rsa    *rsa.PrivateKey
+	ec     *ecdsa.PrivateKey
+	secret []byte
+}
+
+// generatedKeys is shared by the package since RSA generation is slow.
+var generatedKeys = sync.OnceValues(func() (testKeys, error) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return testKeys{}, err
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return testKeys{}, err
+	}
+
+	return testKeys{rsa: rsaKey, ec: ecKey, secret: []byte("0123456789abcdef0123456789abcdef")}, nil
+})
+
+func newTestKeys(t *testing.T) testKeys {
+	t.Helper()
+
+	keys, err := generatedKeys()
+	require.NoError(t, err)
+	return keys
+}
+
+func b64(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// jwks publishes the three keys as rsa-1, ec-1 and hs-1.
+func (k testKeys) jwks(t *testing.T) []byte {
+	t.Helper()
+
+	ecPub := k.ec.Public().(*ecdsa.PublicKey)
+	ecBytes, err := ecPub.Bytes()
+	require.NoError(t, err)
+
+	doc := map[string]any{"keys": []map[string]any{
+		{
+			"kty": "RSA", "kid": "rsa-1", "use": "sig", "alg": "RS256",
+			"n": b64(k.rsa.N.Bytes()),
+			"e": b64(big.NewInt(int64(k.rsa.E)).Bytes()),
+		},
+		{
+			"kty": "EC", "kid": "ec-1", "crv": "P-256",
+			"x": b64(ecBytes[1:33]),
+			"y": b64(ecBytes[33:]),
+		},
+		{"kty": "oct", "kid": "hs-1", "alg": "HS256", "k": b64(k.secret)},
+	}}
+
+	data, err := json.Marshal(doc)
+	require.NoError(t, err)
+	return data
+}
+
+func (k testKeys) sign(t *testing.T, alg, kid string, claims map[string]any) string {
+	t.Helper()
+
+	h, err := json.Marshal(map[string]any{"alg": alg, "kid": kid, "typ": "JWT"})
+	require.NoError(t, err)
+	p, err := json.Marshal(claims)
+	require.NoError(t, err)
+
+	signed := b64(h) + "." + b64(p)
+	digest := sha256.Sum256([]byte(signed))
+
+	var sig []byte
+	switch alg {
+	case "RS256":
+		sig, err = rsa.SignPKCS1v15(rand.Reader, k.rsa, crypto.SHA256, digest[:])
+		require.NoError(t, err)
+	case "ES256":
+		r, s, err := ecdsa.Sign(rand.Reader, k.ec, digest[:])
+		require.NoError(t, err)
+		sig = make([]byte, 64)
+		r.FillBytes(sig[:32])
+		s.FillBytes(sig[32:])
+	case "HS256":
+		mac := hmac.New(sha256.New, k.secret)
+		mac.Write([]byte(signed))
+		sig = mac.Sum(nil)
+	}
+
+	return signed + "." + b64(sig)
+}
@@ -0,0 +1,148 @@
+package oidc_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/heaveless/dbz-api/internal/infrastructure/oidc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type countingSource struct {
+	mu    sync.Mutex
+	data  []byte
+	err   error
+	loads int
+}
+
+func (s *countingSource) Load(context.Context) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.loads++
+	return s.data, s.err
+}
+
+func (s *countingSource) set(data []byte, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data, s.err = data, err
+}
+
+func TestKeyCache_RefreshesAfterInterval(t *testing.T) {
+	keys := newTestKeys(t)
+	src := &countingSource{data: keys.jwks(t)}
+	now := time.Unix(0, 0)
+	cache := oidc.NewKeyCacheWithClock(src, time.Hour, func() time.Time { return now })
+
+	for i := 0; i < 3; i++ {
+		_, err := cache.KeySet(context.Background(), "rsa-1")
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 1, src.loads)
+
+	now = now.Add(time.Hour)
+	_, err := cache.KeySet(context.Background(), "rsa-1")
+	require.NoError(t, err)
+	assert.Equal(t, 2, src.loads)
+}
+
+func TestKeyCache_ReloadsOnUnknownKid(t *testing.T) {
+	keys := newTestKeys(t)
+	src := &countingSource{data: keys.jwks(t)}
+	now := time.Unix(0, 0)
+	cache := oidc.NewKeyCacheWithClock(src, time.Hour, func() time.Time { return now })
+
+	_, err := cache.KeySet(context.Background(), "rsa-1")
+	require.NoError(t, err)
+
+	// Right after a load an unknown kid does not force another one.
+	_, err = cache.KeySet(context.Background(), "rotated")
+	require.NoError(t, err)
+	assert.Equal(t, 1, src.loads)
+
+	now = now.Add(time.Minute)
+	_, err = cache.KeySet(context.Background(), "rotated")
+	require.NoError(t, err)
+	assert.Equal(t, 2, src.loads)
+}
+
+func TestKeyCache_KeepsLastGoodSet(t *testing.T) {
+	keys := newTestKeys(t)
+	src := &countingSource{data: keys.jwks(t)}
+	now := time.Unix(0, 0)
+	cache := oidc.NewKeyCacheWithClock(src, time.Hour, func() time.Time { return now })
+
+	first, err := cache.KeySet(context.Background(), "")
+	require.NoError(t, err)
+
+	src.set(nil, errors.New("idp down"))
+	now = now.Add(2 * time.Hour)
+
+	set, err := cache.KeySet(context.Background(), "")
+	require.NoError(t, err)
+	assert.Same(t, first, set)
+}
+
+func TestKeyCache_FailsWithoutAnySet(t *testing.T) {
+	cache := oidc.NewKeyCache(&countingSource{err: errors.New("idp down")}, time.Hour)
+
+	_, err := cache.KeySet(context.Background(), "")
+
+	assert.EqualError(t, err, "jwks: idp down")
+}
+
+func TestNewSource_File(t *testing.T) {
+	keys := newTestKeys(t)
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, keys.jwks(t), 0o600))
+
+	data, err := oidc.NewSource(path).Load(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, keys.jwks(t)[:10], data[:10])
+}
+
+func TestNewSource_URL(t *testing.T) {
+	keys := newTestKeys(t)
+	jwks := keys.jwks(t)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/.well-known/jwks.json" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(jwks)
+	}))
+	defer srv.Close()
+
+	data, err := oidc.NewSource(srv.URL + "/.well-known/jwks.json").Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, jwks, data)
+
+	_, err = oidc.NewSource(srv.URL + "/missing").Load(context.Background())
+	assert.ErrorContains(t, err, "unexpected status 404")
+}
+
+func TestParseKeySet_SkipsUnusableKeys(t *testing.T) {
+	set, err := oidc.ParseKeySet([]byte(`{"keys":[
+		{"kty":"EC","kid":"p384","crv":"P-384","x":"AA","y":"AA"},
+		{"kty":"RSA","kid":"enc","use":"enc","n":"AQAB","e":"AQAB"},
+		{"kty":"oct","kid":"hs","k":"c2VjcmV0"}
+	]}`))
+
+	require.NoError(t, err)
+	require.Len(t, set.Keys, 1)
+	assert.Equal(t, "hs", set.Keys[0].Id)
+
+	_, err = oidc.ParseKeySet([]byte(`{"keys":[]}`))
+	assert.Error(t, err)
+}
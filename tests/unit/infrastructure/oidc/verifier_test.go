@@ -0,0 +1,211 @@
+package oidc_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/heaveless/dbz-api/internal/infrastructure/oidc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var verifierNow = time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)
+
+type staticKeys struct {
+	set *oidc.KeySet
+	err error
+}
+
+func (s staticKeys) KeySet(context.Context, string) (*oidc.KeySet, error) {
+	return s.set, s.err
+}
+
+func newTestVerifier(t *testing.T, keys testKeys) *oidc.Verifier {
+	t.Helper()
+
+	set, err := oidc.ParseKeySet(keys.jwks(t))
+	require.NoError(t, err)
+	require.Len(t, set.Keys, 3)
+
+	return oidc.NewVerifier(staticKeys{set: set}, oidc.Config{
+		Issuer:   "https://idp.example.com",
+		Audience: "dbz-api",
+		Now:      func() time.Time { return verifierNow },
+	})
+}
+
+func validClaims() map[string]any {
+	return map[string]any{
+		"sub":   "svc-reports",
+		"iss":   "https://idp.example.com",
+		"aud":   "dbz-api",
+		"exp":   verifierNow.Add(time.Hour).Unix(),
+		"scope": "dbz.read dbz.write",
+	}
+}
+
+func with(claims map[string]any, key string, value any) map[string]any {
+	claims[key] = value
+	return claims
+}
+
+func TestVerifier_AcceptsEveryAlgorithm(t *testing.T) {
+	keys := newTestKeys(t)
+	v := newTestVerifier(t, keys)
+
+	for alg, kid := range map[string]string{"RS256": "rsa-1", "ES256": "ec-1", "HS256": "hs-1"} {
+		t.Run(alg, func(t *testing.T) {
+			claims, err := v.Verify(context.Background(), keys.sign(t, alg, kid, validClaims()))
+
+			require.NoError(t, err)
+			assert.Equal(t, "svc-reports", claims.Subject)
+			assert.Equal(t, []string{"dbz-api"}, claims.Audience)
+			assert.Equal(t, []string{"dbz.read", "dbz.write"}, claims.Scopes)
+			assert.Equal(t, verifierNow.Add(time.Hour).Unix(), claims.ExpiresAt.Unix())
+		})
+	}
+}
+
+func TestVerifier_MatchesKeyWithoutKid(t *testing.T) {
+	keys := newTestKeys(t)
+	v := newTestVerifier(t, keys)
+
+	_, err := v.Verify(context.Background(), keys.sign(t, "ES256", "", validClaims()))
+
+	assert.NoError(t, err)
+}
+
+func TestVerifier_MergesScpAndAudienceArrays(t *testing.T) {
+	keys := newTestKeys(t)
+	v := newTestVerifier(t, keys)
+
+	claims := validClaims()
+	claims["aud"] = []string{"other", "dbz-api"}
+	claims["scope"] = "dbz.read"
+	claims["scp"] = []string{"dbz.read", "dbz.admin"}
+
+	got, err := v.Verify(context.Background(), keys.sign(t, "RS256", "rsa-1", claims))
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"dbz.read", "dbz.admin"}, got.Scopes)
+}
+
+func TestVerifier_Rejects(t *testing.T) {
+	keys := newTestKeys(t)
+	v := newTestVerifier(t, keys)
+
+	tests := []struct {
+		name  string
+		token func(t *testing.T) string
+	}{
+		{
+			name: "wrong issuer",
+			token: func(t *testing.T) string {
+				return keys.sign(t, "RS256", "rsa-1", with(validClaims(), "iss", "https://evil.example.com"))
+			},
+		},
+		{
+			name:  "wrong audience",
+			token: func(t *testing.T) string { return keys.sign(t, "RS256", "rsa-1", with(validClaims(), "aud", "other")) },
+		},
+		{
+			name: "expired beyond leeway",
+			token: func(t *testing.T) string {
+				return keys.sign(t, "RS256", "rsa-1", with(validClaims(), "exp", verifierNow.Add(-time.Minute).Unix()))
+			},
+		},
+		{
+			name: "missing exp",
+			token: func(t *testing.T) string {
+				claims := validClaims()
+				delete(claims, "exp")
+				return keys.sign(t, "RS256", "rsa-1", claims)
+			},
+		},
+		{
+			name: "not valid yet",
+			token: func(t *testing.T) string {
+				return keys.sign(t, "RS256", "rsa-1", with(validClaims(), "nbf", verifierNow.Add(time.Hour).Unix()))
+			},
+		},
+		{
+			name:  "unknown kid",
+			token: func(t *testing.T) string { return keys.sign(t, "RS256", "rsa-2", validClaims()) },
+		},
+		{
+			name: "alg none",
+			token: func(t *testing.T) string {
+				parts := strings.Split(keys.sign(t, "none", "rsa-1", validClaims()), ".")
+				return parts[0] + "." + parts[1] + "."
+			},
+		},
+		{
+			name: "algorithm confusion",
+			// An RS256 key must never verify an HS256 token.
+			token: func(t *testing.T) string { return keys.sign(t, "HS256", "rsa-1", validClaims()) },
+		},
+		{
+			name: "tampered claims",
+			token: func(t *testing.T) string {
+				parts := strings.Split(keys.sign(t, "ES256", "ec-1", validClaims()), ".")
+				forged := strings.Split(keys.sign(t, "ES256", "ec-1", with(validClaims(), "sub", "admin")), ".")
+				return parts[0] + "." + forged[1] + "." + parts[2]
+			},
+		},
+		{
+			name:  "malformed",
+			token: func(t *testing.T) string { return "not-a-token" },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := v.Verify(context.Background(), tt.token(t))
+
+			assert.Nil(t, claims)
+			assert.ErrorIs(t, err, oidc.ErrInvalidToken)
+		})
+	}
+}
+
+func TestVerifier_AllowsClockSkew(t *testing.T) {
+	keys := newTestKeys(t)
+	v := newTestVerifier(t, keys)
+
+	_, err := v.Verify(context.Background(), keys.sign(t, "RS256", "rsa-1", with(validClaims(), "exp", verifierNow.Add(-10*time.Second).Unix())))
+
+	assert.NoError(t, err)
+}
+
+func TestVerifier_RejectsEverythingWithoutIssuerOrAudience(t *testing.T) {
+	keys := newTestKeys(t)
+	set, err := oidc.ParseKeySet(keys.jwks(t))
+	require.NoError(t, err)
+
+	for name, cfg := range map[string]oidc.Config{
+		"no issuer":   {Audience: "dbz-api"},
+		"no audience": {Issuer: "https://idp.example.com"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cfg.Now = func() time.Time { return verifierNow }
+			v := oidc.NewVerifier(staticKeys{set: set}, cfg)
+
+			_, err := v.Verify(context.Background(), keys.sign(t, "RS256", "rsa-1", validClaims()))
+
+			assert.ErrorIs(t, err, oidc.ErrInvalidToken)
+		})
+	}
+}
+
+func TestVerifier_KeysUnavailable(t *testing.T) {
+	keys := newTestKeys(t)
+	v := oidc.NewVerifier(staticKeys{err: errors.New("idp down")}, oidc.Config{})
+
+	_, err := v.Verify(context.Background(), keys.sign(t, "RS256", "rsa-1", validClaims()))
+
+	assert.ErrorIs(t, err, oidc.ErrKeysUnavailable)
+	assert.NotErrorIs(t, err, oidc.ErrInvalidToken)
+}
@@ -0,0 +1,233 @@
+package outbox_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/heaveless/dbz-api/internal/domain/event"
+	"github.com/heaveless/dbz-api/internal/infrastructure/outbox"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryOutbox keeps entries in memory the way the Mongo repository keeps
+// them in the outbox collection.
+type memoryOutbox struct {
+	mu      sync.Mutex
+	entries []event.OutboxEntry
+}
+
+func (o *memoryOutbox) Record(_ context.Context, e event.Event) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.entries = append(o.entries, event.OutboxEntry{
+		Key:           string(rune('a' + len(o.entries))),
+		Type:          e.Type,
+		At:            e.At,
+		Data:          `{"id":1}`,
+		NextAttemptAt: e.At,
+	})
+	return nil
+}
+
+func (o *memoryOutbox) Pending(_ context.Context, now time.Time, limit int) ([]event.OutboxEntry, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	var out []event.OutboxEntry
+	for _, e := range o.entries {
+		if e.DeliveredAt == nil && !e.NextAttemptAt.After(now) && len(out) < limit {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+func (o *memoryOutbox) Delivered(_ context.Context, key string, sinks []string, at time.Time) error {
+	return o.update(key, func(e *event.OutboxEntry) {
+		e.DeliveredTo = append(e.DeliveredTo, sinks...)
+		e.DeliveredAt = &at
+	})
+}
+
+func (o *memoryOutbox) Retry(_ context.Context, key string, sinks []string, at time.Time, reason string) error {
+	return o.update(key, func(e *event.OutboxEntry) {
+		e.DeliveredTo = append(e.DeliveredTo, sinks...)
+		e.Attempts++
+		e.NextAttemptAt = at
+		e.LastError = reason
+	})
+}
+
+func (o *memoryOutbox) update(key string, fn func(e *event.OutboxEntry)) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	for i := range o.entries {
+		if o.entries[i].Key == key {
+			fn(&o.entries[i])
+			return nil
+		}
+	}
+	return errors.New("no such entry")
+}
+
+func (o *memoryOutbox) get(key string) event.OutboxEntry {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	for _, e := range o.entries {
+		if e.Key == key {
+			return e
+		}
+	}
+	return event.OutboxEntry{}
+}
+
+// recordingSink fails while err is set.
+type recordingSink struct {
+	name string
+	mu   sync.Mutex
+	err  error
+	got  []event.Message
+}
+
+func (s *recordingSink) Name() string { return s.name }
+
+func (s *recordingSink) Send(_ context.Context, m event.Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err != nil {
+		return s.err
+	}
+	s.got = append(s.got, m)
+	return nil
+}
+
+func (s *recordingSink) keys() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var keys []string
+	for _, m := range s.got {
+		keys = append(keys, m.Key)
+	}
+	return keys
+}
+
+func record(t *testing.T, o *memoryOutbox, n int) {
+	t.Helper()
+	for range n {
+		require.NoError(t, o.Record(context.Background(), event.Event{Type: event.CharacterCached, At: time.Now().Add(-time.Second)}))
+	}
+}
+
+func TestRelay_DeliversToEverySink(t *testing.T) {
+	o := &memoryOutbox{}
+	record(t, o, 2)
+	stdout, webhook := &recordingSink{name: "stdout"}, &recordingSink{name: "webhook"}
+
+	n, err := outbox.NewRelay(o, []event.Sink{stdout, webhook}, outbox.RelayOptions{}).RelayOnce(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, []string{"a", "b"}, stdout.keys())
+	assert.Equal(t, []string{"a", "b"}, webhook.keys())
+	assert.Equal(t, event.Message{Key: "a", Type: event.CharacterCached, At: o.get("a").At, Data: []byte(`{"id":1}`)}, stdout.got[0])
+
+	entry := o.get("a")
+	assert.NotNil(t, entry.DeliveredAt)
+	assert.ElementsMatch(t, []string{"stdout", "webhook"}, entry.DeliveredTo)
+}
+
+func TestRelay_RetriesOnlyFailedSinks(t *testing.T) {
+	o := &memoryOutbox{}
+	record(t, o, 1)
+	stdout := &recordingSink{name: "stdout"}
+	webhook := &recordingSink{name: "webhook", err: errors.New("connection refused")}
+	relay := outbox.NewRelay(o, []event.Sink{stdout, webhook}, outbox.RelayOptions{MinBackoff: time.Millisecond})
+
+	start := time.Now()
+	_, err := relay.RelayOnce(context.Background())
+	require.NoError(t, err)
+
+	entry := o.get("a")
+	assert.Nil(t, entry.DeliveredAt)
+	assert.Equal(t, []string{"stdout"}, entry.DeliveredTo)
+	assert.Equal(t, 1, entry.Attempts)
+	assert.Equal(t, "webhook: connection refused", entry.LastError)
+	assert.True(t, entry.NextAttemptAt.After(start))
+
+	webhook.mu.Lock()
+	webhook.err = nil
+	webhook.mu.Unlock()
+	time.Sleep(5 * time.Millisecond)
+
+	_, err = relay.RelayOnce(context.Background())
+	require.NoError(t, err)
+
+	assert.NotNil(t, o.get("a").DeliveredAt)
+	assert.Equal(t, []string{"a"}, stdout.keys())
+	assert.Equal(t, []string{"a"}, webhook.keys())
+}
+
+func TestRelay_BacksOffExponentially(t *testing.T) {
+	o := &memoryOutbox{}
+	record(t, o, 1)
+	failing := &recordingSink{name: "nats", err: errors.New("no servers")}
+	relay := outbox.NewRelay(o, []event.Sink{failing}, outbox.RelayOptions{MinBackoff: time.Minute, MaxBackoff: 5 * time.Minute})
+
+	var waits []time.Duration
+	for range 5 {
+		require.NoError(t, o.update("a", func(e *event.OutboxEntry) { e.NextAttemptAt = time.Now().Add(-time.Second) }))
+
+		before := time.Now()
+		_, err := relay.RelayOnce(context.Background())
+		require.NoError(t, err)
+
+		waits = append(waits, o.get("a").NextAttemptAt.Sub(before).Round(time.Minute))
+	}
+
+	assert.Equal(t, []time.Duration{time.Minute, 2 * time.Minute, 4 * time.Minute, 5 * time.Minute, 5 * time.Minute}, waits)
+}
+
+func TestRelay_SkipsEntriesNotDue(t *testing.T) {
+	o := &memoryOutbox{}
+	require.NoError(t, o.Record(context.Background(), event.Event{Type: event.CharacterCached, At: time.Now().Add(time.Hour)}))
+	sink := &recordingSink{name: "stdout"}
+
+	n, err := outbox.NewRelay(o, []event.Sink{sink}, outbox.RelayOptions{}).RelayOnce(context.Background())
+
+	require.NoError(t, err)
+	assert.Zero(t, n)
+	assert.Empty(t, sink.keys())
+}
+
+func TestRelay_RunStopsWithContext(t *testing.T) {
+	o := &memoryOutbox{}
+	record(t, o, 3)
+	sink := &recordingSink{name: "stdout"}
+	relay := outbox.NewRelay(o, []event.Sink{sink}, outbox.RelayOptions{Interval: time.Millisecond, Batch: 2})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		relay.Run(ctx)
+		close(done)
+	}()
+
+	require.Eventually(t, func() bool { return slices.Equal(sink.keys(), []string{"a", "b", "c"}) }, time.Second, time.Millisecond)
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run did not return after the context was cancelled")
+	}
+}
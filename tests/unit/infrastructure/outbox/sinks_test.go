@@ -0,0 +1,215 @@
+package outbox_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/heaveless/dbz-api/internal/domain/event"
+	"github.com/heaveless/dbz-api/internal/infrastructure/outbox"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var message = event.Message{
+	Key:  "6650f0c2a1b2c3d4e5f60718",
+	Type: event.CharacterCached,
+	At:   time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+	Data: json.RawMessage(`{"id":1,"name":"Goku"}`),
+}
+
+const messageJSON = `{"key":"6650f0c2a1b2c3d4e5f60718","type":"CharacterCached","at":"2024-05-01T10:00:00Z","data":{"id":1,"name":"Goku"}}`
+
+func TestWriterSink_WritesJSONLines(t *testing.T) {
+	var buf bytes.Buffer
+	sink := outbox.NewWriterSink("stdout", &buf)
+
+	require.NoError(t, sink.Send(context.Background(), message))
+	require.NoError(t, sink.Send(context.Background(), message))
+
+	assert.Equal(t, "stdout", sink.Name())
+	assert.Equal(t, messageJSON+"\n"+messageJSON+"\n", buf.String())
+}
+
+func TestFileSink_Appends(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "outbox.jsonl")
+	require.NoError(t, os.WriteFile(path, []byte("earlier\n"), 0o644))
+
+	sink, err := outbox.NewFileSink(path)
+	require.NoError(t, err)
+	require.NoError(t, sink.Send(context.Background(), message))
+	require.NoError(t, sink.Close())
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "file", sink.Name())
+	assert.Equal(t, "earlier\n"+messageJSON+"\n", string(content))
+}
+
+func TestWebhookSink_Posts(t *testing.T) {
+	var got *http.Request
+	var body []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	err := outbox.NewWebhookSink(srv.URL, srv.Client()).Send(context.Background(), message)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.MethodPost, got.Method)
+	assert.Equal(t, "application/json", got.Header.Get("Content-Type"))
+	assert.Equal(t, message.Key, got.Header.Get("Idempotency-Key"))
+	assert.Equal(t, "CharacterCached", got.Header.Get("X-Event-Type"))
+	assert.JSONEq(t, messageJSON, string(body))
+}
+
+func TestWebhookSink_FailsOnErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	err := outbox.NewWebhookSink(srv.URL, srv.Client()).Send(context.Background(), message)
+
+	assert.EqualError(t, err, "webhook answered 503")
+}
+
+type fakeBroker struct {
+	subject, key string
+	data         []byte
+}
+
+func (b *fakeBroker) Publish(_ context.Context, subject string, key string, data []byte) error {
+	b.subject, b.key, b.data = subject, key, data
+	return nil
+}
+
+func TestBrokerSink_PublishesOnTypeSubject(t *testing.T) {
+	broker := &fakeBroker{}
+
+	err := outbox.NewBrokerSink("kafka", broker, "dbz.events").Send(context.Background(), message)
+
+	require.NoError(t, err)
+	assert.Equal(t, "dbz.events.CharacterCached", broker.subject)
+	assert.Equal(t, message.Key, broker.key)
+	assert.JSONEq(t, messageJSON, string(broker.data))
+}
+
+// natsStandIn speaks just enough of the NATS protocol for the client to
+// connect, publish with headers and flush. Messages whose subject is reject
+// get -ERR instead, and the connection stays open as a real server's does.
+type natsStandIn struct {
+	ln       net.Listener
+	received chan string
+	conns    chan struct{}
+	reject   string
+}
+
+func startNats(t *testing.T, reject string) *natsStandIn {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+
+	s := &natsStandIn{ln: ln, received: make(chan string, 10), conns: make(chan struct{}, 10), reject: reject}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			s.conns <- struct{}{}
+			go s.serve(conn)
+		}
+	}()
+
+	return s
+}
+
+func (s *natsStandIn) url() string { return "nats://" + s.ln.Addr().String() }
+
+func (s *natsStandIn) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	fmt.Fprint(conn, "INFO {\"server_id\":\"stand-in\",\"proto\":1,\"headers\":true,\"max_payload\":1048576}\r\n")
+
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		fields := strings.Fields(line)
+		switch {
+		case len(fields) == 0:
+		case fields[0] == "PING":
+			fmt.Fprint(conn, "PONG\r\n")
+		case fields[0] == "HPUB" && len(fields) == 4:
+			total, _ := strconv.Atoi(fields[3])
+			payload := make([]byte, total+2)
+			if _, err := io.ReadFull(r, payload); err != nil {
+				return
+			}
+			if fields[1] == s.reject {
+				fmt.Fprintf(conn, "-ERR 'Permissions Violation for Publish to \"%s\"'\r\n", fields[1])
+				continue
+			}
+			s.received <- fields[1] + " " + fields[2] + " " + string(payload[:total])
+		}
+	}
+}
+
+func TestNatsBroker_Publishes(t *testing.T) {
+	srv := startNats(t, "")
+	broker, err := outbox.NewNatsBroker(srv.url())
+	require.NoError(t, err)
+	defer broker.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, broker.Publish(ctx, "dbz.events.CharacterCached", "k1", []byte(`{"a":1}`)))
+	require.NoError(t, broker.Publish(ctx, "dbz.events.CharacterCached", "k2", []byte(`{"a":2}`)))
+
+	header := "NATS/1.0\r\nNats-Msg-Id: k1\r\n\r\n"
+	assert.Equal(t, fmt.Sprintf("dbz.events.CharacterCached %d %s{\"a\":1}", len(header), header), <-srv.received)
+	assert.Contains(t, <-srv.received, "Nats-Msg-Id: k2")
+	assert.Len(t, srv.conns, 1, "the connection is reused")
+}
+
+func TestNatsBroker_ReportsRejectedMessages(t *testing.T) {
+	srv := startNats(t, "forbidden")
+	broker, err := outbox.NewNatsBroker(srv.url())
+	require.NoError(t, err)
+	defer broker.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	err = broker.Publish(ctx, "forbidden", "k1", []byte("{}"))
+	assert.ErrorIs(t, err, nats.ErrPermissionViolation)
+
+	require.NoError(t, broker.Publish(ctx, "allowed", "k2", []byte("{}")), "an earlier rejection is not reported again")
+	assert.Contains(t, <-srv.received, "allowed")
+	assert.Len(t, srv.conns, 1)
+}
+
+func TestNatsBroker_RejectsBadURL(t *testing.T) {
+	for _, url := range []string{"", "http://localhost:4222", "nats://"} {
+		_, err := outbox.NewNatsBroker(url)
+		assert.Error(t, err, url)
+	}
+}
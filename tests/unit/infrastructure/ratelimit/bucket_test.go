@@ -0,0 +1,48 @@
+package ratelimit_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/heaveless/dbz-api/internal/infrastructure/ratelimit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBucket_AllowsBurstThenRefills(t *testing.T) {
+	start := time.Unix(0, 0)
+	b := ratelimit.NewBucket(ratelimit.Limit{Rate: 2, Burst: 3}, start)
+
+	for i := 2; i >= 0; i-- {
+		d := b.Take(start)
+		assert.True(t, d.Allowed)
+		assert.Equal(t, 3, d.Limit)
+		assert.Equal(t, i, d.Remaining)
+	}
+
+	d := b.Take(start)
+	assert.False(t, d.Allowed)
+	assert.Equal(t, 0, d.Remaining)
+	assert.Equal(t, 500*time.Millisecond, d.RetryAfter)
+	assert.Equal(t, 1500*time.Millisecond, d.Reset)
+
+	d = b.Take(start.Add(500 * time.Millisecond))
+	assert.True(t, d.Allowed)
+	assert.Zero(t, d.RetryAfter)
+}
+
+func TestBucket_NeverExceedsBurst(t *testing.T) {
+	start := time.Unix(0, 0)
+	b := ratelimit.NewBucket(ratelimit.Limit{Rate: 10, Burst: 2}, start)
+
+	d := b.Take(start.Add(time.Hour))
+
+	assert.True(t, d.Allowed)
+	assert.Equal(t, 1, d.Remaining)
+	assert.True(t, b.Full(start.Add(2*time.Hour)))
+}
+
+func TestLimit_Enabled(t *testing.T) {
+	assert.True(t, ratelimit.Limit{Rate: 1, Burst: 1}.Enabled())
+	assert.False(t, ratelimit.Limit{Rate: 0, Burst: 5}.Enabled())
+	assert.False(t, ratelimit.Limit{Rate: 1}.Enabled())
+}
@@ -0,0 +1,50 @@
+package ratelimit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/heaveless/dbz-api/internal/infrastructure/ratelimit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func TestMemoryStore_KeysAreIndependent(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	store := ratelimit.NewMemoryStoreWithClock(clock.Now)
+	limit := ratelimit.Limit{Rate: 1, Burst: 1}
+
+	d, err := store.Take(context.Background(), "a", limit)
+	require.NoError(t, err)
+	assert.True(t, d.Allowed)
+
+	d, _ = store.Take(context.Background(), "a", limit)
+	assert.False(t, d.Allowed)
+
+	d, _ = store.Take(context.Background(), "b", limit)
+	assert.True(t, d.Allowed)
+}
+
+func TestMemoryStore_SweepsRefilledBuckets(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	store := ratelimit.NewMemoryStoreWithClock(clock.Now)
+	limit := ratelimit.Limit{Rate: 1, Burst: 1}
+
+	_, _ = store.Take(context.Background(), "a", limit)
+	_, _ = store.Take(context.Background(), "b", limit)
+	assert.Equal(t, 2, store.Len())
+
+	clock.now = clock.now.Add(2 * time.Minute)
+	_, _ = store.Take(context.Background(), "c", limit)
+
+	assert.Equal(t, 1, store.Len())
+}
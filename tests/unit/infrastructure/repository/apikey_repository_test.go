@@ -0,0 +1,120 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	apikey "github.com/heaveless/dbz-api/internal/domain/apikey"
+	repo "github.com/heaveless/dbz-api/internal/infrastructure/repositoy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+func TestApiKeyRepository_GetByHash_OK(t *testing.T) {
+	ctx := context.Background()
+	keys := new(MockDbCollection)
+	mockResult := new(MockSingleResult)
+
+	keys.
+		On("FindOne", ctx, bson.M{"hash": "abc"}).
+		Return(mockResult, nil)
+
+	mockResult.
+		On("Decode", mock.AnythingOfType("*apikey.ApiKeyEntity")).
+		Run(func(args mock.Arguments) {
+			*args.Get(0).(*apikey.ApiKeyEntity) = apikey.ApiKeyEntity{Id: "k1", Hash: "abc"}
+		}).
+		Return(nil)
+
+	r := repo.NewApiKeyRepository(keys, new(MockDbCollection))
+
+	res, err := r.GetByHash(ctx, "abc")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "k1", res.Id)
+}
+
+func TestApiKeyRepository_Get_NotFound(t *testing.T) {
+	ctx := context.Background()
+	keys := new(MockDbCollection)
+	mockResult := new(MockSingleResult)
+
+	keys.
+		On("FindOne", ctx, bson.M{"_id": "k1"}).
+		Return(mockResult, nil)
+
+	mockResult.
+		On("Decode", mock.Anything).
+		Return(mongo.ErrNoDocuments)
+
+	r := repo.NewApiKeyRepository(keys, new(MockDbCollection))
+
+	res, err := r.Get(ctx, "k1")
+
+	assert.Nil(t, res)
+	assert.ErrorIs(t, err, apikey.ErrNotFound)
+}
+
+func TestApiKeyRepository_Save_Upserts(t *testing.T) {
+	ctx := context.Background()
+	keys := new(MockDbCollection)
+
+	record := &apikey.ApiKeyEntity{Id: "k1", Hash: "abc"}
+
+	keys.
+		On("ReplaceOne", ctx, bson.M{"_id": "k1"}, record).
+		Return(&mongo.UpdateResult{UpsertedCount: 1}, nil)
+
+	r := repo.NewApiKeyRepository(keys, new(MockDbCollection))
+
+	assert.NoError(t, r.Save(ctx, record))
+	keys.AssertExpectations(t)
+}
+
+func TestApiKeyRepository_IncrementUsage(t *testing.T) {
+	ctx := context.Background()
+	usage := new(MockDbCollection)
+	mockResult := new(MockSingleResult)
+
+	usage.
+		On("FindOneAndUpdate", ctx, bson.M{"_id": "k1:2026-10-19"}, mock.MatchedBy(func(update bson.M) bool {
+			return update["$inc"].(bson.M)["count"] == 1
+		})).
+		Return(mockResult, nil)
+
+	mockResult.
+		On("Decode", mock.Anything).
+		Run(func(args mock.Arguments) {
+			doc := args.Get(0).(*struct {
+				Count int64 `bson:"count"`
+			})
+			doc.Count = 7
+		}).
+		Return(nil)
+
+	r := repo.NewApiKeyRepository(new(MockDbCollection), usage)
+
+	n, err := r.IncrementUsage(ctx, "k1", "2026-10-19")
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), n)
+	usage.AssertExpectations(t)
+}
+
+func TestApiKeyRepository_IncrementUsage_Error(t *testing.T) {
+	ctx := context.Background()
+	usage := new(MockDbCollection)
+
+	usage.
+		On("FindOneAndUpdate", ctx, mock.Anything, mock.Anything).
+		Return((*MockSingleResult)(nil), errors.New("db down"))
+
+	r := repo.NewApiKeyRepository(new(MockDbCollection), usage)
+
+	_, err := r.IncrementUsage(ctx, "k1", "2026-10-19")
+
+	assert.EqualError(t, err, "db down")
+}
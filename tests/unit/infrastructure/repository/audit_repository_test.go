@@ -0,0 +1,105 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/heaveless/dbz-api/internal/domain/audit"
+	repo "github.com/heaveless/dbz-api/internal/infrastructure/repositoy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+func TestAuditRepository_Record(t *testing.T) {
+	ctx := context.Background()
+	client := new(MockDbCollection)
+
+	var entry *audit.Entry
+	client.
+		On("InsertOne", ctx, mock.AnythingOfType("*audit.Entry")).
+		Run(func(args mock.Arguments) { entry = args.Get(1).(*audit.Entry) }).
+		Return(&mongo.InsertOneResult{}, nil)
+
+	r := repo.NewAuditRepository(client)
+
+	err := r.Record(ctx, audit.Entry{Actor: audit.System, Action: audit.CharacterDeleted, Target: "character:1"})
+
+	assert.NoError(t, err)
+	_, err = bson.ObjectIDFromHex(entry.Id)
+	assert.NoError(t, err, entry.Id)
+	assert.WithinDuration(t, time.Now(), entry.At, time.Minute)
+	assert.Equal(t, []audit.Change{}, entry.Changes)
+}
+
+func TestAuditRepository_Record_RequestId(t *testing.T) {
+	ctx := audit.WithRequestId(context.Background(), "req-1")
+	client := new(MockDbCollection)
+
+	var entry *audit.Entry
+	client.
+		On("InsertOne", ctx, mock.AnythingOfType("*audit.Entry")).
+		Run(func(args mock.Arguments) { entry = args.Get(1).(*audit.Entry) }).
+		Return(&mongo.InsertOneResult{}, nil)
+
+	r := repo.NewAuditRepository(client)
+
+	assert.NoError(t, r.Record(ctx, audit.Entry{Actor: audit.System, Action: audit.CharacterDeleted}))
+	assert.Equal(t, "req-1", entry.RequestId)
+}
+
+func TestAuditRepository_Find(t *testing.T) {
+	ctx := context.Background()
+	client := new(MockDbCollection)
+	cursor := new(MockCursor)
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	filter := bson.M{
+		"$or":           bson.A{bson.M{"actor.id": "ops"}, bson.M{"actor.name": "ops"}},
+		"target":        "character:2",
+		"changes.field": "affiliation",
+		"at":            bson.M{"$gte": from},
+	}
+	client.On("CountDocuments", ctx, filter).Return(int64(1), nil)
+	client.On("Find", ctx, filter).Return(cursor, nil)
+	cursor.
+		On("All", ctx, mock.Anything).
+		Run(func(args mock.Arguments) {
+			*args.Get(1).(*[]audit.Entry) = []audit.Entry{{Id: "e1", Action: audit.CharacterUpdated, Target: "character:2"}}
+		}).
+		Return(nil)
+
+	r := repo.NewAuditRepository(client)
+
+	entries, total, err := r.Find(ctx, audit.Query{
+		Actor:  "ops",
+		Target: "character:2",
+		Field:  "affiliation",
+		From:   from,
+		Page:   1,
+		Limit:  20,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, "e1", entries[0].Id)
+	client.AssertExpectations(t)
+}
+
+func TestAuditRepository_Find_CountError(t *testing.T) {
+	ctx := context.Background()
+	client := new(MockDbCollection)
+
+	client.On("CountDocuments", ctx, bson.M{}).Return(int64(0), errors.New("db down"))
+
+	r := repo.NewAuditRepository(client)
+
+	entries, _, err := r.Find(ctx, audit.Query{Page: 1, Limit: 20})
+
+	assert.Nil(t, entries)
+	assert.EqualError(t, err, "db down")
+	client.AssertNotCalled(t, "Find", mock.Anything, mock.Anything)
+}
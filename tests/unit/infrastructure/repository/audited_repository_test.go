@@ -0,0 +1,139 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/heaveless/dbz-api/internal/domain/apikey"
+	"github.com/heaveless/dbz-api/internal/domain/audit"
+	"github.com/heaveless/dbz-api/internal/domain/event"
+	"github.com/heaveless/dbz-api/internal/domain/webhook"
+	repo "github.com/heaveless/dbz-api/internal/infrastructure/repositoy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+func TestAuditedApiKeyRepository_Save_Created(t *testing.T) {
+	ctx := audit.WithActor(context.Background(), admin)
+	keys := new(MockDbCollection)
+	result := new(MockSingleResult)
+	log := &recordedAudit{}
+	key := &apikey.ApiKeyEntity{Id: "k2", Name: "ci", Hash: "secret-hash", Prefix: "dbz_abcd1234", Scopes: []string{"read"}}
+
+	keys.On("FindOne", ctx, bson.M{"_id": "k2"}).Return(result, nil)
+	result.On("Decode", mock.Anything).Return(mongo.ErrNoDocuments)
+	keys.On("ReplaceOne", ctx, bson.M{"_id": "k2"}, key).Return(&mongo.UpdateResult{UpsertedCount: 1}, nil)
+
+	r := repo.NewAuditedApiKeyRepository(repo.NewApiKeyRepository(keys, new(MockDbCollection)), log)
+
+	assert.NoError(t, r.Save(ctx, key))
+	assert.Len(t, log.entries, 1)
+	entry := log.entries[0]
+	assert.Equal(t, admin, entry.Actor)
+	assert.Equal(t, audit.ApiKeyCreated, entry.Action)
+	assert.Equal(t, "apikey:k2", entry.Target)
+	for _, c := range entry.Changes {
+		assert.NotEqual(t, "secret-hash", c.After, "the hash never reaches the log")
+	}
+}
+
+func TestAuditedApiKeyRepository_Save_Revoked(t *testing.T) {
+	ctx := audit.WithActor(context.Background(), admin)
+	keys := new(MockDbCollection)
+	result := new(MockSingleResult)
+	log := &recordedAudit{}
+	revokedAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	stored := apikey.ApiKeyEntity{Id: "k2", Name: "ci"}
+	revoked := stored
+	revoked.RevokedAt = &revokedAt
+
+	keys.On("FindOne", ctx, bson.M{"_id": "k2"}).Return(result, nil)
+	result.
+		On("Decode", mock.Anything).
+		Run(func(args mock.Arguments) { *args.Get(0).(*apikey.ApiKeyEntity) = stored }).
+		Return(nil)
+	keys.On("ReplaceOne", ctx, bson.M{"_id": "k2"}, &revoked).Return(&mongo.UpdateResult{MatchedCount: 1}, nil)
+
+	r := repo.NewAuditedApiKeyRepository(repo.NewApiKeyRepository(keys, new(MockDbCollection)), log)
+
+	assert.NoError(t, r.Save(ctx, &revoked))
+	assert.Equal(t, audit.ApiKeyRevoked, log.entries[0].Action)
+	assert.Equal(t, []audit.Change{{Field: "revokedAt", After: "2024-05-01T10:00:00Z"}}, log.entries[0].Changes)
+}
+
+func TestAuditedApiKeyRepository_Save_Error(t *testing.T) {
+	ctx := context.Background()
+	keys := new(MockDbCollection)
+	result := new(MockSingleResult)
+	log := &recordedAudit{}
+
+	keys.On("FindOne", ctx, mock.Anything).Return(result, nil)
+	result.On("Decode", mock.Anything).Return(mongo.ErrNoDocuments)
+	keys.On("ReplaceOne", ctx, mock.Anything, mock.Anything).Return((*mongo.UpdateResult)(nil), errors.New("db down"))
+
+	r := repo.NewAuditedApiKeyRepository(repo.NewApiKeyRepository(keys, new(MockDbCollection)), log)
+
+	assert.EqualError(t, r.Save(ctx, &apikey.ApiKeyEntity{Id: "k2"}), "db down")
+	assert.Empty(t, log.entries, "nothing changed, so nothing is recorded")
+}
+
+func TestAuditedSubscriptionRepository_Save_Created(t *testing.T) {
+	ctx := audit.WithActor(context.Background(), admin)
+	client := new(MockDbCollection)
+	result := new(MockSingleResult)
+	log := &recordedAudit{}
+	sub := &webhook.SubscriptionEntity{Id: "w1", Url: "https://example.com/hook", Events: []event.Type{event.CharacterCached}, Secret: "s3cret-s3cret-s3cret"}
+
+	client.On("FindOne", ctx, bson.M{"_id": "w1"}).Return(result, nil)
+	result.On("Decode", mock.Anything).Return(mongo.ErrNoDocuments)
+	client.On("ReplaceOne", ctx, bson.M{"_id": "w1"}, sub).Return(&mongo.UpdateResult{UpsertedCount: 1}, nil)
+
+	r := repo.NewAuditedSubscriptionRepository(repo.NewWebhookRepository(client), log)
+
+	assert.NoError(t, r.Save(ctx, sub))
+	assert.Equal(t, []audit.Entry{{
+		Actor:  admin,
+		Action: audit.WebhookCreated,
+		Target: "webhook:w1",
+		Changes: []audit.Change{
+			{Field: "url", After: "https://example.com/hook"},
+			{Field: "events", After: "CharacterCached"},
+		},
+	}}, log.entries)
+}
+
+func TestAuditedApiKeyRepository_Save_AuditErrorKeepsTheSave(t *testing.T) {
+	ctx := context.Background()
+	keys := new(MockDbCollection)
+	result := new(MockSingleResult)
+	log := &recordedAudit{err: errors.New("db down")}
+
+	keys.On("FindOne", ctx, mock.Anything).Return(result, nil)
+	result.On("Decode", mock.Anything).Return(mongo.ErrNoDocuments)
+	keys.On("ReplaceOne", ctx, mock.Anything, mock.Anything).Return(&mongo.UpdateResult{UpsertedCount: 1}, nil)
+
+	r := repo.NewAuditedApiKeyRepository(repo.NewApiKeyRepository(keys, new(MockDbCollection)), log)
+
+	assert.NoError(t, r.Save(ctx, &apikey.ApiKeyEntity{Id: "k2"}), "the key is saved, so its raw value must reach the caller")
+	keys.AssertExpectations(t)
+}
+
+func TestAuditedSubscriptionRepository_Save_AuditErrorKeepsTheSave(t *testing.T) {
+	ctx := context.Background()
+	client := new(MockDbCollection)
+	result := new(MockSingleResult)
+	log := &recordedAudit{err: errors.New("db down")}
+
+	client.On("FindOne", ctx, mock.Anything).Return(result, nil)
+	result.On("Decode", mock.Anything).Return(mongo.ErrNoDocuments)
+	client.On("ReplaceOne", ctx, mock.Anything, mock.Anything).Return(&mongo.UpdateResult{UpsertedCount: 1}, nil)
+
+	r := repo.NewAuditedSubscriptionRepository(repo.NewWebhookRepository(client), log)
+
+	assert.NoError(t, r.Save(ctx, &webhook.SubscriptionEntity{Id: "w1"}), "the subscription is saved, so its secret must reach the caller")
+	client.AssertExpectations(t)
+}
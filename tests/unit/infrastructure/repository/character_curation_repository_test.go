@@ -0,0 +1,204 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/heaveless/dbz-api/internal/domain/audit"
+	domain "github.com/heaveless/dbz-api/internal/domain/character"
+	"github.com/heaveless/dbz-api/internal/domain/event"
+	repo "github.com/heaveless/dbz-api/internal/infrastructure/repositoy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+type recordedAudit struct {
+	entries []audit.Entry
+	err     error
+}
+
+func (a *recordedAudit) Record(_ context.Context, e audit.Entry) error {
+	a.entries = append(a.entries, e)
+	return a.err
+}
+
+var admin = audit.Actor{Id: "k1", Name: "ops"}
+
+func TestCurationRepository_GetById_NotFound(t *testing.T) {
+	ctx := context.Background()
+	client := new(MockDbCollection)
+	result := new(MockSingleResult)
+
+	client.On("FindOne", ctx, bson.M{"_id": int64(7)}).Return(result, nil)
+	result.On("Decode", mock.Anything).Return(mongo.ErrNoDocuments)
+
+	r := repo.NewCharacterCurationRepository(client, repo.NoTransaction{}, event.NopPublisher{}, event.NopOutbox{}, audit.NopLog{})
+
+	chr, err := r.GetById(ctx, 7)
+
+	assert.Nil(t, chr)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestCurationRepository_Insert(t *testing.T) {
+	ctx := audit.WithActor(context.Background(), admin)
+	client := new(MockDbCollection)
+	log := &recordedAudit{}
+
+	var stored *domain.CharacterEntity
+	client.
+		On("InsertOne", ctx, mock.AnythingOfType("*character.CharacterEntity")).
+		Run(func(args mock.Arguments) { stored = args.Get(1).(*domain.CharacterEntity) }).
+		Return(&mongo.InsertOneResult{InsertedID: int64(1001)}, nil)
+
+	r := repo.NewCharacterCurationRepository(client, repo.NoTransaction{}, event.NopPublisher{}, event.NopOutbox{}, log)
+
+	err := r.Insert(ctx, &domain.CharacterEntity{Id: 1001, Name: "Gogeta", Race: "Saiyan"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "gogeta", stored.NameKey)
+	assert.Equal(t, []audit.Entry{{
+		Actor:   admin,
+		Action:  audit.CharacterCreated,
+		Target:  "character:1001",
+		Changes: []audit.Change{{Field: "name", After: "Gogeta"}, {Field: "race", After: "Saiyan"}},
+	}}, log.entries)
+}
+
+func TestCurationRepository_Insert_Exists(t *testing.T) {
+	ctx := context.Background()
+	client := new(MockDbCollection)
+	log := &recordedAudit{}
+
+	client.On("InsertOne", ctx, mock.Anything).Return(&mongo.InsertOneResult{}, nil)
+
+	r := repo.NewCharacterCurationRepository(client, repo.NoTransaction{}, event.NopPublisher{}, event.NopOutbox{}, log)
+
+	err := r.Insert(ctx, &domain.CharacterEntity{Id: 1, Name: "Goku"})
+
+	assert.ErrorIs(t, err, domain.ErrExists)
+	assert.Empty(t, log.entries)
+}
+
+func TestCurationRepository_Update(t *testing.T) {
+	ctx := audit.WithActor(context.Background(), admin)
+	client := new(MockDbCollection)
+	log := &recordedAudit{}
+	readAt := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	before := &domain.CharacterEntity{Id: 2, Name: "Vegeta", Affiliation: "Army of Frieza", UpdatedAt: readAt}
+	after := &domain.CharacterEntity{Id: 2, Name: "Vegeta", Affiliation: "Z Fighter", UpdatedAt: readAt.Add(time.Hour)}
+
+	client.
+		On("ReplaceOne", ctx, bson.M{"_id": int64(2), "updatedAt": readAt}, mock.Anything).
+		Return(&mongo.UpdateResult{MatchedCount: 1, ModifiedCount: 1}, nil)
+
+	r := repo.NewCharacterCurationRepository(client, repo.NoTransaction{}, event.NopPublisher{}, event.NopOutbox{}, log)
+
+	err := r.Update(ctx, before, after)
+
+	assert.NoError(t, err)
+	if assert.Len(t, log.entries, 1) {
+		assert.Equal(t, audit.CharacterUpdated, log.entries[0].Action)
+		assert.Equal(t, []audit.Change{{Field: "affiliation", Before: "Army of Frieza", After: "Z Fighter"}}, log.entries[0].Changes)
+	}
+}
+
+func TestCurationRepository_Update_Conflict(t *testing.T) {
+	ctx := context.Background()
+	client := new(MockDbCollection)
+	log := &recordedAudit{}
+
+	client.On("ReplaceOne", ctx, mock.Anything, mock.Anything).Return(&mongo.UpdateResult{}, nil)
+
+	r := repo.NewCharacterCurationRepository(client, repo.NoTransaction{}, event.NopPublisher{}, event.NopOutbox{}, log)
+
+	err := r.Update(ctx, &domain.CharacterEntity{Id: 2}, &domain.CharacterEntity{Id: 2, Name: "Vegeta"})
+
+	assert.ErrorIs(t, err, domain.ErrConflict)
+	assert.Empty(t, log.entries)
+}
+
+func TestCurationRepository_Delete(t *testing.T) {
+	ctx := context.Background()
+	client := new(MockDbCollection)
+	log := &recordedAudit{}
+	readAt := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	client.
+		On("DeleteOne", ctx, bson.M{"_id": int64(2), "updatedAt": readAt}).
+		Return(&mongo.DeleteResult{DeletedCount: 1}, nil)
+
+	r := repo.NewCharacterCurationRepository(client, repo.NoTransaction{}, event.NopPublisher{}, event.NopOutbox{}, log)
+
+	err := r.Delete(ctx, &domain.CharacterEntity{Id: 2, Name: "Vegeta", UpdatedAt: readAt})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []audit.Entry{{
+		Actor:   audit.System,
+		Action:  audit.CharacterDeleted,
+		Target:  "character:2",
+		Changes: []audit.Change{{Field: "name", Before: "Vegeta"}},
+	}}, log.entries)
+}
+
+func TestCurationRepository_EmitsEvents(t *testing.T) {
+	ctx := context.Background()
+	client := new(MockDbCollection)
+	var events recordedEvents
+	outbox := &recordedOutbox{}
+
+	client.On("InsertOne", ctx, mock.Anything).Return(&mongo.InsertOneResult{InsertedID: int64(1001)}, nil)
+	client.On("DeleteOne", ctx, mock.Anything).Return(&mongo.DeleteResult{DeletedCount: 1}, nil)
+
+	r := repo.NewCharacterCurationRepository(client, repo.NoTransaction{}, &events, outbox, audit.NopLog{})
+
+	assert.NoError(t, r.Insert(ctx, &domain.CharacterEntity{Id: 1001, Name: "Gogeta"}))
+	assert.NoError(t, r.Delete(ctx, &domain.CharacterEntity{Id: 2, Name: "Vegeta"}))
+
+	if assert.Len(t, events, 2) {
+		assert.Equal(t, event.CharacterCreated, events[0].Type)
+		assert.Equal(t, "Gogeta", events[0].Data.(domain.CharacterEntity).Name)
+		assert.Equal(t, event.CharacterDeleted, events[1].Type)
+		assert.Equal(t, "Vegeta", events[1].Data.(domain.CharacterEntity).Name)
+	}
+	assert.Equal(t, events, outbox.events)
+}
+
+func TestCurationRepository_CommittedWritesSucceed(t *testing.T) {
+	ctx := context.Background()
+	client := new(MockDbCollection)
+	log := &recordedAudit{err: errors.New("db error")}
+
+	client.On("DeleteOne", ctx, mock.Anything).Return(&mongo.DeleteResult{DeletedCount: 1}, nil)
+
+	r := repo.NewCharacterCurationRepository(client, repo.NoTransaction{}, event.NopPublisher{}, &recordedOutbox{}, log)
+
+	// The delete is already committed: failing to audit it is only logged.
+	err := r.Delete(ctx, &domain.CharacterEntity{Id: 2})
+
+	assert.NoError(t, err)
+	assert.Len(t, log.entries, 1)
+}
+
+func TestCurationRepository_OutboxFailureFailsTheWrite(t *testing.T) {
+	ctx := context.Background()
+	client := new(MockDbCollection)
+	tx := &recordedTx{}
+	var events recordedEvents
+	log := &recordedAudit{}
+
+	client.On("DeleteOne", ctx, mock.Anything).Return(&mongo.DeleteResult{DeletedCount: 1}, nil)
+
+	r := repo.NewCharacterCurationRepository(client, tx, &events, &recordedOutbox{err: errors.New("db error")}, log)
+
+	err := r.Delete(ctx, &domain.CharacterEntity{Id: 2})
+
+	assert.EqualError(t, err, "db error")
+	assert.Equal(t, []error{err}, tx.errs, "the delete is rolled back with its transaction")
+	assert.Empty(t, events)
+	assert.Empty(t, log.entries)
+}
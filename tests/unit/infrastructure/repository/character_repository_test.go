@@ -3,19 +3,26 @@ package repository_test
 import (
 	"context"
 	"errors"
+	"reflect"
 	"testing"
+	"time"
 
+	"github.com/heaveless/dbz-api/internal/domain/audit"
 	domain "github.com/heaveless/dbz-api/internal/domain/character"
+	"github.com/heaveless/dbz-api/internal/domain/event"
 	"github.com/heaveless/dbz-api/internal/infrastructure/breaker"
 	repo "github.com/heaveless/dbz-api/internal/infrastructure/repositoy"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
 	"go.mongodb.org/mongo-driver/v2/mongo/options"
 )
 
 type MockDbCollection struct {
 	mock.Mock
+	// finds holds the options of every Find, in call order.
+	finds []options.FindOptions
 }
 
 func (m *MockDbCollection) FindOne(
@@ -27,6 +34,32 @@ func (m *MockDbCollection) FindOne(
 	return args.Get(0).(breaker.SingleResult), args.Error(1)
 }
 
+func (m *MockDbCollection) Find(
+	ctx context.Context,
+	filter any,
+	opts ...options.Lister[options.FindOptions],
+) (breaker.Cursor, error) {
+	var o options.FindOptions
+	for _, lister := range opts {
+		for _, set := range lister.List() {
+			_ = set(&o)
+		}
+	}
+	m.finds = append(m.finds, o)
+
+	args := m.Called(ctx, filter)
+	return args.Get(0).(breaker.Cursor), args.Error(1)
+}
+
+func (m *MockDbCollection) CountDocuments(
+	ctx context.Context,
+	filter any,
+	opts ...options.Lister[options.CountOptions],
+) (int64, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 func (m *MockDbCollection) InsertOne(
 	ctx context.Context,
 	document any,
@@ -36,6 +69,35 @@ func (m *MockDbCollection) InsertOne(
 	return args.Get(0).(*mongo.InsertOneResult), args.Error(1)
 }
 
+func (m *MockDbCollection) ReplaceOne(
+	ctx context.Context,
+	filter any,
+	replacement any,
+	opts ...options.Lister[options.ReplaceOptions],
+) (*mongo.UpdateResult, error) {
+	args := m.Called(ctx, filter, replacement)
+	return args.Get(0).(*mongo.UpdateResult), args.Error(1)
+}
+
+func (m *MockDbCollection) FindOneAndUpdate(
+	ctx context.Context,
+	filter any,
+	update any,
+	opts ...options.Lister[options.FindOneAndUpdateOptions],
+) (breaker.SingleResult, error) {
+	args := m.Called(ctx, filter, update)
+	return args.Get(0).(breaker.SingleResult), args.Error(1)
+}
+
+func (m *MockDbCollection) DeleteOne(
+	ctx context.Context,
+	filter any,
+	opts ...options.Lister[options.DeleteOneOptions],
+) (*mongo.DeleteResult, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(*mongo.DeleteResult), args.Error(1)
+}
+
 type MockSingleResult struct {
 	mock.Mock
 }
@@ -50,6 +112,15 @@ func (m *MockSingleResult) Err() error {
 	return args.Error(0)
 }
 
+type MockCursor struct {
+	mock.Mock
+}
+
+func (m *MockCursor) All(ctx context.Context, results any) error {
+	args := m.Called(ctx, results)
+	return args.Error(0)
+}
+
 func TestCharacterRepository_Create_OK(t *testing.T) {
 	ctx := context.Background()
 	mockClient := new(MockDbCollection)
@@ -94,6 +165,281 @@ func TestCharacterRepository_Create_Error(t *testing.T) {
 	mockClient.AssertExpectations(t)
 }
 
+type recordedEvents []event.Event
+
+func (r *recordedEvents) Publish(e event.Event) { *r = append(*r, e) }
+
+type recordedOutbox struct {
+	events recordedEvents
+	err    error
+}
+
+func (o *recordedOutbox) Record(_ context.Context, e event.Event) error {
+	o.events = append(o.events, e)
+	return o.err
+}
+
+func TestCharacterRepository_Create_PublishesCached(t *testing.T) {
+	ctx := context.Background()
+	mockClient := new(MockDbCollection)
+	var events recordedEvents
+	outbox := &recordedOutbox{}
+
+	mockClient.
+		On("InsertOne", ctx, mock.Anything).
+		Return(&mongo.InsertOneResult{InsertedID: int64(1)}, nil)
+
+	r := repo.NewCharacterRepositoryWithEvents(mockClient, repo.NoTransaction{}, &events, outbox)
+
+	err := r.Create(ctx, &domain.CharacterEntity{Id: 1, Name: "Goku"})
+
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+	assert.Equal(t, event.CharacterCached, events[0].Type)
+	assert.Equal(t, "goku", events[0].Data.(domain.CharacterEntity).NameKey)
+	assert.False(t, events[0].At.IsZero())
+	assert.Equal(t, events, outbox.events)
+	mockClient.AssertNotCalled(t, "ReplaceOne", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// recordedTx counts the transactions it runs and passes on how they end.
+type recordedTx struct {
+	runs int
+	errs []error
+}
+
+func (tx *recordedTx) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx.runs++
+	err := fn(ctx)
+	tx.errs = append(tx.errs, err)
+	return err
+}
+
+func TestCharacterRepository_Create_OutboxFailureFailsTheWrite(t *testing.T) {
+	ctx := context.Background()
+	mockClient := new(MockDbCollection)
+	tx := &recordedTx{}
+	var events recordedEvents
+	outbox := &recordedOutbox{err: errors.New("db error")}
+	log := &recordedAudit{}
+
+	mockClient.
+		On("InsertOne", ctx, mock.Anything).
+		Return(&mongo.InsertOneResult{InsertedID: int64(1)}, nil)
+
+	r := repo.NewCharacterRepositoryWithAudit(mockClient, tx, &events, outbox, log)
+
+	err := r.Create(ctx, &domain.CharacterEntity{Id: 1, Name: "Goku"})
+
+	assert.EqualError(t, err, "db error")
+	assert.Equal(t, []error{err}, tx.errs, "the insert is rolled back with its transaction")
+	assert.Empty(t, events, "an event that was never recorded is not published")
+	assert.Empty(t, log.entries)
+}
+
+func TestCharacterRepository_Create_RefreshesOlderCopy(t *testing.T) {
+	tests := []struct {
+		name   string
+		stored error
+		want   []event.Type
+	}{
+		{name: "stored copy is older", want: []event.Type{event.CharacterRefreshed}},
+		{name: "stored copy is as new", stored: mongo.ErrNoDocuments, want: []event.Type{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			mockClient := new(MockDbCollection)
+			result := new(MockSingleResult)
+			tx := &recordedTx{}
+			var events recordedEvents
+			outbox := &recordedOutbox{}
+			updatedAt := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+			mockClient.
+				On("InsertOne", ctx, mock.Anything).
+				Return(&mongo.InsertOneResult{}, nil)
+			mockClient.
+				On("FindOneAndUpdate", ctx, bson.M{"_id": int64(1), "updatedAt": bson.M{"$lt": updatedAt}}, mock.Anything).
+				Return(result, nil)
+			result.
+				On("Decode", mock.AnythingOfType("*character.CharacterEntity")).
+				Run(func(args mock.Arguments) {
+					*args.Get(0).(*domain.CharacterEntity) = domain.CharacterEntity{Id: 1, Name: "Goku", UpdatedAt: updatedAt}
+				}).
+				Return(tt.stored)
+
+			r := repo.NewCharacterRepositoryWithEvents(mockClient, tx, &events, outbox)
+
+			err := r.Create(ctx, &domain.CharacterEntity{Id: 1, Name: "Goku", UpdatedAt: updatedAt})
+
+			assert.NoError(t, err)
+			assert.Equal(t, 2, tx.runs, "the refresh runs in a transaction of its own")
+			assert.Equal(t, events, outbox.events)
+			types := []event.Type{}
+			for _, e := range events {
+				types = append(types, e.Type)
+			}
+			assert.Equal(t, tt.want, types)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCharacterRepository_Create_RefreshKeepsCuratedFields(t *testing.T) {
+	ctx := context.Background()
+	mockClient := new(MockDbCollection)
+	result := new(MockSingleResult)
+
+	var pipeline bson.A
+	mockClient.
+		On("InsertOne", ctx, mock.Anything).
+		Return(&mongo.InsertOneResult{}, nil)
+	mockClient.
+		On("FindOneAndUpdate", ctx, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { pipeline = args.Get(2).(bson.A) }).
+		Return(result, nil)
+	result.On("Decode", mock.Anything).Return(mongo.ErrNoDocuments)
+
+	r := repo.NewCharacterRepository(mockClient)
+
+	err := r.Create(ctx, &domain.CharacterEntity{Id: 1, Name: "Goku", Ki: "9000", Affiliation: "$Z Fighter", UpdatedAt: time.Now()})
+
+	assert.NoError(t, err)
+	set := pipeline[0].(bson.M)["$set"].(bson.M)
+	curated := bson.M{"$ifNull": bson.A{"$overridden", bson.A{}}}
+	assert.Equal(t, bson.M{"$cond": bson.A{
+		bson.M{"$in": bson.A{"affiliation", curated}},
+		"$affiliation",
+		bson.M{"$literal": "$Z Fighter"},
+	}}, set["affiliation"])
+	assert.Equal(t, bson.M{"$cond": bson.A{
+		bson.M{"$in": bson.A{"name", curated}},
+		"$nameKey",
+		bson.M{"$literal": "goku"},
+	}}, set["nameKey"])
+	assert.Equal(t, bson.M{"$cond": bson.A{
+		bson.M{"$in": bson.A{"ki", curated}},
+		"$kiRank",
+		bson.M{"$literal": domain.LenientPowerLevel("9000").Rank()},
+	}}, set["kiRank"])
+}
+
+func TestCharacterRepository_Create_AuditsCached(t *testing.T) {
+	ctx := context.Background()
+	mockClient := new(MockDbCollection)
+	log := &recordedAudit{}
+
+	mockClient.
+		On("InsertOne", ctx, mock.Anything).
+		Return(&mongo.InsertOneResult{InsertedID: int64(1)}, nil)
+
+	r := repo.NewCharacterRepositoryWithAudit(mockClient, repo.NoTransaction{}, event.NopPublisher{}, event.NopOutbox{}, log)
+
+	err := r.Create(ctx, &domain.CharacterEntity{Id: 1, Name: "Goku", Race: "Saiyan"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []audit.Entry{{
+		Actor:  audit.Upstream,
+		Action: audit.CharacterCached,
+		Target: "character:1",
+		Changes: []audit.Change{
+			{Field: "name", After: "Goku"},
+			{Field: "race", After: "Saiyan"},
+		},
+	}}, log.entries)
+}
+
+func TestCharacterRepository_Create_AuditErrorKeepsTheInsert(t *testing.T) {
+	ctx := context.Background()
+	mockClient := new(MockDbCollection)
+	var events recordedEvents
+
+	mockClient.
+		On("InsertOne", ctx, mock.Anything).
+		Return(&mongo.InsertOneResult{InsertedID: int64(1)}, nil)
+
+	r := repo.NewCharacterRepositoryWithAudit(mockClient, repo.NoTransaction{}, &events, event.NopOutbox{}, &recordedAudit{err: errors.New("db down")})
+
+	assert.NoError(t, r.Create(ctx, &domain.CharacterEntity{Id: 1, Name: "Goku"}))
+	assert.Len(t, events, 1, "the event still goes out")
+}
+
+func TestCharacterRepository_Create_AuditsRefresh(t *testing.T) {
+	ctx := context.Background()
+	mockClient := new(MockDbCollection)
+	result := new(MockSingleResult)
+	var events recordedEvents
+	log := &recordedAudit{}
+	stored := domain.CharacterEntity{
+		Id:          2,
+		Name:        "Vegeta",
+		Race:        "Saiyan",
+		Affiliation: "Army of Frieza",
+		Description: "Prince of the Saiyans",
+		Overridden:  []string{"description"},
+	}
+	fetchedAt := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	mockClient.
+		On("InsertOne", ctx, mock.Anything).
+		Return(&mongo.InsertOneResult{}, nil)
+	mockClient.
+		On("FindOneAndUpdate", ctx, mock.Anything, mock.Anything).
+		Return(result, nil)
+	result.
+		On("Decode", mock.AnythingOfType("*character.CharacterEntity")).
+		Run(func(args mock.Arguments) { *args.Get(0).(*domain.CharacterEntity) = stored }).
+		Return(nil)
+
+	r := repo.NewCharacterRepositoryWithAudit(mockClient, repo.NoTransaction{}, &events, event.NopOutbox{}, log)
+
+	err := r.Create(ctx, &domain.CharacterEntity{
+		Id:          2,
+		Name:        "Vegeta",
+		Race:        "Saiyan",
+		Affiliation: "Z Fighter",
+		Description: "upstream text",
+		UpdatedAt:   fetchedAt,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []audit.Entry{{
+		Actor:   audit.Upstream,
+		Action:  audit.CharacterRefreshed,
+		Target:  "character:2",
+		Changes: []audit.Change{{Field: "affiliation", Before: "Army of Frieza", After: "Z Fighter"}},
+	}}, log.entries, "the curated description is left alone")
+	refreshed := events[0].Data.(domain.CharacterEntity)
+	assert.Equal(t, "Prince of the Saiyans", refreshed.Description)
+	assert.Equal(t, fetchedAt, refreshed.UpdatedAt)
+}
+
+func TestCharacterRepository_Create_RefreshWithoutChanges(t *testing.T) {
+	ctx := context.Background()
+	mockClient := new(MockDbCollection)
+	result := new(MockSingleResult)
+	log := &recordedAudit{}
+	stored := domain.CharacterEntity{Id: 1, Name: "Goku"}
+
+	mockClient.
+		On("InsertOne", ctx, mock.Anything).
+		Return(&mongo.InsertOneResult{}, nil)
+	mockClient.
+		On("FindOneAndUpdate", ctx, mock.Anything, mock.Anything).
+		Return(result, nil)
+	result.
+		On("Decode", mock.Anything).
+		Run(func(args mock.Arguments) { *args.Get(0).(*domain.CharacterEntity) = stored }).
+		Return(nil)
+
+	r := repo.NewCharacterRepositoryWithAudit(mockClient, repo.NoTransaction{}, event.NopPublisher{}, event.NopOutbox{}, log)
+
+	assert.NoError(t, r.Create(ctx, &domain.CharacterEntity{Id: 1, Name: "Goku", UpdatedAt: time.Now()}))
+	assert.Empty(t, log.entries)
+}
+
 func TestCharacterRepository_Get_OK(t *testing.T) {
 	ctx := context.Background()
 	mockClient := new(MockDbCollection)
@@ -178,3 +524,278 @@ func TestCharacterRepository_Get_DecodeError(t *testing.T) {
 	mockClient.AssertExpectations(t)
 	mockResult.AssertExpectations(t)
 }
+
+func TestCharacterRepository_Create_StoresNormalizedKey(t *testing.T) {
+	ctx := context.Background()
+	mockClient := new(MockDbCollection)
+
+	mockClient.
+		On("InsertOne", ctx, mock.MatchedBy(func(doc *domain.CharacterEntity) bool {
+			return doc.Name == "Vegéta" && doc.NameKey == "vegeta"
+		})).
+		Return(&mongo.InsertOneResult{InsertedID: int64(2)}, nil)
+
+	r := repo.NewCharacterRepository(mockClient)
+
+	err := r.Create(ctx, &domain.CharacterEntity{Id: 2, Name: "Vegéta"})
+
+	assert.NoError(t, err)
+	mockClient.AssertExpectations(t)
+}
+
+func TestCharacterRepository_Get_QueriesNormalizedKey(t *testing.T) {
+	tests := []string{"goku", " Goku ", "GOKU"}
+
+	for _, name := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			mockClient := new(MockDbCollection)
+			mockResult := new(MockSingleResult)
+
+			mockClient.
+				On("FindOne", ctx, bson.M{"nameKey": "goku"}).
+				Return(mockResult, nil)
+
+			mockResult.
+				On("Decode", mock.Anything).
+				Return(nil)
+
+			r := repo.NewCharacterRepository(mockClient)
+
+			_, err := r.Get(ctx, name)
+
+			assert.NoError(t, err)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCharacterRepository_List_OK(t *testing.T) {
+	ctx := context.Background()
+	mockClient := new(MockDbCollection)
+	mockCursor := new(MockCursor)
+
+	mockClient.
+		On("Find", ctx, bson.M{}).
+		Return(mockCursor, nil)
+
+	mockCursor.
+		On("All", ctx, mock.AnythingOfType("*[]character.CharacterEntity")).
+		Run(func(args mock.Arguments) {
+			arg := args.Get(1).(*[]domain.CharacterEntity)
+			*arg = []domain.CharacterEntity{{Id: 1, Name: "Goku"}, {Id: 2, Name: "Vegeta"}}
+		}).
+		Return(nil)
+
+	r := repo.NewCharacterRepository(mockClient)
+
+	res, err := r.List(ctx)
+
+	assert.NoError(t, err)
+	assert.Len(t, res, 2)
+	assert.Equal(t, "Vegeta", res[1].Name)
+	mockClient.AssertExpectations(t)
+	mockCursor.AssertExpectations(t)
+}
+
+func TestCharacterRepository_List_FindError(t *testing.T) {
+	ctx := context.Background()
+	mockClient := new(MockDbCollection)
+
+	mockClient.
+		On("Find", ctx, bson.M{}).
+		Return((*MockCursor)(nil), errors.New("find error"))
+
+	r := repo.NewCharacterRepository(mockClient)
+
+	res, err := r.List(ctx)
+
+	assert.Nil(t, res)
+	assert.EqualError(t, err, "find error")
+}
+
+// returning makes a cursor that yields records.
+func returning(records ...domain.CharacterEntity) *MockCursor {
+	cur := new(MockCursor)
+	cur.
+		On("All", mock.Anything, mock.AnythingOfType("*[]character.CharacterEntity")).
+		Run(func(args mock.Arguments) { *args.Get(1).(*[]domain.CharacterEntity) = records }).
+		Return(nil)
+
+	return cur
+}
+
+func TestCharacterRepository_ListPage_QueriesThePage(t *testing.T) {
+	ctx := context.Background()
+	mockClient := new(MockDbCollection)
+	minKi := domain.LenientPowerLevel("1 Million")
+
+	filter := bson.M{"kiRank": bson.M{"$gt": "", "$gte": minKi.Rank()}}
+	mockClient.On("CountDocuments", ctx, filter).Return(int64(5), nil)
+	mockClient.On("Find", ctx, filter).Return(returning(domain.CharacterEntity{Id: 3, Name: "Piccolo"}), nil)
+
+	r := repo.NewCharacterRepository(mockClient)
+
+	res, total, err := r.ListPage(ctx, domain.ListQuery{
+		MinKi: &minKi,
+		Sort:  domain.SortOrder{Field: "name", Desc: true},
+		Page:  2,
+		Limit: 2,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), total)
+	assert.Len(t, res, 1)
+	if assert.Len(t, mockClient.finds, 1) {
+		assert.Equal(t, bson.D{{Key: "nameKey", Value: -1}, {Key: "_id", Value: 1}}, mockClient.finds[0].Sort)
+		assert.Equal(t, int64(2), *mockClient.finds[0].Skip)
+		assert.Equal(t, int64(2), *mockClient.finds[0].Limit)
+	}
+}
+
+func TestCharacterRepository_ListPage_UnknownPowerComesLast(t *testing.T) {
+	ctx := context.Background()
+	mockClient := new(MockDbCollection)
+
+	known := bson.M{"$and": bson.A{bson.M{}, bson.M{"maxKiRank": bson.M{"$gt": ""}}}}
+	unknown := bson.M{"$and": bson.A{bson.M{}, bson.M{"maxKiRank": ""}}}
+	mockClient.On("CountDocuments", ctx, bson.M{}).Return(int64(5), nil)
+	mockClient.On("CountDocuments", ctx, known).Return(int64(3), nil)
+	mockClient.On("Find", ctx, known).Return(returning(domain.CharacterEntity{Id: 3, Name: "Piccolo"}), nil)
+	mockClient.On("Find", ctx, unknown).Return(returning(domain.CharacterEntity{Id: 4, Name: "Bulma"}), nil)
+
+	r := repo.NewCharacterRepository(mockClient)
+
+	res, total, err := r.ListPage(ctx, domain.ListQuery{
+		Sort:  domain.SortOrder{Field: "maxKi", Desc: true},
+		Page:  2,
+		Limit: 2,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), total)
+	assert.Equal(t, []domain.CharacterEntity{{Id: 3, Name: "Piccolo"}, {Id: 4, Name: "Bulma"}}, res)
+	if assert.Len(t, mockClient.finds, 2) {
+		assert.Equal(t, bson.D{{Key: "maxKiRank", Value: -1}, {Key: "_id", Value: 1}}, mockClient.finds[0].Sort)
+		assert.Equal(t, int64(2), *mockClient.finds[0].Skip)
+		assert.Equal(t, bson.D{{Key: "_id", Value: 1}}, mockClient.finds[1].Sort)
+		assert.Equal(t, int64(0), *mockClient.finds[1].Skip, "the unknown ones start where the known ones end")
+		assert.Equal(t, int64(1), *mockClient.finds[1].Limit)
+	}
+}
+
+func TestCharacterRepository_TextSearch_OK(t *testing.T) {
+	ctx := context.Background()
+	mockClient := new(MockDbCollection)
+	mockCursor := new(MockCursor)
+
+	filter := bson.M{"$text": bson.M{"$search": "saiyan"}}
+
+	mockClient.
+		On("CountDocuments", ctx, filter).
+		Return(int64(3), nil)
+
+	mockClient.
+		On("Find", ctx, filter).
+		Return(mockCursor, nil)
+
+	mockCursor.
+		On("All", ctx, mock.Anything).
+		Run(func(args mock.Arguments) {
+			decodeInto(t, args.Get(1), bson.M{"_id": int64(1), "name": "Goku", "race": "Saiyan", "score": 1.5})
+		}).
+		Return(nil)
+
+	r := repo.NewCharacterRepository(mockClient)
+
+	matches, total, err := r.TextSearch(ctx, domain.TextSearchQuery{Text: "saiyan", Offset: 0, Limit: 10})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+	assert.Len(t, matches, 1)
+	assert.Equal(t, "Goku", matches[0].Character.Name)
+	assert.Equal(t, "Saiyan", matches[0].Character.Race)
+	assert.Equal(t, 1.5, matches[0].Score)
+	mockClient.AssertExpectations(t)
+	mockCursor.AssertExpectations(t)
+}
+
+func TestCharacterRepository_TextSearch_CountError(t *testing.T) {
+	ctx := context.Background()
+	mockClient := new(MockDbCollection)
+
+	mockClient.
+		On("CountDocuments", ctx, mock.Anything).
+		Return(int64(0), errors.New("text index required"))
+
+	r := repo.NewCharacterRepository(mockClient)
+
+	matches, total, err := r.TextSearch(ctx, domain.TextSearchQuery{Text: "saiyan", Limit: 10})
+
+	assert.Nil(t, matches)
+	assert.Zero(t, total)
+	assert.EqualError(t, err, "text index required")
+	mockClient.AssertNotCalled(t, "Find", mock.Anything, mock.Anything)
+}
+
+// decodeInto appends the BSON round trip of each doc to the slice behind
+// results, mimicking mongo.Cursor.All for element types we cannot name.
+func decodeInto(t *testing.T, results any, docs ...bson.M) {
+	t.Helper()
+
+	slice := reflect.ValueOf(results).Elem()
+	for _, doc := range docs {
+		raw, err := bson.Marshal(doc)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		elem := reflect.New(slice.Type().Elem())
+		if err := bson.Unmarshal(raw, elem.Interface()); err != nil {
+			t.Fatal(err)
+		}
+
+		slice.Set(reflect.Append(slice, elem.Elem()))
+	}
+}
+
+func TestCharacterRepository_GetById_OK(t *testing.T) {
+	ctx := context.Background()
+	mockClient := new(MockDbCollection)
+	mockResult := new(MockSingleResult)
+
+	mockClient.
+		On("FindOne", ctx, bson.M{"_id": int64(3)}).
+		Return(mockResult, nil)
+
+	mockResult.
+		On("Decode", mock.AnythingOfType("*character.CharacterEntity")).
+		Run(func(args mock.Arguments) {
+			*args.Get(0).(*domain.CharacterEntity) = domain.CharacterEntity{Id: 3, Name: "Vegeta"}
+		}).
+		Return(nil)
+
+	r := repo.NewCharacterRepository(mockClient)
+
+	res, err := r.GetById(ctx, 3)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Vegeta", res.Name)
+	mockClient.AssertExpectations(t)
+}
+
+func TestCharacterRepository_GetById_FindError(t *testing.T) {
+	ctx := context.Background()
+	mockClient := new(MockDbCollection)
+
+	mockClient.
+		On("FindOne", ctx, mock.Anything).
+		Return((*MockSingleResult)(nil), errors.New("find error"))
+
+	r := repo.NewCharacterRepository(mockClient)
+
+	res, err := r.GetById(ctx, 3)
+
+	assert.Nil(t, res)
+	assert.EqualError(t, err, "find error")
+}
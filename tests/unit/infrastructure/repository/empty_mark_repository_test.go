@@ -0,0 +1,52 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	repo "github.com/heaveless/dbz-api/internal/infrastructure/repositoy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+func TestEmptyMarkRepository_Mark_Upserts(t *testing.T) {
+	ctx := context.Background()
+	mockClient := new(MockDbCollection)
+
+	mockClient.
+		On("ReplaceOne", ctx, bson.M{"_id": "planet list:{}"}, mock.Anything).
+		Return(&mongo.UpdateResult{UpsertedCount: 1}, nil)
+
+	r := repo.NewEmptyMarkRepository(mockClient)
+
+	assert.NoError(t, r.Mark(ctx, "planet list:{}"))
+	mockClient.AssertExpectations(t)
+}
+
+func TestEmptyMarkRepository_Marked(t *testing.T) {
+	ctx := context.Background()
+	mockClient := new(MockDbCollection)
+	mockCursor := new(MockCursor)
+
+	keys := []string{"t:1", "t:2"}
+	mockClient.
+		On("Find", ctx, bson.M{"_id": bson.M{"$in": keys}}).
+		Return(mockCursor, nil)
+
+	mockCursor.
+		On("All", ctx, mock.Anything).
+		Run(func(args mock.Arguments) {
+			decodeInto(t, args.Get(1), bson.M{"_id": "t:2"})
+		}).
+		Return(nil)
+
+	r := repo.NewEmptyMarkRepository(mockClient)
+
+	marked, err := r.Marked(ctx, keys)
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"t:2": true}, marked)
+}
@@ -0,0 +1,138 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "github.com/heaveless/dbz-api/internal/domain/character"
+	"github.com/heaveless/dbz-api/internal/domain/event"
+	repo "github.com/heaveless/dbz-api/internal/infrastructure/repositoy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+func TestOutboxRepository_Record(t *testing.T) {
+	ctx := context.Background()
+	client := new(MockDbCollection)
+	at := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	var entry *event.OutboxEntry
+	client.
+		On("InsertOne", ctx, mock.AnythingOfType("*event.OutboxEntry")).
+		Run(func(args mock.Arguments) { entry = args.Get(1).(*event.OutboxEntry) }).
+		Return(&mongo.InsertOneResult{}, nil)
+
+	r := repo.NewOutboxRepository(client)
+
+	err := r.Record(ctx, event.Event{Type: event.CharacterCached, At: at, Data: domain.CharacterEntity{Id: 1, Name: "Goku"}})
+
+	assert.NoError(t, err)
+	_, err = bson.ObjectIDFromHex(entry.Key)
+	assert.NoError(t, err, entry.Key)
+	assert.Equal(t, event.CharacterCached, entry.Type)
+	assert.Equal(t, at, entry.At)
+	assert.Equal(t, at, entry.NextAttemptAt)
+	assert.Empty(t, entry.DeliveredTo)
+	assert.JSONEq(t, `{"id":1,"name":"Goku","ki":"","maxKi":"","race":"","gender":"","description":"","image":"","affiliation":""}`, entry.Data)
+}
+
+func TestOutboxRepository_Record_KeysAreUnique(t *testing.T) {
+	ctx := context.Background()
+	client := new(MockDbCollection)
+
+	keys := map[string]bool{}
+	client.
+		On("InsertOne", ctx, mock.Anything).
+		Run(func(args mock.Arguments) { keys[args.Get(1).(*event.OutboxEntry).Key] = true }).
+		Return(&mongo.InsertOneResult{}, nil)
+
+	r := repo.NewOutboxRepository(client)
+	for range 3 {
+		assert.NoError(t, r.Record(ctx, event.Event{Type: event.CharacterCached}))
+	}
+
+	assert.Len(t, keys, 3)
+}
+
+func TestOutboxRepository_Pending(t *testing.T) {
+	ctx := context.Background()
+	client := new(MockDbCollection)
+	cursor := new(MockCursor)
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	client.
+		On("Find", ctx, bson.M{"nextAttemptAt": bson.M{"$lte": now}}).
+		Return(cursor, nil)
+	cursor.
+		On("All", ctx, mock.Anything).
+		Run(func(args mock.Arguments) {
+			*args.Get(1).(*[]event.OutboxEntry) = []event.OutboxEntry{{Key: "a"}, {Key: "b"}}
+		}).
+		Return(nil)
+
+	r := repo.NewOutboxRepository(client)
+
+	entries, err := r.Pending(ctx, now, 10)
+
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+}
+
+func TestOutboxRepository_Delivered(t *testing.T) {
+	ctx := context.Background()
+	client := new(MockDbCollection)
+	result := new(MockSingleResult)
+	at := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	client.
+		On("FindOneAndUpdate", ctx, bson.M{"_id": "a"}, bson.M{
+			"$addToSet": bson.M{"deliveredTo": bson.M{"$each": []string{"webhook"}}},
+			"$set":      bson.M{"deliveredAt": at},
+			"$unset":    bson.M{"nextAttemptAt": "", "lastError": ""},
+		}).
+		Return(result, nil)
+	result.On("Err").Return(nil)
+
+	r := repo.NewOutboxRepository(client)
+
+	assert.NoError(t, r.Delivered(ctx, "a", []string{"webhook"}, at))
+	client.AssertExpectations(t)
+}
+
+func TestOutboxRepository_Retry(t *testing.T) {
+	ctx := context.Background()
+	client := new(MockDbCollection)
+	result := new(MockSingleResult)
+	at := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	client.
+		On("FindOneAndUpdate", ctx, bson.M{"_id": "a"}, bson.M{
+			"$addToSet": bson.M{"deliveredTo": bson.M{"$each": []string{"stdout"}}},
+			"$inc":      bson.M{"attempts": 1},
+			"$set":      bson.M{"nextAttemptAt": at, "lastError": "webhook: timeout"},
+		}).
+		Return(result, nil)
+	result.On("Err").Return(nil)
+
+	r := repo.NewOutboxRepository(client)
+
+	assert.NoError(t, r.Retry(ctx, "a", []string{"stdout"}, at, "webhook: timeout"))
+	client.AssertExpectations(t)
+}
+
+func TestOutboxRepository_Retry_Error(t *testing.T) {
+	ctx := context.Background()
+	client := new(MockDbCollection)
+
+	client.
+		On("FindOneAndUpdate", ctx, mock.Anything, mock.Anything).
+		Return((*MockSingleResult)(nil), errors.New("db down"))
+
+	r := repo.NewOutboxRepository(client)
+
+	assert.EqualError(t, r.Retry(ctx, "a", nil, time.Now(), "x"), "db down")
+}
@@ -0,0 +1,141 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	planet "github.com/heaveless/dbz-api/internal/domain/planet"
+	repo "github.com/heaveless/dbz-api/internal/infrastructure/repositoy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+func TestPlanetRepository_Save_Upserts(t *testing.T) {
+	ctx := context.Background()
+	mockClient := new(MockDbCollection)
+
+	record := &planet.PlanetEntity{Id: 1, Name: "Namek", CharacterIds: []int64{3}}
+
+	mockClient.
+		On("ReplaceOne", ctx, bson.M{"_id": int64(1)}, record).
+		Return(&mongo.UpdateResult{UpsertedCount: 1}, nil)
+
+	r := repo.NewPlanetRepository(mockClient)
+
+	assert.NoError(t, r.Save(ctx, record))
+	mockClient.AssertExpectations(t)
+}
+
+func TestPlanetRepository_Get_OK(t *testing.T) {
+	ctx := context.Background()
+	mockClient := new(MockDbCollection)
+	mockResult := new(MockSingleResult)
+
+	mockClient.
+		On("FindOne", ctx, bson.M{"_id": int64(1)}).
+		Return(mockResult, nil)
+
+	mockResult.
+		On("Decode", mock.AnythingOfType("*planet.PlanetEntity")).
+		Run(func(args mock.Arguments) {
+			*args.Get(0).(*planet.PlanetEntity) = planet.PlanetEntity{Id: 1, Name: "Namek"}
+		}).
+		Return(nil)
+
+	r := repo.NewPlanetRepository(mockClient)
+
+	res, err := r.Get(ctx, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Namek", res.Name)
+}
+
+func TestPlanetRepository_Get_DecodeError(t *testing.T) {
+	ctx := context.Background()
+	mockClient := new(MockDbCollection)
+	mockResult := new(MockSingleResult)
+
+	mockClient.
+		On("FindOne", ctx, mock.Anything).
+		Return(mockResult, nil)
+
+	mockResult.
+		On("Decode", mock.Anything).
+		Return(mongo.ErrNoDocuments)
+
+	r := repo.NewPlanetRepository(mockClient)
+
+	res, err := r.Get(ctx, 1)
+
+	assert.Nil(t, res)
+	assert.ErrorIs(t, err, mongo.ErrNoDocuments)
+}
+
+func TestPlanetRepository_List_OK(t *testing.T) {
+	ctx := context.Background()
+	mockClient := new(MockDbCollection)
+	mockCursor := new(MockCursor)
+
+	mockClient.
+		On("Find", ctx, bson.M{}).
+		Return(mockCursor, nil)
+
+	mockCursor.
+		On("All", ctx, mock.Anything).
+		Run(func(args mock.Arguments) {
+			decodeInto(t, args.Get(1), bson.M{"_id": int64(1), "name": "Namek"}, bson.M{"_id": int64(2), "name": "Tierra"})
+		}).
+		Return(nil)
+
+	r := repo.NewPlanetRepository(mockClient)
+
+	res, err := r.List(ctx)
+
+	assert.NoError(t, err)
+	assert.Len(t, res, 2)
+	assert.Nil(t, res[0].CharacterIds)
+}
+
+func TestPlanetRepository_List_Error(t *testing.T) {
+	ctx := context.Background()
+	mockClient := new(MockDbCollection)
+
+	mockClient.
+		On("Find", ctx, mock.Anything).
+		Return((*MockCursor)(nil), errors.New("find error"))
+
+	r := repo.NewPlanetRepository(mockClient)
+
+	res, err := r.List(ctx)
+
+	assert.Nil(t, res)
+	assert.EqualError(t, err, "find error")
+}
+
+func TestPlanetRepository_GetByIds_OneQuery(t *testing.T) {
+	ctx := context.Background()
+	mockClient := new(MockDbCollection)
+	mockCursor := new(MockCursor)
+
+	mockClient.
+		On("Find", ctx, bson.M{"_id": bson.M{"$in": []int64{1, 2}}}).
+		Return(mockCursor, nil)
+
+	mockCursor.
+		On("All", ctx, mock.Anything).
+		Run(func(args mock.Arguments) {
+			decodeInto(t, args.Get(1), bson.M{"_id": int64(2), "name": "Tierra", "characterIds": bson.A{int64(1)}})
+		}).
+		Return(nil)
+
+	r := repo.NewPlanetRepository(mockClient)
+
+	res, err := r.GetByIds(ctx, []int64{1, 2})
+
+	assert.NoError(t, err)
+	assert.Len(t, res, 1)
+	assert.Equal(t, []int64{1}, res[0].CharacterIds)
+}
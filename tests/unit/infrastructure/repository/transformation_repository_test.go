@@ -0,0 +1,102 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	transformation "github.com/heaveless/dbz-api/internal/domain/transformation"
+	repo "github.com/heaveless/dbz-api/internal/infrastructure/repositoy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+func TestTransformationRepository_CreateMany_OK(t *testing.T) {
+	ctx := context.Background()
+	mockClient := new(MockDbCollection)
+
+	mockClient.
+		On("InsertOne", ctx, mock.AnythingOfType("*transformation.TransformationEntity")).
+		Return(&mongo.InsertOneResult{}, nil)
+
+	r := repo.NewTransformationRepository(mockClient)
+
+	err := r.CreateMany(ctx, []transformation.TransformationEntity{
+		{Id: 1, CharacterId: 1, Name: "Goku SSJ"},
+		{Id: 2, CharacterId: 1, Name: "Goku SSJ2"},
+	})
+
+	assert.NoError(t, err)
+	mockClient.AssertNumberOfCalls(t, "InsertOne", 2)
+}
+
+func TestTransformationRepository_CreateMany_StopsOnError(t *testing.T) {
+	ctx := context.Background()
+	mockClient := new(MockDbCollection)
+
+	mockClient.
+		On("InsertOne", ctx, mock.Anything).
+		Return((*mongo.InsertOneResult)(nil), errors.New("db error"))
+
+	r := repo.NewTransformationRepository(mockClient)
+
+	err := r.CreateMany(ctx, []transformation.TransformationEntity{{Id: 1}, {Id: 2}})
+
+	assert.EqualError(t, err, "db error")
+	mockClient.AssertNumberOfCalls(t, "InsertOne", 1)
+}
+
+func TestTransformationRepository_ListByCharacter_OK(t *testing.T) {
+	ctx := context.Background()
+	mockClient := new(MockDbCollection)
+	mockCursor := new(MockCursor)
+
+	mockClient.
+		On("Find", ctx, bson.M{"characterId": int64(1)}).
+		Return(mockCursor, nil)
+
+	mockCursor.
+		On("All", ctx, mock.Anything).
+		Run(func(args mock.Arguments) {
+			decodeInto(t, args.Get(1), bson.M{"_id": int64(1), "characterId": int64(1), "name": "Goku SSJ"})
+		}).
+		Return(nil)
+
+	r := repo.NewTransformationRepository(mockClient)
+
+	res, err := r.ListByCharacter(ctx, 1)
+
+	assert.NoError(t, err)
+	assert.Len(t, res, 1)
+	assert.Equal(t, int64(1), res[0].CharacterId)
+}
+
+func TestTransformationRepository_ListByCharacters_OneQuery(t *testing.T) {
+	ctx := context.Background()
+	mockClient := new(MockDbCollection)
+	mockCursor := new(MockCursor)
+
+	mockClient.
+		On("Find", ctx, bson.M{"characterId": bson.M{"$in": []int64{1, 2}}}).
+		Return(mockCursor, nil)
+
+	mockCursor.
+		On("All", ctx, mock.Anything).
+		Run(func(args mock.Arguments) {
+			decodeInto(t, args.Get(1),
+				bson.M{"_id": int64(1), "characterId": int64(1), "name": "Goku SSJ"},
+				bson.M{"_id": int64(7), "characterId": int64(2), "name": "Vegeta SSJ"},
+			)
+		}).
+		Return(nil)
+
+	r := repo.NewTransformationRepository(mockClient)
+
+	res, err := r.ListByCharacters(ctx, []int64{1, 2})
+
+	assert.NoError(t, err)
+	assert.Len(t, res, 2)
+	mockClient.AssertNumberOfCalls(t, "Find", 1)
+}
@@ -0,0 +1,110 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	webhook "github.com/heaveless/dbz-api/internal/domain/webhook"
+	repo "github.com/heaveless/dbz-api/internal/infrastructure/repositoy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+func TestWebhookRepository_Get_NotFound(t *testing.T) {
+	ctx := context.Background()
+	client := new(MockDbCollection)
+	result := new(MockSingleResult)
+
+	client.On("FindOne", ctx, bson.M{"_id": "w1"}).Return(result, nil)
+	result.On("Decode", mock.Anything).Return(mongo.ErrNoDocuments)
+
+	r := repo.NewWebhookRepository(client)
+
+	s, err := r.Get(ctx, "w1")
+
+	assert.Nil(t, s)
+	assert.ErrorIs(t, err, webhook.ErrNotFound)
+}
+
+func TestWebhookDeliveryRepository_Due(t *testing.T) {
+	ctx := context.Background()
+	client := new(MockDbCollection)
+	cursor := new(MockCursor)
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	client.
+		On("Find", ctx, bson.M{"status": webhook.StatusPending, "nextAttemptAt": bson.M{"$lte": now}}).
+		Return(cursor, nil)
+	cursor.
+		On("All", ctx, mock.Anything).
+		Run(func(args mock.Arguments) {
+			*args.Get(1).(*[]webhook.DeliveryEntity) = []webhook.DeliveryEntity{{Id: "e1:w1"}}
+		}).
+		Return(nil)
+
+	r := repo.NewWebhookDeliveryRepository(client)
+
+	deliveries, err := r.Due(ctx, now, 10)
+
+	assert.NoError(t, err)
+	assert.Len(t, deliveries, 1)
+}
+
+func TestWebhookDeliveryRepository_Attempted_Retry(t *testing.T) {
+	ctx := context.Background()
+	client := new(MockDbCollection)
+	result := new(MockSingleResult)
+	at := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	attempt := webhook.AttemptEntity{At: at, StatusCode: 502, Error: "unexpected status 502"}
+
+	client.
+		On("FindOneAndUpdate", ctx, bson.M{"_id": "e1:w1"}, bson.M{
+			"$push": bson.M{"attempts": attempt},
+			"$set":  bson.M{"status": webhook.StatusPending, "nextAttemptAt": at.Add(time.Minute)},
+		}).
+		Return(result, nil)
+	result.On("Err").Return(nil)
+
+	r := repo.NewWebhookDeliveryRepository(client)
+
+	assert.NoError(t, r.Attempted(ctx, "e1:w1", attempt, webhook.StatusPending, at.Add(time.Minute)))
+	client.AssertExpectations(t)
+}
+
+func TestWebhookDeliveryRepository_Attempted_Final(t *testing.T) {
+	ctx := context.Background()
+	client := new(MockDbCollection)
+	result := new(MockSingleResult)
+	attempt := webhook.AttemptEntity{At: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC), StatusCode: 200}
+
+	client.
+		On("FindOneAndUpdate", ctx, bson.M{"_id": "e1:w1"}, bson.M{
+			"$push":  bson.M{"attempts": attempt},
+			"$set":   bson.M{"status": webhook.StatusDelivered},
+			"$unset": bson.M{"nextAttemptAt": ""},
+		}).
+		Return(result, nil)
+	result.On("Err").Return(nil)
+
+	r := repo.NewWebhookDeliveryRepository(client)
+
+	assert.NoError(t, r.Attempted(ctx, "e1:w1", attempt, webhook.StatusDelivered, time.Time{}))
+	client.AssertExpectations(t)
+}
+
+func TestWebhookDeliveryRepository_Attempted_Error(t *testing.T) {
+	ctx := context.Background()
+	client := new(MockDbCollection)
+
+	client.
+		On("FindOneAndUpdate", ctx, mock.Anything, mock.Anything).
+		Return((*MockSingleResult)(nil), errors.New("db down"))
+
+	r := repo.NewWebhookDeliveryRepository(client)
+
+	assert.EqualError(t, r.Attempted(ctx, "e1:w1", webhook.AttemptEntity{}, webhook.StatusFailed, time.Time{}), "db down")
+}
@@ -0,0 +1,130 @@
+package webhook_test
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/heaveless/dbz-api/internal/domain/event"
+	domain "github.com/heaveless/dbz-api/internal/domain/webhook"
+	"github.com/heaveless/dbz-api/internal/infrastructure/webhook"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func delivery() *domain.DeliveryEntity {
+	return &domain.DeliveryEntity{
+		Id:       "k1:s1",
+		EventKey: "k1",
+		Type:     event.CharacterCached,
+		Payload:  `{"key":"k1","type":"CharacterCached"}`,
+	}
+}
+
+func TestSender_SignsDelivery(t *testing.T) {
+	var got *http.Request
+	var body []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	sub := &domain.SubscriptionEntity{Id: "s1", Url: srv.URL + "/hooks", Secret: "whsec_test"}
+	attempt := webhook.NewSender(localClient()).Send(context.Background(), sub, delivery())
+
+	assert.True(t, attempt.Succeeded())
+	assert.Equal(t, http.StatusNoContent, attempt.StatusCode)
+	assert.False(t, attempt.At.IsZero())
+
+	require.NotNil(t, got)
+	assert.Equal(t, "/hooks", got.URL.Path)
+	assert.Equal(t, "application/json", got.Header.Get("Content-Type"))
+	assert.Equal(t, "CharacterCached", got.Header.Get(domain.HeaderEvent))
+	assert.Equal(t, "k1:s1", got.Header.Get(domain.HeaderDelivery))
+	assert.Equal(t, "k1", got.Header.Get("Idempotency-Key"))
+	assert.Equal(t, `{"key":"k1","type":"CharacterCached"}`, string(body))
+
+	timestamp, err := strconv.ParseInt(got.Header.Get(domain.HeaderTimestamp), 10, 64)
+	require.NoError(t, err)
+	assert.True(t, domain.Verify("whsec_test", got.Header.Get(domain.HeaderSignature), timestamp, body, time.Now(), time.Minute))
+}
+
+func TestSender_ReportsFailures(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/error":
+			http.Error(w, "maintenance", http.StatusServiceUnavailable)
+		case "/redirect":
+			http.Redirect(w, r, "/ok", http.StatusFound)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name   string
+		url    string
+		status int
+		error  string
+	}{
+		{name: "error status", url: srv.URL + "/error", status: http.StatusServiceUnavailable, error: "unexpected status 503"},
+		{name: "redirect is not followed", url: srv.URL + "/redirect", status: http.StatusFound, error: "unexpected status 302"},
+		{name: "unreachable", url: "http://127.0.0.1:1/hooks", error: "connection refused"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sub := &domain.SubscriptionEntity{Id: "s1", Url: tt.url, Secret: "whsec_test"}
+			attempt := webhook.NewSender(localClient()).Send(context.Background(), sub, delivery())
+
+			assert.False(t, attempt.Succeeded())
+			assert.Equal(t, tt.status, attempt.StatusCode)
+			assert.Contains(t, attempt.Error, tt.error)
+			assert.NotContains(t, attempt.Error, "maintenance", "the reply body is not kept")
+		})
+	}
+}
+
+func TestNewClient_RefusesNonPublicAddresses(t *testing.T) {
+	var called bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer srv.Close()
+	port := srv.URL[strings.LastIndex(srv.URL, ":")+1:]
+
+	hosts := []string{
+		"127.0.0.1", "localhost", "10.0.0.1", "192.168.1.10", "169.254.169.254", "[::1]", "0.0.0.0",
+		"0.1.2.3", "100.64.0.1", "100.127.255.254",
+		"[::ffff:127.0.0.1]", "[::ffff:10.0.0.1]", "[::ffff:169.254.169.254]", "[::ffff:100.64.0.1]",
+	}
+
+	for _, host := range hosts {
+		t.Run(host, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			sub := &domain.SubscriptionEntity{Id: "s1", Url: "http://" + host + ":" + port + "/hooks", Secret: "whsec_test"}
+
+			attempt := webhook.NewSender(webhook.NewClient()).Send(ctx, sub, delivery())
+
+			assert.False(t, attempt.Succeeded())
+			assert.Zero(t, attempt.StatusCode)
+			assert.Contains(t, attempt.Error, "is not a public address")
+		})
+	}
+	assert.False(t, called)
+}
+
+// localClient reaches httptest servers, which NewClient refuses.
+func localClient() *http.Client {
+	return webhook.NewClientWithDialer(&net.Dialer{})
+}
@@ -0,0 +1,17 @@
+package utils_test
+
+import (
+	"math"
+	"testing"
+
+	utils "github.com/heaveless/dbz-api/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOffset(t *testing.T) {
+	assert.Equal(t, 0, utils.Offset(1, 10))
+	assert.Equal(t, 20, utils.Offset(3, 10))
+	assert.Equal(t, 0, utils.Offset(0, 10))
+	assert.Equal(t, math.MaxInt, utils.Offset(math.MaxInt, 50), "saturates instead of overflowing")
+	assert.Equal(t, math.MaxInt, utils.Offset(math.MaxInt/10+2, 10))
+}
@@ -0,0 +1,20 @@
+package utils_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	utils "github.com/heaveless/dbz-api/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRandomString(t *testing.T) {
+	a, err := utils.RandomString(8, hex.EncodeToString)
+	require.NoError(t, err)
+	b, err := utils.RandomString(8, hex.EncodeToString)
+	require.NoError(t, err)
+
+	assert.Regexp(t, `^[0-9a-f]{16}$`, a)
+	assert.NotEqual(t, a, b)
+}